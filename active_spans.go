@@ -0,0 +1,130 @@
+package otx
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// activeProcessors holds every registered activeSpanProcessor.
+var activeProcessors sync.Map // *activeSpanProcessor -> struct{}
+
+// ActiveSpan describes a span that has started but not yet ended.
+type ActiveSpan struct {
+	Name         string
+	Kind         trace.SpanKind
+	TraceID      trace.TraceID
+	SpanID       trace.SpanID
+	ParentSpanID trace.SpanID // Zero for root spans
+	StartTime    time.Time
+}
+
+// activeSpanProcessor tracks open spans for ActiveSpans.
+type activeSpanProcessor struct {
+	spans spanTable[sdktrace.ReadOnlySpan]
+}
+
+// NewActiveSpanProcessor returns a SpanProcessor that records open spans so they
+// can be listed with [ActiveSpans]. It is registered automatically by
+// [NewTracerProvider] when traces.trackActiveSpans is enabled; use it directly
+// when building a TracerProvider by hand.
+//
+// Tracking costs one map insert and delete per span, so it is disabled by default.
+// Shutting the processor down removes its spans from [ActiveSpans].
+//
+// Example:
+//
+//	tp := sdktrace.NewTracerProvider(
+//	    sdktrace.WithSpanProcessor(otx.NewActiveSpanProcessor()),
+//	    sdktrace.WithBatcher(exporter),
+//	)
+func NewActiveSpanProcessor() sdktrace.SpanProcessor {
+	p := &activeSpanProcessor{}
+	activeProcessors.Store(p, struct{}{})
+
+	return p
+}
+
+// ActiveSpans returns the spans that are currently open, oldest first.
+// It is intended for debugging stuck requests during incidents, like a
+// programmatic tracez page.
+//
+// Only spans started by a TracerProvider with an active span processor are
+// listed (see [NewActiveSpanProcessor]); otherwise the result is empty.
+//
+// Example:
+//
+//	for _, s := range otx.ActiveSpans() {
+//	    if s.Age() > time.Minute {
+//	        log.Printf("stuck: %s trace=%s age=%s", s.Name, s.TraceID, s.Age())
+//	    }
+//	}
+func ActiveSpans() []ActiveSpan {
+	var result []ActiveSpan
+	activeProcessors.Range(func(key, _ any) bool {
+		if p, ok := key.(*activeSpanProcessor); ok {
+			result = p.appendSnapshot(result)
+		}
+
+		return true
+	})
+
+	slices.SortFunc(result, func(a, b ActiveSpan) int {
+		return a.StartTime.Compare(b.StartTime)
+	})
+
+	return result
+}
+
+// Age returns how long the span has been open.
+func (s ActiveSpan) Age() time.Duration {
+	return time.Since(s.StartTime)
+}
+
+// OnStart implements sdktrace.SpanProcessor.
+func (p *activeSpanProcessor) OnStart(_ context.Context, s sdktrace.ReadWriteSpan) {
+	p.spans.start(s, func() sdktrace.ReadOnlySpan { return s })
+}
+
+// OnEnd implements sdktrace.SpanProcessor.
+func (p *activeSpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	p.spans.end(s, nil)
+}
+
+// Shutdown implements sdktrace.SpanProcessor.
+func (p *activeSpanProcessor) Shutdown(_ context.Context) error {
+	activeProcessors.Delete(p)
+	p.spans.reset(nil)
+
+	return nil
+}
+
+// ForceFlush implements sdktrace.SpanProcessor.
+func (*activeSpanProcessor) ForceFlush(_ context.Context) error {
+	return nil
+}
+
+// openSpans implements spanTracker.
+func (p *activeSpanProcessor) openSpans() int {
+	return p.spans.len()
+}
+
+// appendSnapshot appends the currently open spans to dst.
+func (p *activeSpanProcessor) appendSnapshot(dst []ActiveSpan) []ActiveSpan {
+	p.spans.each(func(key spanKey, s sdktrace.ReadOnlySpan) {
+		dst = append(dst, ActiveSpan{
+			Name:         s.Name(),
+			Kind:         s.SpanKind(),
+			TraceID:      key.traceID,
+			SpanID:       key.spanID,
+			ParentSpanID: s.Parent().SpanID(),
+			StartTime:    s.StartTime(),
+		})
+	})
+
+	return dst
+}
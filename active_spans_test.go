@@ -0,0 +1,56 @@
+package otx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestActiveSpans(t *testing.T) {
+	proc := NewActiveSpanProcessor()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(proc))
+	tracer := tp.Tracer("test")
+
+	ctx, root := tracer.Start(t.Context(), "root")
+	_, child := tracer.Start(ctx, "child")
+
+	active := ActiveSpans()
+	require.Len(t, active, 2)
+	assert.Equal(t, "root", active[0].Name, "oldest first")
+	assert.Equal(t, "child", active[1].Name)
+	assert.Equal(t, root.SpanContext().TraceID(), active[1].TraceID)
+	assert.Equal(t, root.SpanContext().SpanID(), active[1].ParentSpanID)
+	assert.False(t, active[0].ParentSpanID.IsValid())
+	assert.GreaterOrEqual(t, active[0].Age(), active[1].Age())
+
+	child.End()
+	active = ActiveSpans()
+	require.Len(t, active, 1)
+	assert.Equal(t, "root", active[0].Name)
+
+	// Shutdown unregisters the processor
+	require.NoError(t, tp.Shutdown(t.Context()))
+	assert.Empty(t, ActiveSpans())
+	root.End()
+}
+
+func TestNewTracerProvider_TrackActiveSpans(t *testing.T) {
+	cfg := &TelemetryConfig{
+		Enabled:     boolPtr(true),
+		ServiceName: "test-service",
+		Traces:      &TracesConfig{Exporter: "none", TrackActiveSpans: true},
+	}
+	tp, err := NewTracerProvider(t.Context(), cfg)
+	require.NoError(t, err)
+
+	_, span := tp.Tracer("test").Start(t.Context(), "in-flight")
+	active := ActiveSpans()
+	require.Len(t, active, 1)
+	assert.Equal(t, "in-flight", active[0].Name)
+
+	span.End()
+	assert.Empty(t, ActiveSpans())
+	require.NoError(t, tp.Shutdown(t.Context()))
+}
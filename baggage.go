@@ -2,7 +2,10 @@ package otx
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 
 	"go.opentelemetry.io/otel/baggage"
 )
@@ -30,6 +33,52 @@ func SetBaggage(ctx context.Context, key, value string) (context.Context, error)
 	return baggage.ContextWithBaggage(ctx, bag), nil
 }
 
+// SetBaggageMap adds the key-value pairs of members to baggage in the context,
+// replacing members with the same keys, with a single copy of the baggage rather
+// than one per SetBaggage call.
+//
+// Keys and values are validated as in [SetBaggage]. If any pair is invalid, ctx is
+// returned unchanged with the errors of every invalid pair, so no member is set.
+//
+// Example:
+//
+//	ctx, err := otx.SetBaggageMap(ctx, map[string]string{
+//	    "tenant.id": tenantID,
+//	    "user.tier": tier,
+//	})
+func SetBaggageMap(ctx context.Context, members map[string]string) (context.Context, error) {
+	if len(members) == 0 {
+		return ctx, nil
+	}
+
+	var errs []error
+	list := make([]baggage.Member, 0, len(members))
+	for _, key := range slices.Sorted(maps.Keys(members)) {
+		member, err := baggage.NewMember(key, members[key])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("create baggage member %q: %w", key, err))
+
+			continue
+		}
+		list = append(list, member)
+	}
+	if len(errs) > 0 {
+		return ctx, errors.Join(errs...)
+	}
+
+	for _, m := range baggage.FromContext(ctx).Members() {
+		if _, ok := members[m.Key()]; !ok {
+			list = append(list, m)
+		}
+	}
+	bag, err := baggage.New(list...)
+	if err != nil {
+		return ctx, fmt.Errorf("set baggage members: %w", err)
+	}
+
+	return baggage.ContextWithBaggage(ctx, bag), nil
+}
+
 // MustSetBaggage adds a key-value pair to baggage, panicking on error.
 // Use when key/value are known to be valid (e.g., hardcoded keys).
 //
@@ -0,0 +1,75 @@
+package otx
+
+import (
+	"context"
+	"regexp"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/arloliu/otx/internal/glob"
+)
+
+// baggageAllowlistPropagator injects only the allowlisted baggage members.
+type baggageAllowlistPropagator struct {
+	next    propagation.TextMapPropagator
+	allowed *regexp.Regexp // Nil allows nothing
+}
+
+// NewBaggageAllowlistPropagator wraps next so that only baggage members whose
+// keys match one of keys are injected into outgoing requests and messages, while
+// the process keeps the full baggage: extraction is left to next unchanged.
+// Keys are glob patterns, e.g. "tenant.id" or "public.*"; without keys no
+// baggage is injected.
+//
+// Use it to keep internal baggage, such as debug flags, from reaching third-party
+// APIs. It is applied by [NewTracerProvider] for propagation.baggageAllowlist.
+//
+// Example:
+//
+//	otel.SetTextMapPropagator(otx.NewBaggageAllowlistPropagator(
+//	    propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}),
+//	    "tenant.id", "request.*",
+//	))
+func NewBaggageAllowlistPropagator(next propagation.TextMapPropagator, keys ...string) propagation.TextMapPropagator {
+	p := &baggageAllowlistPropagator{next: next}
+	if len(keys) > 0 {
+		p.allowed = glob.ListRegexp(keys...)
+	}
+
+	return p
+}
+
+// Inject implements propagation.TextMapPropagator.
+func (p *baggageAllowlistPropagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	bag := baggage.FromContext(ctx)
+	if bag.Len() > 0 {
+		bag = p.filter(bag)
+		ctx = baggage.ContextWithBaggage(ctx, bag)
+	}
+	p.next.Inject(ctx, carrier)
+}
+
+// filter returns bag without the members that are not allowlisted.
+func (p *baggageAllowlistPropagator) filter(bag baggage.Baggage) baggage.Baggage {
+	for _, m := range bag.Members() {
+		if p.allowed == nil || !p.allowed.MatchString(m.Key()) {
+			bag = bag.DeleteMember(m.Key())
+		}
+	}
+
+	return bag
+}
+
+// Extract implements propagation.TextMapPropagator.
+func (p *baggageAllowlistPropagator) Extract(
+	ctx context.Context,
+	carrier propagation.TextMapCarrier,
+) context.Context {
+	return p.next.Extract(ctx, carrier)
+}
+
+// Fields implements propagation.TextMapPropagator.
+func (p *baggageAllowlistPropagator) Fields() []string {
+	return p.next.Fields()
+}
@@ -0,0 +1,58 @@
+package otx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func TestBaggageAllowlistPropagator(t *testing.T) {
+	ctx := MustSetBaggage(t.Context(), "tenant.id", "acme")
+	ctx = MustSetBaggage(ctx, "request.path", "/orders")
+	ctx = MustSetBaggage(ctx, "debug.user", "alice")
+
+	prop := NewBaggageAllowlistPropagator(propagation.Baggage{}, "tenant.id", "request.*")
+	carrier := propagation.MapCarrier{}
+	prop.Inject(ctx, carrier)
+
+	injected, err := baggage.Parse(carrier.Get("baggage"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, injected.Len())
+	assert.Equal(t, "acme", injected.Member("tenant.id").Value())
+	assert.Equal(t, "/orders", injected.Member("request.path").Value())
+	assert.Equal(t, "alice", GetBaggage(ctx, "debug.user"), "in-process baggage is unchanged")
+
+	incoming := propagation.MapCarrier{"baggage": "debug.user=bob,tenant.id=beta"}
+	extracted := prop.Extract(t.Context(), incoming)
+	assert.Equal(t, "bob", GetBaggage(extracted, "debug.user"), "incoming baggage is kept in full")
+	assert.Equal(t, []string{"baggage"}, prop.Fields())
+}
+
+func TestBaggageAllowlistPropagator_NoKeys(t *testing.T) {
+	ctx := MustSetBaggage(t.Context(), "tenant.id", "acme")
+
+	carrier := propagation.MapCarrier{}
+	NewBaggageAllowlistPropagator(propagation.Baggage{}).Inject(ctx, carrier)
+	assert.Empty(t, carrier.Get("baggage"))
+}
+
+func TestBuildPropagator_BaggageAllowlist(t *testing.T) {
+	ctx, err := ContextWithRemoteParent(t.Context(),
+		"4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7", true)
+	require.NoError(t, err)
+	ctx = MustSetBaggage(ctx, "tenant.id", "acme")
+	ctx = MustSetBaggage(ctx, "internal.flag", "on")
+
+	prop := buildPropagator(&PropConfig{
+		Propagators:      "tracecontext,baggage",
+		BaggageAllowlist: []string{"tenant.*"},
+	})
+	carrier := propagation.MapCarrier{}
+	prop.Inject(ctx, carrier)
+
+	assert.NotEmpty(t, carrier.Get("traceparent"))
+	assert.Equal(t, "tenant.id=acme", carrier.Get("baggage"))
+}
@@ -0,0 +1,78 @@
+package otx
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/otel/baggage"
+)
+
+// NamespacedBaggage reads and writes baggage members under a key prefix, so
+// teams sharing a trace do not collide on generic keys such as "id" or "env".
+// Create it with [BaggageNamespace].
+type NamespacedBaggage struct {
+	prefix string
+}
+
+// namespacePattern matches valid baggage namespaces: dot-separated words of
+// letters, digits, '-' and '_', which are valid W3C baggage key tokens.
+var namespacePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+)*$`)
+
+// BaggageNamespace returns the baggage helpers of namespace: keys passed to its
+// methods are stored as "namespace.key". The namespace is validated once, here,
+// and must be dot-separated words of letters, digits, '-' and '_', e.g.
+// "checkout" or "acme.billing"; BaggageNamespace panics otherwise, so call it
+// when initializing a package.
+//
+// Example:
+//
+//	var checkoutBaggage = otx.BaggageNamespace("checkout")
+//
+//	ctx, err = checkoutBaggage.Set(ctx, "cart.id", cartID) // checkout.cart.id
+//	cartID := checkoutBaggage.Get(ctx, "cart.id")
+func BaggageNamespace(namespace string) NamespacedBaggage {
+	if !namespacePattern.MatchString(namespace) {
+		panic(fmt.Sprintf("otx: invalid baggage namespace %q", namespace))
+	}
+
+	return NamespacedBaggage{prefix: namespace + "."}
+}
+
+// Key returns the baggage key of key in the namespace.
+func (n NamespacedBaggage) Key(key string) string {
+	return n.prefix + key
+}
+
+// Set adds key with value to baggage in the namespace. See [SetBaggage].
+func (n NamespacedBaggage) Set(ctx context.Context, key, value string) (context.Context, error) {
+	return SetBaggage(ctx, n.prefix+key, value)
+}
+
+// MustSet is Set panicking on error. See [MustSetBaggage].
+func (n NamespacedBaggage) MustSet(ctx context.Context, key, value string) context.Context {
+	return MustSetBaggage(ctx, n.prefix+key, value)
+}
+
+// Get returns the value of key in the namespace, or "" if it is not set.
+func (n NamespacedBaggage) Get(ctx context.Context, key string) string {
+	return GetBaggage(ctx, n.prefix+key)
+}
+
+// Delete removes key in the namespace from baggage.
+func (n NamespacedBaggage) Delete(ctx context.Context, key string) context.Context {
+	return DeleteBaggage(ctx, n.prefix+key)
+}
+
+// All returns the members of the namespace, keyed without the namespace prefix.
+func (n NamespacedBaggage) All(ctx context.Context) map[string]string {
+	result := make(map[string]string)
+	for _, m := range baggage.FromContext(ctx).Members() {
+		if key, ok := strings.CutPrefix(m.Key(), n.prefix); ok {
+			result[key] = m.Value()
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,38 @@
+package otx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaggageNamespace(t *testing.T) {
+	checkout := BaggageNamespace("checkout")
+	billing := BaggageNamespace("billing")
+
+	ctx, err := checkout.Set(t.Context(), "id", "cart-1")
+	require.NoError(t, err)
+	ctx = billing.MustSet(ctx, "id", "invoice-7")
+
+	assert.Equal(t, "checkout.id", checkout.Key("id"))
+	assert.Equal(t, "cart-1", checkout.Get(ctx, "id"))
+	assert.Equal(t, "invoice-7", billing.Get(ctx, "id"))
+	assert.Equal(t, "cart-1", GetBaggage(ctx, "checkout.id"))
+	assert.Empty(t, GetBaggage(ctx, "id"), "keys never land outside the namespace")
+	assert.Equal(t, map[string]string{"id": "cart-1"}, checkout.All(ctx))
+
+	ctx = checkout.Delete(ctx, "id")
+	assert.Empty(t, checkout.Get(ctx, "id"))
+	assert.Equal(t, "invoice-7", billing.Get(ctx, "id"))
+
+	_, err = checkout.Set(ctx, "bad key", "v")
+	require.Error(t, err)
+}
+
+func TestBaggageNamespace_Invalid(t *testing.T) {
+	assert.Panics(t, func() { BaggageNamespace("my app") })
+	assert.Panics(t, func() { BaggageNamespace("") })
+	assert.Panics(t, func() { BaggageNamespace("app.") })
+	assert.NotPanics(t, func() { BaggageNamespace("acme.billing-v2") })
+}
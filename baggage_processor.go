@@ -0,0 +1,32 @@
+package otx
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/baggage"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/arloliu/otx/internal/baggageattr"
+)
+
+// NewBaggageAttributeProcessor returns a SpanProcessor that copies the baggage
+// members matching keys onto every span as string attributes when it starts. The
+// attribute key is the baggage key. Keys are glob patterns, as for SetErrorBaggage.
+// Members missing from the baggage are skipped, so spans never get empty attributes.
+//
+// It is registered automatically by [NewTracerProvider] when
+// traces.baggageAttributes is set. Only allowlisted keys are copied: baggage comes
+// from upstream callers and may carry values that must not reach the backend.
+//
+// Example:
+//
+//	tp, err := otx.NewTracerProvider(ctx, cfg,
+//	    otx.WithSpanProcessor(otx.NewBaggageAttributeProcessor("tenant.id", "user.tier")),
+//	)
+func NewBaggageAttributeProcessor(keys ...string) sdktrace.SpanProcessor {
+	selector := baggageattr.New(keys...)
+
+	return NewSpanHooks(func(ctx context.Context, s sdktrace.ReadWriteSpan) {
+		s.SetAttributes(selector.Attributes(baggage.FromContext(ctx), "")...)
+	}, nil)
+}
@@ -0,0 +1,58 @@
+package otx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestNewBaggageAttributeProcessor(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(NewBaggageAttributeProcessor("tenant.id", "user.tier")),
+		sdktrace.WithSyncer(exporter),
+	)
+	defer func() { _ = tp.Shutdown(t.Context()) }()
+
+	ctx := MustSetBaggage(t.Context(), "tenant.id", "acme")
+	ctx = MustSetBaggage(ctx, "session.token", "secret")
+	_, span := tp.Tracer("test").Start(ctx, "with-baggage")
+	span.End()
+	_, span = tp.Tracer("test").Start(t.Context(), "without-baggage")
+	span.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.True(t, hasAttribute(spans[0].Attributes, attribute.String("tenant.id", "acme")))
+	assert.False(t, hasKey(spans[0].Attributes, "user.tier"), "missing members are skipped")
+	assert.False(t, hasKey(spans[0].Attributes, "session.token"), "only allowlisted keys are copied")
+	assert.Empty(t, spans[1].Attributes)
+}
+
+func TestNewTracerProvider_BaggageAttributes(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	cfg := &TelemetryConfig{
+		Enabled:     boolPtr(true),
+		ServiceName: "test-service",
+		Traces: &TracesConfig{
+			Exporter:          "none",
+			BaggageAttributes: []string{"user.tier"},
+			SpanProcessors:    []sdktrace.SpanProcessor{sdktrace.NewSimpleSpanProcessor(exporter)},
+		},
+	}
+	tp, err := NewTracerProvider(t.Context(), cfg)
+	require.NoError(t, err)
+	defer func() { _ = tp.Shutdown(t.Context()) }()
+
+	ctx := MustSetBaggage(t.Context(), "user.tier", "gold")
+	_, span := tp.Tracer("test").Start(ctx, "op")
+	span.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.True(t, hasAttribute(spans[0].Attributes, attribute.String("user.tier", "gold")))
+}
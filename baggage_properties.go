@@ -0,0 +1,98 @@
+package otx
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"go.opentelemetry.io/otel/baggage"
+)
+
+// SetBaggageWithProperties adds a key-value pair to baggage in the context with the
+// W3C member properties props, e.g. "ttl=2" giving "key=value;ttl=2" on the wire, so
+// metadata about the value travels with it. A property with an empty value is
+// written as a key-only property, e.g. "key=value;sensitive".
+//
+// The member replaces any member with the same key, including its properties.
+// Keys and values are validated as in [SetBaggage], property values are
+// percent-encoded the same way as member values.
+//
+// Example:
+//
+//	ctx, err := otx.SetBaggageWithProperties(ctx, "tenant.id", tenantID, map[string]string{
+//	    "ttl": "2",
+//	})
+func SetBaggageWithProperties(
+	ctx context.Context,
+	key, value string,
+	props map[string]string,
+) (context.Context, error) {
+	properties := make([]baggage.Property, 0, len(props))
+	for _, name := range slices.Sorted(maps.Keys(props)) {
+		prop, err := newBaggageProperty(name, props[name])
+		if err != nil {
+			return ctx, fmt.Errorf("create baggage property %q: %w", name, err)
+		}
+		properties = append(properties, prop)
+	}
+
+	member, err := baggage.NewMember(key, value, properties...)
+	if err != nil {
+		return ctx, fmt.Errorf("create baggage member: %w", err)
+	}
+	bag, err := baggage.FromContext(ctx).SetMember(member)
+	if err != nil {
+		return ctx, fmt.Errorf("set baggage member: %w", err)
+	}
+
+	return baggage.ContextWithBaggage(ctx, bag), nil
+}
+
+// GetBaggageProperties returns the properties of the baggage member key, with an
+// empty value for key-only properties. It returns nil if the member is missing or
+// has no properties.
+func GetBaggageProperties(ctx context.Context, key string) map[string]string {
+	props := baggage.FromContext(ctx).Member(key).Properties()
+	if len(props) == 0 {
+		return nil
+	}
+
+	result := make(map[string]string, len(props))
+	for _, p := range props {
+		result[p.Key()], _ = p.Value()
+	}
+
+	return result
+}
+
+// GetBaggageProperty returns the value of the property prop of the baggage member
+// key, empty for a key-only property. ok is false if the member or the property
+// is missing.
+//
+// Example:
+//
+//	if ttl, ok := otx.GetBaggageProperty(ctx, "tenant.id", "ttl"); ok {
+//	    // decrement and forward
+//	}
+func GetBaggageProperty(ctx context.Context, key, prop string) (value string, ok bool) {
+	for _, p := range baggage.FromContext(ctx).Member(key).Properties() {
+		if p.Key() == prop {
+			value, _ = p.Value()
+
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+// newBaggageProperty returns a key-only property for an empty value and a
+// key-value property otherwise.
+func newBaggageProperty(name, value string) (baggage.Property, error) {
+	if value == "" {
+		return baggage.NewKeyProperty(name)
+	}
+
+	return baggage.NewKeyValueProperty(name, value)
+}
@@ -0,0 +1,68 @@
+package otx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func TestBaggageProperties(t *testing.T) {
+	ctx, err := SetBaggageWithProperties(t.Context(), "tenant.id", "acme", map[string]string{
+		"ttl":       "2",
+		"sensitive": "",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "acme", GetBaggage(ctx, "tenant.id"))
+	assert.Equal(t, map[string]string{"ttl": "2", "sensitive": ""}, GetBaggageProperties(ctx, "tenant.id"))
+
+	ttl, ok := GetBaggageProperty(ctx, "tenant.id", "ttl")
+	assert.True(t, ok)
+	assert.Equal(t, "2", ttl)
+
+	value, ok := GetBaggageProperty(ctx, "tenant.id", "sensitive")
+	assert.True(t, ok)
+	assert.Empty(t, value)
+
+	_, ok = GetBaggageProperty(ctx, "tenant.id", "missing")
+	assert.False(t, ok)
+	_, ok = GetBaggageProperty(ctx, "missing", "ttl")
+	assert.False(t, ok)
+	assert.Nil(t, GetBaggageProperties(ctx, "missing"))
+}
+
+func TestBaggageProperties_Propagated(t *testing.T) {
+	ctx, err := SetBaggageWithProperties(t.Context(), "tenant.id", "acme", map[string]string{"ttl": "2"})
+	require.NoError(t, err)
+
+	carrier := propagation.MapCarrier{}
+	propagation.Baggage{}.Inject(ctx, carrier)
+	assert.Equal(t, "tenant.id=acme;ttl=2", carrier.Get("baggage"))
+
+	extracted := propagation.Baggage{}.Extract(t.Context(), carrier)
+	assert.Equal(t, map[string]string{"ttl": "2"}, GetBaggageProperties(extracted, "tenant.id"))
+}
+
+func TestBaggageProperties_ReplacesMember(t *testing.T) {
+	ctx, err := SetBaggageWithProperties(t.Context(), "tenant.id", "acme", map[string]string{"ttl": "2"})
+	require.NoError(t, err)
+
+	ctx = MustSetBaggage(ctx, "tenant.id", "globex")
+	assert.Equal(t, "globex", GetBaggage(ctx, "tenant.id"))
+	assert.Nil(t, GetBaggageProperties(ctx, "tenant.id"))
+}
+
+func TestBaggageProperties_Invalid(t *testing.T) {
+	ctx := t.Context()
+
+	got, err := SetBaggageWithProperties(ctx, "tenant.id", "acme", map[string]string{"bad prop": "1"})
+	require.Error(t, err)
+	assert.Equal(t, ctx, got)
+	assert.Equal(t, 0, baggage.FromContext(got).Len())
+
+	_, err = SetBaggageWithProperties(ctx, "bad key", "acme", nil)
+	require.Error(t, err)
+}
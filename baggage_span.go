@@ -0,0 +1,71 @@
+package otx
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/arloliu/otx/internal/baggageattr"
+)
+
+// BaggageSpanPrefix is the default key prefix of the attributes set by
+// CopyBaggageToSpan.
+const BaggageSpanPrefix = "baggage."
+
+// baggageSpanOptions holds the options applied by CopyBaggageToSpanWithOptions.
+type baggageSpanOptions struct {
+	prefix string
+}
+
+// BaggageSpanOption configures CopyBaggageToSpanWithOptions.
+type BaggageSpanOption func(*baggageSpanOptions)
+
+// WithBaggageSpanPrefix sets the key prefix of the copied attributes, [BaggageSpanPrefix]
+// by default. An empty prefix uses the baggage keys as attribute keys.
+func WithBaggageSpanPrefix(prefix string) BaggageSpanOption {
+	return func(o *baggageSpanOptions) {
+		o.prefix = prefix
+	}
+}
+
+// CopyBaggageToSpan copies the baggage members matching keys onto the current span
+// as string attributes named [BaggageSpanPrefix] plus the baggage key, so traces can
+// be searched by e.g. tenant or user. See CopyBaggageToSpanWithOptions.
+//
+// Example:
+//
+//	ctx, span := otx.Start(ctx, "HandleOrder")
+//	defer span.End()
+//	otx.CopyBaggageToSpan(ctx, "tenant.id", "user.id") // adds baggage.tenant.id, baggage.user.id
+func CopyBaggageToSpan(ctx context.Context, keys ...string) {
+	CopyBaggageToSpanWithOptions(ctx, nil, keys...)
+}
+
+// CopyBaggageToSpanWithOptions copies the baggage members matching keys onto the
+// current span as string attributes. Keys are glob patterns, as for SetErrorBaggage;
+// members missing from the baggage are skipped. Without keys, every member is
+// copied, including any an upstream service added, so list keys for spans sent to
+// a shared backend. To copy keys onto every span, see [NewBaggageAttributeProcessor].
+//
+// Example:
+//
+//	otx.CopyBaggageToSpanWithOptions(ctx,
+//	    []otx.BaggageSpanOption{otx.WithBaggageSpanPrefix("app.")},
+//	    "tenant.*",
+//	) // adds app.tenant.id, app.tenant.region, ...
+func CopyBaggageToSpanWithOptions(ctx context.Context, opts []BaggageSpanOption, keys ...string) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	o := baggageSpanOptions{prefix: BaggageSpanPrefix}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if len(keys) == 0 {
+		keys = []string{"*"}
+	}
+	span.SetAttributes(baggageattr.New(keys...).Attributes(baggage.FromContext(ctx), o.prefix)...)
+}
@@ -0,0 +1,58 @@
+package otx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestCopyBaggageToSpan(t *testing.T) {
+	exporter := setupTracing(t)
+
+	ctx, err := SetBaggageMap(t.Context(), map[string]string{
+		"tenant.id": "acme",
+		"user.id":   "42",
+		"secret":    "s3cr3t",
+	})
+	require.NoError(t, err)
+
+	spanCtx, span := Start(ctx, "selected")
+	CopyBaggageToSpan(spanCtx, "tenant.id", "user.id", "missing")
+	span.End()
+
+	spanCtx, span = Start(ctx, "all")
+	CopyBaggageToSpan(spanCtx)
+	span.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String("baggage.tenant.id", "acme"),
+		attribute.String("baggage.user.id", "42"),
+	}, spans[0].Attributes)
+	assert.Len(t, spans[1].Attributes, 3)
+	assert.Contains(t, spans[1].Attributes, attribute.String("baggage.secret", "s3cr3t"))
+}
+
+func TestCopyBaggageToSpanWithOptions(t *testing.T) {
+	exporter := setupTracing(t)
+
+	ctx, err := SetBaggageMap(t.Context(), map[string]string{
+		"tenant.id":     "acme",
+		"tenant.region": "eu",
+		"user.id":       "42",
+	})
+	require.NoError(t, err)
+	ctx, span := Start(ctx, "op")
+	CopyBaggageToSpanWithOptions(ctx, []BaggageSpanOption{WithBaggageSpanPrefix("app.")}, "tenant.*")
+	span.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String("app.tenant.id", "acme"),
+		attribute.String("app.tenant.region", "eu"),
+	}, spans[0].Attributes)
+}
@@ -30,3 +30,40 @@ func TestBaggageHelpers(t *testing.T) {
 	val = GetBaggage(ctx, "key")
 	assert.Empty(t, val)
 }
+
+func TestSetBaggageMap(t *testing.T) {
+	ctx := MustSetBaggage(t.Context(), "tenant.id", "old")
+	ctx = MustSetBaggage(ctx, "region", "eu")
+
+	ctx, err := SetBaggageMap(ctx, map[string]string{
+		"tenant.id":  "acme",
+		"user.tier":  "gold",
+		"request.id": "r-1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"tenant.id":  "acme",
+		"user.tier":  "gold",
+		"request.id": "r-1",
+		"region":     "eu",
+	}, AllBaggage(ctx))
+
+	same, err := SetBaggageMap(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, ctx, same)
+}
+
+func TestSetBaggageMap_Invalid(t *testing.T) {
+	ctx := MustSetBaggage(t.Context(), "region", "eu")
+
+	got, err := SetBaggageMap(ctx, map[string]string{
+		"tenant.id": "acme",
+		"bad key":   "v",
+		"also bad":  "v",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"bad key"`)
+	assert.Contains(t, err.Error(), `"also bad"`)
+	assert.Equal(t, ctx, got, "nothing is set when a member is invalid")
+	assert.Empty(t, GetBaggage(got, "tenant.id"))
+}
@@ -0,0 +1,68 @@
+package otx
+
+import (
+	"context"
+	"strconv"
+
+	"go.opentelemetry.io/otel/baggage"
+)
+
+// GetBaggageInt returns the baggage member key parsed as a base-10 integer.
+// ok is false if the member is missing or not an integer.
+func GetBaggageInt(ctx context.Context, key string) (value int64, ok bool) {
+	v, ok := lookupBaggage(ctx, key)
+	if !ok {
+		return 0, false
+	}
+	value, err := strconv.ParseInt(v, 10, 64)
+
+	return value, err == nil
+}
+
+// GetBaggageFloat returns the baggage member key parsed as a float.
+// ok is false if the member is missing or not a number.
+func GetBaggageFloat(ctx context.Context, key string) (value float64, ok bool) {
+	v, ok := lookupBaggage(ctx, key)
+	if !ok {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(v, 64)
+
+	return value, err == nil
+}
+
+// GetBaggageBool returns the baggage member key parsed as a bool, accepting the
+// values of strconv.ParseBool such as "true", "false", "1" and "0".
+// ok is false if the member is missing or not a bool.
+func GetBaggageBool(ctx context.Context, key string) (value, ok bool) {
+	v, ok := lookupBaggage(ctx, key)
+	if !ok {
+		return false, false
+	}
+	value, err := strconv.ParseBool(v)
+
+	return value, err == nil
+}
+
+// SetBaggageInt adds key with the base-10 value to baggage. See [SetBaggage].
+func SetBaggageInt(ctx context.Context, key string, value int64) (context.Context, error) {
+	return SetBaggage(ctx, key, strconv.FormatInt(value, 10))
+}
+
+// SetBaggageFloat adds key with the value to baggage, in the shortest form that
+// parses back to the same float. See [SetBaggage].
+func SetBaggageFloat(ctx context.Context, key string, value float64) (context.Context, error) {
+	return SetBaggage(ctx, key, strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+// SetBaggageBool adds key with the value, "true" or "false", to baggage. See [SetBaggage].
+func SetBaggageBool(ctx context.Context, key string, value bool) (context.Context, error) {
+	return SetBaggage(ctx, key, strconv.FormatBool(value))
+}
+
+// lookupBaggage returns the value of the baggage member key, and whether it is set.
+func lookupBaggage(ctx context.Context, key string) (string, bool) {
+	m := baggage.FromContext(ctx).Member(key)
+
+	return m.Value(), m.Key() != ""
+}
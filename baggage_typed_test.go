@@ -0,0 +1,68 @@
+package otx
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypedBaggage(t *testing.T) {
+	ctx := t.Context()
+	var err error
+
+	ctx, err = SetBaggageInt(ctx, "retries", -3)
+	require.NoError(t, err)
+	ctx, err = SetBaggageFloat(ctx, "ratio", 0.25)
+	require.NoError(t, err)
+	ctx, err = SetBaggageBool(ctx, "debug", true)
+	require.NoError(t, err)
+
+	assert.Equal(t, "-3", GetBaggage(ctx, "retries"))
+	assert.Equal(t, "0.25", GetBaggage(ctx, "ratio"))
+	assert.Equal(t, "true", GetBaggage(ctx, "debug"))
+
+	i, ok := GetBaggageInt(ctx, "retries")
+	assert.True(t, ok)
+	assert.Equal(t, int64(-3), i)
+
+	f, ok := GetBaggageFloat(ctx, "ratio")
+	assert.True(t, ok)
+	assert.InDelta(t, 0.25, f, 0)
+
+	b, ok := GetBaggageBool(ctx, "debug")
+	assert.True(t, ok)
+	assert.True(t, b)
+}
+
+func TestTypedBaggage_MissingOrInvalid(t *testing.T) {
+	ctx := MustSetBaggage(t.Context(), "name", "acme")
+
+	_, ok := GetBaggageInt(ctx, "name")
+	assert.False(t, ok)
+	_, ok = GetBaggageFloat(ctx, "name")
+	assert.False(t, ok)
+	_, ok = GetBaggageBool(ctx, "name")
+	assert.False(t, ok)
+
+	_, ok = GetBaggageInt(ctx, "missing")
+	assert.False(t, ok)
+
+	ctx = MustSetBaggage(ctx, "count", "")
+	_, ok = GetBaggageInt(ctx, "count")
+	assert.False(t, ok, "empty values are not integers")
+
+	_, err := SetBaggageInt(ctx, "bad key", 1)
+	require.Error(t, err)
+}
+
+func TestSetBaggageFloat_RoundTrip(t *testing.T) {
+	for _, v := range []float64{0, 1e-9, math.Pi, -12345.678, 1e21} {
+		ctx, err := SetBaggageFloat(t.Context(), "v", v)
+		require.NoError(t, err)
+		got, ok := GetBaggageFloat(ctx, "v")
+		assert.True(t, ok)
+		assert.Equal(t, v, got)
+	}
+}
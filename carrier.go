@@ -0,0 +1,75 @@
+package otx
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// funcCarrier adapts get, set and keys functions to propagation.TextMapCarrier.
+type funcCarrier struct {
+	get  func(key string) string
+	set  func(key, value string)
+	keys func() []string
+}
+
+// CarrierFunc returns a propagation.TextMapCarrier reading values with get,
+// writing them with set and listing the keys with keys, so [Inject] and [Extract]
+// work with any transport, e.g. AMQP tables, Kafka headers or SQS message
+// attributes, without otx depending on its client library. A nil function makes
+// the carrier read nothing, drop writes or list no keys, so an extract-only
+// carrier can pass a nil set.
+//
+// Example:
+//
+//	carrier := otx.CarrierFunc(
+//	    func(key string) string { v, _ := msg.Headers[key].(string); return v },
+//	    func(key, value string) { msg.Headers[key] = value },
+//	    func() []string { return slices.Collect(maps.Keys(msg.Headers)) },
+//	)
+//	otx.Inject(ctx, carrier)
+func CarrierFunc(
+	get func(key string) string,
+	set func(key, value string),
+	keys func() []string,
+) propagation.TextMapCarrier {
+	return funcCarrier{get: get, set: set, keys: keys}
+}
+
+// Get implements propagation.TextMapCarrier.
+func (c funcCarrier) Get(key string) string {
+	if c.get == nil {
+		return ""
+	}
+
+	return c.get(key)
+}
+
+// Set implements propagation.TextMapCarrier.
+func (c funcCarrier) Set(key, value string) {
+	if c.set != nil {
+		c.set(key, value)
+	}
+}
+
+// Keys implements propagation.TextMapCarrier.
+func (c funcCarrier) Keys() []string {
+	if c.keys == nil {
+		return nil
+	}
+
+	return c.keys()
+}
+
+// Inject injects trace context and baggage into carrier with the global
+// propagator. See [CarrierFunc] to adapt a transport to a carrier.
+func Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+}
+
+// Extract extracts trace context and baggage from carrier with the global
+// propagator. See [CarrierFunc] to adapt a transport to a carrier.
+func Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
@@ -0,0 +1,47 @@
+package otx
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestCarrierFunc(t *testing.T) {
+	prev := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(prev) })
+
+	ctx, err := ContextWithRemoteParent(t.Context(),
+		"4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7", true)
+	require.NoError(t, err)
+
+	// A transport with non-string header values, like AMQP tables
+	headers := map[string]any{}
+	carrier := CarrierFunc(
+		func(key string) string { v, _ := headers[key].(string); return v },
+		func(key, value string) { headers[key] = value },
+		func() []string { return slices.Collect(maps.Keys(headers)) },
+	)
+
+	Inject(ctx, carrier)
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", headers["traceparent"])
+	assert.Equal(t, []string{"traceparent"}, carrier.Keys())
+
+	sc := trace.SpanContextFromContext(Extract(t.Context(), carrier))
+	assert.True(t, sc.IsRemote())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID().String())
+}
+
+func TestCarrierFunc_NilFuncs(t *testing.T) {
+	carrier := CarrierFunc(nil, nil, nil)
+
+	assert.NotPanics(t, func() { carrier.Set("traceparent", "value") })
+	assert.Empty(t, carrier.Get("traceparent"))
+	assert.Empty(t, carrier.Keys())
+}
@@ -0,0 +1,195 @@
+package otx
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Child span attributes, set on parent spans by the child span processor.
+const (
+	// AttrChildSpanCount is the number of direct children of a span that have ended.
+	AttrChildSpanCount = "otx.child_span_count"
+
+	// AttrChildDuration is the cumulative duration, in milliseconds, of the direct
+	// children of a span that have ended.
+	AttrChildDuration = "otx.child_duration_ms"
+)
+
+// childSpanProcessors holds every registered childSpanProcessor.
+var childSpanProcessors sync.Map // *childSpanProcessor -> struct{}
+
+// SpanSummary summarizes the spans below an open span, as returned by [TraceSummary].
+type SpanSummary struct {
+	Spans         int           // Ended descendant spans, including nested ones
+	Children      int           // Ended direct children
+	ChildDuration time.Duration // Cumulative duration of the ended direct children
+	DBCalls       int           // Ended descendant spans with a db.system attribute
+	Errors        int           // Ended descendant spans with an error status
+	Duration      time.Duration // Time elapsed since the span started
+}
+
+// childSpanEntry is an open span, the key of its local parent and its summary.
+type childSpanEntry struct {
+	span      sdktrace.ReadWriteSpan
+	parent    spanKey
+	hasParent bool
+	summary   SpanSummary
+}
+
+// childSpanProcessor counts the children and descendants of open spans.
+type childSpanProcessor struct {
+	spans spanTable[*childSpanEntry]
+}
+
+// NewChildSpanProcessor returns a SpanProcessor that sets [AttrChildSpanCount] and
+// [AttrChildDuration] on every span with children, and keeps a summary of the
+// descendants of each open span for [TraceSummary].
+//
+// The attributes are updated as each child ends, so children still running when
+// the parent ends are not counted. Only spans started in this process are seen,
+// and descendants of a span that ended before them are not counted for its
+// ancestors. It is registered automatically by [NewTracerProvider] when
+// traces.childSpanStats is enabled.
+func NewChildSpanProcessor() sdktrace.SpanProcessor {
+	p := &childSpanProcessor{}
+	childSpanProcessors.Store(p, struct{}{})
+
+	return p
+}
+
+// TraceSummary returns a summary of the spans below the current span, e.g. for an
+// access log written at the end of a request. The current span must still be
+// open and tracked by a child span processor (see [NewChildSpanProcessor]);
+// otherwise the zero SpanSummary is returned.
+//
+// Example:
+//
+//	defer func() {
+//	    log.Printf("%s %s: %s", r.Method, r.URL.Path, otx.TraceSummary(r.Context()))
+//	}()
+//	// GET /orders: 12 spans, 3 DB calls, 240ms total
+func TraceSummary(ctx context.Context) SpanSummary {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return SpanSummary{}
+	}
+
+	key := spanKey{traceID: sc.TraceID(), spanID: sc.SpanID()}
+	var (
+		summary SpanSummary
+		found   bool
+	)
+	childSpanProcessors.Range(func(k, _ any) bool {
+		if p, ok := k.(*childSpanProcessor); ok {
+			summary, found = p.summary(key)
+		}
+
+		return !found
+	})
+
+	return summary
+}
+
+// String formats the summary as "12 spans, 3 DB calls, 240ms total".
+func (s SpanSummary) String() string {
+	return fmt.Sprintf("%d spans, %d DB calls, %s total", s.Spans, s.DBCalls, s.Duration.Round(time.Millisecond))
+}
+
+// OnStart implements sdktrace.SpanProcessor.
+func (p *childSpanProcessor) OnStart(_ context.Context, s sdktrace.ReadWriteSpan) {
+	entry := &childSpanEntry{span: s}
+	if parent := s.Parent(); parent.IsValid() && !parent.IsRemote() {
+		entry.parent = parentKeyOf(s)
+		entry.hasParent = true
+	}
+
+	p.spans.start(s, func() *childSpanEntry { return entry })
+}
+
+// OnEnd implements sdktrace.SpanProcessor.
+func (p *childSpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	isDB := isDBSpan(s)
+	isError := s.Status().Code == codes.Error
+
+	p.spans.end(s, func(entry *childSpanEntry, ok bool, open func(spanKey) (*childSpanEntry, bool)) {
+		if !ok || !entry.hasParent {
+			return
+		}
+		parent, ok := open(entry.parent)
+		if !ok {
+			return
+		}
+
+		// The counters are cumulative, so the attributes are set together with the
+		// increment; a sibling ending concurrently sets them after this one.
+		parent.summary.Children++
+		parent.summary.ChildDuration += s.EndTime().Sub(s.StartTime())
+		parent.span.SetAttributes(
+			attribute.Int(AttrChildSpanCount, parent.summary.Children),
+			attribute.Float64(AttrChildDuration, float64(parent.summary.ChildDuration)/float64(time.Millisecond)),
+		)
+
+		for ancestor := parent; ; {
+			ancestor.summary.Spans++
+			if isDB {
+				ancestor.summary.DBCalls++
+			}
+			if isError {
+				ancestor.summary.Errors++
+			}
+			if !ancestor.hasParent {
+				break
+			}
+			if ancestor, ok = open(ancestor.parent); !ok {
+				break
+			}
+		}
+	})
+}
+
+// Shutdown implements sdktrace.SpanProcessor.
+func (p *childSpanProcessor) Shutdown(_ context.Context) error {
+	childSpanProcessors.Delete(p)
+	p.spans.reset(nil)
+
+	return nil
+}
+
+// ForceFlush implements sdktrace.SpanProcessor.
+func (*childSpanProcessor) ForceFlush(_ context.Context) error {
+	return nil
+}
+
+// openSpans implements spanTracker.
+func (p *childSpanProcessor) openSpans() int {
+	return p.spans.len()
+}
+
+// summary returns the summary of the open span key, if it is tracked.
+func (p *childSpanProcessor) summary(key spanKey) (SpanSummary, bool) {
+	var summary SpanSummary
+	ok := p.spans.read(key, func(entry *childSpanEntry) {
+		summary = entry.summary
+		summary.Duration = time.Since(entry.span.StartTime())
+	})
+
+	return summary, ok
+}
+
+// isDBSpan reports whether s describes a database call.
+func isDBSpan(s sdktrace.ReadOnlySpan) bool {
+	for _, kv := range s.Attributes() {
+		if kv.Key == "db.system" || kv.Key == "db.system.name" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,79 @@
+package otx
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewChildSpanProcessor(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(NewChildSpanProcessor()),
+		sdktrace.WithSyncer(exporter),
+	)
+	defer func() { _ = tp.Shutdown(t.Context()) }()
+	tracer := tp.Tracer("test")
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(ms int) time.Time { return base.Add(time.Duration(ms) * time.Millisecond) }
+
+	ctx, request := tracer.Start(t.Context(), "request", trace.WithTimestamp(at(0)))
+	handlerCtx, handler := tracer.Start(ctx, "handler", trace.WithTimestamp(at(0)))
+	for i := range 3 {
+		_, query := tracer.Start(handlerCtx, "query", trace.WithTimestamp(at(i*10)))
+		query.SetAttributes(attribute.String("db.system.name", "postgresql"))
+		if i == 2 {
+			query.RecordError(errors.New("timeout"))
+			query.SetStatus(codes.Error, "timeout")
+		}
+		query.End(trace.WithTimestamp(at(i*10 + 5)))
+	}
+
+	summary := TraceSummary(handlerCtx)
+	assert.Equal(t, 3, summary.Spans)
+	assert.Equal(t, 3, summary.Children)
+	assert.Equal(t, 15*time.Millisecond, summary.ChildDuration)
+
+	handler.End(trace.WithTimestamp(at(40)))
+
+	summary = TraceSummary(ctx)
+	assert.Equal(t, 4, summary.Spans, "nested descendants are counted")
+	assert.Equal(t, 1, summary.Children)
+	assert.Equal(t, 3, summary.DBCalls)
+	assert.Equal(t, 1, summary.Errors)
+	assert.Positive(t, summary.Duration)
+
+	request.End(trace.WithTimestamp(at(50)))
+	assert.Zero(t, TraceSummary(ctx), "ended spans are no longer tracked")
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 5)
+	assert.True(t, hasAttribute(spans[3].Attributes, attribute.Int(AttrChildSpanCount, 3)))
+	assert.True(t, hasAttribute(spans[3].Attributes, attribute.Float64(AttrChildDuration, 15)))
+	assert.True(t, hasAttribute(spans[4].Attributes, attribute.Int(AttrChildSpanCount, 1)))
+	assert.True(t, hasAttribute(spans[4].Attributes, attribute.Float64(AttrChildDuration, 40)))
+	assert.False(t, hasKey(spans[0].Attributes, AttrChildSpanCount), "spans without children")
+}
+
+func TestTraceSummary_Untracked(t *testing.T) {
+	assert.Zero(t, TraceSummary(t.Context()))
+
+	tp := sdktrace.NewTracerProvider()
+	ctx, span := tp.Tracer("test").Start(t.Context(), "untracked")
+	defer span.End()
+	assert.Zero(t, TraceSummary(ctx))
+}
+
+func TestSpanSummary_String(t *testing.T) {
+	summary := SpanSummary{Spans: 12, DBCalls: 3, Duration: 240*time.Millisecond + 300*time.Microsecond}
+	assert.Equal(t, "12 spans, 3 DB calls, 240ms total", summary.String())
+}
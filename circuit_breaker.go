@@ -0,0 +1,328 @@
+package otx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Circuit breaker defaults, used when CircuitBreakerConfig leaves a field at zero.
+const (
+	DefaultCircuitFailureThreshold = 5
+	DefaultCircuitOpenDuration     = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned by exports skipped because the circuit is open and
+// there is no fallback exporter.
+var ErrCircuitOpen = errors.New("otx: exporter circuit open, export skipped")
+
+// circuitBreaker tracks consecutive export failures of one exporter.
+type circuitBreaker struct {
+	signal    string
+	threshold int
+	openFor   time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	failures  int
+	open      bool
+	openUntil time.Time
+	probing   bool // A half-open probe is in flight
+}
+
+// breakerSpanExporter sends spans to primary unless its circuit is open.
+type breakerSpanExporter struct {
+	primary  sdktrace.SpanExporter
+	fallback sdktrace.SpanExporter // nil = drop
+	breaker  *circuitBreaker
+	closer   io.Closer // fallback file, or nil
+}
+
+// breakerLogExporter sends log records to primary unless its circuit is open.
+type breakerLogExporter struct {
+	primary  sdklog.Exporter
+	fallback sdklog.Exporter // nil = drop
+	breaker  *circuitBreaker
+	closer   io.Closer // fallback file, or nil
+}
+
+// breakerMetricExporter sends metrics to primary unless its circuit is open.
+type breakerMetricExporter struct {
+	sdkmetric.Exporter                    // primary
+	fallback           sdkmetric.Exporter // nil = drop
+	breaker            *circuitBreaker
+	closer             io.Closer // fallback file, or nil
+}
+
+// newCircuitBreaker returns a closed breaker for signal, applying the defaults to
+// the zero fields of cfg.
+func newCircuitBreaker(signal string, cfg *CircuitBreakerConfig) *circuitBreaker {
+	b := &circuitBreaker{
+		signal:    signal,
+		threshold: cfg.FailureThreshold,
+		openFor:   cfg.OpenDuration,
+		now:       time.Now,
+	}
+	if b.threshold <= 0 {
+		b.threshold = DefaultCircuitFailureThreshold
+	}
+	if b.openFor <= 0 {
+		b.openFor = DefaultCircuitOpenDuration
+	}
+
+	return b
+}
+
+// allow reports whether the primary exporter should be called. Once the open period
+// has passed, a single export is let through to probe the collector; others are
+// skipped until its result is recorded.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.open {
+		return true
+	}
+	if b.probing || b.now().Before(b.openUntil) {
+		return false
+	}
+	b.probing = true
+
+	return true
+}
+
+// record updates the breaker with the result of a primary export. A success closes
+// the circuit; reaching the threshold, or failing a probe, (re)opens it.
+func (b *circuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if err == nil {
+		b.failures, b.open = 0, false
+
+		return
+	}
+
+	b.failures++
+	if !b.open && b.failures < b.threshold {
+		return
+	}
+	if !b.open {
+		otel.Handle(fmt.Errorf("otx: %s exporter failed %d times in a row, skipping it for %s: %w",
+			b.signal, b.failures, b.openFor, err))
+	}
+	b.open = true
+	b.openUntil = b.now().Add(b.openFor)
+}
+
+// breakerExport exports data to primary, or to fallback while the circuit is open.
+// Data the primary rejects is also sent to fallback, but the primary's error is returned.
+func breakerExport[T any](
+	ctx context.Context,
+	b *circuitBreaker,
+	data T,
+	primary func(context.Context, T) error,
+	fallback func(context.Context, T) error,
+) error {
+	if !b.allow() {
+		if fallback == nil {
+			return ErrCircuitOpen
+		}
+
+		return fallback(ctx, data)
+	}
+
+	err := primary(ctx, data)
+	b.record(err)
+	if err != nil && fallback != nil {
+		_ = fallback(ctx, data)
+	}
+
+	return err
+}
+
+// buildFallback builds the fallback exporter configured in params.Breaker with build.
+// It returns the zero value if there is no fallback, and the file to close if the
+// fallback writes to one.
+func buildFallback[E any](
+	ctx context.Context,
+	params exporterParams,
+	build func(context.Context, exporterParams) (E, error),
+) (E, io.Closer, error) {
+	var zero E
+	breaker := params.Breaker
+	params.Type = "console"
+	params.Breaker = nil // the fallback itself is not wrapped
+
+	switch breaker.Fallback {
+	case "otlp":
+		params.Type = "otlp"
+		params.Endpoint = breaker.FallbackEndpoint
+		exp, err := build(ctx, params)
+
+		return exp, nil, err
+	case "console":
+		exp, err := build(ctx, params)
+
+		return exp, nil, err
+	case "file":
+		f, err := os.OpenFile(breaker.FallbackFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return zero, nil, fmt.Errorf("open circuit breaker fallback file: %w", err)
+		}
+		params.Console = &ConsoleConfig{Writer: f, PrettyPrint: boolPtr(false)}
+		exp, err := build(ctx, params)
+		if err != nil {
+			_ = f.Close()
+
+			return zero, nil, err
+		}
+
+		return exp, f, nil
+	default:
+		return zero, nil, nil
+	}
+}
+
+// closeFallback shuts down fallback and closes its file, if any.
+func closeFallback(ctx context.Context, fallback interface{ Shutdown(context.Context) error }, closer io.Closer) error {
+	var errs []error
+	if fallback != nil {
+		errs = append(errs, fallback.Shutdown(ctx))
+	}
+	if closer != nil {
+		errs = append(errs, closer.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// withSpanCircuitBreaker builds the OTLP span exporter and, if params.Breaker is set,
+// wraps it in a circuit breaker.
+func withSpanCircuitBreaker(ctx context.Context, params exporterParams) (sdktrace.SpanExporter, error) {
+	exp, err := buildOTLPTraceExporter(ctx, params)
+	if err != nil || params.Breaker == nil {
+		return exp, err
+	}
+
+	fallback, closer, err := buildFallback(ctx, params, buildTraceExporter)
+	if err != nil {
+		_ = exp.Shutdown(ctx)
+
+		return nil, err
+	}
+
+	return &breakerSpanExporter{
+		primary:  exp,
+		fallback: fallback,
+		breaker:  newCircuitBreaker("traces", params.Breaker),
+		closer:   closer,
+	}, nil
+}
+
+// withLogCircuitBreaker builds the OTLP log exporter and, if params.Breaker is set,
+// wraps it in a circuit breaker.
+func withLogCircuitBreaker(ctx context.Context, params exporterParams) (sdklog.Exporter, error) {
+	exp, err := buildOTLPLogExporter(ctx, params)
+	if err != nil || params.Breaker == nil {
+		return exp, err
+	}
+
+	fallback, closer, err := buildFallback(ctx, params, buildLogExporter)
+	if err != nil {
+		_ = exp.Shutdown(ctx)
+
+		return nil, err
+	}
+
+	return &breakerLogExporter{
+		primary:  exp,
+		fallback: fallback,
+		breaker:  newCircuitBreaker("logs", params.Breaker),
+		closer:   closer,
+	}, nil
+}
+
+// withMetricCircuitBreaker builds the OTLP metric exporter and, if params.Breaker is set,
+// wraps it in a circuit breaker.
+func withMetricCircuitBreaker(ctx context.Context, params exporterParams) (sdkmetric.Exporter, error) {
+	exp, err := buildOTLPMetricExporter(ctx, params)
+	if err != nil || params.Breaker == nil {
+		return exp, err
+	}
+
+	fallback, closer, err := buildFallback(ctx, params, buildMetricExporter)
+	if err != nil {
+		_ = exp.Shutdown(ctx)
+
+		return nil, err
+	}
+
+	return &breakerMetricExporter{
+		Exporter: exp,
+		fallback: fallback,
+		breaker:  newCircuitBreaker("metrics", params.Breaker),
+		closer:   closer,
+	}, nil
+}
+
+// ExportSpans implements sdktrace.SpanExporter.
+func (e *breakerSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	var fallback func(context.Context, []sdktrace.ReadOnlySpan) error
+	if e.fallback != nil {
+		fallback = e.fallback.ExportSpans
+	}
+
+	return breakerExport(ctx, e.breaker, spans, e.primary.ExportSpans, fallback)
+}
+
+// Shutdown implements sdktrace.SpanExporter.
+func (e *breakerSpanExporter) Shutdown(ctx context.Context) error {
+	return errors.Join(e.primary.Shutdown(ctx), closeFallback(ctx, e.fallback, e.closer))
+}
+
+// Export implements sdklog.Exporter.
+func (e *breakerLogExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	var fallback func(context.Context, []sdklog.Record) error
+	if e.fallback != nil {
+		fallback = e.fallback.Export
+	}
+
+	return breakerExport(ctx, e.breaker, records, e.primary.Export, fallback)
+}
+
+// ForceFlush implements sdklog.Exporter.
+func (e *breakerLogExporter) ForceFlush(ctx context.Context) error {
+	return e.primary.ForceFlush(ctx)
+}
+
+// Shutdown implements sdklog.Exporter.
+func (e *breakerLogExporter) Shutdown(ctx context.Context) error {
+	return errors.Join(e.primary.Shutdown(ctx), closeFallback(ctx, e.fallback, e.closer))
+}
+
+// Export implements sdkmetric.Exporter.
+func (e *breakerMetricExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	var fallback func(context.Context, *metricdata.ResourceMetrics) error
+	if e.fallback != nil {
+		fallback = e.fallback.Export
+	}
+
+	return breakerExport(ctx, e.breaker, rm, e.Exporter.Export, fallback)
+}
+
+// Shutdown implements sdkmetric.Exporter.
+func (e *breakerMetricExporter) Shutdown(ctx context.Context) error {
+	return errors.Join(e.Exporter.Shutdown(ctx), closeFallback(ctx, e.fallback, e.closer))
+}
@@ -0,0 +1,234 @@
+package otx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// scriptedSpanExporter fails exports while err is set and counts exported spans.
+type scriptedSpanExporter struct {
+	mu    sync.Mutex
+	err   error
+	calls int
+	spans int
+}
+
+func (e *scriptedSpanExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.calls++
+	if e.err != nil {
+		return e.err
+	}
+	e.spans += len(spans)
+
+	return nil
+}
+
+func (*scriptedSpanExporter) Shutdown(context.Context) error { return nil }
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Cleanup(func() { SetErrorHandler(nil) })
+	var warnings []error
+	SetErrorHandler(func(err error) { warnings = append(warnings, err) })
+
+	now := time.Unix(0, 0)
+	breaker := newCircuitBreaker("traces", &CircuitBreakerConfig{FailureThreshold: 2, OpenDuration: time.Minute})
+	breaker.now = func() time.Time { return now }
+
+	primary := &scriptedSpanExporter{err: errors.New("collector down")}
+	fallback := &scriptedSpanExporter{}
+	exp := &breakerSpanExporter{primary: primary, fallback: fallback, breaker: breaker}
+	spans := tracetest.SpanStubs{{Name: "a"}, {Name: "b"}}.Snapshots()
+	ctx := t.Context()
+
+	require.Error(t, exp.ExportSpans(ctx, spans))
+	require.Error(t, exp.ExportSpans(ctx, spans))
+	assert.Equal(t, 2, primary.calls)
+	assert.Equal(t, 4, fallback.spans, "rejected batches are kept by the fallback")
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0].Error(), "traces exporter failed 2 times in a row, skipping it for 1m0s")
+
+	require.NoError(t, exp.ExportSpans(ctx, spans))
+	assert.Equal(t, 2, primary.calls, "open circuit skips the primary")
+	assert.Equal(t, 6, fallback.spans)
+
+	now = now.Add(time.Minute)
+	require.Error(t, exp.ExportSpans(ctx, spans))
+	assert.Equal(t, 3, primary.calls, "the primary is probed after the open duration")
+	require.NoError(t, exp.ExportSpans(ctx, spans))
+	assert.Equal(t, 3, primary.calls, "a failed probe reopens the circuit")
+	assert.Len(t, warnings, 1, "reopening is not reported again")
+
+	now = now.Add(time.Minute)
+	primary.err = nil
+	require.NoError(t, exp.ExportSpans(ctx, spans))
+	require.NoError(t, exp.ExportSpans(ctx, spans))
+	assert.Equal(t, 5, primary.calls, "a successful probe closes the circuit")
+	assert.Equal(t, 4, primary.spans)
+}
+
+func TestCircuitBreaker_SingleProbe(t *testing.T) {
+	t.Cleanup(func() { SetErrorHandler(nil) })
+	SetErrorHandler(func(error) {})
+
+	now := time.Unix(0, 0)
+	breaker := newCircuitBreaker("traces", &CircuitBreakerConfig{FailureThreshold: 1, OpenDuration: time.Minute})
+	breaker.now = func() time.Time { return now }
+	breaker.record(errors.New("collector down"))
+	assert.False(t, breaker.allow())
+
+	now = now.Add(time.Minute)
+	assert.True(t, breaker.allow(), "the first export after the open duration probes")
+	assert.False(t, breaker.allow(), "others are skipped while the probe is in flight")
+
+	breaker.record(errors.New("collector down"))
+	assert.False(t, breaker.allow(), "a failed probe reopens the circuit")
+
+	now = now.Add(time.Minute)
+	assert.True(t, breaker.allow())
+	breaker.record(nil)
+	assert.True(t, breaker.allow(), "a successful probe closes the circuit")
+	assert.True(t, breaker.allow())
+}
+
+func TestCircuitBreaker_NoFallback(t *testing.T) {
+	t.Cleanup(func() { SetErrorHandler(nil) })
+	SetErrorHandler(func(error) {})
+
+	primary := &scriptedSpanExporter{err: errors.New("collector down")}
+	exp := &breakerSpanExporter{
+		primary: primary,
+		breaker: newCircuitBreaker("traces", &CircuitBreakerConfig{FailureThreshold: 1}),
+	}
+	spans := tracetest.SpanStubs{{Name: "a"}}.Snapshots()
+
+	require.Error(t, exp.ExportSpans(t.Context(), spans))
+	require.ErrorIs(t, exp.ExportSpans(t.Context(), spans), ErrCircuitOpen)
+	assert.Equal(t, 1, primary.calls)
+	assert.Equal(t, DefaultCircuitOpenDuration, exp.breaker.openFor)
+}
+
+func TestCircuitBreaker_FileFallback(t *testing.T) {
+	t.Cleanup(func() { SetErrorHandler(nil) })
+	SetErrorHandler(func(error) {})
+
+	path := filepath.Join(t.TempDir(), "fallback.jsonl")
+	cfg := &TelemetryConfig{
+		Enabled: boolPtr(true),
+		OTLP: &OTLPConfig{
+			Endpoint: "127.0.0.1:1",
+			Timeout:  100 * time.Millisecond,
+			Retry:    &RetryConfig{Enabled: boolPtr(false)},
+			CircuitBreaker: &CircuitBreakerConfig{
+				FailureThreshold: 1,
+				Fallback:         "file",
+				FallbackFile:     path,
+			},
+		},
+	}
+	exporters, err := buildTraceExporters(t.Context(), cfg)
+	require.NoError(t, err)
+	require.Len(t, exporters, 1)
+	require.IsType(t, &breakerSpanExporter{}, exporters[0])
+
+	spans := tracetest.SpanStubs{{Name: "lost-span"}}.Snapshots()
+	require.Error(t, exporters[0].ExportSpans(t.Context(), spans))
+	require.NoError(t, exporters[0].ExportSpans(t.Context(), spans))
+	require.NoError(t, exporters[0].Shutdown(t.Context()))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Len(t, lines, 2, "one JSON line per span")
+	assert.Contains(t, lines[0], `"Name":"lost-span"`)
+}
+
+func TestCircuitBreaker_Disabled(t *testing.T) {
+	cfg := &TelemetryConfig{Enabled: boolPtr(true)}
+	exporters, err := buildTraceExporters(t.Context(), cfg)
+	require.NoError(t, err)
+	_, isBreaker := exporters[0].(*breakerSpanExporter)
+	assert.False(t, isBreaker)
+	require.NoError(t, exporters[0].Shutdown(t.Context()))
+}
+
+// flakyTraceService fails trace exports while err is set.
+type flakyTraceService struct {
+	fakeTraceService
+
+	err atomic.Pointer[error]
+}
+
+func (s *flakyTraceService) Export(
+	ctx context.Context,
+	req *coltracepb.ExportTraceServiceRequest,
+) (*coltracepb.ExportTraceServiceResponse, error) {
+	if err := s.err.Load(); err != nil {
+		return nil, *err
+	}
+
+	return s.fakeTraceService.Export(ctx, req)
+}
+
+func TestCircuitBreaker_OTLPFallback(t *testing.T) {
+	t.Cleanup(func() { SetErrorHandler(nil) })
+	SetErrorHandler(func(error) {})
+
+	primary := &flakyTraceService{}
+	unavailable := status.Error(codes.Unavailable, "rolling out")
+	primary.err.Store(&unavailable)
+	secondary := &fakeTraceService{}
+	cfg := &TelemetryConfig{
+		Enabled: boolPtr(true),
+		OTLP: &OTLPConfig{
+			Endpoint: startOTLPGRPCServer(t, primary),
+			Headers:  map[string]string{"Authorization": "Bearer token"},
+			Retry:    &RetryConfig{Enabled: boolPtr(false)},
+			CircuitBreaker: &CircuitBreakerConfig{
+				FailureThreshold: 1,
+				OpenDuration:     time.Minute,
+				Fallback:         "otlp",
+				FallbackEndpoint: startOTLPGRPCServer(t, secondary),
+			},
+		},
+	}
+	exporters, err := buildTraceExporters(t.Context(), cfg)
+	require.NoError(t, err)
+	exp, ok := exporters[0].(*breakerSpanExporter)
+	require.True(t, ok)
+	defer func() { _ = exp.Shutdown(t.Context()) }()
+
+	now := time.Unix(0, 0)
+	exp.breaker.now = func() time.Time { return now }
+	spans := tracetest.SpanStubs{{Name: "a"}}.Snapshots()
+	ctx := t.Context()
+
+	require.Error(t, exp.ExportSpans(ctx, spans), "the primary's failure is reported")
+	require.NoError(t, exp.ExportSpans(ctx, spans), "open circuit fails over")
+	assert.Len(t, secondary.auths, 2, "the secondary also gets the rejected batch, with the shared headers")
+	assert.Empty(t, primary.auths)
+
+	primary.err.Store(nil)
+	now = now.Add(time.Minute)
+	require.NoError(t, exp.ExportSpans(ctx, spans), "the primary is probed and recovers")
+	require.NoError(t, exp.ExportSpans(ctx, spans))
+	assert.Len(t, primary.auths, 2)
+	assert.Len(t, secondary.auths, 2)
+}
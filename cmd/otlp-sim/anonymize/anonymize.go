@@ -0,0 +1,356 @@
+// Package anonymize strips identifying data from captured traces while keeping
+// their shape: span tree, kinds, timing, attribute keys and value equality.
+//
+// Input is the JSON written by the console/stdout trace exporter, one span per
+// JSON document (pretty-printed or compact). The result can be written back in
+// the same format or converted into an otlp-sim scenario.
+package anonymize
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+// hashPrefix marks anonymized string values.
+const hashPrefix = "anon-"
+
+// DefaultKeepAttributes lists attribute keys whose values describe the shape of
+// a trace rather than its data, and are kept verbatim by default.
+var DefaultKeepAttributes = []string{
+	"http.method",
+	"http.request.method",
+	"http.status_code",
+	"http.response.status_code",
+	"rpc.system",
+	"rpc.grpc.status_code",
+	"messaging.system",
+	"messaging.operation",
+	"messaging.operation.type",
+	"db.system",
+	"network.protocol.name",
+	"network.transport",
+	"error.type",
+	"otel.status_code",
+	"telemetry.sdk.language",
+	"telemetry.sdk.name",
+	"telemetry.sdk.version",
+}
+
+// Options configures an Anonymizer.
+type Options struct {
+	// Salt keys the hash of values and IDs. Equal inputs map to equal outputs only
+	// under the same salt. If empty, a random salt is used, so results cannot be
+	// correlated across runs.
+	Salt string
+
+	// KeepAttributes lists attribute keys whose values are kept verbatim.
+	// If nil, DefaultKeepAttributes is used.
+	KeepAttributes []string
+
+	// KeepNames keeps span, event and instrumentation scope names.
+	// By default they are hashed, since names like "GET /users/42" may carry data.
+	KeepNames bool
+}
+
+// Span is a span as written by the stdout trace exporter.
+type Span struct {
+	Name                   string
+	SpanContext            SpanContext
+	Parent                 SpanContext
+	SpanKind               int
+	StartTime              time.Time
+	EndTime                time.Time
+	Attributes             []KeyValue
+	Events                 []Event
+	Links                  []Link
+	Status                 Status
+	DroppedAttributes      int
+	DroppedEvents          int
+	DroppedLinks           int
+	ChildSpanCount         int
+	Resource               []KeyValue
+	InstrumentationScope   Scope
+	InstrumentationLibrary Scope
+}
+
+// SpanContext identifies a span.
+type SpanContext struct {
+	TraceID    string
+	SpanID     string
+	TraceFlags string
+	TraceState string
+	Remote     bool
+}
+
+// KeyValue is a typed attribute.
+type KeyValue struct {
+	Key   string
+	Value Value
+}
+
+// Value is an attribute value. Type is the OTel attribute type name
+// (STRING, INT64, FLOAT64, BOOL or their SLICE variants).
+type Value struct {
+	Type  string
+	Value any
+}
+
+// Event is a span event.
+type Event struct {
+	Name                  string
+	Attributes            []KeyValue
+	DroppedAttributeCount int
+	Time                  time.Time
+}
+
+// Link is a span link.
+type Link struct {
+	SpanContext           SpanContext
+	Attributes            []KeyValue
+	DroppedAttributeCount int
+}
+
+// Status is the span status.
+type Status struct {
+	Code        string
+	Description string
+}
+
+// Scope is an instrumentation scope.
+type Scope struct {
+	Name       string
+	Version    string
+	SchemaURL  string
+	Attributes []KeyValue
+}
+
+// Anonymizer rewrites spans with hashed values and IDs.
+// Hashing is deterministic for a given salt, so repeated values, parent/child
+// links and trace membership are preserved.
+type Anonymizer struct {
+	key       []byte
+	keep      map[string]struct{}
+	keepNames bool
+}
+
+// New creates an Anonymizer.
+func New(opts Options) (*Anonymizer, error) {
+	key := []byte(opts.Salt)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("failed to generate salt: %w", err)
+		}
+	}
+
+	keepKeys := opts.KeepAttributes
+	if keepKeys == nil {
+		keepKeys = DefaultKeepAttributes
+	}
+	keep := make(map[string]struct{}, len(keepKeys))
+	for _, k := range keepKeys {
+		keep[strings.TrimSpace(k)] = struct{}{}
+	}
+
+	return &Anonymizer{key: key, keep: keep, keepNames: opts.KeepNames}, nil
+}
+
+// ReadSpans decodes a stream of spans written by the stdout trace exporter.
+func ReadSpans(r io.Reader) ([]Span, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	var spans []Span
+	for {
+		var s Span
+		err := dec.Decode(&s)
+		if errors.Is(err, io.EOF) {
+			return spans, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode span %d: %w", len(spans)+1, err)
+		}
+		spans = append(spans, s)
+	}
+}
+
+// WriteSpans encodes spans as JSON, one span per line.
+func WriteSpans(w io.Writer, spans []Span) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for i := range spans {
+		if err := enc.Encode(&spans[i]); err != nil {
+			return fmt.Errorf("failed to encode span %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// Spans returns anonymized copies of spans. Timestamps, kinds, status codes,
+// attribute keys and counts are kept; IDs are remapped and values are hashed.
+func (a *Anonymizer) Spans(spans []Span) []Span {
+	result := make([]Span, len(spans))
+	for i, s := range spans {
+		result[i] = a.Span(s)
+	}
+
+	return result
+}
+
+// Span returns an anonymized copy of s.
+func (a *Anonymizer) Span(s Span) Span {
+	s.Name = a.name(s.Name)
+	s.SpanContext = a.spanContext(s.SpanContext)
+	s.Parent = a.spanContext(s.Parent)
+	s.Attributes = a.attributes(s.Attributes)
+	s.Resource = a.attributes(s.Resource)
+	s.Status.Description = a.hashString(s.Status.Description)
+	s.InstrumentationScope = a.scope(s.InstrumentationScope)
+	s.InstrumentationLibrary = a.scope(s.InstrumentationLibrary)
+
+	if s.Events != nil {
+		events := make([]Event, len(s.Events))
+		for i, e := range s.Events {
+			e.Name = a.name(e.Name)
+			e.Attributes = a.attributes(e.Attributes)
+			events[i] = e
+		}
+		s.Events = events
+	}
+
+	if s.Links != nil {
+		links := make([]Link, len(s.Links))
+		for i, l := range s.Links {
+			l.SpanContext = a.spanContext(l.SpanContext)
+			l.Attributes = a.attributes(l.Attributes)
+			links[i] = l
+		}
+		s.Links = links
+	}
+
+	return s
+}
+
+// name hashes a span, event or scope name unless names are kept.
+func (a *Anonymizer) name(name string) string {
+	if a.keepNames {
+		return name
+	}
+
+	return a.hashString(name)
+}
+
+// scope anonymizes an instrumentation scope. The version is kept.
+func (a *Anonymizer) scope(s Scope) Scope {
+	s.Name = a.name(s.Name)
+	s.Attributes = a.attributes(s.Attributes)
+
+	return s
+}
+
+// spanContext remaps trace and span IDs and drops the trace state,
+// which may carry vendor or tenant data.
+func (a *Anonymizer) spanContext(sc SpanContext) SpanContext {
+	sc.TraceID = a.hashID(sc.TraceID)
+	sc.SpanID = a.hashID(sc.SpanID)
+	sc.TraceState = ""
+
+	return sc
+}
+
+// attributes returns a copy of attrs with values hashed, except for kept keys.
+func (a *Anonymizer) attributes(attrs []KeyValue) []KeyValue {
+	if attrs == nil {
+		return nil
+	}
+
+	result := make([]KeyValue, len(attrs))
+	for i, kv := range attrs {
+		if _, ok := a.keep[kv.Key]; !ok {
+			kv.Value = a.value(kv.Value)
+		}
+		result[i] = kv
+	}
+
+	return result
+}
+
+// value hashes v keeping its type. Booleans are kept, since they carry little data.
+func (a *Anonymizer) value(v Value) Value {
+	switch v.Type {
+	case "STRING", "INT64", "FLOAT64":
+		v.Value = a.scalar(v.Type, v.Value)
+	case "STRINGSLICE", "INT64SLICE", "FLOAT64SLICE":
+		items, ok := v.Value.([]any)
+		if !ok {
+			break
+		}
+		elemType := strings.TrimSuffix(v.Type, "SLICE")
+		hashed := make([]any, len(items))
+		for i, item := range items {
+			hashed[i] = a.scalar(elemType, item)
+		}
+		v.Value = hashed
+	case "BOOL", "BOOLSLICE":
+		// Kept as is.
+	default:
+		v.Value = a.hashString(fmt.Sprint(v.Value))
+	}
+
+	return v
+}
+
+// scalar hashes a single value of the given type. Numbers stay numbers, so
+// consumers that rely on the attribute type keep working. Equal values hash
+// equally regardless of their key.
+func (a *Anonymizer) scalar(typ string, v any) any {
+	sum := a.sum(fmt.Sprint(v))
+	switch typ {
+	case "INT64":
+		return int64(binary.BigEndian.Uint64(sum) >> 1)
+	case "FLOAT64":
+		return float64(binary.BigEndian.Uint32(sum)) / math.MaxUint32
+	default:
+		s, _ := v.(string)
+
+		return a.hashString(s)
+	}
+}
+
+// hashString returns a short keyed hash of s. Empty strings stay empty.
+func (a *Anonymizer) hashString(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	return hashPrefix + hex.EncodeToString(a.sum(s)[:6])
+}
+
+// hashID remaps a hex trace or span ID to a hex ID of the same length.
+// Invalid (all-zero) IDs, such as the parent of a root span, are kept.
+func (a *Anonymizer) hashID(id string) string {
+	if strings.Trim(id, "0") == "" {
+		return id
+	}
+
+	return hex.EncodeToString(a.sum("id:" + id))[:len(id)]
+}
+
+// sum returns the keyed HMAC-SHA256 of s.
+func (a *Anonymizer) sum(s string) []byte {
+	mac := hmac.New(sha256.New, a.key)
+	_, _ = mac.Write([]byte(s))
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,162 @@
+package anonymize
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// captureSpans records a small two-span trace with the stdout exporter and decodes it.
+func captureSpans(t *testing.T, prettyPrint bool) []Span {
+	t.Helper()
+
+	var buf bytes.Buffer
+	opts := []stdouttrace.Option{stdouttrace.WithWriter(&buf)}
+	if prettyPrint {
+		opts = append(opts, stdouttrace.WithPrettyPrint())
+	}
+	exporter, err := stdouttrace.New(opts...)
+	require.NoError(t, err)
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", "checkout"))),
+	)
+	tracer := tp.Tracer("github.com/acme/checkout")
+
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	ctx, parent := tracer.Start(t.Context(), "GET /users/42",
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithTimestamp(start),
+		trace.WithAttributes(
+			attribute.String("http.request.method", "GET"),
+			attribute.String("user.email", "jane@example.com"),
+			attribute.Int("http.response.status_code", 200),
+			attribute.Int("user.id", 42),
+			attribute.StringSlice("user.roles", []string{"admin", "jane@example.com"}),
+			attribute.Bool("cache.hit", true),
+		),
+	)
+	_, child := tracer.Start(ctx, "SELECT users", trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithTimestamp(start.Add(10*time.Millisecond)),
+		trace.WithAttributes(attribute.String("db.user", "jane@example.com")),
+	)
+	child.RecordError(errors.New("deadlock for jane@example.com"))
+	child.SetStatus(codes.Error, "deadlock for jane@example.com")
+	child.End(trace.WithTimestamp(start.Add(40 * time.Millisecond)))
+	parent.End(trace.WithTimestamp(start.Add(100 * time.Millisecond)))
+
+	spans, err := ReadSpans(&buf)
+	require.NoError(t, err)
+	require.Len(t, spans, 2)
+
+	return spans
+}
+
+func TestReadSpans(t *testing.T) {
+	for _, pretty := range []bool{false, true} {
+		spans := captureSpans(t, pretty)
+		assert.Equal(t, "SELECT users", spans[0].Name)
+		assert.Equal(t, "GET /users/42", spans[1].Name)
+		assert.Equal(t, spans[1].SpanContext.SpanID, spans[0].Parent.SpanID)
+		assert.Equal(t, "Error", spans[0].Status.Code)
+	}
+}
+
+func TestReadSpans_Invalid(t *testing.T) {
+	_, err := ReadSpans(strings.NewReader(`{"Name": "a"} not-json`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "span 2")
+}
+
+func TestAnonymizer_Span(t *testing.T) {
+	spans := captureSpans(t, false)
+	a, err := New(Options{Salt: "s3cret"})
+	require.NoError(t, err)
+
+	anon := a.Spans(spans)
+	child, parent := anon[0], anon[1]
+
+	// Structure and timing are preserved.
+	assert.Equal(t, parent.SpanContext.SpanID, child.Parent.SpanID)
+	assert.Equal(t, parent.SpanContext.TraceID, child.SpanContext.TraceID)
+	assert.NotEqual(t, spans[1].SpanContext.TraceID, parent.SpanContext.TraceID)
+	assert.Len(t, parent.SpanContext.TraceID, 32)
+	assert.Len(t, parent.SpanContext.SpanID, 16)
+	assert.Equal(t, "0000000000000000", parent.Parent.SpanID)
+	assert.Equal(t, spans[1].StartTime, parent.StartTime)
+	assert.Equal(t, spans[1].EndTime, parent.EndTime)
+	assert.Equal(t, spans[1].SpanKind, parent.SpanKind)
+	assert.Equal(t, "Error", child.Status.Code)
+
+	attrs := make(map[string]Value)
+	for _, kv := range parent.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	assert.Equal(t, "GET", attrs["http.request.method"].Value, "kept by default")
+	assert.Equal(t, "200", fmt.Sprint(attrs["http.response.status_code"].Value))
+	assert.Equal(t, true, attrs["cache.hit"].Value)
+
+	email, ok := attrs["user.email"].Value.(string)
+	require.True(t, ok)
+	assert.True(t, strings.HasPrefix(email, hashPrefix))
+	assert.IsType(t, int64(0), attrs["user.id"].Value, "numbers stay numbers")
+	assert.NotEqual(t, "42", fmt.Sprint(attrs["user.id"].Value))
+	roles, ok := attrs["user.roles"].Value.([]any)
+	require.True(t, ok)
+	assert.Equal(t, email, roles[1], "equal values hash equally")
+	assert.Equal(t, email, child.Attributes[0].Value.Value, "equal values hash equally across spans")
+
+	// Free text is hashed.
+	assert.True(t, strings.HasPrefix(parent.Name, hashPrefix))
+	assert.NotEqual(t, spans[0].Status.Description, child.Status.Description)
+	assert.NotContains(t, child.Events[0].Attributes[1].Value.Value, "jane")
+	assert.NotEqual(t, "checkout", parent.Resource[0].Value.Value)
+	assert.True(t, strings.HasPrefix(parent.InstrumentationScope.Name, hashPrefix))
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteSpans(&buf, anon))
+	assert.NotContains(t, buf.String(), "jane")
+	assert.NotContains(t, buf.String(), "users/42")
+}
+
+func TestAnonymizer_Options(t *testing.T) {
+	spans := captureSpans(t, false)
+
+	a, err := New(Options{Salt: "s3cret", KeepNames: true, KeepAttributes: []string{"user.id"}})
+	require.NoError(t, err)
+	parent := a.Span(spans[1])
+	assert.Equal(t, "GET /users/42", parent.Name)
+	assert.Equal(t, "github.com/acme/checkout", parent.InstrumentationScope.Name)
+	for _, kv := range parent.Attributes {
+		switch kv.Key {
+		case "user.id":
+			assert.Equal(t, "42", fmt.Sprint(kv.Value.Value))
+		case "http.request.method":
+			assert.NotEqual(t, "GET", kv.Value.Value, "defaults are replaced")
+		}
+	}
+
+	// The same salt yields the same output; random salts do not.
+	again, err := New(Options{Salt: "s3cret", KeepNames: true, KeepAttributes: []string{"user.id"}})
+	require.NoError(t, err)
+	assert.Equal(t, parent, again.Span(spans[1]))
+
+	r1, err := New(Options{})
+	require.NoError(t, err)
+	r2, err := New(Options{})
+	require.NoError(t, err)
+	assert.NotEqual(t, r1.Span(spans[1]).SpanContext, r2.Span(spans[1]).SpanContext)
+}
@@ -0,0 +1,167 @@
+package anonymize
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/arloliu/otx/cmd/otlp-sim/scenario"
+)
+
+// ErrNoSpans is returned by ToScenario when the input has no spans.
+var ErrNoSpans = errors.New("anonymize: no spans in input")
+
+// serviceNameKey is the resource attribute holding the service name.
+const serviceNameKey = "service.name"
+
+// unknownService is used for spans without a service.name resource attribute.
+const unknownService = "unknown"
+
+// spanKinds maps trace.SpanKind values to scenario span kinds.
+var spanKinds = map[int]scenario.SpanKind{
+	1: scenario.SpanKindInternal,
+	2: scenario.SpanKindServer,
+	3: scenario.SpanKindClient,
+	4: scenario.SpanKindProducer,
+	5: scenario.SpanKindConsumer,
+}
+
+// ToScenario converts the first trace in spans into an otlp-sim scenario.
+// Anonymize spans first when the scenario is meant to be shared.
+//
+// The root is the span whose parent is not part of the capture; if there are
+// several, the earliest one is used. Children are ordered by start time. Since
+// otlp-sim runs children sequentially and then waits for the span's own
+// duration, each span's duration is its total duration minus that of its
+// children. Spans with an error status get an errorRate of 1.
+func ToScenario(spans []Span, name string) (*scenario.Scenario, error) {
+	if len(spans) == 0 {
+		return nil, ErrNoSpans
+	}
+
+	traceID := spans[0].SpanContext.TraceID
+	byID := make(map[string]Span)
+	for _, s := range spans {
+		if s.SpanContext.TraceID == traceID {
+			byID[s.SpanContext.SpanID] = s
+		}
+	}
+
+	children := make(map[string][]Span)
+	var roots []Span
+	for _, s := range byID {
+		if _, ok := byID[s.Parent.SpanID]; ok {
+			children[s.Parent.SpanID] = append(children[s.Parent.SpanID], s)
+		} else {
+			roots = append(roots, s)
+		}
+	}
+	if len(roots) == 0 {
+		return nil, fmt.Errorf("anonymize: trace %s has no root span", traceID)
+	}
+	sortByStart(roots)
+	for _, c := range children {
+		sortByStart(c)
+	}
+
+	s := &scenario.Scenario{
+		Version:     scenario.CurrentVersion,
+		Name:        name,
+		Description: fmt.Sprintf("Generated from trace %s (%d spans)", traceID, len(byID)),
+		RootSpan:    spanTemplate(roots[0], children),
+	}
+
+	var services []string
+	for _, sp := range spansByStart(byID) {
+		if svc := serviceName(sp); !slices.Contains(services, svc) {
+			services = append(services, svc)
+		}
+	}
+	for _, svc := range services {
+		s.Services = append(s.Services, scenario.Service{Name: svc})
+	}
+
+	return s, nil
+}
+
+// spanTemplate converts s and its descendants into a span template.
+func spanTemplate(s Span, children map[string][]Span) scenario.SpanTemplate {
+	kind, ok := spanKinds[s.SpanKind]
+	if !ok {
+		kind = scenario.SpanKindInternal
+	}
+
+	tmpl := scenario.SpanTemplate{
+		Name:    s.Name,
+		Service: serviceName(s),
+		Kind:    kind,
+	}
+	if len(s.Attributes) > 0 {
+		tmpl.Attributes = make(map[string]string, len(s.Attributes))
+		for _, kv := range s.Attributes {
+			tmpl.Attributes[kv.Key] = valueString(kv.Value)
+		}
+	}
+	if s.Status.Code == "Error" {
+		tmpl.ErrorRate = scenario.FixedErrorRate(1)
+		tmpl.ErrorStatus = s.Status.Description
+	}
+
+	self := s.EndTime.Sub(s.StartTime)
+	for _, c := range children[s.SpanContext.SpanID] {
+		self -= c.EndTime.Sub(c.StartTime)
+		tmpl.Children = append(tmpl.Children, spanTemplate(c, children))
+	}
+	tmpl.Duration = scenario.Duration(max(self, 0).Round(time.Microsecond))
+
+	return tmpl
+}
+
+// serviceName returns the service.name resource attribute of s.
+func serviceName(s Span) string {
+	for _, kv := range s.Resource {
+		if kv.Key == serviceNameKey {
+			if name, ok := kv.Value.Value.(string); ok && name != "" {
+				return name
+			}
+		}
+	}
+
+	return unknownService
+}
+
+// valueString formats an attribute value for a scenario, which stores attributes as strings.
+func valueString(v Value) string {
+	if strings.HasSuffix(v.Type, "SLICE") {
+		if data, err := json.Marshal(v.Value); err == nil {
+			return string(data)
+		}
+	}
+
+	return fmt.Sprint(v.Value)
+}
+
+// spansByStart returns the spans of byID ordered by start time.
+func spansByStart(byID map[string]Span) []Span {
+	spans := make([]Span, 0, len(byID))
+	for _, s := range byID {
+		spans = append(spans, s)
+	}
+	sortByStart(spans)
+
+	return spans
+}
+
+// sortByStart orders spans by start time, breaking ties by span ID for stable output.
+func sortByStart(spans []Span) {
+	slices.SortFunc(spans, func(a, b Span) int {
+		if c := a.StartTime.Compare(b.StartTime); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.SpanContext.SpanID, b.SpanContext.SpanID)
+	})
+}
@@ -0,0 +1,81 @@
+package anonymize
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/arloliu/otx/cmd/otlp-sim/scenario"
+)
+
+func TestToScenario(t *testing.T) {
+	spans := captureSpans(t, false)
+
+	s, err := ToScenario(spans, "captured")
+	require.NoError(t, err)
+
+	assert.Equal(t, "captured", s.Name)
+	assert.Equal(t, scenario.CurrentVersion, s.Version)
+	assert.Equal(t, []scenario.Service{{Name: "checkout"}}, s.Services)
+
+	root := s.RootSpan
+	assert.Equal(t, "GET /users/42", root.Name)
+	assert.Equal(t, "checkout", root.Service)
+	assert.Equal(t, scenario.SpanKindServer, root.Kind)
+	assert.Equal(t, 70*time.Millisecond, root.Duration.AsDuration(), "total minus children")
+	assert.Equal(t, "200", root.Attributes["http.response.status_code"])
+	assert.Equal(t, `["admin","jane@example.com"]`, root.Attributes["user.roles"])
+	assert.Zero(t, root.ErrorRate)
+
+	require.Len(t, root.Children, 1)
+	child := root.Children[0]
+	assert.Equal(t, scenario.SpanKindClient, child.Kind)
+	assert.Equal(t, 30*time.Millisecond, child.Duration.AsDuration())
+	assert.Equal(t, scenario.FixedErrorRate(1), child.ErrorRate)
+	assert.Equal(t, "deadlock for jane@example.com", child.ErrorStatus)
+
+	_, err = ToScenario(nil, "empty")
+	require.ErrorIs(t, err, ErrNoSpans)
+}
+
+func TestToScenario_Anonymized(t *testing.T) {
+	a, err := New(Options{Salt: "s3cret"})
+	require.NoError(t, err)
+
+	s, err := ToScenario(a.Spans(captureSpans(t, false)), "shared")
+	require.NoError(t, err)
+
+	require.Len(t, s.Services, 1)
+	assert.Equal(t, s.Services[0].Name, s.RootSpan.Service)
+	assert.NotEqual(t, "checkout", s.RootSpan.Service)
+	assert.Equal(t, 70*time.Millisecond, s.RootSpan.Duration.AsDuration())
+	require.Len(t, s.RootSpan.Children, 1)
+	assert.NotContains(t, s.RootSpan.Children[0].ErrorStatus, "jane")
+}
+
+func TestToScenario_PartialTrace(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	span := func(id, parent string, offset time.Duration) Span {
+		return Span{
+			Name:        id,
+			SpanContext: SpanContext{TraceID: "t1", SpanID: id},
+			Parent:      SpanContext{TraceID: "t1", SpanID: parent},
+			StartTime:   start.Add(offset),
+			EndTime:     start.Add(offset + time.Millisecond),
+		}
+	}
+	spans := []Span{
+		span("b", "missing", 2*time.Millisecond),
+		span("a", "missing", 0),
+		{Name: "other", SpanContext: SpanContext{TraceID: "t2", SpanID: "c"}},
+	}
+
+	s, err := ToScenario(spans, "partial")
+	require.NoError(t, err)
+	assert.Equal(t, "a", s.RootSpan.Name, "earliest root wins")
+	assert.Equal(t, unknownService, s.RootSpan.Service)
+	assert.Equal(t, scenario.SpanKindInternal, s.RootSpan.Kind)
+	assert.Contains(t, s.Description, "2 spans")
+}
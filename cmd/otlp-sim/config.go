@@ -2,6 +2,8 @@ package main
 
 import (
 	"flag"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/arloliu/fuda"
@@ -16,12 +18,16 @@ type Config struct {
 	Insecure    *bool  `yaml:"insecure" default:"true" env:"OTEL_EXPORTER_OTLP_INSECURE"`
 	ServiceName string `yaml:"serviceName" env:"OTEL_SERVICE_NAME"`
 
+	// Output writes OTLP JSON to a file (file://path) instead of the endpoint
+	Output string `yaml:"output"`
+
 	// Scenario settings
 	Scenario     string `yaml:"scenario" default:"payment"`
 	ScenarioFile string `yaml:"scenarioFile"`
 
 	// Signals
-	EnableLogs bool `yaml:"logs" default:"false"`
+	EnableLogs    bool `yaml:"logs" default:"false"`
+	EnableMetrics bool `yaml:"metrics" default:"false"`
 
 	// Quick mode
 	Count int `yaml:"count" default:"10"`
@@ -58,10 +64,34 @@ func (c *Config) bindCommonFlags(fs *flag.FlagSet) {
 
 		return nil
 	})
+	fs.StringVar(&c.Output, "output", c.Output, "Write OTLP JSON to file://path instead of the endpoint")
 	fs.StringVar(&c.ServiceName, "service-name", c.ServiceName, "Override service name")
 	fs.StringVar(&c.Scenario, "scenario", c.Scenario, "Scenario name")
 	fs.StringVar(&c.ScenarioFile, "scenario-file", c.ScenarioFile, "Custom YAML scenario file")
 	fs.BoolVar(&c.EnableLogs, "logs", c.EnableLogs, "Enable log generation")
+	fs.BoolVar(&c.EnableMetrics, "metrics", c.EnableMetrics, "Enable span duration histograms")
+}
+
+// outputFile returns the file path of Output, or "" to send to the endpoint.
+func (c *Config) outputFile() (string, error) {
+	if c.Output == "" {
+		return "", nil
+	}
+	path, ok := strings.CutPrefix(c.Output, "file://")
+	if !ok || path == "" {
+		return "", fmt.Errorf("unsupported output %q (want file://path)", c.Output)
+	}
+
+	return path, nil
+}
+
+// destination describes where telemetry goes, for progress messages.
+func (c *Config) destination() string {
+	if c.Output != "" {
+		return c.Output
+	}
+
+	return c.Endpoint
 }
 
 func (c *Config) applyEnvOverrides() {
@@ -103,3 +103,23 @@ func TestConfig_IsInsecure_NilPointer(t *testing.T) {
 	// Should default to true when nil
 	assert.True(t, cfg.IsInsecure())
 }
+
+func TestConfig_OutputFile(t *testing.T) {
+	cfg := newConfig()
+	path, err := cfg.outputFile()
+	require.NoError(t, err)
+	assert.Empty(t, path)
+	assert.Equal(t, "localhost:4317", cfg.destination())
+
+	cfg.Output = "file://out/traces.json"
+	path, err = cfg.outputFile()
+	require.NoError(t, err)
+	assert.Equal(t, "out/traces.json", path)
+	assert.Equal(t, "file://out/traces.json", cfg.destination())
+
+	for _, output := range []string{"traces.json", "s3://bucket/traces.json", "file://"} {
+		cfg.Output = output
+		_, err = cfg.outputFile()
+		assert.Error(t, err, output)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/rand/v2"
 	"strconv"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -17,7 +18,9 @@ import (
 	"go.opentelemetry.io/otel/codes"
 	otellog "go.opentelemetry.io/otel/log"
 	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/metric"
 	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -55,10 +58,20 @@ func (h *errorHandler) Summary() string {
 type Engine struct {
 	tracerProvider *sdktrace.TracerProvider
 	loggerProvider *sdklog.LoggerProvider
+	meterProvider  *sdkmetric.MeterProvider
+	sink           *fileSink               // Nil unless writing to a file
+	spanDuration   metric.Float64Histogram // Nil unless metrics are enabled
 	errorHandler   *errorHandler
 	enableLogs     bool
 	jitterPct      int
 	serviceName    string
+	cfg            Config
+	started        time.Time // Start of the simulation, for ramped error rates
+
+	// Providers for spans with their own resource, keyed by resource
+	mu                sync.Mutex
+	resourceProviders map[attribute.Distinct]*sdktrace.TracerProvider
+	newExporter       func(ctx context.Context) (sdktrace.SpanExporter, error)
 }
 
 // Config holds engine configuration.
@@ -69,10 +82,26 @@ type Config struct {
 	ServiceName string
 	EnableLogs  bool
 	JitterPct   int
+
+	// EnableMetrics sends span duration histograms matching the generated traces.
+	EnableMetrics bool
+
+	// OutputFile, if set, receives the telemetry as OTLP JSON instead of Endpoint.
+	OutputFile string
 }
 
 // New creates a new Engine with the given configuration.
 func New(ctx context.Context, cfg Config) (*Engine, error) {
+	// Export to a local receiver writing the file instead of the endpoint
+	var sink *fileSink
+	if cfg.OutputFile != "" {
+		var err error
+		if sink, err = newFileSink(cfg.OutputFile); err != nil {
+			return nil, err
+		}
+		cfg.Endpoint, cfg.UseHTTP, cfg.Insecure = sink.Endpoint(), true, true
+	}
+
 	// Build otx telemetry config
 	protocol := "grpc"
 	if cfg.UseHTTP {
@@ -103,16 +132,23 @@ func New(ctx context.Context, cfg Config) (*Engine, error) {
 	// Initialize tracer provider
 	tp, err := otx.NewTracerProvider(ctx, telCfg)
 	if err != nil {
+		if sink != nil {
+			_ = sink.Close(ctx)
+		}
 		return nil, fmt.Errorf("failed to create tracer provider: %w", err)
 	}
 
 	e := &Engine{
 		tracerProvider: tp,
+		sink:           sink,
 		errorHandler:   errHandler,
 		enableLogs:     cfg.EnableLogs,
 		jitterPct:      cfg.JitterPct,
 		serviceName:    serviceName,
+		cfg:            cfg,
+		started:        time.Now(),
 	}
+	e.newExporter = e.otlpExporter
 
 	// Initialize logger provider if logs enabled
 	if cfg.EnableLogs {
@@ -127,6 +163,22 @@ func New(ctx context.Context, cfg Config) (*Engine, error) {
 		}
 	}
 
+	// Initialize meter provider if metrics enabled
+	if cfg.EnableMetrics {
+		enabled := true
+		telCfg.Metrics = &otx.MetricsConfig{Enabled: &enabled}
+		mp, err := otx.NewMeterProvider(ctx, telCfg)
+		if err != nil {
+			_ = e.Shutdown(ctx)
+			return nil, fmt.Errorf("failed to create meter provider: %w", err)
+		}
+		e.meterProvider = mp
+		if e.spanDuration, err = newSpanDuration(mp); err != nil {
+			_ = e.Shutdown(ctx)
+			return nil, fmt.Errorf("failed to create span duration histogram: %w", err)
+		}
+	}
+
 	return e, nil
 }
 
@@ -138,11 +190,28 @@ func (e *Engine) Shutdown(ctx context.Context) error {
 			errs = append(errs, err)
 		}
 	}
+	e.mu.Lock()
+	for _, tp := range e.resourceProviders {
+		if err := tp.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	e.mu.Unlock()
+	if e.meterProvider != nil {
+		if err := e.meterProvider.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
 	if e.loggerProvider != nil {
 		if err := e.loggerProvider.Shutdown(ctx); err != nil {
 			errs = append(errs, err)
 		}
 	}
+	if e.sink != nil {
+		if err := e.sink.Close(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
 	// Report export error summary
 	if e.errorHandler != nil {
 		if summary := e.errorHandler.Summary(); summary != "" {
@@ -159,7 +228,7 @@ func (e *Engine) Shutdown(ctx context.Context) error {
 
 // GenerateTrace generates a complete trace from a scenario.
 func (e *Engine) GenerateTrace(ctx context.Context, s *scenario.Scenario) error {
-	return e.generateSpan(ctx, s.RootSpan, nil)
+	return e.generateSpan(ctx, s.RootSpan, nil, s.Faults)
 }
 
 // generateSpan recursively generates a span and its children.
@@ -167,6 +236,7 @@ func (e *Engine) generateSpan(
 	ctx context.Context,
 	tmpl scenario.SpanTemplate,
 	parentSpan trace.Span,
+	faults *scenario.Faults,
 ) error {
 	// Determine service name for this span
 	serviceName := tmpl.Service
@@ -175,14 +245,18 @@ func (e *Engine) generateSpan(
 		serviceName = e.serviceName
 	}
 
-	// Create tracer for this service
-	tracer := otel.Tracer(serviceName)
+	// Create tracer for this service, with the span's own resource if any
+	tracer, err := e.tracer(ctx, serviceName, spanResource(tmpl, faults))
+	if err != nil {
+		return err
+	}
 
 	// Convert span kind
 	kind := toTraceSpanKind(tmpl.Kind)
 
 	// Build attributes
 	attrs := parseAttributes(tmpl.Attributes)
+	attrs = append(attrs, faultAttributes(faults)...)
 
 	// Start span
 	spanCtx := ctx
@@ -190,9 +264,11 @@ func (e *Engine) generateSpan(
 		spanCtx = trace.ContextWithSpan(ctx, parentSpan)
 	}
 
+	start := time.Now()
 	_, span := tracer.Start(spanCtx, tmpl.Name,
 		trace.WithSpanKind(kind),
 		trace.WithAttributes(attrs...),
+		trace.WithTimestamp(start),
 	)
 
 	// Calculate duration with jitter
@@ -204,14 +280,15 @@ func (e *Engine) generateSpan(
 	}
 
 	// Check for error simulation
-	if tmpl.ErrorRate > 0 && rand.Float64() < tmpl.ErrorRate { //nolint:gosec // weak rand is fine for simulation
+	failed := chance(tmpl.ErrorRate.At(time.Since(e.started)))
+	if failed {
 		span.SetStatus(codes.Error, tmpl.ErrorStatus)
 		span.RecordError(fmt.Errorf("%s", tmpl.ErrorStatus))
 	}
 
 	// Generate child spans
 	for _, child := range tmpl.Children {
-		if err := e.generateSpan(spanCtx, child, span); err != nil {
+		if err := e.generateSpan(spanCtx, child, span, faults); err != nil {
 			span.End()
 			return err
 		}
@@ -220,7 +297,10 @@ func (e *Engine) generateSpan(
 	// Simulate duration by sleeping
 	time.Sleep(duration)
 
-	span.End()
+	// Record the metric with the span's own timestamps, so both report the same latency
+	end := time.Now()
+	span.End(trace.WithTimestamp(end))
+	e.recordDuration(trace.ContextWithSpan(ctx, span), serviceName, tmpl, end.Sub(start), failed)
 
 	return nil
 }
@@ -0,0 +1,129 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/arloliu/otx/cmd/otlp-sim/scenario"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// invalidSemconvAttributes are span attributes violating semantic conventions.
+var invalidSemconvAttributes = []attribute.KeyValue{
+	attribute.String("http.response.status_code", "OK"),
+	attribute.String("http.request.method", "FETCH"),
+	attribute.Int("server.port", -1),
+}
+
+// invalidResource has an empty service.name and a numeric service.version.
+var invalidResource = resource.NewSchemaless(
+	attribute.String("service.name", ""),
+	attribute.Int("service.version", 2),
+)
+
+// spanResource returns the resource a span of tmpl is emitted with, or nil for
+// the engine's own resource. An explicit tmpl.Resource wins over faults.
+func spanResource(tmpl scenario.SpanTemplate, faults *scenario.Faults) *resource.Resource {
+	if tmpl.Resource != nil {
+		return resource.NewSchemaless(parseAttributes(tmpl.Resource.Attributes)...)
+	}
+	if faults == nil {
+		return nil
+	}
+
+	switch {
+	case chance(faults.MissingServiceName):
+		return resource.Empty()
+	case chance(faults.InvalidResource):
+		return invalidResource
+	default:
+		return nil
+	}
+}
+
+// faultAttributes returns the invalid span attributes to add for faults, if any.
+func faultAttributes(faults *scenario.Faults) []attribute.KeyValue {
+	if faults == nil || !chance(faults.InvalidSemconv) {
+		return nil
+	}
+
+	return invalidSemconvAttributes
+}
+
+// chance reports true with probability rate.
+func chance(rate float64) bool {
+	return rate > 0 && rand.Float64() < rate //nolint:gosec // weak rand is fine for simulation
+}
+
+// tracer returns the tracer named name emitting spans with res. A nil res uses
+// the global provider; other resources get a provider of their own, created on
+// first use and shut down with the engine.
+func (e *Engine) tracer(ctx context.Context, name string, res *resource.Resource) (trace.Tracer, error) {
+	if res == nil {
+		return otel.Tracer(name), nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	key := res.Equivalent()
+	if tp, ok := e.resourceProviders[key]; ok {
+		return tp.Tracer(name), nil
+	}
+
+	exporter, err := e.newExporter(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create exporter for span resource: %w", err)
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithResource(res),
+		sdktrace.WithBatcher(exporter),
+	)
+	if e.resourceProviders == nil {
+		e.resourceProviders = make(map[attribute.Distinct]*sdktrace.TracerProvider)
+	}
+	e.resourceProviders[key] = tp
+
+	return tp.Tracer(name), nil
+}
+
+// otlpExporter creates an OTLP span exporter for the engine endpoint.
+func (e *Engine) otlpExporter(ctx context.Context) (sdktrace.SpanExporter, error) {
+	hasScheme := strings.Contains(e.cfg.Endpoint, "://")
+	if e.cfg.UseHTTP {
+		var opts []otlptracehttp.Option
+		switch {
+		case hasScheme:
+			opts = append(opts, otlptracehttp.WithEndpointURL(e.cfg.Endpoint))
+		case e.cfg.Endpoint != "":
+			opts = append(opts, otlptracehttp.WithEndpoint(e.cfg.Endpoint))
+		}
+		if e.cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+
+		return otlptracehttp.New(ctx, opts...)
+	}
+
+	var opts []otlptracegrpc.Option
+	switch {
+	case hasScheme:
+		opts = append(opts, otlptracegrpc.WithEndpointURL(e.cfg.Endpoint))
+	case e.cfg.Endpoint != "":
+		opts = append(opts, otlptracegrpc.WithEndpoint(e.cfg.Endpoint))
+	}
+	if e.cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+
+	return otlptracegrpc.New(ctx, opts...)
+}
@@ -0,0 +1,77 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/arloliu/otx/cmd/otlp-sim/scenario"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSpanResource(t *testing.T) {
+	tmpl := scenario.SpanTemplate{Name: "span"}
+	assert.Nil(t, spanResource(tmpl, nil))
+	assert.Nil(t, spanResource(tmpl, &scenario.Faults{}))
+
+	res := spanResource(tmpl, &scenario.Faults{MissingServiceName: 1})
+	require.NotNil(t, res)
+	assert.Zero(t, res.Len())
+
+	res = spanResource(tmpl, &scenario.Faults{InvalidResource: 1})
+	value, ok := res.Set().Value("service.name")
+	require.True(t, ok)
+	assert.Empty(t, value.AsString())
+	value, _ = res.Set().Value("service.version")
+	assert.Equal(t, attribute.INT64, value.Type())
+
+	tmpl.Resource = &scenario.ResourceTemplate{Attributes: map[string]string{"service.version": "2"}}
+	res = spanResource(tmpl, &scenario.Faults{MissingServiceName: 1})
+	assert.Equal(t, []attribute.KeyValue{attribute.Int64("service.version", 2)}, res.Attributes(),
+		"explicit resource wins over faults")
+}
+
+func TestFaultAttributes(t *testing.T) {
+	assert.Nil(t, faultAttributes(nil))
+	assert.Nil(t, faultAttributes(&scenario.Faults{InvalidSemconv: 0}))
+	assert.Equal(t, invalidSemconvAttributes, faultAttributes(&scenario.Faults{InvalidSemconv: 1}))
+}
+
+func TestEngine_GenerateTrace_Faults(t *testing.T) {
+	ctx := t.Context()
+	exporter := tracetest.NewInMemoryExporter()
+	created := 0
+	e := &Engine{newExporter: func(context.Context) (sdktrace.SpanExporter, error) {
+		created++
+
+		return exporter, nil
+	}}
+
+	s := &scenario.Scenario{
+		Name:   "faults",
+		Faults: &scenario.Faults{MissingServiceName: 1, InvalidSemconv: 1},
+		RootSpan: scenario.SpanTemplate{
+			Name:     "root",
+			Service:  "svc",
+			Children: []scenario.SpanTemplate{{Name: "child", Service: "svc"}},
+		},
+	}
+	require.NoError(t, e.GenerateTrace(ctx, s))
+	for _, tp := range e.resourceProviders {
+		require.NoError(t, tp.ForceFlush(ctx))
+	}
+
+	assert.Equal(t, 1, created, "spans with the same resource share a provider")
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	for _, span := range spans {
+		assert.Equal(t, resource.Empty(), span.Resource)
+		assert.Contains(t, span.Attributes, attribute.String("http.response.status_code", "OK"))
+	}
+	assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+}
@@ -0,0 +1,48 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"github.com/arloliu/otx/cmd/otlp-sim/scenario"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// SpanDurationMetric is the histogram of generated span durations, in seconds.
+const SpanDurationMetric = "otlp_sim.span.duration"
+
+// durationBuckets are the bucket boundaries of SpanDurationMetric, as recommended
+// for HTTP and RPC durations by the semantic conventions.
+var durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10}
+
+// newSpanDuration creates the SpanDurationMetric histogram of mp.
+func newSpanDuration(mp metric.MeterProvider) (metric.Float64Histogram, error) {
+	return mp.Meter("otlp-sim").Float64Histogram(SpanDurationMetric,
+		metric.WithDescription("Duration of the generated spans, per service and operation."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(durationBuckets...),
+	)
+}
+
+// recordDuration records the duration of a span generated from tmpl. ctx holds
+// the span, so trace-based exemplars link the measurement to its trace.
+func (e *Engine) recordDuration(
+	ctx context.Context, service string, tmpl scenario.SpanTemplate, d time.Duration, failed bool,
+) {
+	if e.spanDuration == nil {
+		return
+	}
+
+	status := "UNSET"
+	if failed {
+		status = "ERROR"
+	}
+	e.spanDuration.Record(ctx, d.Seconds(), metric.WithAttributes(
+		attribute.String("service.name", service),
+		attribute.String("span.name", tmpl.Name),
+		attribute.String("span.kind", toTraceSpanKind(tmpl.Kind).String()),
+		attribute.String("otel.status_code", status),
+	))
+}
@@ -0,0 +1,77 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/arloliu/otx/cmd/otlp-sim/scenario"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestEngine_GenerateTrace_SpanDuration(t *testing.T) {
+	ctx := t.Context()
+	spans := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	defer func() { _ = mp.Shutdown(ctx) }()
+	histogram, err := newSpanDuration(mp)
+	require.NoError(t, err)
+
+	e := &Engine{
+		spanDuration: histogram,
+		newExporter: func(context.Context) (sdktrace.SpanExporter, error) {
+			return spans, nil
+		},
+	}
+	res := &scenario.ResourceTemplate{Attributes: map[string]string{"service.name": "svc"}}
+	s := &scenario.Scenario{
+		Name: "metrics",
+		RootSpan: scenario.SpanTemplate{
+			Name:      "GET /orders",
+			Service:   "gateway",
+			Kind:      scenario.SpanKindServer,
+			Duration:  scenario.Duration(5 * time.Millisecond),
+			ErrorRate: scenario.FixedErrorRate(1),
+			Resource:  res,
+		},
+	}
+	require.NoError(t, e.GenerateTrace(ctx, s))
+	for _, tp := range e.resourceProviders {
+		require.NoError(t, tp.ForceFlush(ctx))
+	}
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+	hist, ok := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, hist.DataPoints, 1)
+	dp := hist.DataPoints[0]
+
+	require.Len(t, spans.GetSpans(), 1)
+	span := spans.GetSpans()[0]
+	assert.Equal(t, uint64(1), dp.Count)
+	assert.InDelta(t, span.EndTime.Sub(span.StartTime).Seconds(), dp.Sum, 1e-9, "metric matches the span duration")
+	for _, kv := range []attribute.KeyValue{
+		attribute.String("service.name", "gateway"),
+		attribute.String("span.name", "GET /orders"),
+		attribute.String("span.kind", "server"),
+		attribute.String("otel.status_code", "ERROR"),
+	} {
+		value, ok := dp.Attributes.Value(kv.Key)
+		assert.True(t, ok, kv.Key)
+		assert.Equal(t, kv.Value, value, kv.Key)
+	}
+	require.Len(t, dp.Exemplars, 1)
+	traceID := span.SpanContext.TraceID()
+	assert.Equal(t, traceID[:], dp.Exemplars[0].TraceID, "exemplar links to the trace")
+}
@@ -0,0 +1,153 @@
+package engine
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// idKeys are the OTLP JSON keys of trace and span IDs, which are hex encoded
+// instead of the base64 protojson uses for bytes.
+var idKeys = map[string]bool{"traceId": true, "spanId": true, "parentSpanId": true}
+
+// fileSink is an OTLP/HTTP receiver on a loopback port that writes the export
+// requests it receives to a file as OTLP JSON, one request per line. This is the
+// format read by the collector's otlpjsonfile receiver.
+type fileSink struct {
+	mu     sync.Mutex
+	file   *os.File
+	server *http.Server
+	addr   string
+}
+
+// newFileSink creates path and starts receiving.
+func newFileSink(path string) (*fileSink, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to listen for file output: %w", err)
+	}
+
+	s := &fileSink{file: file, addr: lis.Addr().String()}
+	s.server = &http.Server{Handler: s, ReadHeaderTimeout: 5 * time.Second}
+	go func() { _ = s.server.Serve(lis) }()
+
+	return s, nil
+}
+
+// Endpoint returns the host:port of the sink, to be used with insecure OTLP/HTTP.
+func (s *fileSink) Endpoint() string {
+	return s.addr
+}
+
+// Close stops receiving and closes the file. Call it after the providers are shut down.
+func (s *fileSink) Close(ctx context.Context) error {
+	return errors.Join(s.server.Shutdown(ctx), s.file.Close())
+}
+
+// ServeHTTP implements http.Handler.
+func (s *fileSink) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req proto.Message
+	switch r.URL.Path {
+	case "/v1/traces":
+		req = &coltracepb.ExportTraceServiceRequest{}
+	case "/v1/metrics":
+		req = &colmetricspb.ExportMetricsServiceRequest{}
+	case "/v1/logs":
+		req = &collogspb.ExportLogsServiceRequest{}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	body := io.Reader(r.Body)
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer func() { _ = gz.Close() }()
+		body = gz
+	}
+	data, err := io.ReadAll(body)
+	if err == nil {
+		err = proto.Unmarshal(data, req)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	line, err := otlpJSON(req)
+	if err == nil {
+		s.mu.Lock()
+		_, err = s.file.Write(append(line, '\n'))
+		s.mu.Unlock()
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// An empty body is a valid, empty export response
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.WriteHeader(http.StatusOK)
+}
+
+// otlpJSON encodes msg as OTLP JSON: protojson with enums as numbers and hex IDs.
+func otlpJSON(msg proto.Message) ([]byte, error) {
+	data, err := protojson.MarshalOptions{UseEnumNumbers: true}.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	hexIDs(v)
+
+	return json.Marshal(v)
+}
+
+// hexIDs re-encodes the base64 trace and span IDs found in v as hex, in place.
+func hexIDs(v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if s, ok := value.(string); ok && idKeys[key] {
+				if id, err := base64.StdEncoding.DecodeString(s); err == nil {
+					v[key] = hex.EncodeToString(id)
+				}
+
+				continue
+			}
+			hexIDs(value)
+		}
+	case []any:
+		for _, value := range v {
+			hexIDs(value)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package engine
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/arloliu/otx/cmd/otlp-sim/scenario"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_OutputFile(t *testing.T) {
+	ctx := t.Context()
+	path := filepath.Join(t.TempDir(), "traces.json")
+	e, err := New(ctx, Config{ServiceName: "checkout", OutputFile: path, EnableLogs: true, EnableMetrics: true})
+	require.NoError(t, err)
+
+	s := &scenario.Scenario{
+		Name: "output",
+		RootSpan: scenario.SpanTemplate{
+			Name:     "GET /cart",
+			Service:  "cart",
+			Logs:     []scenario.LogTemplate{{Level: "INFO", Message: "cart loaded"}},
+			Children: []scenario.SpanTemplate{{Name: "SELECT carts", Service: "db"}},
+		},
+	}
+	require.NoError(t, e.GenerateTrace(ctx, s))
+	require.NoError(t, e.Shutdown(ctx))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	signals := map[string]int{}
+	hexID := regexp.MustCompile(`^[0-9a-f]{32}$`)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var req map[string][]map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &req), scanner.Text())
+		for signal := range req {
+			signals[signal]++
+		}
+		for _, rs := range req["resourceSpans"] {
+			for _, ss := range rs["scopeSpans"].([]any) {
+				for _, span := range ss.(map[string]any)["spans"].([]any) {
+					assert.Regexp(t, hexID, span.(map[string]any)["traceId"])
+				}
+			}
+		}
+	}
+	require.NoError(t, scanner.Err())
+
+	assert.Contains(t, signals, "resourceSpans")
+	assert.Contains(t, signals, "resourceLogs")
+	assert.Contains(t, signals, "resourceMetrics")
+}
+
+func TestOTLPJSON_HexIDs(t *testing.T) {
+	v := map[string]any{"links": []any{map[string]any{"traceId": "AAECAw==", "spanId": "BAU=", "name": "AAECAw=="}}}
+	hexIDs(v)
+
+	link := v["links"].([]any)[0].(map[string]any)
+	assert.Equal(t, "00010203", link["traceId"])
+	assert.Equal(t, "0405", link["spanId"])
+	assert.Equal(t, "AAECAw==", link["name"], "only ID keys are re-encoded")
+}
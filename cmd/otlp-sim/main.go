@@ -5,11 +5,17 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
+	"slices"
+	"strings"
 	"syscall"
 	"time"
 
+	"gopkg.in/yaml.v3"
+
+	"github.com/arloliu/otx/cmd/otlp-sim/anonymize"
 	"github.com/arloliu/otx/cmd/otlp-sim/engine"
 	"github.com/arloliu/otx/cmd/otlp-sim/scenario"
 )
@@ -28,6 +34,10 @@ func main() {
 		runContinuousMode(os.Args[2:])
 	case "list":
 		listScenarios()
+	case "schema":
+		printSchema()
+	case "anonymize":
+		runAnonymizeMode(os.Args[2:])
 	case "-h", "--help", "help":
 		printUsage()
 	default:
@@ -44,9 +54,11 @@ Usage:
   otlp-sim <mode> [flags]
 
 Modes:
-  quick   Send traces immediately for quick visualization
-  run     Simulate real-world timing continuously
-  list    List available scenarios
+  quick      Send traces immediately for quick visualization
+  run        Simulate real-world timing continuously
+  list       List available scenarios
+  schema     Print the JSON Schema for scenario YAML files
+  anonymize  Strip data from captured traces, keeping their shape
 
 Quick Mode Flags:
   --endpoint     OTLP endpoint (default: localhost:4317)
@@ -55,6 +67,8 @@ Quick Mode Flags:
   --scenario     Scenario name (default: payment)
   --count        Number of traces to send (default: 10)
   --logs         Enable log generation
+  --metrics      Enable span duration histograms
+  --output       Write OTLP JSON to file://path instead of the endpoint
   --service-name Override service name
 
 Continuous Mode Flags:
@@ -66,8 +80,19 @@ Continuous Mode Flags:
   --rate         Traces per second (default: 1)
   --jitter       Timing variation percentage (default: 20)
   --logs         Enable log generation
+  --metrics      Enable span duration histograms
+  --output       Write OTLP JSON to file://path instead of the endpoint
   --service-name Override service name
 
+Anonymize Mode Flags:
+  --in           Captured spans (console exporter JSON), - for stdin (default: -)
+  --out          Output file, - for stdout (default: -)
+  --format       Output format: json or scenario (default: json)
+  --salt         Hash salt; reuse it to keep hashes stable across runs (default: random)
+  --keep         Extra attribute keys to keep verbatim (comma-separated)
+  --keep-names   Keep span, event and scope names
+  --name         Scenario name for --format scenario (default: anonymized)
+
 Environment Variables:
   OTEL_EXPORTER_OTLP_ENDPOINT   OTLP endpoint
   OTEL_EXPORTER_OTLP_PROTOCOL   grpc or http
@@ -77,7 +102,10 @@ Environment Variables:
 Examples:
   otlp-sim quick --scenario payment --count 5
   otlp-sim run --scenario edge-iot --duration 5m --rate 10
-  otlp-sim list`)
+  otlp-sim quick --count 5 --logs --output file://traces.json
+  otlp-sim list
+  otlp-sim schema > scenario.schema.json
+  otlp-sim anonymize --in spans.json --format scenario > shape.yaml`)
 }
 
 func runQuickMode(args []string) {
@@ -145,6 +173,46 @@ func listScenarios() {
                - Useful for verifying OTLP connection`)
 }
 
+func runAnonymizeMode(args []string) {
+	fs := flag.NewFlagSet("anonymize", flag.ExitOnError)
+	in := fs.String("in", "-", "Captured spans (console exporter JSON), - for stdin")
+	out := fs.String("out", "-", "Output file, - for stdout")
+	format := fs.String("format", "json", "Output format: json or scenario")
+	name := fs.String("name", "anonymized", "Scenario name for --format scenario")
+	keep := fs.String("keep", "", "Extra attribute keys to keep verbatim (comma-separated)")
+	var opts anonymize.Options
+	fs.StringVar(&opts.Salt, "salt", "", "Hash salt (default: random)")
+	fs.BoolVar(&opts.KeepNames, "keep-names", false, "Keep span, event and scope names")
+
+	if err := fs.Parse(args); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error parsing flags: %v\n", err)
+		return
+	}
+
+	opts.KeepAttributes = slices.Clone(anonymize.DefaultKeepAttributes)
+	for key := range strings.SplitSeq(*keep, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			opts.KeepAttributes = append(opts.KeepAttributes, key)
+		}
+	}
+
+	if err := executeAnonymize(*in, *out, *format, *name, opts); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// printSchema writes the scenario JSON Schema to stdout.
+func printSchema() {
+	schema, err := scenario.JSONSchema()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println(string(schema))
+}
+
 // executeQuick sends traces immediately.
 func executeQuick(ctx context.Context, cfg *Config) error {
 	s, err := loadScenario(cfg)
@@ -152,19 +220,27 @@ func executeQuick(ctx context.Context, cfg *Config) error {
 		return err
 	}
 
+	outputFile, err := cfg.outputFile()
+	if err != nil {
+		return err
+	}
+
 	eng, err := engine.New(ctx, engine.Config{
-		Endpoint:    cfg.Endpoint,
-		UseHTTP:     cfg.UseHTTP,
-		Insecure:    cfg.IsInsecure(),
-		ServiceName: cfg.ServiceName,
-		EnableLogs:  cfg.EnableLogs,
-		JitterPct:   0, // No jitter in quick mode
+		Endpoint:      cfg.Endpoint,
+		UseHTTP:       cfg.UseHTTP,
+		Insecure:      cfg.IsInsecure(),
+		ServiceName:   cfg.ServiceName,
+		EnableLogs:    cfg.EnableLogs,
+		EnableMetrics: cfg.EnableMetrics,
+		OutputFile:    outputFile,
+		JitterPct:     0, // No jitter in quick mode
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create engine: %w", err)
 	}
+	defer func() { _ = eng.Shutdown(context.WithoutCancel(ctx)) }()
 
-	fmt.Printf("Sending %d traces to %s (scenario: %s)\n", cfg.Count, cfg.Endpoint, s.Name)
+	fmt.Printf("Sending %d traces to %s (scenario: %s)\n", cfg.Count, cfg.destination(), s.Name)
 
 	for i := range cfg.Count {
 		select {
@@ -194,17 +270,25 @@ func executeContinuous(ctx context.Context, cfg *Config) error {
 		return err
 	}
 
+	outputFile, err := cfg.outputFile()
+	if err != nil {
+		return err
+	}
+
 	eng, err := engine.New(ctx, engine.Config{
-		Endpoint:    cfg.Endpoint,
-		UseHTTP:     cfg.UseHTTP,
-		Insecure:    cfg.IsInsecure(),
-		ServiceName: cfg.ServiceName,
-		EnableLogs:  cfg.EnableLogs,
-		JitterPct:   cfg.Jitter,
+		Endpoint:      cfg.Endpoint,
+		UseHTTP:       cfg.UseHTTP,
+		Insecure:      cfg.IsInsecure(),
+		ServiceName:   cfg.ServiceName,
+		EnableLogs:    cfg.EnableLogs,
+		EnableMetrics: cfg.EnableMetrics,
+		OutputFile:    outputFile,
+		JitterPct:     cfg.Jitter,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create engine: %w", err)
 	}
+	defer func() { _ = eng.Shutdown(context.WithoutCancel(ctx)) }()
 
 	fmt.Printf("Running %s scenario for %v at %.1f traces/sec\n", s.Name, cfg.Duration, cfg.Rate)
 
@@ -235,10 +319,71 @@ func executeContinuous(ctx context.Context, cfg *Config) error {
 	}
 }
 
+// executeAnonymize anonymizes the spans read from in and writes them to out
+// as console exporter JSON or as a scenario YAML.
+func executeAnonymize(in, out, format, name string, opts anonymize.Options) error {
+	if format != "json" && format != "scenario" {
+		return fmt.Errorf("unknown format %q (want json or scenario)", format)
+	}
+
+	var r io.Reader = os.Stdin
+	if in != "-" {
+		f, err := os.Open(in)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		r = f
+	}
+
+	spans, err := anonymize.ReadSpans(r)
+	if err != nil {
+		return err
+	}
+
+	a, err := anonymize.New(opts)
+	if err != nil {
+		return err
+	}
+	spans = a.Spans(spans)
+
+	var w io.Writer = os.Stdout
+	if out != "-" {
+		f, err := os.Create(out)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		w = f
+	}
+
+	if format == "json" {
+		return anonymize.WriteSpans(w, spans)
+	}
+
+	s, err := anonymize.ToScenario(spans, name)
+	if err != nil {
+		return err
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(s); err != nil {
+		return fmt.Errorf("failed to encode scenario: %w", err)
+	}
+
+	return enc.Close()
+}
+
 func loadScenario(cfg *Config) (*scenario.Scenario, error) {
 	// Try custom YAML file first
 	if cfg.ScenarioFile != "" {
-		return scenario.LoadFromFile(cfg.ScenarioFile)
+		s, warnings, err := scenario.LoadFromFileWithWarnings(cfg.ScenarioFile)
+		for _, w := range warnings {
+			_, _ = fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+		}
+
+		return s, err
 	}
 
 	// Look up embedded scenario
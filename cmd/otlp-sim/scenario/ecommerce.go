@@ -46,7 +46,7 @@ func EcommerceScenario() *Scenario {
 								"rpc.service": "InventoryService",
 								"rpc.method":  "ReserveStock",
 							},
-							ErrorRate:   0.02, // 2% out of stock
+							ErrorRate:   FixedErrorRate(0.02), // 2% out of stock
 							ErrorStatus: "insufficient stock",
 							Children: []SpanTemplate{
 								{
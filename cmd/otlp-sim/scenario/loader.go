@@ -6,16 +6,74 @@ import (
 	"github.com/arloliu/fuda"
 )
 
+// CurrentVersion is the newest scenario file format version this build understands.
+// Bump it together with a new entry in migrations whenever SpanTemplate changes
+// in a way that older files must be rewritten for.
+const CurrentVersion = 1
+
+// migrations upgrade a scenario from version from to from+1.
+// Each step returns human-readable warnings about what it assumed or changed.
+var migrations = map[int]func(s *Scenario) []string{
+	0: migrateUnversioned,
+}
+
 // LoadFromFile loads a scenario from a YAML file using fuda for parsing.
+// Older file versions are migrated; use LoadFromFileWithWarnings to see what changed.
 func LoadFromFile(path string) (*Scenario, error) {
+	s, _, err := LoadFromFileWithWarnings(path)
+
+	return s, err
+}
+
+// LoadFromFileWithWarnings loads a scenario from a YAML file and migrates it to
+// CurrentVersion. The returned warnings describe each migration step applied,
+// so callers can tell users how to update their files.
+func LoadFromFileWithWarnings(path string) (*Scenario, []string, error) {
 	var s Scenario
 	if err := fuda.LoadFile(path, &s); err != nil {
-		return nil, fmt.Errorf("failed to load scenario file: %w", err)
+		return nil, nil, fmt.Errorf("failed to load scenario file: %w", err)
 	}
 
 	if s.Name == "" {
-		return nil, fmt.Errorf("scenario name is required")
+		return nil, nil, fmt.Errorf("scenario name is required")
+	}
+
+	warnings, err := Migrate(&s)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return &s, nil
+	return &s, warnings, nil
+}
+
+// Migrate upgrades s in place to CurrentVersion and returns warnings for every step applied.
+// It fails if s was written for a newer version than this build supports.
+func Migrate(s *Scenario) ([]string, error) {
+	if s.Version < 0 {
+		return nil, fmt.Errorf("scenario %q: invalid version %d", s.Name, s.Version)
+	}
+	if s.Version > CurrentVersion {
+		return nil, fmt.Errorf("scenario %q uses version %d, but this otlp-sim supports up to version %d; upgrade otlp-sim",
+			s.Name, s.Version, CurrentVersion)
+	}
+
+	var warnings []string
+	for s.Version < CurrentVersion {
+		migrate, ok := migrations[s.Version]
+		if !ok {
+			return warnings, fmt.Errorf("scenario %q: no migration from version %d", s.Name, s.Version)
+		}
+		warnings = append(warnings, migrate(s)...)
+		s.Version++
+	}
+
+	return warnings, nil
+}
+
+// migrateUnversioned upgrades files written before the version field existed.
+// The format is unchanged, so only a warning is produced.
+func migrateUnversioned(s *Scenario) []string {
+	return []string{fmt.Sprintf(
+		"scenario %q has no version field; assuming version 1 (add \"version: %d\" to silence this warning)",
+		s.Name, CurrentVersion)}
 }
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -110,10 +111,58 @@ rootSpan:
 	s, err := LoadFromFile(filePath)
 	require.NoError(t, err)
 
-	assert.Equal(t, 0.1, s.RootSpan.ErrorRate)
+	assert.Equal(t, FixedErrorRate(0.1), s.RootSpan.ErrorRate)
 	assert.Equal(t, "simulated failure", s.RootSpan.ErrorStatus)
 }
 
+func TestLoadFromFile_WithErrorRateRamp(t *testing.T) {
+	yamlContent := `
+name: ramp-scenario
+rootSpan:
+  name: "span"
+  service: svc
+  errorRate: {start: 0.0, end: 0.3, over: 10m}
+`
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "ramp-scenario.yaml")
+	err := os.WriteFile(filePath, []byte(yamlContent), 0o644)
+	require.NoError(t, err)
+
+	s, err := LoadFromFile(filePath)
+	require.NoError(t, err)
+
+	assert.Equal(t, ErrorRate{Start: 0, End: 0.3, Over: Duration(10 * time.Minute)}, s.RootSpan.ErrorRate)
+}
+
+func TestLoadFromFile_WithFaults(t *testing.T) {
+	yamlContent := `
+name: fault-scenario
+faults:
+  missingServiceName: 0.1
+  invalidSemconv: 0.5
+rootSpan:
+  name: "span"
+  service: svc
+  resource:
+    attributes:
+      service.version: "2"
+`
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "fault-scenario.yaml")
+	err := os.WriteFile(filePath, []byte(yamlContent), 0o644)
+	require.NoError(t, err)
+
+	s, err := LoadFromFile(filePath)
+	require.NoError(t, err)
+
+	require.NotNil(t, s.Faults)
+	assert.Equal(t, 0.1, s.Faults.MissingServiceName)
+	assert.Zero(t, s.Faults.InvalidResource)
+	assert.Equal(t, 0.5, s.Faults.InvalidSemconv)
+	require.NotNil(t, s.RootSpan.Resource)
+	assert.Equal(t, map[string]string{"service.version": "2"}, s.RootSpan.Resource.Attributes)
+}
+
 func TestLoadFromFile_FileNotFound(t *testing.T) {
 	s, err := LoadFromFile("/non/existent/path.yaml")
 	assert.Error(t, err)
@@ -158,3 +207,50 @@ rootSpan:
 	assert.Nil(t, s)
 	assert.Contains(t, err.Error(), "scenario name is required")
 }
+
+func TestLoadFromFileWithWarnings_Versions(t *testing.T) {
+	body := `
+name: versioned
+services:
+  - name: svc
+rootSpan:
+  name: "root"
+  service: svc
+  kind: SERVER
+  duration: "10ms"
+`
+	tests := []struct {
+		name         string
+		header       string
+		wantWarnings int
+		wantErr      string
+	}{
+		{name: "current", header: "version: 1", wantWarnings: 0},
+		{name: "unversioned", header: "", wantWarnings: 1},
+		{name: "newer", header: "version: 99", wantErr: "supports up to version 1"},
+		{name: "negative", header: "version: -1", wantErr: "invalid version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "scenario.yaml")
+			require.NoError(t, os.WriteFile(filePath, []byte(tt.header+body), 0o644))
+
+			s, warnings, err := LoadFromFileWithWarnings(filePath)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Nil(t, s)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, CurrentVersion, s.Version)
+			assert.Len(t, warnings, tt.wantWarnings)
+			if tt.wantWarnings > 0 {
+				assert.Contains(t, warnings[0], `add "version: 1"`)
+			}
+		})
+	}
+}
@@ -76,7 +76,7 @@ func PaymentScenario() *Scenario {
 							Service:     "payment-processor",
 							Kind:        SpanKindInternal,
 							Duration:    Duration(80_000_000), // 80ms
-							ErrorRate:   0.05,                 // 5% error rate
+							ErrorRate:   FixedErrorRate(0.05), // 5% error rate
 							ErrorStatus: "payment declined",
 							Children: []SpanTemplate{
 								{
@@ -10,37 +10,66 @@ import (
 
 // Scenario defines a complete trace/log simulation scenario.
 type Scenario struct {
-	Name        string       `yaml:"name"`
+	// Version is the scenario file format version. Files without it are
+	// treated as unversioned and migrated with a warning; see Migrate.
+	Version     int          `yaml:"version,omitempty" jsonschema:"minimum=0"`
+	Name        string       `yaml:"name" jsonschema:"required"`
 	Description string       `yaml:"description"`
 	Services    []Service    `yaml:"services"`
-	RootSpan    SpanTemplate `yaml:"rootSpan"`
+	RootSpan    SpanTemplate `yaml:"rootSpan" jsonschema:"required"`
+	Faults      *Faults      `yaml:"faults,omitempty"`
+}
+
+// Faults injects malformed telemetry into every span of a scenario, to test
+// backend validation and collector transform processors. Each rate is the
+// probability (0.0-1.0) that a span gets the fault.
+type Faults struct {
+	// MissingServiceName emits spans with an empty resource, without service.name.
+	MissingServiceName float64 `yaml:"missingServiceName,omitempty" jsonschema:"minimum=0,maximum=1"`
+
+	// InvalidResource emits spans whose resource has an empty service.name and a
+	// numeric service.version.
+	InvalidResource float64 `yaml:"invalidResource,omitempty" jsonschema:"minimum=0,maximum=1"`
+
+	// InvalidSemconv adds span attributes with invalid semantic convention values,
+	// such as a non-numeric http.response.status_code or a negative server.port.
+	InvalidSemconv float64 `yaml:"invalidSemconv,omitempty" jsonschema:"minimum=0,maximum=1"`
+}
+
+// ResourceTemplate defines the resource a span is emitted with, replacing the
+// simulator's own. Attribute values are typed like span attributes, so
+// "service.version: 2" produces a numeric value. service.name is not added
+// automatically: leave it out to emit a span without one.
+type ResourceTemplate struct {
+	Attributes map[string]string `yaml:"attributes,omitempty"`
 }
 
 // Service represents a microservice in the scenario.
 type Service struct {
-	Name       string            `yaml:"name"`
+	Name       string            `yaml:"name" jsonschema:"required"`
 	Attributes map[string]string `yaml:"attributes,omitempty"`
 }
 
 // SpanTemplate defines a span and its children.
 type SpanTemplate struct {
-	Name       string            `yaml:"name"`
-	Service    string            `yaml:"service"`
+	Name       string            `yaml:"name" jsonschema:"required"`
+	Service    string            `yaml:"service" jsonschema:"required"`
 	Kind       SpanKind          `yaml:"kind"`
 	Duration   Duration          `yaml:"duration"`
 	Attributes map[string]string `yaml:"attributes,omitempty"`
 	Children   []SpanTemplate    `yaml:"children,omitempty"`
 	Logs       []LogTemplate     `yaml:"logs,omitempty"`
+	Resource   *ResourceTemplate `yaml:"resource,omitempty"` // Replaces the span's resource
 
 	// Error simulation
-	ErrorRate   float64 `yaml:"errorRate,omitempty"`   // 0.0-1.0
-	ErrorStatus string  `yaml:"errorStatus,omitempty"` // Error message when triggered
+	ErrorRate   ErrorRate `yaml:"errorRate,omitempty" jsonschema:"minimum=0,maximum=1"` // 0.0-1.0, or a ramp
+	ErrorStatus string    `yaml:"errorStatus,omitempty"`                                // Error message when triggered
 }
 
 // LogTemplate defines a log entry within a span.
 type LogTemplate struct {
-	Level      string            `yaml:"level"` // INFO, WARN, ERROR, DEBUG
-	Message    string            `yaml:"message"`
+	Level      string            `yaml:"level" jsonschema:"enum=DEBUG|INFO|WARN|ERROR"` // INFO, WARN, ERROR, DEBUG
+	Message    string            `yaml:"message" jsonschema:"required"`
 	Attributes map[string]string `yaml:"attributes,omitempty"`
 	Delay      Duration          `yaml:"delay,omitempty"` // Delay after span start
 }
@@ -84,6 +113,69 @@ func (d Duration) AsDuration() time.Duration {
 	return time.Duration(d)
 }
 
+// ErrorRate is the probability (0.0-1.0) that a span fails. In YAML it is either
+// a number or a ramp, {start: 0.0, end: 0.3, over: 10m}, which moves linearly
+// from Start to End over the given time since the simulation started and then
+// stays at End, to rehearse alerting on a gradually degrading service.
+type ErrorRate struct {
+	Start float64  `yaml:"start" jsonschema:"minimum=0,maximum=1"`
+	End   float64  `yaml:"end" jsonschema:"minimum=0,maximum=1"`
+	Over  Duration `yaml:"over,omitempty"`
+}
+
+// errorRateFields has the fields of ErrorRate without its YAML methods.
+type errorRateFields ErrorRate
+
+// FixedErrorRate returns an error rate that does not change over time.
+func FixedErrorRate(rate float64) ErrorRate {
+	return ErrorRate{Start: rate, End: rate}
+}
+
+// At returns the error rate after elapsed time since the simulation started.
+func (r ErrorRate) At(elapsed time.Duration) float64 {
+	over := r.Over.AsDuration()
+	if over <= 0 || elapsed >= over {
+		return r.End
+	}
+	if elapsed <= 0 {
+		return r.Start
+	}
+
+	return r.Start + (r.End-r.Start)*float64(elapsed)/float64(over)
+}
+
+// IsZero reports whether spans never fail.
+func (r ErrorRate) IsZero() bool {
+	return r.Start == 0 && r.End == 0
+}
+
+// MarshalYAML implements yaml.Marshaler, writing fixed rates as a number.
+func (r ErrorRate) MarshalYAML() (any, error) {
+	if r.Start == r.End {
+		return r.End, nil
+	}
+
+	return errorRateFields(r), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler, accepting a number or a ramp.
+func (r *ErrorRate) UnmarshalYAML(unmarshal func(any) error) error {
+	var rate float64
+	if err := unmarshal(&rate); err == nil {
+		*r = FixedErrorRate(rate)
+
+		return nil
+	}
+
+	var fields errorRateFields
+	if err := unmarshal(&fields); err != nil {
+		return err
+	}
+	*r = ErrorRate(fields)
+
+	return nil
+}
+
 // Registry holds all available scenarios.
 var Registry = map[string]*Scenario{}
 
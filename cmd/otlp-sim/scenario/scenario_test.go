@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestDuration_AsDuration(t *testing.T) {
@@ -29,6 +30,37 @@ func TestDuration_AsDuration(t *testing.T) {
 	}
 }
 
+func TestErrorRate_At(t *testing.T) {
+	ramp := ErrorRate{Start: 0, End: 0.3, Over: Duration(10 * time.Minute)}
+	assert.InDelta(t, 0.0, ramp.At(0), 1e-9)
+	assert.InDelta(t, 0.15, ramp.At(5*time.Minute), 1e-9)
+	assert.InDelta(t, 0.3, ramp.At(10*time.Minute), 1e-9)
+	assert.InDelta(t, 0.3, ramp.At(time.Hour), 1e-9, "stays at end")
+
+	assert.InDelta(t, 0.05, FixedErrorRate(0.05).At(time.Minute), 1e-9)
+	assert.True(t, ErrorRate{}.IsZero())
+	assert.False(t, ramp.IsZero())
+}
+
+func TestErrorRate_YAML(t *testing.T) {
+	var span SpanTemplate
+	require.NoError(t, yaml.Unmarshal([]byte("errorRate: 0.1"), &span))
+	assert.Equal(t, FixedErrorRate(0.1), span.ErrorRate)
+
+	require.NoError(t, yaml.Unmarshal([]byte("errorRate: {start: 0.0, end: 0.3, over: 10m}"), &span))
+	assert.Equal(t, ErrorRate{Start: 0, End: 0.3, Over: Duration(10 * time.Minute)}, span.ErrorRate)
+
+	require.Error(t, yaml.Unmarshal([]byte("errorRate: often"), &span))
+
+	out, err := yaml.Marshal(SpanTemplate{Name: "a", ErrorRate: FixedErrorRate(0.1)})
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "errorRate: 0.1\n")
+
+	out, err = yaml.Marshal(SpanTemplate{Name: "a"})
+	require.NoError(t, err)
+	assert.NotContains(t, string(out), "errorRate")
+}
+
 func TestDuration_MarshalYAML(t *testing.T) {
 	tests := []struct {
 		name     string
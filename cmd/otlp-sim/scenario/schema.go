@@ -0,0 +1,151 @@
+package scenario
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// schemaID identifies the generated scenario schema.
+const schemaID = "https://github.com/arloliu/otx/cmd/otlp-sim/scenario.schema.json"
+
+// durationPattern matches strings accepted by time.ParseDuration.
+const durationPattern = `^-?([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+
+// schemaEnums lists the allowed values of named string types.
+var schemaEnums = map[reflect.Type][]string{
+	reflect.TypeFor[SpanKind](): {
+		string(SpanKindServer), string(SpanKindClient), string(SpanKindProducer),
+		string(SpanKindConsumer), string(SpanKindInternal),
+	},
+}
+
+// schemaGenerator builds a JSON Schema from Go types using their yaml and jsonschema tags.
+type schemaGenerator struct {
+	defs map[string]any
+}
+
+// JSONSchema returns a JSON Schema (draft 2020-12) for scenario YAML files,
+// generated from the Scenario struct. Editors such as VS Code (with the YAML
+// extension) use it for autocomplete and validation.
+//
+// Field names come from yaml tags. The jsonschema tag adds constraints as a
+// comma-separated list: "required", "minimum=N", "maximum=N" and "enum=A|B".
+func JSONSchema() ([]byte, error) {
+	g := &schemaGenerator{defs: make(map[string]any)}
+	root := map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$id":     schemaID,
+		"title":   "otlp-sim scenario",
+		"$ref":    g.schemaFor(reflect.TypeFor[Scenario]())["$ref"],
+		"$defs":   g.defs,
+	}
+
+	return json.MarshalIndent(root, "", "  ")
+}
+
+// applyConstraints adds the constraints of a jsonschema tag to prop and
+// reports whether the field is required.
+func applyConstraints(prop map[string]any, tag string) bool {
+	required := false
+	for item := range strings.SplitSeq(tag, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(item), "=")
+		switch key {
+		case "required":
+			required = true
+		case "minimum", "maximum":
+			if n, err := strconv.ParseFloat(value, 64); err == nil {
+				prop[key] = n
+			}
+		case "enum":
+			prop["enum"] = strings.Split(value, "|")
+		}
+	}
+
+	return required
+}
+
+// schemaFor returns the schema of t. Named structs are stored in defs and referenced,
+// which also handles recursive types such as SpanTemplate.
+func (g *schemaGenerator) schemaFor(t reflect.Type) map[string]any {
+	if t == reflect.TypeFor[Duration]() {
+		return map[string]any{"type": "string", "pattern": durationPattern}
+	}
+	if t == reflect.TypeFor[ErrorRate]() {
+		// A number or a ramp; numeric constraints from tags only apply to the number
+		return map[string]any{"oneOf": []any{
+			map[string]any{"type": "number"},
+			g.structRef(t),
+		}}
+	}
+	if values, ok := schemaEnums[t]; ok {
+		return map[string]any{"type": "string", "enum": values}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return g.structRef(t)
+	case reflect.Slice:
+		return map[string]any{"type": "array", "items": g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.schemaFor(t.Elem())}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Pointer:
+		return g.schemaFor(t.Elem())
+	default:
+		return map[string]any{}
+	}
+}
+
+// structRef returns a reference to the schema of struct type t, adding it to defs.
+func (g *schemaGenerator) structRef(t reflect.Type) map[string]any {
+	if _, ok := g.defs[t.Name()]; !ok {
+		g.defs[t.Name()] = nil // Reserve before descending into recursive fields
+		g.defs[t.Name()] = g.structSchema(t)
+	}
+
+	return map[string]any{"$ref": "#/$defs/" + t.Name()}
+}
+
+// structSchema returns the object schema of struct type t.
+func (g *schemaGenerator) structSchema(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	var required []string
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		prop := g.schemaFor(field.Type)
+		if applyConstraints(prop, field.Tag.Get("jsonschema")) {
+			required = append(required, name)
+		}
+		properties[name] = prop
+	}
+
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	return schema
+}
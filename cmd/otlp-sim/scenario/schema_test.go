@@ -0,0 +1,74 @@
+package scenario
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONSchema(t *testing.T) {
+	data, err := JSONSchema()
+	require.NoError(t, err)
+
+	var schema struct {
+		Ref  string                    `json:"$ref"`
+		Defs map[string]map[string]any `json:"$defs"`
+	}
+	require.NoError(t, json.Unmarshal(data, &schema))
+
+	assert.Equal(t, "#/$defs/Scenario", schema.Ref)
+	for _, name := range []string{"Scenario", "Service", "SpanTemplate", "LogTemplate"} {
+		assert.Contains(t, schema.Defs, name)
+	}
+
+	scenarioDef := schema.Defs["Scenario"]
+	assert.ElementsMatch(t, []any{"name", "rootSpan"}, scenarioDef["required"])
+	assert.Equal(t, false, scenarioDef["additionalProperties"])
+
+	span := schema.Defs["SpanTemplate"]
+	props, ok := span["properties"].(map[string]any)
+	require.True(t, ok)
+	assert.ElementsMatch(t, []any{"name", "service"}, span["required"])
+
+	children := props["children"].(map[string]any)
+	assert.Equal(t, "array", children["type"])
+	assert.Equal(t, map[string]any{"$ref": "#/$defs/SpanTemplate"}, children["items"])
+
+	kind := props["kind"].(map[string]any)
+	assert.ElementsMatch(t, []any{"SERVER", "CLIENT", "PRODUCER", "CONSUMER", "INTERNAL"}, kind["enum"])
+
+	duration := props["duration"].(map[string]any)
+	assert.Equal(t, "string", duration["type"])
+	assert.Equal(t, durationPattern, duration["pattern"])
+
+	errorRate := props["errorRate"].(map[string]any)
+	assert.Equal(t, []any{
+		map[string]any{"type": "number"},
+		map[string]any{"$ref": "#/$defs/ErrorRate"},
+	}, errorRate["oneOf"])
+	assert.ElementsMatch(t, []any{"start", "end", "over"},
+		slices.Collect(maps.Keys(schema.Defs["ErrorRate"]["properties"].(map[string]any))))
+	assert.InDelta(t, 0.0, errorRate["minimum"], 0)
+	assert.InDelta(t, 1.0, errorRate["maximum"], 0)
+
+	attrs := props["attributes"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "string"}, attrs["additionalProperties"])
+
+	logProps := schema.Defs["LogTemplate"]["properties"].(map[string]any)
+	level := logProps["level"].(map[string]any)
+	assert.Equal(t, []any{"DEBUG", "INFO", "WARN", "ERROR"}, level["enum"])
+}
+
+func TestApplyConstraints(t *testing.T) {
+	prop := map[string]any{}
+	assert.True(t, applyConstraints(prop, "required, minimum=1,maximum=5"))
+	assert.Equal(t, map[string]any{"minimum": 1.0, "maximum": 5.0}, prop)
+
+	prop = map[string]any{}
+	assert.False(t, applyConstraints(prop, ""))
+	assert.Empty(t, prop)
+}
@@ -1,25 +1,63 @@
 package otx
 
 import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+
 	"github.com/arloliu/fuda"
+	"go.opentelemetry.io/otel"
 )
 
+// envResourceAttributes holds extra resource attributes as comma-separated key=value pairs.
+const envResourceAttributes = "OTEL_RESOURCE_ATTRIBUTES"
+
 // LoadConfig loads TelemetryConfig from a file path.
 // It supports YAML and JSON formats.
-// Environment variables are also parsed and override file values.
+//
+// Values are merged in the following precedence order (highest first):
+//  1. OTel environment variables (e.g., OTEL_EXPORTER_OTLP_ENDPOINT)
+//  2. Values from the file
+//  3. Struct-tag defaults
+//
+// Optional sections (otlp, traces, traces.sampling, logs, metrics, propagation, console,
+// resourceDetectors) that are absent from the file are created when one of their
+// environment variables is set, so env-only overrides are never silently dropped.
+//
+// If path is empty, only defaults and environment variables are applied.
 func LoadConfig(path string) (*TelemetryConfig, error) {
 	var cfg TelemetryConfig
-	// fuda.LoadFile handles reading, parsing, env vars, defaults, and validation
-	if err := fuda.LoadFile(path, &cfg); err != nil {
+
+	var err error
+	if path == "" {
+		// fuda.SetDefaults applies defaults and env vars without a file source
+		err = fuda.SetDefaults(&cfg)
+	} else {
+		// fuda.LoadFile handles reading, parsing, env vars, defaults, and validation
+		err = fuda.LoadFile(path, &cfg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyEnvSections(&cfg); err != nil {
+		return nil, err
+	}
+	if err := splitExporterLists(&cfg); err != nil {
 		return nil, err
 	}
+	applyResourceAttributesEnv(&cfg)
 
 	return &cfg, nil
 }
 
 // ParseConfig parsers TelemetryConfig from a byte slice.
 // It supports YAML and JSON formats (auto-detected).
-// Environment variables are also parsed and override file values.
+// Environment variables are also parsed and override file values,
+// following the same precedence rules as [LoadConfig].
 func ParseConfig(data []byte) (*TelemetryConfig, error) {
 	var cfg TelemetryConfig
 	// fuda.LoadBytes handles parsing, env vars, defaults, and validation
@@ -27,5 +65,182 @@ func ParseConfig(data []byte) (*TelemetryConfig, error) {
 		return nil, err
 	}
 
+	if err := applyEnvSections(&cfg); err != nil {
+		return nil, err
+	}
+	if err := splitExporterLists(&cfg); err != nil {
+		return nil, err
+	}
+	applyResourceAttributesEnv(&cfg)
+
 	return &cfg, nil
 }
+
+// applyEnvSections creates optional sections that are missing from the source
+// but have at least one of their environment variables set.
+// Deprecated sections are left untouched to preserve backward compatibility.
+func applyEnvSections(cfg *TelemetryConfig) error {
+	// The deprecated Exporter section already carries the shared OTLP env tags.
+	if cfg.Exporter == nil {
+		if err := fillFromEnv(&cfg.OTLP); err != nil {
+			return err
+		}
+	}
+
+	tracesMissing := cfg.Traces == nil
+	if err := fillFromEnv(&cfg.Traces); err != nil {
+		return err
+	}
+
+	// The deprecated Sampling section already carries the sampler env tags.
+	if cfg.GetSamplingConfig() == nil && hasEnvOverride(reflect.TypeFor[SamplingConfig]()) {
+		if cfg.Traces == nil {
+			cfg.Traces = &TracesConfig{}
+			if err := fuda.SetDefaults(cfg.Traces); err != nil {
+				return err
+			}
+		}
+		if err := fillFromEnv(&cfg.Traces.Sampling); err != nil {
+			return err
+		}
+	}
+
+	// Keep the deprecated exporter.type effective when traces was created from env only.
+	if tracesMissing && cfg.Traces != nil && cfg.Exporter != nil {
+		cfg.Traces.Exporter = ""
+	}
+
+	return errors.Join(
+		fillFromEnv(&cfg.Logs),
+		fillFromEnv(&cfg.Metrics),
+		fillFromEnv(&cfg.Propagation),
+		fillFromEnv(&cfg.Console),
+		fillFromEnv(&cfg.ResourceDetectors),
+	)
+}
+
+// splitExporterLists moves comma-separated exporter values, such as
+// OTEL_TRACES_EXPORTER=otlp,console, into the Exporters list of their signal and
+// checks each exporter type, as the struct tags cannot validate a list.
+func splitExporterLists(cfg *TelemetryConfig) error {
+	var errs []error
+	if cfg.Traces != nil {
+		errs = append(errs, splitExporterList("traces", &cfg.Traces.Exporter, &cfg.Traces.Exporters))
+	}
+	if cfg.Logs != nil {
+		errs = append(errs, splitExporterList("logs", &cfg.Logs.Exporter, &cfg.Logs.Exporters))
+	}
+	if cfg.Metrics != nil {
+		errs = append(errs, splitExporterList("metrics", &cfg.Metrics.Exporter, &cfg.Metrics.Exporters))
+	}
+
+	return errors.Join(errs...)
+}
+
+// splitExporterList splits *single into *list when it holds several exporter types,
+// keeping the first in *single.
+func splitExporterList(signal string, single *string, list *[]string) error {
+	if !strings.Contains(*single, ",") {
+		return validateExporterType(signal+".exporter", *single)
+	}
+
+	var types []string
+	var errs []error
+	for typ := range strings.SplitSeq(*single, ",") {
+		if typ = strings.TrimSpace(typ); typ == "" {
+			continue
+		}
+		errs = append(errs, validateExporterType(signal+".exporter", typ))
+		types = append(types, typ)
+	}
+	if len(types) > 0 {
+		*single, *list = types[0], types
+	}
+
+	return errors.Join(errs...)
+}
+
+// applyResourceAttributesEnv merges OTEL_RESOURCE_ATTRIBUTES into cfg.ResourceAttributes,
+// overriding file values with the same key. Per the OTel specification, an invalid
+// value is discarded entirely and reported to the global error handler.
+func applyResourceAttributesEnv(cfg *TelemetryConfig) {
+	value, ok := os.LookupEnv(envResourceAttributes)
+	if !ok {
+		return
+	}
+
+	attrs, err := parseResourceAttributes(value)
+	if err != nil {
+		otel.Handle(fmt.Errorf("otx: ignoring %s: %w", envResourceAttributes, err))
+
+		return
+	}
+	if len(attrs) == 0 {
+		return
+	}
+
+	if cfg.ResourceAttributes == nil {
+		cfg.ResourceAttributes = make(map[string]string, len(attrs))
+	}
+	for k, v := range attrs {
+		cfg.ResourceAttributes[k] = v
+	}
+}
+
+// parseResourceAttributes parses the OTEL_RESOURCE_ATTRIBUTES format:
+// comma-separated key=value pairs with percent-encoded values, e.g.
+// "service.namespace=shop,team=platform%20core". Empty items are skipped.
+func parseResourceAttributes(value string) (map[string]string, error) {
+	attrs := make(map[string]string)
+	for item := range strings.SplitSeq(value, ",") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+
+		key, val, found := strings.Cut(item, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid key=value pair %q", item)
+		}
+
+		decoded, err := url.PathUnescape(strings.TrimSpace(val))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for key %q: %w", key, err)
+		}
+		attrs[key] = decoded
+	}
+
+	return attrs, nil
+}
+
+// fillFromEnv allocates *dst with defaults and environment overrides applied
+// when it is nil and at least one of its env-tagged fields has a variable set.
+func fillFromEnv[T any](dst **T) error {
+	if *dst != nil || !hasEnvOverride(reflect.TypeFor[T]()) {
+		return nil
+	}
+
+	section := new(T)
+	if err := fuda.SetDefaults(section); err != nil {
+		return err
+	}
+	*dst = section
+
+	return nil
+}
+
+// hasEnvOverride reports whether any env-tagged field of struct type t has
+// its environment variable set.
+func hasEnvOverride(t reflect.Type) bool {
+	for i := range t.NumField() {
+		name := t.Field(i).Tag.Get("env")
+		if name == "" {
+			continue
+		}
+		if _, ok := os.LookupEnv(name); ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -66,3 +67,256 @@ func TestLoadConfigDefaults(t *testing.T) {
 	// Environment default is development
 	assert.Equal(t, "development", cfg.Environment)
 }
+
+func TestLoadConfigEnvOverlaysMissingSections(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "collector:4317")
+	t.Setenv("OTEL_TRACES_SAMPLER", "always_off")
+	t.Setenv("OTEL_METRICS_EXPORTER", "console")
+
+	cfg, err := ParseConfig([]byte(`
+enabled: true
+serviceName: "env-overlay"
+`))
+	require.NoError(t, err)
+
+	require.NotNil(t, cfg.OTLP)
+	assert.Equal(t, "collector:4317", cfg.OTLP.Endpoint)
+	assert.Equal(t, "grpc", cfg.OTLP.Protocol)
+
+	require.NotNil(t, cfg.GetSamplingConfig())
+	assert.Equal(t, "always_off", cfg.GetSamplingConfig().Sampler)
+	assert.Equal(t, "otlp", cfg.GetTracesExporter())
+
+	require.NotNil(t, cfg.Metrics)
+	assert.Equal(t, "console", cfg.Metrics.Exporter)
+	assert.False(t, cfg.Metrics.IsEnabled())
+
+	assert.Nil(t, cfg.Logs)
+	assert.Nil(t, cfg.Propagation)
+}
+
+func TestLoadConfigBatch(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`
+traces:
+  batch:
+    maxQueueSize: 8192
+    scheduleDelay: 200ms
+`))
+	require.NoError(t, err)
+
+	require.NotNil(t, cfg.Traces.Batch)
+	assert.Equal(t, 8192, cfg.Traces.Batch.MaxQueueSize)
+	assert.Equal(t, 200*time.Millisecond, cfg.Traces.Batch.ScheduleDelay)
+	assert.Zero(t, cfg.Traces.Batch.MaxExportBatchSize, "unset fields keep the SDK default")
+}
+
+func TestLoadConfigLogsBatch(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`
+traces:
+  batch:
+    maxQueueSize: 2048
+logs:
+  batch:
+    maxQueueSize: 512
+    scheduleDelay: 2s
+`))
+	require.NoError(t, err)
+
+	require.NotNil(t, cfg.Logs.Batch)
+	assert.Equal(t, 512, cfg.Logs.Batch.MaxQueueSize)
+	assert.Equal(t, 2*time.Second, cfg.Logs.Batch.ScheduleDelay)
+	assert.Equal(t, 2048, cfg.Traces.Batch.MaxQueueSize, "signals are configured independently")
+}
+
+func TestLoadConfigExporters(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`
+traces:
+  exporters: [otlp, console]
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"otlp", "console"}, cfg.GetTracesExporters())
+
+	_, err = ParseConfig([]byte(`
+traces:
+  exporters: [otlp, zipkin]
+`))
+	require.Error(t, err)
+}
+
+func TestLoadConfigExporterEnvLists(t *testing.T) {
+	t.Setenv("OTEL_TRACES_EXPORTER", "otlp,console")
+	t.Setenv("OTEL_LOGS_EXPORTER", "console, none")
+	t.Setenv("OTEL_METRICS_EXPORTER", "otlp")
+
+	cfg, err := LoadConfig("")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"otlp", "console"}, cfg.GetTracesExporters())
+	assert.Equal(t, []string{"console", "none"}, cfg.Logs.Exporters)
+	assert.Equal(t, "otlp", cfg.Metrics.Exporter)
+	assert.Empty(t, cfg.Metrics.Exporters)
+
+	t.Setenv("OTEL_TRACES_EXPORTER", "otlp,zipkin")
+	_, err = LoadConfig("")
+	require.ErrorIs(t, err, ErrInvalidConfig)
+	assert.Contains(t, err.Error(), `traces.exporter: unknown exporter type "zipkin"`)
+}
+
+func TestLoadConfigBaggageAttributesEnv(t *testing.T) {
+	t.Setenv("OTX_TRACES_BAGGAGE_ATTRIBUTES", "tenant.id,user.tier")
+
+	cfg, err := ParseConfig([]byte(`
+traces:
+  exporter: none
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tenant.id", "user.tier"}, cfg.Traces.BaggageAttributes)
+}
+
+func TestLoadConfigEnvKeepsDeprecatedSections(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "traces:4317")
+
+	cfg, err := ParseConfig([]byte(`
+exporter:
+  type: "console"
+  endpoint: "legacy:4317"
+sampling:
+  sampler: "always_on"
+`))
+	require.NoError(t, err)
+
+	assert.Nil(t, cfg.OTLP)
+	assert.Equal(t, "legacy:4317", cfg.GetOTLPConfig().Endpoint)
+	assert.Equal(t, "console", cfg.GetTracesExporter())
+	assert.Equal(t, "always_on", cfg.GetSamplingConfig().Sampler)
+	assert.Equal(t, "traces:4317", cfg.GetOTLPEndpoint())
+}
+
+func TestLoadConfigEmptyPath(t *testing.T) {
+	t.Setenv("OTX_ENABLED", "true")
+	t.Setenv("OTEL_SERVICE_NAME", "env-only")
+	t.Setenv("OTEL_PROPAGATORS", "tracecontext")
+
+	cfg, err := LoadConfig("")
+	require.NoError(t, err)
+	assert.True(t, cfg.IsEnabled())
+	assert.Equal(t, "env-only", cfg.ServiceName)
+	assert.Equal(t, "development", cfg.Environment)
+	require.NotNil(t, cfg.Propagation)
+	assert.False(t, cfg.Propagation.HasBaggage())
+}
+
+func TestParseResourceAttributes(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:  "pairs",
+			value: "team=platform,region=us-east-1",
+			want:  map[string]string{"team": "platform", "region": "us-east-1"},
+		},
+		{
+			name:  "percent-encoded",
+			value: "owner=Jane%20Doe%2C%20SRE,path=a%2Fb",
+			want:  map[string]string{"owner": "Jane Doe, SRE", "path": "a/b"},
+		},
+		{name: "plus is literal", value: "expr=a+b", want: map[string]string{"expr": "a+b"}},
+		{name: "equals in value", value: "query=a=b", want: map[string]string{"query": "a=b"}},
+		{name: "whitespace and empty items", value: " team = platform ,, ", want: map[string]string{"team": "platform"}},
+		{name: "empty value", value: "team=", want: map[string]string{"team": ""}},
+		{name: "empty", value: "", want: map[string]string{}},
+		{name: "missing equals", value: "team=platform,region", wantErr: true},
+		{name: "empty key", value: "=platform", wantErr: true},
+		{name: "invalid escape", value: "team=%zz", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseResourceAttributes(tt.value)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoadConfigResourceAttributesEnv(t *testing.T) {
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "region=eu-west-1,owner=Jane%20Doe")
+
+	cfg, err := ParseConfig([]byte(`
+serviceName: "attrs"
+resourceAttributes:
+  team: "platform"
+  region: "us-east-1"
+`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"team":   "platform",
+		"region": "eu-west-1",
+		"owner":  "Jane Doe",
+	}, cfg.ResourceAttributes)
+
+	cfg, err = LoadConfig("")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"region": "eu-west-1", "owner": "Jane Doe"}, cfg.ResourceAttributes)
+}
+
+func TestLoadConfigResourceAttributesEnvInvalid(t *testing.T) {
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "region=eu-west-1,broken")
+
+	cfg, err := ParseConfig([]byte(`
+serviceName: "attrs"
+resourceAttributes:
+  team: "platform"
+`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "platform"}, cfg.ResourceAttributes, "invalid value is discarded entirely")
+}
+
+func TestLoadConfigSignalHeaders(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "Authorization:Bearer shared")
+	t.Setenv("OTEL_EXPORTER_OTLP_LOGS_HEADERS", "Authorization:Bearer logs,X-Scope:team-a")
+
+	cfg, err := ParseConfig([]byte(`
+enabled: true
+serviceName: "headers"
+metrics:
+  headers:
+    Authorization: "Bearer metrics"
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"Authorization": "Bearer shared"}, resolveTraceExporterParams(cfg).Headers)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer logs", "X-Scope": "team-a"},
+		resolveLogExporterParams(cfg).Headers, "signal headers replace the shared ones")
+	assert.Equal(t, map[string]string{"Authorization": "Bearer metrics"}, resolveMetricExporterParams(cfg).Headers)
+}
+
+func TestLoadConfigSamplerArg(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`
+traces:
+  sampling:
+    sampler: traceidratio
+    samplerArg: 0.25
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "0.25", cfg.GetSamplingConfig().SamplerArg, "YAML numbers keep working")
+	ratio, ok := cfg.GetSamplingConfig().Ratio()
+	assert.True(t, ok)
+	assert.InDelta(t, 0.25, ratio, 1e-9)
+
+	t.Setenv("OTEL_TRACES_SAMPLER", "parentbased_always_on")
+	t.Setenv("OTEL_TRACES_SAMPLER_ARG", "endpoint=http://localhost:14250,pollingIntervalMs=5000")
+	cfg, err = ParseConfig([]byte("{}"))
+	require.NoError(t, err)
+	assert.Equal(t, "endpoint=http://localhost:14250,pollingIntervalMs=5000", cfg.GetSamplingConfig().SamplerArg)
+
+	cfg, err = LoadConfig("")
+	require.NoError(t, err)
+	assert.Equal(t, "endpoint=http://localhost:14250,pollingIntervalMs=5000", cfg.GetSamplingConfig().SamplerArg)
+}
@@ -0,0 +1,84 @@
+package otx
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrDeprecatedConfig is wrapped by every deprecated field reported by
+// [TelemetryConfig.ValidateStrict]. It wraps [ErrInvalidConfig].
+var ErrDeprecatedConfig = fmt.Errorf("%w: deprecated field", ErrInvalidConfig)
+
+// Migrate moves the deprecated Sampling and Exporter fields into Traces and OTLP
+// and clears them, keeping the effective configuration: a deprecated value is
+// only moved when the field replacing it is unset, as the replacement takes
+// precedence today. It returns one description per change, e.g. for a startup
+// log line asking to update the config file, and nothing when no deprecated
+// field is set.
+//
+// Example:
+//
+//	for _, change := range cfg.Migrate() {
+//	    slog.Warn("deprecated telemetry config", "change", change)
+//	}
+func (c *TelemetryConfig) Migrate() []string {
+	if c == nil {
+		return nil
+	}
+
+	var changes []string
+	if c.Sampling != nil {
+		if c.Traces != nil && c.Traces.Sampling != nil {
+			changes = append(changes, "sampling: removed, overridden by traces.sampling")
+		} else {
+			c.traces().Sampling = c.Sampling
+			changes = append(changes, "sampling: moved to traces.sampling")
+		}
+		c.Sampling = nil
+	}
+
+	if c.Exporter != nil {
+		if c.Exporter.Type != "" {
+			if c.Traces != nil && (c.Traces.Exporter != "" || len(c.Traces.Exporters) > 0) {
+				changes = append(changes, "exporter.type: removed, overridden by traces.exporter")
+			} else {
+				c.traces().Exporter = c.Exporter.Type
+				changes = append(changes, "exporter.type: moved to traces.exporter")
+			}
+		}
+		if c.OTLP != nil {
+			changes = append(changes, "exporter: OTLP settings removed, overridden by otlp")
+		} else {
+			c.OTLP = c.GetOTLPConfig()
+			changes = append(changes, "exporter: OTLP settings moved to otlp")
+		}
+		c.Exporter = nil
+	}
+
+	return changes
+}
+
+// ValidateStrict is Validate also rejecting the deprecated Sampling and Exporter
+// fields, for services that have migrated and want mixed old and new settings
+// caught at startup. Deprecated fields are reported with errors wrapping
+// [ErrDeprecatedConfig]; see Migrate to convert them.
+func (c *TelemetryConfig) ValidateStrict() error {
+	if c == nil {
+		return nil
+	}
+
+	var errs []error
+	if c.Sampling != nil {
+		errs = append(errs, deprecatedf("sampling, use traces.sampling"))
+	}
+	if c.Exporter != nil {
+		errs = append(errs, deprecatedf("exporter, use otlp and traces.exporter"))
+	}
+
+	return errors.Join(append(errs, c.Validate())...)
+}
+
+// deprecatedf formats a deprecated field problem wrapping ErrDeprecatedConfig.
+func deprecatedf(format string, args ...any) error {
+	return fmt.Errorf("%w: "+format, append([]any{ErrDeprecatedConfig}, args...)...)
+}
@@ -0,0 +1,88 @@
+package otx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate_MovesDeprecatedFields(t *testing.T) {
+	cfg := &TelemetryConfig{
+		Enabled:     boolPtr(true),
+		ServiceName: "orders",
+		Sampling:    &SamplingConfig{Sampler: "traceidratio", SamplerArg: "0.5"},
+		Exporter: &ExporterConfig{
+			Type:     "console",
+			Endpoint: "collector:4317",
+			Insecure: boolPtr(false),
+			Headers:  map[string]string{"api-key": "secret"},
+			Protocol: "grpc",
+			Timeout:  5 * time.Second,
+		},
+	}
+	before := resolveTraceExporterParams(cfg)
+	sampling := cfg.GetSamplingConfig()
+
+	changes := cfg.Migrate()
+	assert.Equal(t, []string{
+		"sampling: moved to traces.sampling",
+		"exporter.type: moved to traces.exporter",
+		"exporter: OTLP settings moved to otlp",
+	}, changes)
+
+	assert.Nil(t, cfg.Sampling)
+	assert.Nil(t, cfg.Exporter)
+	assert.Same(t, sampling, cfg.GetSamplingConfig())
+	assert.Equal(t, "console", cfg.Traces.Exporter)
+	assert.Equal(t, "collector:4317", cfg.OTLP.Endpoint)
+	assert.Equal(t, before, resolveTraceExporterParams(cfg))
+	require.NoError(t, cfg.ValidateStrict())
+
+	assert.Empty(t, cfg.Migrate(), "migrated config has nothing left to migrate")
+}
+
+func TestMigrate_KeepsOverridingFields(t *testing.T) {
+	cfg := &TelemetryConfig{
+		Sampling: &SamplingConfig{Sampler: "always_off"},
+		Exporter: &ExporterConfig{Type: "console", Endpoint: "old:4317"},
+		OTLP:     &OTLPConfig{Endpoint: "new:4317"},
+		Traces: &TracesConfig{
+			Exporter: "otlp",
+			Sampling: &SamplingConfig{Sampler: "always_on"},
+		},
+	}
+	before := resolveTraceExporterParams(cfg)
+
+	changes := cfg.Migrate()
+	assert.Equal(t, []string{
+		"sampling: removed, overridden by traces.sampling",
+		"exporter.type: removed, overridden by traces.exporter",
+		"exporter: OTLP settings removed, overridden by otlp",
+	}, changes)
+	assert.Equal(t, "always_on", cfg.GetSamplingConfig().Sampler)
+	assert.Equal(t, before, resolveTraceExporterParams(cfg))
+}
+
+func TestMigrate_Nil(t *testing.T) {
+	var cfg *TelemetryConfig
+	assert.Empty(t, cfg.Migrate())
+	assert.NoError(t, cfg.ValidateStrict())
+	assert.Empty(t, (&TelemetryConfig{}).Migrate())
+}
+
+func TestValidateStrict(t *testing.T) {
+	cfg := &TelemetryConfig{
+		Sampling: &SamplingConfig{Sampler: "bogus"},
+		Exporter: &ExporterConfig{Type: "otlp"},
+	}
+	require.NoError(t, (&TelemetryConfig{Sampling: &SamplingConfig{Sampler: "always_on"}}).Validate())
+
+	err := cfg.ValidateStrict()
+	require.ErrorIs(t, err, ErrDeprecatedConfig)
+	require.ErrorIs(t, err, ErrInvalidConfig)
+	assert.Contains(t, err.Error(), "deprecated field: sampling, use traces.sampling")
+	assert.Contains(t, err.Error(), "deprecated field: exporter, use otlp and traces.exporter")
+	assert.Contains(t, err.Error(), `unknown sampler "bogus"`, "Validate problems are reported too")
+}
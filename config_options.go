@@ -0,0 +1,237 @@
+package otx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/arloliu/fuda"
+	"go.opentelemetry.io/otel"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// ConfigOption sets a field of the TelemetryConfig built by [NewConfig].
+type ConfigOption func(*TelemetryConfig)
+
+// NewConfig returns an enabled configuration built from opts, for programs and
+// libraries constructing their telemetry in code rather than from YAML. Fields
+// not set by an option take the values LoadConfig("") gives them: the struct-tag
+// defaults, overridden by environment variables. Without environment variables,
+// traces, logs and metrics are exported over insecure OTLP/gRPC to localhost:4317,
+// traces are sampled with parentbased_always_on, and tracecontext and baggage are
+// propagated. Options take precedence over environment variables.
+//
+// Example:
+//
+//	cfg := otx.NewConfig(
+//	    otx.WithServiceName("orders"),
+//	    otx.WithOTLPEndpoint("otel-collector:4317"),
+//	    otx.WithSampler("parentbased_traceidratio", "0.1"),
+//	)
+//	tp, err := otx.NewTracerProvider(ctx, cfg, otx.WithSpanFilter(dropHealthChecks))
+func NewConfig(opts ...ConfigOption) *TelemetryConfig {
+	cfg := &TelemetryConfig{
+		OTLP:        &OTLPConfig{},
+		Traces:      &TracesConfig{Sampling: &SamplingConfig{}},
+		Logs:        &LogsConfig{},
+		Metrics:     &MetricsConfig{},
+		Propagation: &PropConfig{},
+		Console:     &ConsoleConfig{},
+	}
+	// fuda.SetDefaults fills the allocated sections too. Validation runs before
+	// the options are applied, so its errors are left to the providers, which
+	// validate the final configuration.
+	var validationErr *fuda.ValidationError
+	if err := fuda.SetDefaults(cfg); err != nil && !errors.As(err, &validationErr) {
+		otel.Handle(fmt.Errorf("otx: applying configuration defaults: %w", err))
+	}
+	applyResourceAttributesEnv(cfg)
+
+	// Unlike a loaded configuration, every signal is on unless an option turns it off.
+	cfg.Enabled = boolPtr(true)
+	cfg.Logs.Enabled = boolPtr(true)
+	cfg.Metrics.Enabled = boolPtr(true)
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return cfg
+}
+
+// WithServiceName sets the service.name resource attribute.
+func WithServiceName(name string) ConfigOption {
+	return func(c *TelemetryConfig) {
+		c.ServiceName = name
+	}
+}
+
+// WithServiceVersion sets the service.version resource attribute.
+func WithServiceVersion(version string) ConfigOption {
+	return func(c *TelemetryConfig) {
+		c.Version = version
+	}
+}
+
+// WithEnvironment sets the deployment.environment resource attribute.
+// Defaults to "development".
+func WithEnvironment(env string) ConfigOption {
+	return func(c *TelemetryConfig) {
+		c.Environment = env
+	}
+}
+
+// WithResourceAttributes adds resource attributes, replacing those of the same key.
+func WithResourceAttributes(attrs map[string]string) ConfigOption {
+	return func(c *TelemetryConfig) {
+		if c.ResourceAttributes == nil {
+			c.ResourceAttributes = make(map[string]string, len(attrs))
+		}
+		maps.Copy(c.ResourceAttributes, attrs)
+	}
+}
+
+// WithOTLPEndpoint sets the OTLP collector endpoint of all signals: "host:port"
+// for gRPC, a full URL for HTTP. Defaults to "localhost:4317".
+func WithOTLPEndpoint(endpoint string) ConfigOption {
+	return func(c *TelemetryConfig) {
+		c.otlp().Endpoint = endpoint
+	}
+}
+
+// WithOTLPProtocol sets the OTLP protocol of all signals: "grpc" (default),
+// "http/protobuf" or "http".
+func WithOTLPProtocol(protocol string) ConfigOption {
+	return func(c *TelemetryConfig) {
+		c.otlp().Protocol = protocol
+	}
+}
+
+// WithOTLPHeaders adds headers to OTLP requests, e.g. an API key, replacing those
+// of the same name.
+func WithOTLPHeaders(headers map[string]string) ConfigOption {
+	return func(c *TelemetryConfig) {
+		o := c.otlp()
+		if o.Headers == nil {
+			o.Headers = make(map[string]string, len(headers))
+		}
+		maps.Copy(o.Headers, headers)
+	}
+}
+
+// WithInsecure sets whether OTLP connections skip TLS. Defaults to true, for a
+// local collector.
+func WithInsecure(insecure bool) ConfigOption {
+	return func(c *TelemetryConfig) {
+		c.otlp().Insecure = boolPtr(insecure)
+	}
+}
+
+// WithSampler sets the trace sampler and its argument, e.g.
+// WithSampler("parentbased_traceidratio", "0.1"); see SamplingConfig for the
+// sampler names. An empty arg keeps "1.0".
+func WithSampler(sampler, arg string) ConfigOption {
+	return func(c *TelemetryConfig) {
+		if arg == "" {
+			arg = "1.0"
+		}
+		c.traces().Sampling = &SamplingConfig{Sampler: sampler, SamplerArg: arg}
+	}
+}
+
+// WithExporter sets the exporter of traces, logs and metrics: "otlp" (default),
+// "console" or "none".
+func WithExporter(exporter string) ConfigOption {
+	return func(c *TelemetryConfig) {
+		c.traces().Exporter = exporter
+		c.logs().Exporter = exporter
+		c.metrics().Exporter = exporter
+	}
+}
+
+// WithSignalsEnabled enables or disables traces, logs and metrics. All are enabled by default.
+func WithSignalsEnabled(traces, logs, metrics bool) ConfigOption {
+	return func(c *TelemetryConfig) {
+		c.traces().Enabled = boolPtr(traces)
+		c.logs().Enabled = boolPtr(logs)
+		c.metrics().Enabled = boolPtr(metrics)
+	}
+}
+
+// WithConfig calls fn with the configuration, to set fields without an option.
+//
+// Example:
+//
+//	otx.WithConfig(func(c *otx.TelemetryConfig) {
+//	    c.Traces.SpanMetrics = true
+//	})
+func WithConfig(fn func(c *TelemetryConfig)) ConfigOption {
+	return func(c *TelemetryConfig) {
+		fn(c)
+	}
+}
+
+// otlp returns the OTLP section, creating it if needed.
+func (c *TelemetryConfig) otlp() *OTLPConfig {
+	if c.OTLP == nil {
+		c.OTLP = &OTLPConfig{}
+	}
+
+	return c.OTLP
+}
+
+// traces returns the traces section, creating it if needed.
+func (c *TelemetryConfig) traces() *TracesConfig {
+	if c.Traces == nil {
+		c.Traces = &TracesConfig{}
+	}
+
+	return c.Traces
+}
+
+// logs returns the logs section, creating it if needed.
+func (c *TelemetryConfig) logs() *LogsConfig {
+	if c.Logs == nil {
+		c.Logs = &LogsConfig{}
+	}
+
+	return c.Logs
+}
+
+// metrics returns the metrics section, creating it if needed.
+func (c *TelemetryConfig) metrics() *MetricsConfig {
+	if c.Metrics == nil {
+		c.Metrics = &MetricsConfig{}
+	}
+
+	return c.Metrics
+}
+
+// NewTracerProviderWithOptions is NewTracerProvider with the configuration built
+// by [NewConfig] from opts. Use NewTracerProvider with NewConfig to also pass
+// TracerProviderOptions.
+//
+// Example:
+//
+//	tp, err := otx.NewTracerProviderWithOptions(ctx,
+//	    otx.WithServiceName("orders"),
+//	    otx.WithOTLPEndpoint("otel-collector:4317"),
+//	    otx.WithSampler("parentbased_traceidratio", "0.1"),
+//	)
+func NewTracerProviderWithOptions(ctx context.Context, opts ...ConfigOption) (*sdktrace.TracerProvider, error) {
+	return NewTracerProvider(ctx, NewConfig(opts...))
+}
+
+// NewLoggerProviderWithOptions is NewLoggerProvider with the configuration built
+// by [NewConfig] from opts.
+func NewLoggerProviderWithOptions(ctx context.Context, opts ...ConfigOption) (*sdklog.LoggerProvider, error) {
+	return NewLoggerProvider(ctx, NewConfig(opts...))
+}
+
+// NewMeterProviderWithOptions is NewMeterProvider with the configuration built
+// by [NewConfig] from opts.
+func NewMeterProviderWithOptions(ctx context.Context, opts ...ConfigOption) (*sdkmetric.MeterProvider, error) {
+	return NewMeterProvider(ctx, NewConfig(opts...))
+}
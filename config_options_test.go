@@ -0,0 +1,118 @@
+package otx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestNewConfig_Defaults(t *testing.T) {
+	cfg := NewConfig(WithServiceName("orders"))
+	require.NoError(t, cfg.Validate())
+
+	assert.True(t, cfg.IsEnabled())
+	assert.Equal(t, "orders", cfg.ServiceName)
+	assert.Equal(t, "localhost:4317", cfg.GetOTLPEndpoint())
+	assert.True(t, cfg.GetOTLPConfig().IsInsecure())
+	assert.Equal(t, []string{"otlp"}, cfg.GetTracesExporters())
+	assert.Equal(t, "parentbased_always_on", cfg.GetSamplingConfig().Sampler)
+	assert.True(t, cfg.Traces.IsEnabled())
+	assert.True(t, cfg.Logs.IsEnabled())
+	assert.True(t, cfg.Metrics.IsEnabled())
+}
+
+func TestNewConfig_Options(t *testing.T) {
+	cfg := NewConfig(
+		WithServiceName("orders"),
+		WithServiceVersion("1.2.3"),
+		WithEnvironment("staging"),
+		WithResourceAttributes(map[string]string{"team": "payments"}),
+		WithOTLPEndpoint("https://collector:4318/v1/traces"),
+		WithOTLPProtocol("http/protobuf"),
+		WithOTLPHeaders(map[string]string{"api-key": "secret"}),
+		WithInsecure(false),
+		WithSampler("parentbased_traceidratio", "0.25"),
+		WithExporter("console"),
+		WithSignalsEnabled(true, false, false),
+		WithConfig(func(c *TelemetryConfig) { c.Traces.SpanMetrics = true }),
+	)
+	require.NoError(t, cfg.Validate())
+
+	assert.Equal(t, "1.2.3", cfg.Version)
+	assert.Equal(t, "staging", cfg.Environment)
+	assert.Equal(t, map[string]string{"team": "payments"}, cfg.ResourceAttributes)
+	assert.Equal(t, "https://collector:4318/v1/traces", cfg.OTLP.Endpoint)
+	assert.Equal(t, "http/protobuf", cfg.OTLP.Protocol)
+	assert.Equal(t, map[string]string{"api-key": "secret"}, cfg.OTLP.Headers)
+	assert.False(t, cfg.GetOTLPConfig().IsInsecure())
+	assert.Equal(t, &SamplingConfig{Sampler: "parentbased_traceidratio", SamplerArg: "0.25"}, cfg.Traces.Sampling)
+	assert.Equal(t, "console", cfg.Traces.Exporter)
+	assert.Equal(t, "console", cfg.Logs.Exporter)
+	assert.Equal(t, "console", cfg.Metrics.Exporter)
+	assert.True(t, cfg.Traces.IsEnabled())
+	assert.False(t, cfg.Logs.IsEnabled())
+	assert.False(t, cfg.Metrics.IsEnabled())
+	assert.True(t, cfg.Traces.SpanMetrics)
+}
+
+func TestNewConfig_Env(t *testing.T) {
+	t.Setenv("OTEL_SERVICE_NAME", "from-env")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "env-collector:4317")
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "team=payments")
+
+	cfg := NewConfig(WithOTLPEndpoint("collector:4317"))
+	require.NoError(t, cfg.Validate())
+
+	assert.Equal(t, "from-env", cfg.ServiceName)
+	assert.Equal(t, "collector:4317", cfg.GetOTLPEndpoint())
+	assert.Equal(t, map[string]string{"team": "payments"}, cfg.ResourceAttributes)
+	assert.True(t, cfg.Logs.IsEnabled())
+}
+
+func TestNewConfig_EnvValidatedAfterOptions(t *testing.T) {
+	t.Setenv("OTX_ENABLED", "true")
+
+	var reported []error
+	prev := otel.GetErrorHandler()
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) { reported = append(reported, err) }))
+	t.Cleanup(func() { otel.SetErrorHandler(prev) })
+
+	cfg := NewConfig(WithServiceName("orders"))
+	require.NoError(t, cfg.Validate())
+	assert.Empty(t, reported)
+}
+
+func TestNewTracerProvider_InvalidRatio(t *testing.T) {
+	_, err := NewTracerProviderWithOptions(t.Context(),
+		WithServiceName("orders"), WithExporter("none"), WithSampler("parentbased_traceidratio", "1.5"))
+	require.ErrorIs(t, err, ErrInvalidConfig, "an invalid ratio must not fall back to sampling everything")
+}
+
+func TestNewConfig_SamplerDefaultArg(t *testing.T) {
+	cfg := NewConfig(WithSampler("always_on", ""))
+	assert.Equal(t, "1.0", cfg.Traces.Sampling.SamplerArg)
+}
+
+func TestNewProvidersWithOptions(t *testing.T) {
+	ctx := t.Context()
+
+	tp, err := NewTracerProviderWithOptions(ctx, WithServiceName("orders"), WithExporter("none"))
+	require.NoError(t, err)
+	require.NoError(t, tp.Shutdown(ctx))
+
+	lp, err := NewLoggerProviderWithOptions(ctx, WithServiceName("orders"), WithExporter("none"))
+	require.NoError(t, err)
+	require.NoError(t, lp.Shutdown(ctx))
+
+	mp, err := NewMeterProviderWithOptions(ctx, WithServiceName("orders"), WithExporter("none"))
+	require.NoError(t, err)
+	require.NoError(t, mp.Shutdown(ctx))
+
+	_, err = NewTracerProviderWithOptions(ctx, WithExporter("none"))
+	require.ErrorIs(t, err, ErrServiceNameRequired)
+
+	_, err = NewLoggerProviderWithOptions(ctx, WithServiceName("orders"), WithSignalsEnabled(true, false, true))
+	require.ErrorIs(t, err, ErrLogsDisabled)
+}
@@ -11,3 +11,22 @@ func TestIsEnabled(t *testing.T) {
 	assert.False(t, (&TelemetryConfig{}).IsEnabled())
 	assert.True(t, (&TelemetryConfig{Enabled: boolPtr(true)}).IsEnabled())
 }
+
+func TestSamplingConfig_Ratio(t *testing.T) {
+	tests := []struct {
+		arg   string
+		ratio float64
+		ok    bool
+	}{
+		{arg: "", ratio: 1, ok: true},
+		{arg: "0.1", ratio: 0.1, ok: true},
+		{arg: " 0 ", ratio: 0, ok: true},
+		{arg: "1.5", ratio: 1, ok: false},
+		{arg: "endpoint=http://localhost:14250", ratio: 1, ok: false},
+	}
+	for _, tt := range tests {
+		ratio, ok := (&SamplingConfig{SamplerArg: tt.arg}).Ratio()
+		assert.InDelta(t, tt.ratio, ratio, 1e-9, tt.arg)
+		assert.Equal(t, tt.ok, ok, tt.arg)
+	}
+}
@@ -0,0 +1,377 @@
+package otx
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidConfig is wrapped by every problem reported by [TelemetryConfig.Validate].
+var ErrInvalidConfig = errors.New("otx: invalid config")
+
+// validSamplers lists the OTEL_TRACES_SAMPLER values understood by buildSampler.
+var validSamplers = map[string]bool{
+	"always_on":                true,
+	"always_off":               true,
+	"traceidratio":             true,
+	"parentbased_always_on":    true,
+	"parentbased_always_off":   true,
+	"parentbased_traceidratio": true,
+}
+
+// validExporters lists exporter types after normalizeExporterType.
+var validExporters = map[string]bool{
+	"otlp":    true,
+	"console": true,
+	"none":    true,
+	"nop":     true,
+}
+
+// Validate checks the configuration for problems that would otherwise surface
+// late inside the OTLP clients with opaque messages.
+//
+// It reports unknown sampler names, out-of-range sampler arguments, unknown
+// exporter types, protocols, compression, propagators, ID generators and error
+// handlers, negative durations, empty traces.dropSpans rules, incomplete
+// traces.peerServices rules, invalid metrics.views, and endpoint formats that do
+// not match the protocol (gRPC endpoints must not include a scheme, HTTP endpoints
+// must be full URLs; both may be unix: sockets).
+//
+// All problems are returned together as a joined error; each one wraps
+// [ErrInvalidConfig]. A nil config is valid.
+//
+// Example:
+//
+//	if err := cfg.Validate(); err != nil {
+//	    log.Fatalf("telemetry config:\n%v", err)
+//	}
+func (c *TelemetryConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	var errs []error
+	if c.IsEnabled() && c.ServiceName == "" {
+		errs = append(errs, invalidf("serviceName is required when telemetry is enabled"))
+	}
+
+	errs = append(errs, validateSampling(c.GetSamplingConfig())...)
+	errs = append(errs, validateOTLP(c.GetOTLPConfig())...)
+	var tracesExporters []string
+	if c.Traces != nil {
+		tracesExporters = c.Traces.Exporters
+	}
+	errs = append(errs, validateExporters("traces", c.GetTracesExporter(), tracesExporters,
+		resolveTraceExporterParams(c), c.Traces.IsEnabled())...)
+	if c.Traces != nil && c.Traces.Batch != nil {
+		errs = append(errs, validateBatch("traces.batch", c.Traces.Batch)...)
+	}
+	if c.Traces != nil {
+		errs = append(errs, validateClientCertificate("traces", c.Traces.ClientCertificate, c.Traces.ClientKey)...)
+		for i, rule := range c.Traces.DropSpans {
+			if rule.Name == "" && len(rule.Attributes) == 0 {
+				errs = append(errs, invalidf("traces.dropSpans[%d]: name or attributes is required", i))
+			}
+		}
+		for i, rule := range c.Traces.PeerServices {
+			if rule.Host == "" || rule.Service == "" {
+				errs = append(errs, invalidf("traces.peerServices[%d]: host and service are required", i))
+			}
+		}
+		switch c.Traces.IDGenerator {
+		case "", IDGeneratorRandom, IDGeneratorXRay:
+		default:
+			errs = append(errs, invalidf("traces.idGenerator: unknown ID generator %q", c.Traces.IDGenerator))
+		}
+	}
+	if c.Logs != nil && c.Logs.Batch != nil {
+		errs = append(errs, validateBatch("logs.batch", c.Logs.Batch)...)
+	}
+	if c.Logs != nil {
+		errs = append(errs, validateClientCertificate("logs", c.Logs.ClientCertificate, c.Logs.ClientKey)...)
+		errs = append(errs, validateExporters("logs", c.Logs.Exporter, c.Logs.Exporters,
+			resolveLogExporterParams(c), c.Logs.IsEnabled())...)
+		switch strings.ToLower(c.Logs.Processor) {
+		case "", LogProcessorBatch, LogProcessorSimple:
+		default:
+			errs = append(errs, invalidf("logs.processor: unknown log processor %q", c.Logs.Processor))
+		}
+		if c.Logs.AttributeCountLimit < -1 {
+			errs = append(errs, invalidf("logs.attributeCountLimit must be -1 or more, got %d", c.Logs.AttributeCountLimit))
+		}
+		if c.Logs.AttributeValueLengthLimit < -1 {
+			errs = append(errs, invalidf("logs.attributeValueLengthLimit must be -1 or more, got %d",
+				c.Logs.AttributeValueLengthLimit))
+		}
+	}
+	if c.Metrics != nil {
+		errs = append(errs, validateClientCertificate("metrics", c.Metrics.ClientCertificate, c.Metrics.ClientKey)...)
+		errs = append(errs, validateExporters("metrics", c.Metrics.Exporter, c.Metrics.Exporters,
+			resolveMetricExporterParams(c), c.Metrics.IsEnabled())...)
+		if c.Metrics.Interval < 0 {
+			errs = append(errs, invalidf("metrics.interval must not be negative, got %s", c.Metrics.Interval))
+		}
+		for i, view := range c.Metrics.Views {
+			errs = append(errs, validateMetricView(fmt.Sprintf("metrics.views[%d]", i), view)...)
+		}
+		errs = append(errs, validateMetricExport(c.Metrics)...)
+		switch c.Metrics.ExemplarFilter {
+		case "", ExemplarFilterAlwaysOn, ExemplarFilterAlwaysOff, ExemplarFilterTraceBased:
+		default:
+			errs = append(errs, invalidf("metrics.exemplarFilter: unknown exemplar filter %q", c.Metrics.ExemplarFilter))
+		}
+	}
+	switch c.ErrorHandler {
+	case "", ErrorHandlerDefault, ErrorHandlerSlog, ErrorHandlerNone:
+	default:
+		errs = append(errs, invalidf("errorHandler: unknown error handler %q", c.ErrorHandler))
+	}
+	if c.Propagation != nil {
+		for _, name := range splitPropagators(c.Propagation.Propagators) {
+			if !isKnownPropagator(name) {
+				errs = append(errs, invalidf("propagation.propagators: unknown propagator %q", name))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// invalidf formats a validation problem wrapping ErrInvalidConfig.
+func invalidf(format string, args ...any) error {
+	return fmt.Errorf("%w: "+format, append([]any{ErrInvalidConfig}, args...)...)
+}
+
+// validateMetricExport checks the temporality preference and the aggregations
+// per instrument kind.
+func validateMetricExport(cfg *MetricsConfig) []error {
+	var errs []error
+	switch strings.ToLower(cfg.Temporality) {
+	case "", TemporalityCumulative, TemporalityDelta, TemporalityLowMemory:
+	default:
+		errs = append(errs, invalidf("metrics.temporality: unknown temporality %q", cfg.Temporality))
+	}
+	for _, key := range slices.Sorted(maps.Keys(cfg.Aggregations)) {
+		if _, ok := instrumentKinds[key]; !ok {
+			errs = append(errs, invalidf("metrics.aggregations: unknown instrument kind %q", key))
+		}
+		if !isAggregation(cfg.Aggregations[key]) {
+			errs = append(errs, invalidf("metrics.aggregations.%s: unknown aggregation %q", key, cfg.Aggregations[key]))
+		}
+	}
+
+	return errs
+}
+
+// validateSampling checks the sampler name and its argument.
+func validateSampling(cfg *SamplingConfig) []error {
+	if cfg == nil {
+		return nil
+	}
+
+	var errs []error
+	if cfg.Sampler != "" && !validSamplers[cfg.Sampler] {
+		errs = append(errs, invalidf("sampling.sampler: unknown sampler %q", cfg.Sampler))
+	}
+	if strings.HasSuffix(cfg.Sampler, "traceidratio") {
+		if _, ok := cfg.Ratio(); !ok {
+			errs = append(errs, invalidf("sampling.samplerArg must be a number within [0, 1], got %q", cfg.SamplerArg))
+		}
+	}
+
+	return errs
+}
+
+// validateBatch checks the batch processor settings found at path.
+func validateBatch(path string, cfg *BatchConfig) []error {
+	var errs []error
+	if cfg.MaxQueueSize < 0 || cfg.MaxExportBatchSize < 0 {
+		errs = append(errs, invalidf("%s sizes must not be negative", path))
+	}
+	if cfg.ScheduleDelay < 0 || cfg.ExportTimeout < 0 {
+		errs = append(errs, invalidf("%s durations must not be negative", path))
+	}
+	if cfg.MaxQueueSize > 0 && cfg.MaxExportBatchSize > cfg.MaxQueueSize {
+		errs = append(errs, invalidf("%s.maxExportBatchSize (%d) exceeds maxQueueSize (%d)",
+			path, cfg.MaxExportBatchSize, cfg.MaxQueueSize))
+	}
+
+	return errs
+}
+
+// validateOTLP checks the shared OTLP settings.
+func validateOTLP(cfg *OTLPConfig) []error {
+	var errs []error
+	switch cfg.Protocol {
+	case "", "grpc", "http/protobuf", "http":
+	default:
+		errs = append(errs, invalidf("otlp.protocol: unknown protocol %q", cfg.Protocol))
+	}
+	switch cfg.Compression {
+	case "", "gzip", "none":
+	default:
+		errs = append(errs, invalidf("otlp.compression: unknown compression %q", cfg.Compression))
+	}
+	if cfg.Timeout < 0 {
+		errs = append(errs, invalidf("otlp.timeout must not be negative, got %s", cfg.Timeout))
+	}
+	errs = append(errs, validateClientCertificate("otlp", cfg.ClientCertificate, cfg.ClientKey)...)
+	if _, err := parseProxyURL(cfg.Proxy); err != nil {
+		errs = append(errs, invalidf("otlp.proxy: %v", err))
+	}
+	if cb := cfg.CircuitBreaker; cb != nil {
+		errs = append(errs, validateCircuitBreaker(cb, cfg.Protocol)...)
+	}
+	if r := cfg.Retry; r != nil {
+		if r.InitialInterval < 0 || r.MaxInterval < 0 || r.MaxElapsedTime < 0 {
+			errs = append(errs, invalidf("otlp.retry intervals must not be negative"))
+		}
+		if r.MaxInterval > 0 && r.InitialInterval > r.MaxInterval {
+			errs = append(errs, invalidf("otlp.retry.initialInterval (%s) exceeds maxInterval (%s)",
+				r.InitialInterval, r.MaxInterval))
+		}
+	}
+
+	return errs
+}
+
+// validateMetricView checks that a view selects instruments and describes a valid stream.
+func validateMetricView(path string, v MetricView) []error {
+	var errs []error
+	if v.Instrument == "" {
+		errs = append(errs, invalidf("%s.instrument is required", path))
+	}
+	if v.Name != "" && v.hasWildcard() {
+		errs = append(errs, invalidf("%s.name cannot rename instrument pattern %q", path, v.Instrument))
+	}
+	if len(v.AttributeKeys) > 0 && len(v.ExcludeAttributeKeys) > 0 {
+		errs = append(errs, invalidf("%s: attributeKeys and excludeAttributeKeys are mutually exclusive", path))
+	}
+	for i := 1; i < len(v.Buckets); i++ {
+		if v.Buckets[i] <= v.Buckets[i-1] {
+			errs = append(errs, invalidf("%s.buckets must be strictly increasing, got %v", path, v.Buckets))
+
+			break
+		}
+	}
+
+	return errs
+}
+
+// validateClientCertificate checks that the client certificate and key at path are set together.
+func validateClientCertificate(path, cert, key string) []error {
+	if (cert == "") != (key == "") {
+		return []error{invalidf("%s.clientCertificate and %s.clientKey must be set together", path, path)}
+	}
+
+	return nil
+}
+
+// validateCircuitBreaker checks otlp.circuitBreaker.
+func validateCircuitBreaker(cfg *CircuitBreakerConfig, protocol string) []error {
+	var errs []error
+	if cfg.FailureThreshold < 0 || cfg.OpenDuration < 0 {
+		errs = append(errs, invalidf("otlp.circuitBreaker values must not be negative"))
+	}
+	switch cfg.Fallback {
+	case "", "none", "console":
+	case "otlp":
+		if cfg.FallbackEndpoint == "" {
+			errs = append(errs, invalidf("otlp.circuitBreaker.fallbackEndpoint is required for fallback \"otlp\""))
+		} else if err := validateEndpoint("fallback", exporterParams{
+			Endpoint: cfg.FallbackEndpoint,
+			Protocol: protocol,
+		}); err != nil {
+			errs = append(errs, err)
+		}
+	case "file":
+		if cfg.FallbackFile == "" {
+			errs = append(errs, invalidf("otlp.circuitBreaker.fallbackFile is required for fallback \"file\""))
+		}
+	default:
+		errs = append(errs, invalidf("otlp.circuitBreaker.fallback: unknown fallback %q", cfg.Fallback))
+	}
+
+	return errs
+}
+
+// validateExporters checks the exporter types of a signal (list if set, otherwise
+// single) and, when the signal is enabled and any exporter uses OTLP, the resolved endpoint.
+func validateExporters(signal, single string, list []string, params exporterParams, enabled bool) []error {
+	field, types := signal+".exporter", []string{single}
+	if len(list) > 0 {
+		field, types = signal+".exporters", list
+	}
+
+	var errs []error
+	usesOTLP := false
+	for _, typ := range types {
+		errs = append(errs, validateExporterType(field, typ))
+		// Unknown exporter types fall back to OTLP in the builders, so check them too.
+		switch normalizeExporterType(typ) {
+		case "console", "none", "nop":
+		default:
+			usesOTLP = true
+		}
+	}
+	if enabled && usesOTLP {
+		errs = append(errs, validateEndpoint(signal, params))
+	}
+
+	return errs
+}
+
+// validateExporterType checks that value names a supported exporter.
+// An empty value is valid and means the default (otlp).
+func validateExporterType(field, value string) error {
+	if validExporters[normalizeExporterType(value)] {
+		return nil
+	}
+
+	return invalidf("%s: unknown exporter type %q", field, value)
+}
+
+// validateEndpoint checks that the resolved endpoint matches the protocol.
+func validateEndpoint(signal string, params exporterParams) error {
+	if params.Endpoint == "" {
+		return invalidf("%s endpoint must not be empty", signal)
+	}
+	if strings.HasPrefix(params.Endpoint, "unix:") {
+		return validateUnixEndpoint(signal, params)
+	}
+
+	hasScheme := false
+	if parsed, err := url.Parse(params.Endpoint); err == nil && isHTTPSScheme(parsed.Scheme) {
+		hasScheme = true
+	}
+
+	switch params.Protocol {
+	case "http/protobuf", "http":
+		if !hasScheme {
+			return invalidf("%s endpoint %q must be a full URL (http:// or https://) for protocol %q",
+				signal, params.Endpoint, params.Protocol)
+		}
+	case "grpc", "":
+		if hasScheme || strings.Contains(params.Endpoint, "/v1/") {
+			return invalidf("%s endpoint %q must be host:port without scheme or path for protocol \"grpc\"",
+				signal, params.Endpoint)
+		}
+	}
+
+	return nil
+}
+
+// validateUnixEndpoint checks a unix domain socket endpoint, which is
+// "unix:///absolute/path" or "unix:relative/path" for both protocols.
+func validateUnixEndpoint(signal string, params exporterParams) error {
+	path, _ := unixSocketPath(params.Endpoint)
+	if path == "" || (strings.HasPrefix(params.Endpoint, "unix://") && !strings.HasPrefix(path, "/")) {
+		return invalidf("%s endpoint %q must be unix:///absolute/path or unix:relative/path",
+			signal, params.Endpoint)
+	}
+
+	return nil
+}
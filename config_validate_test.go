@@ -0,0 +1,275 @@
+package otx
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate_Valid(t *testing.T) {
+	assert.NoError(t, (*TelemetryConfig)(nil).Validate())
+	assert.NoError(t, (&TelemetryConfig{}).Validate())
+
+	cfg := &TelemetryConfig{
+		Enabled:     boolPtr(true),
+		ServiceName: "svc",
+		OTLP:        &OTLPConfig{Endpoint: "http://collector:4318", Protocol: "http/protobuf"},
+		Traces:      &TracesConfig{Exporter: "otlp", Sampling: &SamplingConfig{Sampler: "traceidratio", SamplerArg: "0.5"}},
+		Logs:        &LogsConfig{Enabled: boolPtr(true), Exporter: "stdout"},
+		Metrics:     &MetricsConfig{Enabled: boolPtr(true), Exporter: "noop"},
+		Propagation: &PropConfig{Propagators: "tracecontext,baggage"},
+	}
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidate_AggregatesErrors(t *testing.T) {
+	cfg := &TelemetryConfig{
+		Enabled: boolPtr(true),
+		OTLP:    &OTLPConfig{Endpoint: "http://collector:4317", Protocol: "grpc", Compression: "zstd"},
+		Traces: &TracesConfig{
+			Exporter: "zipkin",
+			Sampling: &SamplingConfig{Sampler: "sometimes"},
+		},
+		Metrics: &MetricsConfig{
+			Enabled:  boolPtr(true),
+			Endpoint: "collector:4318",
+		},
+		Propagation: &PropConfig{Propagators: "tracecontext,bogus"},
+	}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	var joined interface{ Unwrap() []error }
+	require.True(t, errors.As(err, &joined))
+
+	msg := err.Error()
+	for _, want := range []string{
+		"serviceName is required",
+		`unknown sampler "sometimes"`,
+		`unknown compression "zstd"`,
+		`traces.exporter: unknown exporter type "zipkin"`,
+		`traces endpoint "http://collector:4317" must be host:port`,
+		`unknown propagator "bogus"`,
+	} {
+		assert.Contains(t, msg, want)
+	}
+	assert.Len(t, joined.Unwrap(), 6, msg)
+	assert.False(t, strings.Contains(msg, "metrics endpoint"), "grpc host:port metrics endpoint is valid")
+}
+
+func TestValidate_HTTPEndpointRequiresScheme(t *testing.T) {
+	cfg := &TelemetryConfig{
+		OTLP: &OTLPConfig{Endpoint: "collector:4318", Protocol: "http/protobuf"},
+	}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `traces endpoint "collector:4318" must be a full URL`)
+}
+
+func TestValidate_UnixEndpoint(t *testing.T) {
+	cfg := &TelemetryConfig{OTLP: &OTLPConfig{Endpoint: "unix:///var/run/otel.sock"}}
+	require.NoError(t, cfg.Validate())
+
+	cfg.OTLP.Endpoint = "unix:otel.sock"
+	require.NoError(t, cfg.Validate())
+
+	cfg.OTLP.Endpoint = "unix://var/run/otel.sock"
+	require.ErrorContains(t, cfg.Validate(), "must be unix:///absolute/path or unix:relative/path")
+
+	cfg.OTLP = &OTLPConfig{Endpoint: "unix:///var/run/otel.sock", Protocol: "http/protobuf"}
+	require.NoError(t, cfg.Validate())
+}
+
+func TestValidate_ClientCertificate(t *testing.T) {
+	cfg := &TelemetryConfig{
+		OTLP: &OTLPConfig{ClientCertificate: "/etc/otel/client.crt"},
+	}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "otlp.clientCertificate and otlp.clientKey must be set together")
+
+	cfg.OTLP.ClientKey = "/etc/otel/client.key"
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidate_CircuitBreaker(t *testing.T) {
+	cfg := &TelemetryConfig{
+		OTLP: &OTLPConfig{CircuitBreaker: &CircuitBreakerConfig{FailureThreshold: -1, Fallback: "file"}},
+	}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "otlp.circuitBreaker values must not be negative")
+	assert.Contains(t, err.Error(), `otlp.circuitBreaker.fallbackFile is required for fallback "file"`)
+
+	cfg.OTLP.CircuitBreaker = &CircuitBreakerConfig{Fallback: "kafka"}
+	require.ErrorContains(t, cfg.Validate(), `otlp.circuitBreaker.fallback: unknown fallback "kafka"`)
+
+	cfg.OTLP.CircuitBreaker = &CircuitBreakerConfig{Fallback: "otlp"}
+	require.ErrorContains(t, cfg.Validate(), `otlp.circuitBreaker.fallbackEndpoint is required for fallback "otlp"`)
+
+	cfg.OTLP.CircuitBreaker = &CircuitBreakerConfig{Fallback: "otlp", FallbackEndpoint: "http://backup:4317"}
+	require.ErrorContains(t, cfg.Validate(), `fallback endpoint "http://backup:4317" must be host:port`)
+
+	cfg.OTLP.CircuitBreaker = &CircuitBreakerConfig{Fallback: "otlp", FallbackEndpoint: "backup:4317"}
+	require.NoError(t, cfg.Validate())
+
+	cfg.OTLP.CircuitBreaker = &CircuitBreakerConfig{Fallback: "console"}
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidate_Batch(t *testing.T) {
+	cfg := &TelemetryConfig{
+		Traces: &TracesConfig{
+			Exporter: "console",
+			Batch:    &BatchConfig{MaxQueueSize: 100, MaxExportBatchSize: 500, ExportTimeout: -time.Second},
+		},
+	}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "traces.batch.maxExportBatchSize (500) exceeds maxQueueSize (100)")
+	assert.Contains(t, err.Error(), "traces.batch durations must not be negative")
+
+	cfg.Traces.Batch = &BatchConfig{MaxQueueSize: 4096, MaxExportBatchSize: 1024}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Logs = &LogsConfig{Exporter: "console", Batch: &BatchConfig{MaxQueueSize: -1}}
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "logs.batch sizes must not be negative")
+}
+
+func TestValidate_DropSpans(t *testing.T) {
+	cfg := &TelemetryConfig{
+		Traces: &TracesConfig{
+			Exporter:  "console",
+			DropSpans: []SpanDropRule{{Name: "GET /metrics"}, {}},
+		},
+	}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "traces.dropSpans[1]: name or attributes is required")
+
+	cfg.Traces.DropSpans = cfg.Traces.DropSpans[:1]
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidate_IDGenerator(t *testing.T) {
+	cfg := &TelemetryConfig{Traces: &TracesConfig{Exporter: "console", IDGenerator: "snowflake"}}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `traces.idGenerator: unknown ID generator "snowflake"`)
+
+	cfg.Traces.IDGenerator = IDGeneratorXRay
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidate_ExemplarFilter(t *testing.T) {
+	cfg := &TelemetryConfig{Metrics: &MetricsConfig{Exporter: "console", ExemplarFilter: "sometimes"}}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `metrics.exemplarFilter: unknown exemplar filter "sometimes"`)
+
+	cfg.Metrics.ExemplarFilter = ExemplarFilterAlwaysOn
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidate_Exporters(t *testing.T) {
+	cfg := &TelemetryConfig{
+		Traces: &TracesConfig{Exporters: []string{"console", "zipkin"}},
+		Logs:   &LogsConfig{Enabled: boolPtr(true), Exporters: []string{"console", "none"}},
+	}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `traces.exporters: unknown exporter type "zipkin"`)
+	assert.NotContains(t, err.Error(), "logs", "console-only fan-out needs no endpoint")
+
+	cfg.Traces.Exporters = []string{"otlp", "console"}
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidate_MetricExport(t *testing.T) {
+	cfg := &TelemetryConfig{Metrics: &MetricsConfig{
+		Exporter:     "console",
+		Temporality:  "sometimes",
+		Aggregations: map[string]string{"timer": "sum", "histogram": "percentiles"},
+	}}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `metrics.temporality: unknown temporality "sometimes"`)
+	assert.Contains(t, err.Error(), `metrics.aggregations: unknown instrument kind "timer"`)
+	assert.Contains(t, err.Error(), `metrics.aggregations.histogram: unknown aggregation "percentiles"`)
+
+	cfg.Metrics.Temporality = TemporalityDelta
+	cfg.Metrics.Aggregations = map[string]string{"histogram": AggregationBase2ExponentialBucketHistogram}
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidate_SamplerArg(t *testing.T) {
+	for _, arg := range []string{"2", "-0.1", "half"} {
+		cfg := &TelemetryConfig{Traces: &TracesConfig{
+			Exporter: "console",
+			Sampling: &SamplingConfig{Sampler: "parentbased_traceidratio", SamplerArg: arg},
+		}}
+		err := cfg.Validate()
+		require.Error(t, err, arg)
+		assert.Contains(t, err.Error(), "sampling.samplerArg must be a number within [0, 1]")
+	}
+
+	cfg := &TelemetryConfig{Traces: &TracesConfig{
+		Exporter: "console",
+		Sampling: &SamplingConfig{Sampler: "always_on", SamplerArg: "http://sampling:5778"},
+	}}
+	assert.NoError(t, cfg.Validate(), "non-ratio samplers take any argument")
+}
+
+func TestValidate_LogProcessor(t *testing.T) {
+	cfg := &TelemetryConfig{Logs: &LogsConfig{Exporter: "console", Processor: "async"}}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `logs.processor: unknown log processor "async"`)
+
+	cfg.Logs.Processor = LogProcessorSimple
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidate_LogLimits(t *testing.T) {
+	cfg := &TelemetryConfig{Logs: &LogsConfig{Exporter: "console", AttributeCountLimit: -2, AttributeValueLengthLimit: -5}}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "logs.attributeCountLimit must be -1 or more, got -2")
+	assert.Contains(t, err.Error(), "logs.attributeValueLengthLimit must be -1 or more, got -5")
+
+	cfg.Logs.AttributeCountLimit, cfg.Logs.AttributeValueLengthLimit = -1, 4096
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidate_PeerServices(t *testing.T) {
+	cfg := &TelemetryConfig{Traces: &TracesConfig{PeerServices: []PeerServiceRule{
+		{Host: "api.stripe.com", Service: "stripe"},
+		{Host: "*.amazonaws.com"},
+	}}}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "traces.peerServices[1]: host and service are required")
+
+	cfg.Traces.PeerServices[1].Service = "aws"
+	assert.NoError(t, cfg.Validate())
+}
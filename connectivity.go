@@ -0,0 +1,206 @@
+package otx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// maxProbeResponseSize bounds how much of an HTTP probe response is read.
+const maxProbeResponseSize = 4 << 10
+
+// connectivityTarget is an OTLP endpoint used by one signal.
+type connectivityTarget struct {
+	signal string
+	params exporterParams
+}
+
+// CheckConnectivity verifies that the OTLP endpoint of every enabled signal accepts exports.
+//
+// For each signal exported with "otlp", it sends an empty export request using the
+// configured protocol, endpoint, headers, TLS setting and timeout, and waits for the
+// response. A wrong endpoint, protocol or credential is reported at startup instead of
+// failing silently until the first batch times out. The check sends no telemetry.
+//
+// All signals are checked; the returned error joins one error per failing signal, each
+// naming the signal, protocol and endpoint. Returns nil if telemetry is disabled or no
+// enabled signal exports via OTLP.
+//
+// Usage:
+//
+//	if err := otx.CheckConnectivity(ctx, cfg); err != nil {
+//	    log.Printf("telemetry collector unreachable: %v", err)
+//	}
+func CheckConnectivity(ctx context.Context, cfg *TelemetryConfig) error {
+	if !cfg.IsEnabled() {
+		return nil
+	}
+
+	var errs []error
+	for _, target := range connectivityTargets(cfg) {
+		if err := checkEndpoint(ctx, target.signal, target.params); err != nil {
+			errs = append(errs, fmt.Errorf("otx: %s endpoint %q (%s): %w",
+				target.signal, target.params.Endpoint, target.params.Protocol, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// connectivityTargets returns the enabled signals that export via OTLP.
+func connectivityTargets(cfg *TelemetryConfig) []connectivityTarget {
+	var targets []connectivityTarget
+	if cfg.Traces.IsEnabled() && slices.Contains(exporterTypes(cfg.GetTracesExporters()), "otlp") {
+		targets = append(targets, connectivityTarget{signal: "traces", params: resolveTraceExporterParams(cfg)})
+	}
+	if cfg.Logs.IsEnabled() && slices.Contains(exporterTypes(logExporterTypes(cfg)), "otlp") {
+		targets = append(targets, connectivityTarget{signal: "logs", params: resolveLogExporterParams(cfg)})
+	}
+	if cfg.Metrics.IsEnabled() && slices.Contains(exporterTypes(metricExporterTypes(cfg)), "otlp") {
+		targets = append(targets, connectivityTarget{signal: "metrics", params: resolveMetricExporterParams(cfg)})
+	}
+
+	return targets
+}
+
+// checkEndpoint sends an empty export request for signal to the endpoint in params.
+func checkEndpoint(ctx context.Context, signal string, params exporterParams) error {
+	if params.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, params.Timeout)
+		defer cancel()
+	}
+
+	if params.Protocol == "http/protobuf" || params.Protocol == "http" {
+		return checkHTTPEndpoint(ctx, signal, params)
+	}
+
+	return checkGRPCEndpoint(ctx, signal, params)
+}
+
+// checkHTTPEndpoint posts an empty OTLP/HTTP request. An empty body is a valid
+// protobuf encoding of an empty export request.
+func checkHTTPEndpoint(ctx context.Context, signal string, params exporterParams) error {
+	tlsCfg, err := params.TLS.config()
+	if err != nil {
+		return err
+	}
+	proxy, err := parseProxyURL(params.Proxy)
+	if err != nil {
+		return err
+	}
+	client := http.DefaultClient
+	socket, isUnix := unixSocketPath(params.Endpoint)
+	if isUnix || tlsCfg != nil || proxy != nil {
+		transport := otlpHTTPTransport(tlsCfg, proxy)
+		if isUnix {
+			dialUnixSocket(transport, socket)
+		}
+		client = &http.Client{Transport: transport}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, probeURL(signal, params), http.NoBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	for key, value := range params.Headers {
+		req.Header.Set(key, value)
+	}
+	if params.HeaderProvider != nil {
+		for key, value := range params.HeaderProvider(ctx) {
+			req.Header.Set(key, value)
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxProbeResponseSize))
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed:
+		return fmt.Errorf("unexpected HTTP status %s: the endpoint does not accept OTLP %s exports", resp.Status, signal)
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return fmt.Errorf("unexpected HTTP status %s", resp.Status)
+	}
+
+	return nil
+}
+
+// probeURL returns the URL the OTLP/HTTP exporter of signal posts to.
+// The endpoint is a host:port, a unix socket, or a URL whose path, if any, replaces
+// the default /v1/<signal>.
+func probeURL(signal string, params exporterParams) string {
+	target := url.URL{Scheme: "https", Host: params.Endpoint, Path: "/v1/" + signal}
+	if _, isUnix := unixSocketPath(params.Endpoint); isUnix {
+		target.Host = unixSocketHost
+	} else if host, path := splitEndpointURL(params.Endpoint); host != "" {
+		target.Host = host
+		if path != "" {
+			target.Path = path
+		}
+	}
+	if params.Insecure {
+		target.Scheme = "http"
+	}
+
+	return target.String()
+}
+
+// checkGRPCEndpoint calls the OTLP/gRPC export service of signal with an empty request.
+func checkGRPCEndpoint(ctx context.Context, signal string, params exporterParams) error {
+	tlsCfg, err := params.TLS.config()
+	if err != nil {
+		return err
+	}
+	creds := credentials.NewClientTLSFromCert(nil, "")
+	switch {
+	case tlsCfg != nil:
+		creds = credentials.NewTLS(tlsCfg)
+	case params.Insecure:
+		creds = insecure.NewCredentials()
+	}
+	dialOpts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if params.HeaderProvider != nil {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(headerCredentials{provider: params.HeaderProvider}))
+	}
+	conn, err := grpc.NewClient(params.Endpoint, dialOpts...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if len(params.Headers) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.New(params.Headers))
+	}
+	switch signal {
+	case "logs":
+		_, err = collogspb.NewLogsServiceClient(conn).Export(ctx, &collogspb.ExportLogsServiceRequest{})
+	case "metrics":
+		_, err = colmetricspb.NewMetricsServiceClient(conn).Export(ctx, &colmetricspb.ExportMetricsServiceRequest{})
+	default:
+		_, err = coltracepb.NewTraceServiceClient(conn).Export(ctx, &coltracepb.ExportTraceServiceRequest{})
+	}
+	if status.Code(err) == codes.Unimplemented {
+		return fmt.Errorf("%w: the endpoint does not accept OTLP %s exports", err, signal)
+	}
+
+	return err
+}
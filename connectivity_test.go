@@ -0,0 +1,251 @@
+package otx
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// fakeTraceService records the authorization metadata of trace exports.
+type fakeTraceService struct {
+	coltracepb.UnimplementedTraceServiceServer
+
+	mu    sync.Mutex
+	auths []string
+}
+
+func (s *fakeTraceService) Export(
+	ctx context.Context,
+	_ *coltracepb.ExportTraceServiceRequest,
+) (*coltracepb.ExportTraceServiceResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.mu.Lock()
+	s.auths = append(s.auths, md.Get("authorization")...)
+	s.mu.Unlock()
+
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+func startOTLPGRPCServer(t *testing.T, traces coltracepb.TraceServiceServer) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	coltracepb.RegisterTraceServiceServer(server, traces)
+	collogspb.RegisterLogsServiceServer(server, collogspb.UnimplementedLogsServiceServer{})
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	return lis.Addr().String()
+}
+
+func TestCheckConnectivity_GRPC(t *testing.T) {
+	traces := &fakeTraceService{}
+	endpoint := startOTLPGRPCServer(t, traces)
+
+	cfg := &TelemetryConfig{
+		Enabled: boolPtr(true),
+		OTLP:    &OTLPConfig{Endpoint: endpoint, Headers: map[string]string{"Authorization": "Bearer token"}},
+	}
+	require.NoError(t, CheckConnectivity(t.Context(), cfg))
+	assert.Equal(t, []string{"Bearer token"}, traces.auths)
+
+	cfg.Logs = &LogsConfig{Enabled: boolPtr(true)}
+	err := CheckConnectivity(t.Context(), cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `otx: logs endpoint "`+endpoint+`" (grpc)`)
+	assert.Contains(t, err.Error(), "does not accept OTLP logs exports")
+	assert.NotContains(t, err.Error(), "traces endpoint")
+}
+
+func TestCheckConnectivity_HTTP(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		paths []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.Method+" "+r.URL.Path+" "+r.Header.Get("Authorization"))
+		mu.Unlock()
+		if r.URL.Path == "/v1/logs" {
+			http.NotFound(w, r)
+
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	cfg := &TelemetryConfig{
+		Enabled: boolPtr(true),
+		OTLP: &OTLPConfig{
+			Endpoint: host,
+			Protocol: "http/protobuf",
+			Headers:  map[string]string{"Authorization": "Bearer token"},
+		},
+		Metrics: &MetricsConfig{Enabled: boolPtr(true), Endpoint: server.URL + "/custom/metrics"},
+	}
+	require.NoError(t, CheckConnectivity(t.Context(), cfg))
+	assert.Equal(t, []string{"POST /v1/traces Bearer token", "POST /custom/metrics Bearer token"}, paths)
+
+	cfg.Logs = &LogsConfig{Enabled: boolPtr(true)}
+	err := CheckConnectivity(t.Context(), cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "404 Not Found")
+	assert.Contains(t, err.Error(), "does not accept OTLP logs exports")
+}
+
+func TestCheckConnectivity_Unreachable(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	endpoint := lis.Addr().String()
+	require.NoError(t, lis.Close())
+
+	for _, protocol := range []string{"grpc", "http/protobuf"} {
+		t.Run(protocol, func(t *testing.T) {
+			cfg := &TelemetryConfig{
+				Enabled: boolPtr(true),
+				OTLP:    &OTLPConfig{Endpoint: endpoint, Protocol: protocol},
+			}
+			err := CheckConnectivity(t.Context(), cfg)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), `otx: traces endpoint "`+endpoint+`" (`+protocol+`)`)
+			assert.Contains(t, err.Error(), "connection refused")
+		})
+	}
+}
+
+func TestCheckConnectivity_NothingToCheck(t *testing.T) {
+	// Nothing listens on the default endpoint; no request may be sent.
+	tests := []struct {
+		name string
+		cfg  *TelemetryConfig
+	}{
+		{name: "nil config", cfg: nil},
+		{name: "disabled", cfg: &TelemetryConfig{Enabled: boolPtr(false)}},
+		{
+			name: "console only",
+			cfg: &TelemetryConfig{
+				Enabled: boolPtr(true),
+				Traces:  &TracesConfig{Exporter: "console"},
+				Metrics: &MetricsConfig{Enabled: boolPtr(true), Exporters: []string{"stdout", "none"}},
+			},
+		},
+		{
+			name: "traces disabled",
+			cfg:  &TelemetryConfig{Enabled: boolPtr(true), Traces: &TracesConfig{Enabled: boolPtr(false)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NoError(t, CheckConnectivity(t.Context(), tt.cfg))
+		})
+	}
+}
+
+func TestProbeURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		insecure bool
+		want     string
+	}{
+		{name: "host port", endpoint: "collector:4318", want: "https://collector:4318/v1/traces"},
+		{name: "insecure", endpoint: "collector:4318", insecure: true, want: "http://collector:4318/v1/traces"},
+		{name: "url without path", endpoint: "https://collector:4318", want: "https://collector:4318/v1/traces"},
+		{
+			name:     "url with path",
+			endpoint: "http://collector/otlp/traces",
+			insecure: true,
+			want:     "http://collector/otlp/traces",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, probeURL("traces", exporterParams{Endpoint: tt.endpoint, Insecure: tt.insecure}))
+		})
+	}
+}
+
+func TestCheckConnectivity_UnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "otel.sock")
+	lis, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	traces := &fakeTraceService{}
+	server := grpc.NewServer()
+	coltracepb.RegisterTraceServiceServer(server, traces)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	cfg := &TelemetryConfig{
+		Enabled: boolPtr(true),
+		OTLP:    &OTLPConfig{Endpoint: "unix://" + path, Headers: map[string]string{"Authorization": "Bearer uds"}},
+	}
+	require.NoError(t, CheckConnectivity(t.Context(), cfg))
+
+	exporter, err := buildOTLPTraceExporter(t.Context(), resolveTraceExporterParams(cfg))
+	require.NoError(t, err)
+	require.NoError(t, exporter.ExportSpans(t.Context(), tracetest.SpanStubs{{Name: "a"}}.Snapshots()))
+	require.NoError(t, exporter.Shutdown(t.Context()))
+
+	assert.Equal(t, []string{"Bearer uds", "Bearer uds"}, traces.auths, "probe and export reach the socket")
+}
+
+func TestCheckConnectivity_UnixSocketHTTP(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "otel.sock")
+	lis, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	var (
+		mu       sync.Mutex
+		requests []string
+	)
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			requests = append(requests, r.Host+r.URL.Path+" "+r.Header.Get("Authorization"))
+			mu.Unlock()
+			w.Header().Set("Content-Type", "application/x-protobuf")
+		}),
+		ReadHeaderTimeout: time.Second,
+	}
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(func() { _ = server.Close() })
+
+	cfg := &TelemetryConfig{
+		Enabled: boolPtr(true),
+		OTLP: &OTLPConfig{
+			Endpoint: "unix://" + path,
+			Protocol: "http/protobuf",
+			Headers:  map[string]string{"Authorization": "Bearer uds"},
+		},
+	}
+	require.NoError(t, CheckConnectivity(t.Context(), cfg))
+
+	exporter, err := buildOTLPTraceExporter(t.Context(), resolveTraceExporterParams(cfg))
+	require.NoError(t, err)
+	require.NoError(t, exporter.ExportSpans(t.Context(), tracetest.SpanStubs{{Name: "a"}}.Snapshots()))
+	require.NoError(t, exporter.Shutdown(t.Context()))
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"localhost/v1/traces Bearer uds", "localhost/v1/traces Bearer uds"}, requests,
+		"probe and export reach the socket")
+}
@@ -0,0 +1,128 @@
+package otx
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Critical-path attributes, read by the backend's critical-path analysis.
+const (
+	// AttrCriticalPath marks a span as being on the critical path of its trace.
+	AttrCriticalPath = "otx.critical_path"
+
+	// AttrCriticalPathChildDuration is the duration, in milliseconds, of the longest
+	// chain of sequential child spans, set by the critical-path processor.
+	AttrCriticalPathChildDuration = "otx.critical_path.child_duration_ms"
+)
+
+// interval is the time range of an ended span.
+type interval struct {
+	start, end time.Time
+}
+
+// criticalPathEntry is an open span and the intervals of its ended children.
+type criticalPathEntry struct {
+	span     sdktrace.ReadWriteSpan
+	children []interval
+}
+
+// criticalPathProcessor attaches the longest child chain duration to parent spans.
+type criticalPathProcessor struct {
+	spans spanTable[*criticalPathEntry]
+}
+
+// MarkCriticalPath marks the current span as being on the critical path of its
+// trace, by setting [AttrCriticalPath] to true.
+func MarkCriticalPath(ctx context.Context) {
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Bool(AttrCriticalPath, true))
+}
+
+// NewCriticalPathProcessor returns a SpanProcessor that sets
+// [AttrCriticalPathChildDuration] on every span with children: the total duration
+// of the longest chain of sequential children, found by walking back from the
+// child that ended last to the one that ended before it started, and so on.
+// Comparing it with the span's own duration shows how much of the span was spent
+// waiting on children versus in its own work.
+//
+// The attribute is updated as each child ends, so children still running when the
+// parent ends are not counted. Only children started in this process are seen.
+// It is registered automatically by [NewTracerProvider] when traces.criticalPath is
+// enabled.
+func NewCriticalPathProcessor() sdktrace.SpanProcessor {
+	return &criticalPathProcessor{}
+}
+
+// OnStart implements sdktrace.SpanProcessor.
+func (p *criticalPathProcessor) OnStart(_ context.Context, s sdktrace.ReadWriteSpan) {
+	p.spans.start(s, func() *criticalPathEntry { return &criticalPathEntry{span: s} })
+}
+
+// OnEnd implements sdktrace.SpanProcessor.
+func (p *criticalPathProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if s.Parent().IsRemote() {
+		p.spans.end(s, nil)
+
+		return
+	}
+
+	child := interval{start: s.StartTime(), end: s.EndTime()}
+	p.spans.end(s, func(_ *criticalPathEntry, _ bool, open func(spanKey) (*criticalPathEntry, bool)) {
+		parent, ok := open(parentKeyOf(s))
+		if !ok {
+			return
+		}
+
+		// The chain is recomputed from every child ended so far, so siblings ending
+		// concurrently must append and set the attribute one at a time.
+		parent.children = append(parent.children, child)
+		chain := longestChain(parent.children)
+		parent.span.SetAttributes(attribute.Float64(AttrCriticalPathChildDuration,
+			float64(chain)/float64(time.Millisecond)))
+	})
+}
+
+// Shutdown implements sdktrace.SpanProcessor.
+func (p *criticalPathProcessor) Shutdown(_ context.Context) error {
+	p.spans.reset(nil)
+
+	return nil
+}
+
+// ForceFlush implements sdktrace.SpanProcessor.
+func (*criticalPathProcessor) ForceFlush(_ context.Context) error {
+	return nil
+}
+
+// openSpans implements spanTracker.
+func (p *criticalPathProcessor) openSpans() int {
+	return p.spans.len()
+}
+
+// longestChain returns the total duration of the chain of children found by
+// starting at the child that ended last and repeatedly stepping to the child
+// that ended last before the current one started.
+func longestChain(children []interval) time.Duration {
+	sorted := slices.Clone(children)
+	slices.SortFunc(sorted, func(a, b interval) int {
+		return b.end.Compare(a.end)
+	})
+
+	var (
+		total  time.Duration
+		cursor time.Time
+	)
+	for i, c := range sorted {
+		if i > 0 && c.end.After(cursor) {
+			continue
+		}
+		total += c.end.Sub(c.start)
+		cursor = c.start
+	}
+
+	return total
+}
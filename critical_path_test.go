@@ -0,0 +1,73 @@
+package otx
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestMarkCriticalPath(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer func() { _ = tp.Shutdown(t.Context()) }()
+
+	ctx, span := tp.Tracer("test").Start(t.Context(), "checkout")
+	MarkCriticalPath(ctx)
+	span.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.True(t, hasAttribute(spans[0].Attributes, attribute.Bool(AttrCriticalPath, true)))
+}
+
+func TestNewCriticalPathProcessor(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(NewCriticalPathProcessor()),
+		sdktrace.WithSyncer(exporter),
+	)
+	defer func() { _ = tp.Shutdown(t.Context()) }()
+	tracer := tp.Tracer("test")
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(ms int) time.Time { return base.Add(time.Duration(ms) * time.Millisecond) }
+	child := func(ctx context.Context, name string, start, end int) {
+		_, span := tracer.Start(ctx, name, trace.WithTimestamp(at(start)))
+		span.End(trace.WithTimestamp(at(end)))
+	}
+
+	// db (0-30) and cache (0-10) run in parallel, then render (30-50) follows db.
+	// The chain is render <- db, so 50ms; cache overlaps and is not on it.
+	ctx, parent := tracer.Start(t.Context(), "request", trace.WithTimestamp(at(0)))
+	child(ctx, "cache", 0, 10)
+	child(ctx, "db", 0, 30)
+	child(ctx, "render", 30, 50)
+	parent.End(trace.WithTimestamp(at(60)))
+
+	_, leaf := tracer.Start(t.Context(), "leaf")
+	leaf.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 5)
+	assert.True(t, hasAttribute(spans[3].Attributes, attribute.Float64(AttrCriticalPathChildDuration, 50)))
+	assert.False(t, hasKey(spans[4].Attributes, AttrCriticalPathChildDuration), "spans without children")
+}
+
+func TestLongestChain(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	iv := func(start, end int) interval {
+		return interval{start: base.Add(time.Duration(start)), end: base.Add(time.Duration(end))}
+	}
+
+	assert.Zero(t, longestChain(nil))
+	assert.Equal(t, time.Duration(10), longestChain([]interval{iv(0, 10)}))
+	assert.Equal(t, time.Duration(30), longestChain([]interval{iv(0, 10), iv(10, 20), iv(20, 30)}), "sequential")
+	assert.Equal(t, time.Duration(25), longestChain([]interval{iv(0, 15), iv(5, 20), iv(20, 30)}), "overlapping")
+}
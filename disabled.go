@@ -0,0 +1,39 @@
+package otx
+
+import (
+	"github.com/arloliu/otx/internal/tracker"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/log/global"
+	lognoop "go.opentelemetry.io/otel/log/noop"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+// InitDisabled sets up the package for a service with telemetry disabled. It
+// installs no-op tracer, meter and logger providers as the OTel globals and
+// clears the tracer set by InitTracing, so Start and the other span helpers
+// return the span of the context without going through a tracer or allocating.
+// Arguments passed to variadic helpers such as SetAttributes are still built by
+// the caller; guard costly ones with Span(ctx).IsRecording().
+//
+// Call it instead of InitTracing when a provider constructor returns ErrDisabled.
+// A later InitTracing enables the span helpers again.
+//
+// Example:
+//
+//	tp, err := otx.NewTracerProvider(ctx, cfg)
+//	switch {
+//	case errors.Is(err, otx.ErrDisabled):
+//	    otx.InitDisabled()
+//	case err != nil:
+//	    return err
+//	default:
+//	    defer tp.Shutdown(ctx)
+//	    otx.InitTracing(tp.Tracer("my-service"), otx.DefaultNamer{})
+//	}
+func InitDisabled() {
+	otel.SetTracerProvider(tracenoop.NewTracerProvider())
+	otel.SetMeterProvider(metricnoop.NewMeterProvider())
+	global.SetLoggerProvider(lognoop.NewLoggerProvider())
+	tracker.Set(nil, nil)
+}
@@ -0,0 +1,45 @@
+package otx
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/log/global"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestInitDisabled(t *testing.T) {
+	tracerProvider, meterProvider, loggerProvider := otel.GetTracerProvider(), otel.GetMeterProvider(),
+		global.GetLoggerProvider()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(tracerProvider)
+		otel.SetMeterProvider(meterProvider)
+		global.SetLoggerProvider(loggerProvider)
+		InitTracing(nil, nil)
+	})
+
+	InitTracing(sdktrace.NewTracerProvider().Tracer("otx"), DefaultNamer{})
+	InitDisabled()
+
+	ctx := t.Context()
+	err := errors.New("boom")
+	allocs := testing.AllocsPerRun(100, func() {
+		ctx, span := StartServer(ctx, "op")
+		AddEvent(ctx, "event")
+		RecordError(ctx, err)
+		SetSuccess(ctx)
+		span.End()
+	})
+	assert.Zero(t, allocs)
+
+	_, span := Start(ctx, "op")
+	assert.False(t, span.SpanContext().IsValid())
+	_, span = otel.Tracer("otx").Start(ctx, "op")
+	assert.False(t, span.IsRecording())
+
+	InitTracing(sdktrace.NewTracerProvider().Tracer("otx"), DefaultNamer{})
+	_, span = Start(ctx, "op")
+	assert.True(t, span.IsRecording(), "InitTracing enables the helpers again")
+}
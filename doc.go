@@ -59,6 +59,7 @@
 // The [SpanNamer] interface controls how operation names become span names.
 // [DefaultNamer] returns operation unchanged, adhering to OTel semantic conventions.
 // Use helpers like [NameHTTP], [NameRPC], etc. to construct standard names.
+// [KindInferringNamer] also infers span kinds from names such as "SELECT users".
 //
 // # Baggage
 //
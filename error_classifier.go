@@ -0,0 +1,55 @@
+package otx
+
+import (
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ErrorClassifier decides how an error is recorded on a span: attrs are added
+// to the span, and isError false keeps the span status unset, e.g. for
+// context.Canceled or expected domain errors. The error is still recorded as an
+// exception event.
+type ErrorClassifier func(err error) (attrs []attribute.KeyValue, isError bool)
+
+// errorClassifier is the classifier set with SetErrorClassifier, nil for none.
+var errorClassifier atomic.Pointer[ErrorClassifier]
+
+// SetErrorClassifier makes RecordError, RecordSpanError, the sql and nats
+// instrumentation and the panic recovery of the middleware stack classify errors
+// with c before recording them. Without a classifier, or with nil, every error
+// sets the span status to Error.
+//
+// HTTP and gRPC server spans are not classified: otelhttp and otelgrpc set their
+// status from the response code, and HTTP handlers return no error to classify.
+//
+// Example:
+//
+//	otx.SetErrorClassifier(func(err error) ([]attribute.KeyValue, bool) {
+//	    if errors.Is(err, context.Canceled) {
+//	        return nil, false
+//	    }
+//	    var domainErr *orders.Error
+//	    if errors.As(err, &domainErr) {
+//	        return []attribute.KeyValue{attribute.String("orders.error_code", domainErr.Code)}, true
+//	    }
+//	    return nil, true
+//	})
+func SetErrorClassifier(c ErrorClassifier) {
+	if c == nil {
+		errorClassifier.Store(nil)
+
+		return
+	}
+	errorClassifier.Store(&c)
+}
+
+// classifyError returns the attributes and error status of err.
+func classifyError(err error) ([]attribute.KeyValue, bool) {
+	c := errorClassifier.Load()
+	if c == nil {
+		return nil, true
+	}
+
+	return (*c)(err)
+}
@@ -0,0 +1,55 @@
+package otx
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func TestSetErrorClassifier(t *testing.T) {
+	exporter := setupTracing(t)
+	SetErrorClassifier(func(err error) ([]attribute.KeyValue, bool) {
+		if errors.Is(err, context.Canceled) {
+			return []attribute.KeyValue{attribute.Bool("canceled", true)}, false
+		}
+
+		return []attribute.KeyValue{attribute.String("error.code", "BOOM")}, true
+	})
+	t.Cleanup(func() { SetErrorClassifier(nil) })
+
+	ctx, span := Start(t.Context(), "canceled")
+	RecordError(ctx, context.Canceled)
+	span.End()
+
+	ctx, span = Start(t.Context(), "failed")
+	RecordError(ctx, errBoom)
+	span.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	canceled, failed := spans[0], spans[1]
+	assert.Equal(t, codes.Unset, canceled.Status.Code, "not an error per the classifier")
+	assert.True(t, hasAttribute(canceled.Attributes, attribute.Bool("canceled", true)))
+	require.Len(t, canceled.Events, 1, "the error is still recorded")
+	assert.Equal(t, codes.Error, failed.Status.Code)
+	assert.True(t, hasAttribute(failed.Attributes, attribute.String("error.code", "BOOM")))
+}
+
+func TestRecordSpanError(t *testing.T) {
+	exporter := setupTracing(t)
+
+	_, span := Start(t.Context(), "op")
+	RecordSpanError(span, nil)
+	RecordSpanError(span, errBoom)
+	span.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Len(t, spans[0].Events, 1)
+}
@@ -0,0 +1,75 @@
+package otx
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"os"
+
+	"go.opentelemetry.io/otel"
+)
+
+// Error handler modes for TelemetryConfig.ErrorHandler.
+const (
+	ErrorHandlerDefault = "default"
+	ErrorHandlerSlog    = "slog"
+	ErrorHandlerNone    = "none"
+)
+
+// stderrLogger mirrors the logger used by the OpenTelemetry default error handler.
+var stderrLogger = log.New(os.Stderr, "", log.LstdFlags)
+
+// SetErrorHandler routes errors reported through otel.Handle, such as failed exports,
+// dropped telemetry and otx configuration warnings, to fn. A nil fn restores the
+// default behavior of printing them to stderr.
+//
+// Example:
+//
+//	otx.SetErrorHandler(func(err error) {
+//	    logger.Warn("telemetry error", "error", err)
+//	})
+func SetErrorHandler(fn func(error)) {
+	if fn == nil {
+		fn = func(err error) { stderrLogger.Print(err) }
+	}
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(fn))
+}
+
+// SlogErrorHandler returns an error handler that logs to logger at Error level with
+// the error under the "error" key and "component" set to "opentelemetry".
+// If logger is nil, slog.Default() at the time of the error is used.
+//
+// Example:
+//
+//	otx.SetErrorHandler(otx.SlogErrorHandler(logger))
+func SlogErrorHandler(logger *slog.Logger) func(error) {
+	return func(err error) {
+		l := logger
+		if l == nil {
+			l = slog.Default()
+		}
+		l.LogAttrs(context.Background(), slog.LevelError, "opentelemetry error",
+			slog.String("component", "opentelemetry"),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// installErrorHandler applies OnError or ErrorHandler from cfg. The global handler is
+// left alone when neither is set, so handlers installed by the application survive.
+func installErrorHandler(cfg *TelemetryConfig) {
+	if cfg.OnError != nil {
+		SetErrorHandler(cfg.OnError)
+
+		return
+	}
+
+	switch cfg.ErrorHandler {
+	case ErrorHandlerSlog:
+		SetErrorHandler(SlogErrorHandler(nil))
+	case ErrorHandlerNone:
+		SetErrorHandler(func(error) {})
+	case ErrorHandlerDefault:
+		SetErrorHandler(nil)
+	}
+}
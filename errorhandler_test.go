@@ -0,0 +1,79 @@
+package otx
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSetErrorHandler(t *testing.T) {
+	t.Cleanup(func() { SetErrorHandler(nil) })
+
+	var got []error
+	SetErrorHandler(func(err error) { got = append(got, err) })
+
+	errExport := errors.New("export failed")
+	otel.Handle(errExport)
+	assert.Equal(t, []error{errExport}, got)
+}
+
+func TestSlogErrorHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	SlogErrorHandler(logger)(errors.New("export failed"))
+
+	out := buf.String()
+	assert.Contains(t, out, `"level":"ERROR"`)
+	assert.Contains(t, out, `"msg":"opentelemetry error"`)
+	assert.Contains(t, out, `"component":"opentelemetry"`)
+	assert.Contains(t, out, `"error":"export failed"`)
+}
+
+func TestNewTracerProvider_ErrorHandler(t *testing.T) {
+	t.Cleanup(func() { SetErrorHandler(nil) })
+
+	var buf bytes.Buffer
+	prevLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(prevLogger) })
+
+	cfg := &TelemetryConfig{
+		Enabled:      boolPtr(true),
+		ServiceName:  "test-service",
+		ErrorHandler: ErrorHandlerSlog,
+		Traces:       &TracesConfig{Exporter: "none"},
+	}
+	tp, err := NewTracerProvider(t.Context(), cfg)
+	require.NoError(t, err)
+	defer func() { _ = tp.Shutdown(t.Context()) }()
+
+	otel.Handle(errors.New("routed to slog"))
+	assert.Contains(t, buf.String(), "routed to slog")
+
+	var got error
+	cfg.OnError = func(err error) { got = err }
+	tp2, err := NewTracerProvider(t.Context(), cfg)
+	require.NoError(t, err)
+	defer func() { _ = tp2.Shutdown(t.Context()) }()
+
+	errCustom := errors.New("custom")
+	otel.Handle(errCustom)
+	assert.Equal(t, errCustom, got, "OnError takes precedence over ErrorHandler")
+}
+
+func TestLoadConfigErrorHandler(t *testing.T) {
+	_, err := ParseConfig([]byte(`errorHandler: "syslog"`))
+	require.Error(t, err)
+	assert.Error(t, (&TelemetryConfig{ErrorHandler: "syslog"}).Validate())
+
+	t.Setenv("OTX_ERROR_HANDLER", "none")
+	cfg, err := ParseConfig([]byte(`serviceName: "svc"`))
+	require.NoError(t, err)
+	assert.Equal(t, ErrorHandlerNone, cfg.ErrorHandler)
+}
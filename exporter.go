@@ -2,7 +2,11 @@ package otx
 
 import (
 	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 	"time"
 
@@ -20,17 +24,37 @@ import (
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
+// unixSocketHost is the host of OTLP/HTTP requests sent over a unix socket, like
+// the authority gRPC sends for unix endpoints.
+const unixSocketHost = "localhost"
+
 // exporterParams holds common parameters for building exporters.
 type exporterParams struct {
-	Type        string            // "otlp", "console", "none"
-	Protocol    string            // "grpc", "http/protobuf"
-	Endpoint    string            // host:port or URL
-	Headers     map[string]string // custom headers
-	Timeout     time.Duration     // request timeout
-	Compression string            // "gzip", "none"
-	Insecure    bool              // disable TLS
+	Type           string                // "otlp", "console", "none"
+	Protocol       string                // "grpc", "http/protobuf"
+	Endpoint       string                // host:port, URL or unix:path
+	Headers        map[string]string     // custom headers
+	HeaderProvider HeaderProvider        // per-request headers, nil = none
+	Timeout        time.Duration         // request timeout
+	Compression    string                // "gzip", "none"
+	Insecure       bool                  // disable TLS
+	TLS            tlsFiles              // certificate files, zero = system roots
+	Proxy          string                // OTLP/HTTP proxy URL, "" = proxy environment variables
+	Retry          *RetryConfig          // retry policy, nil = exporter defaults
+	Breaker        *CircuitBreakerConfig // circuit breaker, nil = disabled
+	Console        *ConsoleConfig        // console exporter output, nil = pretty-printed stdout
+}
+
+// otlpRetryConfig is the underlying type of every OTLP exporter's RetryConfig.
+type otlpRetryConfig = struct {
+	Enabled         bool
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	MaxElapsedTime  time.Duration
 }
 
 func baseExporterParams(cfg *TelemetryConfig) exporterParams {
@@ -45,6 +69,7 @@ func baseExporterParams(cfg *TelemetryConfig) exporterParams {
 	if cfg == nil {
 		return params
 	}
+	params.Console = cfg.Console
 
 	otlp := cfg.GetOTLPConfig()
 	if otlp.Endpoint != "" {
@@ -59,32 +84,61 @@ func baseExporterParams(cfg *TelemetryConfig) exporterParams {
 	if otlp.Headers != nil {
 		params.Headers = otlp.Headers
 	}
+	params.HeaderProvider = otlp.HeaderProvider
 	params.Compression = otlp.Compression
 	params.Insecure = otlp.IsInsecure()
+	params.TLS = tlsFiles{
+		Certificate:       otlp.Certificate,
+		ClientCertificate: otlp.ClientCertificate,
+		ClientKey:         otlp.ClientKey,
+	}
+	if params.TLS.isSet() {
+		params.Insecure = false
+	}
+	params.Proxy = otlp.Proxy
+	params.Retry = otlp.Retry
+	params.Breaker = otlp.CircuitBreaker
 
 	return params
 }
 
+// applySignalTLS applies signal-specific certificate files over the shared ones.
+// Like the shared files, they enable TLS.
+func (p *exporterParams) applySignalTLS(files tlsFiles) {
+	if !files.isSet() {
+		return
+	}
+	p.TLS = p.TLS.override(files)
+	p.Insecure = false
+}
+
 // nopSpanExporter is a no-op span exporter.
 type nopSpanExporter struct{}
 
 func (nopSpanExporter) ExportSpans(_ context.Context, _ []sdktrace.ReadOnlySpan) error { return nil }
 func (nopSpanExporter) Shutdown(_ context.Context) error                               { return nil }
 
-// buildTraceExporter creates a trace exporter based on configuration.
-func buildTraceExporter(ctx context.Context, cfg *TelemetryConfig) (sdktrace.SpanExporter, error) {
-	params := resolveTraceExporterParams(cfg)
-	params.Type = normalizeExporterType(params.Type)
+// buildTraceExporters creates one trace exporter per configured exporter type.
+func buildTraceExporters(ctx context.Context, cfg *TelemetryConfig) ([]sdktrace.SpanExporter, error) {
+	return buildExporters(ctx, resolveTraceExporterParams(cfg), cfg.GetTracesExporters(), buildTraceExporter)
+}
 
+// buildTraceExporter creates a trace exporter for params.Type.
+func buildTraceExporter(ctx context.Context, params exporterParams) (sdktrace.SpanExporter, error) {
 	switch params.Type {
 	case "console":
-		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+		opts := []stdouttrace.Option{stdouttrace.WithWriter(params.Console.writer())}
+		if params.Console.IsPrettyPrint() {
+			opts = append(opts, stdouttrace.WithPrettyPrint())
+		}
+
+		return stdouttrace.New(opts...)
 	case "none", "nop":
 		return nopSpanExporter{}, nil
 	case "otlp":
-		return buildOTLPTraceExporter(ctx, params)
+		return withSpanCircuitBreaker(ctx, params)
 	default:
-		return buildOTLPTraceExporter(ctx, params)
+		return withSpanCircuitBreaker(ctx, params)
 	}
 }
 
@@ -97,14 +151,35 @@ func resolveTraceExporterParams(cfg *TelemetryConfig) exporterParams {
 	if cfg.Traces != nil && cfg.Traces.Endpoint != "" {
 		params.Endpoint = cfg.Traces.Endpoint
 	}
+	if cfg.Traces != nil && len(cfg.Traces.Headers) > 0 {
+		params.Headers = cfg.Traces.Headers
+	}
+	if cfg.Traces != nil {
+		params.applySignalTLS(tlsFiles{
+			Certificate:       cfg.Traces.Certificate,
+			ClientCertificate: cfg.Traces.ClientCertificate,
+			ClientKey:         cfg.Traces.ClientKey,
+		})
+	}
 
 	return params
 }
 
 func buildOTLPTraceExporter(ctx context.Context, params exporterParams) (sdktrace.SpanExporter, error) {
+	tlsCfg, err := params.TLS.config()
+	if err != nil {
+		return nil, err
+	}
+
 	if params.Protocol == "http/protobuf" || params.Protocol == "http" {
+		proxy, err := parseProxyURL(params.Proxy)
+		if err != nil {
+			return nil, err
+		}
 		opts := []otlptracehttp.Option{}
-		if endpoint, path := splitEndpointURL(params.Endpoint); endpoint != "" {
+		if _, isUnix := unixSocketPath(params.Endpoint); isUnix {
+			opts = append(opts, otlptracehttp.WithEndpoint(unixSocketHost))
+		} else if endpoint, path := splitEndpointURL(params.Endpoint); endpoint != "" {
 			opts = append(opts, otlptracehttp.WithEndpoint(endpoint))
 			if path != "" {
 				opts = append(opts, otlptracehttp.WithURLPath(path))
@@ -122,9 +197,19 @@ func buildOTLPTraceExporter(ctx context.Context, params exporterParams) (sdktrac
 		if params.Insecure {
 			opts = append(opts, otlptracehttp.WithInsecure())
 		}
+		if tlsCfg != nil {
+			opts = append(opts, otlptracehttp.WithTLSClientConfig(tlsCfg))
+		}
+		if proxy != nil {
+			opts = append(opts, otlptracehttp.WithProxy(http.ProxyURL(proxy)))
+		}
+		if client := otlpHTTPClient(params, tlsCfg, proxy); client != nil {
+			opts = append(opts, otlptracehttp.WithHTTPClient(client))
+		}
 		if params.Compression == "gzip" {
 			opts = append(opts, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
 		}
+		opts = append(opts, retryOptions(params.Retry, otlptracehttp.WithRetry)...)
 
 		return otlptrace.New(ctx, otlptracehttp.NewClient(opts...))
 	}
@@ -142,9 +227,17 @@ func buildOTLPTraceExporter(ctx context.Context, params exporterParams) (sdktrac
 	if params.Insecure {
 		opts = append(opts, otlptracegrpc.WithInsecure())
 	}
+	if tlsCfg != nil {
+		opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+	}
+	if params.HeaderProvider != nil {
+		opts = append(opts, otlptracegrpc.WithDialOption(
+			grpc.WithPerRPCCredentials(headerCredentials{provider: params.HeaderProvider})))
+	}
 	if params.Compression == "gzip" {
 		opts = append(opts, otlptracegrpc.WithCompressor("gzip"))
 	}
+	opts = append(opts, retryOptions(params.Retry, otlptracegrpc.WithRetry)...)
 
 	return otlptrace.New(ctx, otlptracegrpc.NewClient(opts...))
 }
@@ -156,20 +249,36 @@ func (nopLogExporter) Export(_ context.Context, _ []sdklog.Record) error { retur
 func (nopLogExporter) Shutdown(_ context.Context) error                  { return nil }
 func (nopLogExporter) ForceFlush(_ context.Context) error                { return nil }
 
-// buildLogExporter creates a log exporter based on configuration.
-func buildLogExporter(ctx context.Context, cfg *TelemetryConfig) (sdklog.Exporter, error) {
-	params := resolveLogExporterParams(cfg)
-	params.Type = normalizeExporterType(params.Type)
+// buildLogExporters creates one log exporter per configured exporter type.
+func buildLogExporters(ctx context.Context, cfg *TelemetryConfig) ([]sdklog.Exporter, error) {
+	return buildExporters(ctx, resolveLogExporterParams(cfg), logExporterTypes(cfg), buildLogExporter)
+}
+
+// logExporterTypes returns the configured log exporter types: logs.exporters, or logs.exporter.
+func logExporterTypes(cfg *TelemetryConfig) []string {
+	if cfg.Logs != nil && len(cfg.Logs.Exporters) > 0 {
+		return cfg.Logs.Exporters
+	}
+
+	return []string{resolveLogExporterParams(cfg).Type}
+}
 
+// buildLogExporter creates a log exporter for params.Type.
+func buildLogExporter(ctx context.Context, params exporterParams) (sdklog.Exporter, error) {
 	switch params.Type {
 	case "console":
-		return stdoutlog.New(stdoutlog.WithPrettyPrint())
+		opts := []stdoutlog.Option{stdoutlog.WithWriter(params.Console.writer())}
+		if params.Console.IsPrettyPrint() {
+			opts = append(opts, stdoutlog.WithPrettyPrint())
+		}
+
+		return stdoutlog.New(opts...)
 	case "none", "nop":
 		return nopLogExporter{}, nil
 	case "otlp":
-		return buildOTLPLogExporter(ctx, params)
+		return withLogCircuitBreaker(ctx, params)
 	default:
-		return buildOTLPLogExporter(ctx, params)
+		return withLogCircuitBreaker(ctx, params)
 	}
 }
 
@@ -185,22 +294,45 @@ func resolveLogExporterParams(cfg *TelemetryConfig) exporterParams {
 		if cfg.Logs.Endpoint != "" {
 			params.Endpoint = cfg.Logs.Endpoint
 		}
+		if len(cfg.Logs.Headers) > 0 {
+			params.Headers = cfg.Logs.Headers
+		}
+		params.applySignalTLS(tlsFiles{
+			Certificate:       cfg.Logs.Certificate,
+			ClientCertificate: cfg.Logs.ClientCertificate,
+			ClientKey:         cfg.Logs.ClientKey,
+		})
 	}
 
 	return params
 }
 
 func buildOTLPLogExporter(ctx context.Context, params exporterParams) (sdklog.Exporter, error) {
+	tlsCfg, err := params.TLS.config()
+	if err != nil {
+		return nil, err
+	}
+
 	if params.Protocol == "http/protobuf" || params.Protocol == "http" {
+		proxy, err := parseProxyURL(params.Proxy)
+		if err != nil {
+			return nil, err
+		}
 		opts := buildHTTPOptions(
 			params,
+			tlsCfg,
+			proxy,
 			otlploghttp.WithEndpoint,
 			otlploghttp.WithEndpointURL,
 			otlploghttp.WithHeaders,
 			otlploghttp.WithTimeout,
 			otlploghttp.WithInsecure,
+			otlploghttp.WithTLSClientConfig,
+			func(u *url.URL) otlploghttp.Option { return otlploghttp.WithProxy(http.ProxyURL(u)) },
+			otlploghttp.WithHTTPClient,
 			func() otlploghttp.Option { return otlploghttp.WithCompression(otlploghttp.GzipCompression) },
 		)
+		opts = append(opts, retryOptions(params.Retry, otlploghttp.WithRetry)...)
 
 		return otlploghttp.New(ctx, opts...)
 	}
@@ -208,30 +340,50 @@ func buildOTLPLogExporter(ctx context.Context, params exporterParams) (sdklog.Ex
 	// Default to gRPC
 	opts := buildGRPCOptions(
 		params,
+		tlsCfg,
 		otlploggrpc.WithEndpoint,
 		otlploggrpc.WithHeaders,
 		otlploggrpc.WithTimeout,
 		otlploggrpc.WithInsecure,
+		func(c *tls.Config) otlploggrpc.Option { return otlploggrpc.WithTLSCredentials(credentials.NewTLS(c)) },
+		otlploggrpc.WithDialOption,
 		func() otlploggrpc.Option { return otlploggrpc.WithCompressor("gzip") },
 	)
+	opts = append(opts, retryOptions(params.Retry, otlploggrpc.WithRetry)...)
 
 	return otlploggrpc.New(ctx, opts...)
 }
 
-// buildMetricExporter creates a metric exporter based on configuration.
-func buildMetricExporter(ctx context.Context, cfg *TelemetryConfig) (sdkmetric.Exporter, error) {
-	params := resolveMetricExporterParams(cfg)
-	params.Type = normalizeExporterType(params.Type)
+// buildMetricExporters creates one metric exporter per configured exporter type.
+func buildMetricExporters(ctx context.Context, cfg *TelemetryConfig) ([]sdkmetric.Exporter, error) {
+	return buildExporters(ctx, resolveMetricExporterParams(cfg), metricExporterTypes(cfg), buildMetricExporter)
+}
+
+// metricExporterTypes returns the configured metric exporter types: metrics.exporters, or metrics.exporter.
+func metricExporterTypes(cfg *TelemetryConfig) []string {
+	if cfg.Metrics != nil && len(cfg.Metrics.Exporters) > 0 {
+		return cfg.Metrics.Exporters
+	}
+
+	return []string{resolveMetricExporterParams(cfg).Type}
+}
 
+// buildMetricExporter creates a metric exporter for params.Type.
+func buildMetricExporter(ctx context.Context, params exporterParams) (sdkmetric.Exporter, error) {
 	switch params.Type {
 	case "console":
-		return stdoutmetric.New(stdoutmetric.WithPrettyPrint())
+		opts := []stdoutmetric.Option{stdoutmetric.WithWriter(params.Console.writer())}
+		if params.Console.IsPrettyPrint() {
+			opts = append(opts, stdoutmetric.WithPrettyPrint())
+		}
+
+		return stdoutmetric.New(opts...)
 	case "none", "nop":
 		return newNopMetricExporter(), nil
 	case "otlp":
-		return buildOTLPMetricExporter(ctx, params)
+		return withMetricCircuitBreaker(ctx, params)
 	default:
-		return buildOTLPMetricExporter(ctx, params)
+		return withMetricCircuitBreaker(ctx, params)
 	}
 }
 
@@ -247,22 +399,45 @@ func resolveMetricExporterParams(cfg *TelemetryConfig) exporterParams {
 		if cfg.Metrics.Endpoint != "" {
 			params.Endpoint = cfg.Metrics.Endpoint
 		}
+		if len(cfg.Metrics.Headers) > 0 {
+			params.Headers = cfg.Metrics.Headers
+		}
+		params.applySignalTLS(tlsFiles{
+			Certificate:       cfg.Metrics.Certificate,
+			ClientCertificate: cfg.Metrics.ClientCertificate,
+			ClientKey:         cfg.Metrics.ClientKey,
+		})
 	}
 
 	return params
 }
 
 func buildOTLPMetricExporter(ctx context.Context, params exporterParams) (sdkmetric.Exporter, error) {
+	tlsCfg, err := params.TLS.config()
+	if err != nil {
+		return nil, err
+	}
+
 	if params.Protocol == "http/protobuf" || params.Protocol == "http" {
+		proxy, err := parseProxyURL(params.Proxy)
+		if err != nil {
+			return nil, err
+		}
 		opts := buildHTTPOptions(
 			params,
+			tlsCfg,
+			proxy,
 			otlpmetrichttp.WithEndpoint,
 			otlpmetrichttp.WithEndpointURL,
 			otlpmetrichttp.WithHeaders,
 			otlpmetrichttp.WithTimeout,
 			otlpmetrichttp.WithInsecure,
+			otlpmetrichttp.WithTLSClientConfig,
+			func(u *url.URL) otlpmetrichttp.Option { return otlpmetrichttp.WithProxy(http.ProxyURL(u)) },
+			otlpmetrichttp.WithHTTPClient,
 			func() otlpmetrichttp.Option { return otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression) },
 		)
+		opts = append(opts, retryOptions(params.Retry, otlpmetrichttp.WithRetry)...)
 
 		return otlpmetrichttp.New(ctx, opts...)
 	}
@@ -270,12 +445,18 @@ func buildOTLPMetricExporter(ctx context.Context, params exporterParams) (sdkmet
 	// Default to gRPC
 	opts := buildGRPCOptions(
 		params,
+		tlsCfg,
 		otlpmetricgrpc.WithEndpoint,
 		otlpmetricgrpc.WithHeaders,
 		otlpmetricgrpc.WithTimeout,
 		otlpmetricgrpc.WithInsecure,
+		func(c *tls.Config) otlpmetricgrpc.Option {
+			return otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(c))
+		},
+		otlpmetricgrpc.WithDialOption,
 		func() otlpmetricgrpc.Option { return otlpmetricgrpc.WithCompressor("gzip") },
 	)
+	opts = append(opts, retryOptions(params.Retry, otlpmetricgrpc.WithRetry)...)
 
 	return otlpmetricgrpc.New(ctx, opts...)
 }
@@ -296,6 +477,50 @@ func (nopMetricExporter) Aggregation(k sdkmetric.InstrumentKind) sdkmetric.Aggre
 func (nopMetricExporter) ForceFlush(_ context.Context) error { return nil }
 func (nopMetricExporter) Shutdown(_ context.Context) error   { return nil }
 
+// buildExporters builds one exporter per distinct normalized type in types.
+// If any exporter fails to build, the ones already built are shut down.
+func buildExporters[E interface{ Shutdown(context.Context) error }](
+	ctx context.Context,
+	params exporterParams,
+	types []string,
+	build func(context.Context, exporterParams) (E, error),
+) ([]E, error) {
+	types = exporterTypes(types)
+	exporters := make([]E, 0, len(types))
+	for _, typ := range types {
+		params.Type = typ
+		exp, err := build(ctx, params)
+		if err != nil {
+			for _, built := range exporters {
+				_ = built.Shutdown(ctx)
+			}
+
+			return nil, fmt.Errorf("%s exporter: %w", typ, err)
+		}
+		exporters = append(exporters, exp)
+	}
+
+	return exporters, nil
+}
+
+// exporterTypes normalizes types and removes duplicates, keeping the first occurrence.
+// An empty list yields the default type.
+func exporterTypes(types []string) []string {
+	if len(types) == 0 {
+		return []string{normalizeExporterType("")}
+	}
+
+	result := make([]string, 0, len(types))
+	for _, typ := range types {
+		typ = normalizeExporterType(typ)
+		if !slices.Contains(result, typ) {
+			result = append(result, typ)
+		}
+	}
+
+	return result
+}
+
 func normalizeExporterType(value string) string {
 	v := strings.ToLower(strings.TrimSpace(value))
 	if v == "" {
@@ -321,6 +546,20 @@ func normalizeDuration(value time.Duration) time.Duration {
 	return value
 }
 
+// unixSocketPath returns the socket path of a "unix:///absolute/path" or
+// "unix:relative/path" endpoint, and false for other endpoints.
+func unixSocketPath(endpoint string) (string, bool) {
+	rest, ok := strings.CutPrefix(endpoint, "unix:")
+	if !ok {
+		return "", false
+	}
+	if path, hasSlashes := strings.CutPrefix(rest, "//"); hasSlashes {
+		return path, true
+	}
+
+	return rest, true
+}
+
 func splitEndpointURL(raw string) (host string, path string) {
 	if raw == "" {
 		return "", ""
@@ -335,15 +574,22 @@ func splitEndpointURL(raw string) (host string, path string) {
 
 func buildHTTPOptions[T any](
 	params exporterParams,
+	tlsCfg *tls.Config,
+	proxy *url.URL,
 	withEndpoint func(string) T,
 	withEndpointURL func(string) T,
 	withHeaders func(map[string]string) T,
 	withTimeout func(time.Duration) T,
 	withInsecure func() T,
+	withTLS func(*tls.Config) T,
+	withProxy func(*url.URL) T,
+	withHTTPClient func(*http.Client) T,
 	withCompression func() T,
 ) []T {
 	var opts []T
-	if parsed, err := url.Parse(params.Endpoint); err == nil && isHTTPSScheme(parsed.Scheme) {
+	if _, isUnix := unixSocketPath(params.Endpoint); isUnix {
+		opts = append(opts, withEndpoint(unixSocketHost))
+	} else if parsed, err := url.Parse(params.Endpoint); err == nil && isHTTPSScheme(parsed.Scheme) {
 		opts = append(opts, withEndpointURL(params.Endpoint))
 	} else {
 		opts = append(opts, withEndpoint(params.Endpoint))
@@ -357,6 +603,15 @@ func buildHTTPOptions[T any](
 	if params.Insecure {
 		opts = append(opts, withInsecure())
 	}
+	if tlsCfg != nil {
+		opts = append(opts, withTLS(tlsCfg))
+	}
+	if proxy != nil {
+		opts = append(opts, withProxy(proxy))
+	}
+	if client := otlpHTTPClient(params, tlsCfg, proxy); client != nil {
+		opts = append(opts, withHTTPClient(client))
+	}
 	if params.Compression == "gzip" {
 		opts = append(opts, withCompression())
 	}
@@ -364,6 +619,43 @@ func buildHTTPOptions[T any](
 	return opts
 }
 
+// retryOptions converts cfg into the exporter-specific retry option.
+// Returns no options if cfg is nil, leaving the exporter defaults in place.
+func retryOptions[C ~otlpRetryConfig, T any](cfg *RetryConfig, withRetry func(C) T) []T {
+	if cfg == nil {
+		return nil
+	}
+	retry := cfg.withDefaults()
+
+	return []T{withRetry(C{
+		Enabled:         cfg.IsEnabled(),
+		InitialInterval: retry.InitialInterval,
+		MaxInterval:     retry.MaxInterval,
+		MaxElapsedTime:  retry.MaxElapsedTime,
+	})}
+}
+
+// parseProxyURL parses an OTLP/HTTP proxy URL. It returns nil if raw is empty.
+func parseProxyURL(raw string) (*url.URL, error) {
+	if raw == "" {
+		return nil, nil //nolint:nilnil // no proxy configured
+	}
+	proxy, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch proxy.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("proxy %q must be an http, https or socks5 URL", proxy.Redacted())
+	}
+	if proxy.Host == "" {
+		return nil, fmt.Errorf("proxy %q has no host", proxy.Redacted())
+	}
+
+	return proxy, nil
+}
+
 func isHTTPSScheme(scheme string) bool {
 	switch strings.ToLower(scheme) {
 	case "http", "https":
@@ -375,10 +667,13 @@ func isHTTPSScheme(scheme string) bool {
 
 func buildGRPCOptions[T any](
 	params exporterParams,
+	tlsCfg *tls.Config,
 	withEndpoint func(string) T,
 	withHeaders func(map[string]string) T,
 	withTimeout func(time.Duration) T,
 	withInsecure func() T,
+	withTLS func(*tls.Config) T,
+	withDialOption func(...grpc.DialOption) T,
 	withCompression func() T,
 ) []T {
 	opts := []T{withEndpoint(params.Endpoint)}
@@ -391,6 +686,12 @@ func buildGRPCOptions[T any](
 	if params.Insecure {
 		opts = append(opts, withInsecure())
 	}
+	if tlsCfg != nil {
+		opts = append(opts, withTLS(tlsCfg))
+	}
+	if params.HeaderProvider != nil {
+		opts = append(opts, withDialOption(grpc.WithPerRPCCredentials(headerCredentials{provider: params.HeaderProvider})))
+	}
 	if params.Compression == "gzip" {
 		opts = append(opts, withCompression())
 	}
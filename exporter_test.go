@@ -1,11 +1,24 @@
 package otx
 
 import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
 )
 
 type opt struct {
@@ -33,6 +46,74 @@ func TestNormalizeExporterType(t *testing.T) {
 	}
 }
 
+func TestExporterTypes(t *testing.T) {
+	assert.Equal(t, []string{"otlp"}, exporterTypes(nil))
+	assert.Equal(t, []string{"otlp", "console"}, exporterTypes([]string{"OTLP", "console", "stdout", "otlp"}))
+}
+
+func TestBuildExportersFanOut(t *testing.T) {
+	ctx := t.Context()
+	cfg := &TelemetryConfig{
+		Traces:  &TracesConfig{Exporter: "none", Exporters: []string{"otlp", "console", "stdout"}},
+		Logs:    &LogsConfig{Exporter: "otlp", Exporters: []string{"none", "console"}},
+		Metrics: &MetricsConfig{Exporter: "console"},
+	}
+
+	traces, err := buildTraceExporters(ctx, cfg)
+	require.NoError(t, err)
+	require.Len(t, traces, 2)
+	assert.IsType(t, &stdouttrace.Exporter{}, traces[1])
+
+	logs, err := buildLogExporters(ctx, cfg)
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	assert.IsType(t, nopLogExporter{}, logs[0])
+
+	metrics, err := buildMetricExporters(ctx, cfg)
+	require.NoError(t, err)
+	assert.Len(t, metrics, 1, "single exporter is used when exporters is empty")
+
+	for _, exp := range traces {
+		require.NoError(t, exp.Shutdown(ctx))
+	}
+}
+
+func TestConsoleExporterWriter(t *testing.T) {
+	ctx := t.Context()
+	var buf bytes.Buffer
+	cfg := &TelemetryConfig{
+		Traces:  &TracesConfig{Exporter: "console"},
+		Console: &ConsoleConfig{Writer: &buf, PrettyPrint: boolPtr(false)},
+	}
+
+	exporters, err := buildTraceExporters(ctx, cfg)
+	require.NoError(t, err)
+	require.Len(t, exporters, 1)
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporters[0]))
+	_, span := tp.Tracer("test").Start(ctx, "console-span")
+	span.End()
+	require.NoError(t, tp.Shutdown(ctx))
+
+	out := buf.String()
+	assert.Contains(t, out, `"Name":"console-span"`)
+	assert.Equal(t, 1, strings.Count(strings.TrimSpace(out), "\n")+1, "compact output is one line per span")
+}
+
+func TestConsoleConfigWriter(t *testing.T) {
+	assert.Equal(t, os.Stdout, (*ConsoleConfig)(nil).writer())
+	assert.Equal(t, os.Stderr, (&ConsoleConfig{Output: "stderr"}).writer())
+	assert.True(t, (*ConsoleConfig)(nil).IsPrettyPrint())
+
+	t.Setenv("OTX_CONSOLE_OUTPUT", "stderr")
+	t.Setenv("OTX_CONSOLE_PRETTY_PRINT", "false")
+	cfg, err := ParseConfig([]byte(`serviceName: "console"`))
+	require.NoError(t, err)
+	require.NotNil(t, cfg.Console)
+	assert.Equal(t, os.Stderr, cfg.Console.writer())
+	assert.False(t, cfg.Console.IsPrettyPrint())
+}
+
 func TestSplitEndpointURL(t *testing.T) {
 	host, path := splitEndpointURL("http://localhost:4318/v1/traces")
 	assert.Equal(t, "localhost:4318", host)
@@ -58,11 +139,16 @@ func TestBuildHTTPOptions(t *testing.T) {
 
 	opts := buildHTTPOptions(
 		params,
+		nil,
+		nil,
 		func(v string) opt { return opt{kind: "endpoint", val: v} },
 		func(v string) opt { return opt{kind: "endpointURL", val: v} },
 		func(_ map[string]string) opt { return opt{kind: "headers"} },
 		func(d time.Duration) opt { return opt{kind: "timeout", val: d.String()} },
 		func() opt { return opt{kind: "insecure"} },
+		func(*tls.Config) opt { return opt{kind: "tls"} },
+		func(*url.URL) opt { return opt{kind: "proxy"} },
+		func(*http.Client) opt { return opt{kind: "httpClient"} },
 		func() opt { return opt{kind: "compression"} },
 	)
 
@@ -76,14 +162,20 @@ func TestBuildHTTPOptions(t *testing.T) {
 	params.Endpoint = "localhost:4317"
 	opts = buildHTTPOptions(
 		params,
+		&tls.Config{MinVersion: tls.VersionTLS12},
+		nil,
 		func(v string) opt { return opt{kind: "endpoint", val: v} },
 		func(v string) opt { return opt{kind: "endpointURL", val: v} },
 		func(_ map[string]string) opt { return opt{kind: "headers"} },
 		func(d time.Duration) opt { return opt{kind: "timeout", val: d.String()} },
 		func() opt { return opt{kind: "insecure"} },
+		func(*tls.Config) opt { return opt{kind: "tls"} },
+		func(*url.URL) opt { return opt{kind: "proxy"} },
+		func(*http.Client) opt { return opt{kind: "httpClient"} },
 		func() opt { return opt{kind: "compression"} },
 	)
 	assert.Equal(t, "endpoint", opts[0].kind)
+	assert.Contains(t, kinds(opts), "tls")
 }
 
 func TestBuildGRPCOptions(t *testing.T) {
@@ -97,10 +189,13 @@ func TestBuildGRPCOptions(t *testing.T) {
 
 	opts := buildGRPCOptions(
 		params,
+		nil,
 		func(v string) opt { return opt{kind: "endpoint", val: v} },
 		func(_ map[string]string) opt { return opt{kind: "headers"} },
 		func(d time.Duration) opt { return opt{kind: "timeout", val: d.String()} },
 		func() opt { return opt{kind: "insecure"} },
+		func(*tls.Config) opt { return opt{kind: "tls"} },
+		func(...grpc.DialOption) opt { return opt{kind: "dialOption"} },
 		func() opt { return opt{kind: "compression"} },
 	)
 
@@ -110,6 +205,7 @@ func TestBuildGRPCOptions(t *testing.T) {
 	assert.Contains(t, kinds(opts), "timeout")
 	assert.Contains(t, kinds(opts), "insecure")
 	assert.Contains(t, kinds(opts), "compression")
+	assert.NotContains(t, kinds(opts), "tls")
 }
 
 func kinds(opts []opt) []string {
@@ -120,3 +216,94 @@ func kinds(opts []opt) []string {
 
 	return out
 }
+
+func TestRetryOptions(t *testing.T) {
+	type retryCfg struct {
+		Enabled         bool
+		InitialInterval time.Duration
+		MaxInterval     time.Duration
+		MaxElapsedTime  time.Duration
+	}
+	withRetry := func(c retryCfg) retryCfg { return c }
+
+	assert.Empty(t, retryOptions(nil, withRetry))
+
+	opts := retryOptions(&RetryConfig{
+		Enabled:         boolPtr(false),
+		InitialInterval: time.Second,
+		MaxInterval:     10 * time.Second,
+		MaxElapsedTime:  5 * time.Minute,
+	}, withRetry)
+	require.Len(t, opts, 1)
+	assert.Equal(t, retryCfg{
+		Enabled:         false,
+		InitialInterval: time.Second,
+		MaxInterval:     10 * time.Second,
+		MaxElapsedTime:  5 * time.Minute,
+	}, opts[0])
+
+	// Enabled and zero durations take their defaults
+	opts = retryOptions(&RetryConfig{}, withRetry)
+	assert.Equal(t, retryCfg{
+		Enabled:         true,
+		InitialInterval: 5 * time.Second,
+		MaxInterval:     30 * time.Second,
+		MaxElapsedTime:  time.Minute,
+	}, opts[0])
+}
+
+func TestParseConfigRetry(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`
+otlp:
+  retry:
+    maxElapsedTime: 5m
+`))
+	require.NoError(t, err)
+	require.NotNil(t, cfg.OTLP.Retry)
+	assert.True(t, cfg.OTLP.Retry.IsEnabled())
+	assert.Equal(t, 5*time.Second, cfg.OTLP.Retry.InitialInterval)
+	assert.Equal(t, 30*time.Second, cfg.OTLP.Retry.MaxInterval)
+	assert.Equal(t, 5*time.Minute, cfg.OTLP.Retry.MaxElapsedTime)
+	assert.Same(t, cfg.OTLP.Retry, baseExporterParams(cfg).Retry)
+}
+
+func TestOTLPProxy(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []string
+	)
+	proxy := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.URL.String()+" "+r.Header.Get("Proxy-Authorization"))
+		mu.Unlock()
+	}))
+	defer proxy.Close()
+
+	proxyURL, err := url.Parse(proxy.URL)
+	require.NoError(t, err)
+	proxyURL.User = url.UserPassword("otx", "secret")
+	t.Setenv("OTX_OTLP_PROXY", proxyURL.String())
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector.internal:4318")
+	t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "http/protobuf")
+
+	cfg, err := ParseConfig([]byte(`
+enabled: true
+serviceName: "proxied"
+`))
+	require.NoError(t, err)
+	require.NoError(t, CheckConnectivity(t.Context(), cfg))
+
+	exporter, err := buildOTLPTraceExporter(t.Context(), resolveTraceExporterParams(cfg))
+	require.NoError(t, err)
+	require.NoError(t, exporter.ExportSpans(t.Context(), tracetest.SpanStubs{{Name: "a"}}.Snapshots()))
+	require.NoError(t, exporter.Shutdown(t.Context()))
+
+	auth := "Basic " + base64.StdEncoding.EncodeToString([]byte("otx:secret"))
+	assert.Equal(t, []string{
+		"http://collector.internal:4318/v1/traces " + auth,
+		"http://collector.internal:4318/v1/traces " + auth,
+	}, requests, "probe and export go through the authenticated proxy")
+
+	cfg.OTLP.Proxy = "ftp://proxy:21"
+	require.ErrorContains(t, cfg.Validate(), `otlp.proxy: proxy "ftp://proxy:21" must be an http, https or socks5 URL`)
+}
@@ -0,0 +1,151 @@
+package otx
+
+import (
+	"context"
+	"regexp"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/arloliu/otx/internal/glob"
+)
+
+// SpanFilter reports whether a finished span should be dropped instead of exported.
+type SpanFilter func(s sdktrace.ReadOnlySpan) bool
+
+// SpanDropRule matches spans that are never exported, such as health checks,
+// metrics scrapes and static assets. Name and attribute values are glob patterns
+// where "*" matches any sequence of characters and "?" matches one character.
+//
+// A span matches when its name matches Name (if set) and every listed attribute
+// is present with a matching value. At least one of Name and Attributes is required.
+type SpanDropRule struct {
+	// Name is a glob pattern for the span name, e.g. "GET /static/*".
+	Name string `yaml:"name,omitempty"`
+
+	// Attributes maps attribute keys to glob patterns for their values,
+	// e.g. {"url.path": "/metrics"}. Non-string values are compared in their
+	// string form, e.g. "200" or "true".
+	Attributes map[string]string `yaml:"attributes,omitempty"`
+}
+
+// filterProcessor forwards spans to next unless a filter drops them.
+type filterProcessor struct {
+	next    sdktrace.SpanProcessor
+	filters []SpanFilter
+}
+
+// WithSpanFilter drops spans matching any of filters before they reach the
+// exporters, in addition to traces.dropSpans. Span processors registered with
+// WithSpanProcessor still see every span.
+//
+// Example:
+//
+//	tp, err := otx.NewTracerProvider(ctx, cfg,
+//	    otx.WithSpanFilter(otx.DropSpanNames("GET /metrics", "GET /static/*")),
+//	)
+func WithSpanFilter(filters ...SpanFilter) TracerProviderOption {
+	return func(o *tracerProviderOptions) {
+		o.filters = append(o.filters, filters...)
+	}
+}
+
+// NewFilterProcessor returns a SpanProcessor that forwards spans to next unless
+// one of filters drops them. Wrap the batch processor of an exporter to keep
+// unwanted spans off the network without a collector-side filter.
+// Filters run on the goroutine ending the span, so keep them cheap.
+//
+// It is applied automatically by [NewTracerProvider] for traces.dropSpans and
+// [WithSpanFilter].
+//
+// Example:
+//
+//	tp := sdktrace.NewTracerProvider(
+//	    sdktrace.WithSpanProcessor(otx.NewFilterProcessor(
+//	        sdktrace.NewBatchSpanProcessor(exporter),
+//	        otx.DropSpanNames("GET /healthz"),
+//	    )),
+//	)
+func NewFilterProcessor(next sdktrace.SpanProcessor, filters ...SpanFilter) sdktrace.SpanProcessor {
+	return &filterProcessor{
+		next:    next,
+		filters: compactFilters(filters),
+	}
+}
+
+// DropSpanNames returns a filter dropping spans whose name matches one of the glob patterns.
+func DropSpanNames(patterns ...string) SpanFilter {
+	re := glob.ListRegexp(patterns...)
+
+	return func(s sdktrace.ReadOnlySpan) bool {
+		return re.MatchString(s.Name())
+	}
+}
+
+// Filter returns the SpanFilter for the rule. A rule with neither Name nor
+// Attributes matches nothing.
+func (r SpanDropRule) Filter() SpanFilter {
+	if r.Name == "" && len(r.Attributes) == 0 {
+		return func(sdktrace.ReadOnlySpan) bool { return false }
+	}
+
+	var name *regexp.Regexp
+	if r.Name != "" {
+		name = glob.Regexp(r.Name)
+	}
+	attrs := make(map[string]*regexp.Regexp, len(r.Attributes))
+	for key, pattern := range r.Attributes {
+		attrs[key] = glob.Regexp(pattern)
+	}
+
+	return func(s sdktrace.ReadOnlySpan) bool {
+		if name != nil && !name.MatchString(s.Name()) {
+			return false
+		}
+
+		matched := 0
+		for _, kv := range s.Attributes() {
+			if re, ok := attrs[string(kv.Key)]; ok && re.MatchString(kv.Value.Emit()) {
+				matched++
+			}
+		}
+
+		return matched == len(attrs)
+	}
+}
+
+// compactFilters returns filters without nil entries.
+func compactFilters(filters []SpanFilter) []SpanFilter {
+	result := make([]SpanFilter, 0, len(filters))
+	for _, f := range filters {
+		if f != nil {
+			result = append(result, f)
+		}
+	}
+
+	return result
+}
+
+// OnStart implements sdktrace.SpanProcessor.
+func (p *filterProcessor) OnStart(ctx context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(ctx, s)
+}
+
+// OnEnd implements sdktrace.SpanProcessor.
+func (p *filterProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	for _, drop := range p.filters {
+		if drop(s) {
+			return
+		}
+	}
+	p.next.OnEnd(s)
+}
+
+// Shutdown implements sdktrace.SpanProcessor.
+func (p *filterProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush implements sdktrace.SpanProcessor.
+func (p *filterProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
@@ -0,0 +1,120 @@
+package otx
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// exportedNames starts and ends one span per name, with attrs, and returns the exported names.
+func exportedNames(t *testing.T, filters []SpanFilter, names []string, attrs ...attribute.KeyValue) []string {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(NewFilterProcessor(sdktrace.NewSimpleSpanProcessor(exporter), filters...)),
+	)
+	defer func() { _ = tp.Shutdown(t.Context()) }()
+
+	for _, name := range names {
+		_, span := tp.Tracer("test").Start(t.Context(), name, trace.WithAttributes(attrs...))
+		span.End()
+	}
+
+	var exported []string
+	for _, s := range exporter.GetSpans() {
+		exported = append(exported, s.Name)
+	}
+
+	return exported
+}
+
+func TestDropSpanNames(t *testing.T) {
+	filters := []SpanFilter{nil, DropSpanNames("GET /metrics", "GET /static/*", "ping?")}
+	names := []string{"GET /metrics", "GET /static/css/app.css", "pings", "GET /metrics/extra", "GET /users", "ping"}
+
+	assert.Equal(t, []string{"GET /metrics/extra", "GET /users", "ping"}, exportedNames(t, filters, names))
+}
+
+func TestSpanDropRule_Filter(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  SpanDropRule
+		attrs []attribute.KeyValue
+		want  []string
+	}{
+		{
+			name:  "attribute glob",
+			rule:  SpanDropRule{Attributes: map[string]string{"url.path": "/static/*"}},
+			attrs: []attribute.KeyValue{attribute.String("url.path", "/static/app.js")},
+		},
+		{
+			name:  "non-string attribute",
+			rule:  SpanDropRule{Name: "http.request", Attributes: map[string]string{"http.response.status_code": "200"}},
+			attrs: []attribute.KeyValue{attribute.Int("http.response.status_code", 200)},
+		},
+		{
+			name:  "name mismatch",
+			rule:  SpanDropRule{Name: "grpc.*", Attributes: map[string]string{"url.path": "/metrics"}},
+			attrs: []attribute.KeyValue{attribute.String("url.path", "/metrics")},
+			want:  []string{"http.request"},
+		},
+		{
+			name: "missing attribute",
+			rule: SpanDropRule{Attributes: map[string]string{"url.path": "*"}},
+			want: []string{"http.request"},
+		},
+		{
+			name: "empty rule",
+			rule: SpanDropRule{},
+			want: []string{"http.request"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := exportedNames(t, []SpanFilter{tt.rule.Filter()}, []string{"http.request"}, tt.attrs...)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewTracerProvider_DropSpans(t *testing.T) {
+	var buf bytes.Buffer
+	var started []string
+	cfg := &TelemetryConfig{
+		Enabled:     boolPtr(true),
+		ServiceName: "test-service",
+		Traces: &TracesConfig{
+			Exporter:  "console",
+			DropSpans: []SpanDropRule{{Name: "GET /metrics"}},
+		},
+		Console: &ConsoleConfig{Writer: &buf, PrettyPrint: boolPtr(false)},
+	}
+	tp, err := NewTracerProvider(t.Context(), cfg,
+		WithSpanFilter(DropSpanNames("GET /healthz")),
+		WithSpanProcessor(NewSpanHooks(func(_ context.Context, s sdktrace.ReadWriteSpan) {
+			started = append(started, s.Name())
+		}, nil)),
+	)
+	require.NoError(t, err)
+
+	for _, name := range []string{"GET /metrics", "GET /healthz", "GET /users"} {
+		_, span := tp.Tracer("test").Start(t.Context(), name)
+		span.End()
+	}
+	require.NoError(t, tp.Shutdown(t.Context()))
+
+	assert.Equal(t, []string{"GET /metrics", "GET /healthz", "GET /users"}, started, "processors see every span")
+	out := buf.String()
+	assert.Contains(t, out, `"Name":"GET /users"`)
+	assert.NotContains(t, out, `"Name":"GET /metrics"`)
+	assert.NotContains(t, out, `"Name":"GET /healthz"`)
+}
@@ -0,0 +1,151 @@
+package grpc
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+)
+
+// Business error attributes, set on server spans by [RecordBusinessError].
+const (
+	// AttrStatusCode is the numeric gRPC status code of the RPC.
+	AttrStatusCode = "rpc.grpc.status_code"
+
+	// AttrBusinessError marks RPCs that failed with an application-level error.
+	AttrBusinessError = "otx.rpc.business_error"
+
+	// AttrErrorType is the error type; for business errors, the gRPC code name.
+	AttrErrorType = "error.type"
+)
+
+// BusinessErrorMapper maps an application error returned by a handler to a gRPC
+// code and extra span attributes. It returns ok false for errors that are not
+// business errors, which are left untouched.
+type BusinessErrorMapper func(err error) (code codes.Code, attrs []attribute.KeyValue, ok bool)
+
+// businessErrorKey is the context key of the businessError of an RPC.
+type businessErrorKey struct{}
+
+// businessError is the business error recorded for an RPC, if any.
+type businessError struct {
+	mu   sync.Mutex
+	code codes.Code
+	msg  string
+	set  bool
+}
+
+// businessErrorHandler reports the business error of an RPC that completed with
+// an OK status as its gRPC status.
+type businessErrorHandler struct {
+	stats.Handler
+}
+
+// RecordBusinessError marks the RPC of ctx as failed with the application-level
+// error code and msg, even if the handler returns an OK response. The server span
+// gets an error status, [AttrBusinessError], [AttrErrorType] and attrs, and
+// handlers from [ServerHandler] and [ServerHandlerWithProviders] report code as
+// rpc.grpc.status_code on the span and the RPC metrics.
+//
+// It is a no-op for codes.OK. If called more than once, the last call wins.
+//
+// Example:
+//
+//	if order.Stock == 0 {
+//	    otxgrpc.RecordBusinessError(ctx, codes.FailedPrecondition, "out of stock",
+//	        attribute.String("order.sku", order.SKU))
+//	    return &pb.OrderResponse{Result: pb.Result_OUT_OF_STOCK}, nil
+//	}
+func RecordBusinessError(ctx context.Context, code codes.Code, msg string, attrs ...attribute.KeyValue) {
+	if code == codes.OK {
+		return
+	}
+
+	if be, ok := ctx.Value(businessErrorKey{}).(*businessError); ok {
+		be.mu.Lock()
+		be.code, be.msg, be.set = code, msg, true
+		be.mu.Unlock()
+	}
+
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	span.SetStatus(otelcodes.Error, msg)
+	span.SetAttributes(
+		attribute.Int(AttrStatusCode, int(code)),
+		attribute.Bool(AttrBusinessError, true),
+		attribute.String(AttrErrorType, code.String()),
+	)
+	span.SetAttributes(attrs...)
+}
+
+// BusinessErrorOptions returns server options that pass errors returned by unary
+// and stream handlers to mapper. Business errors are recorded with
+// [RecordBusinessError] and returned to the client with the mapped code, so the
+// client sees the same status as the trace; other errors are returned unchanged.
+//
+// Example:
+//
+//	server := grpc.NewServer(append(otxgrpc.BusinessErrorOptions(mapErr),
+//	    grpc.StatsHandler(otxgrpc.ServerHandler()),
+//	)...)
+func BusinessErrorOptions(mapper BusinessErrorMapper) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(
+			ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+		) (any, error) {
+			resp, err := handler(ctx, req)
+
+			return resp, mapBusinessError(ctx, mapper, err)
+		}),
+		grpc.ChainStreamInterceptor(func(
+			srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler,
+		) error {
+			return mapBusinessError(ss.Context(), mapper, handler(srv, ss))
+		}),
+	}
+}
+
+// mapBusinessError records err if mapper reports it as a business error and
+// returns the error to send to the client.
+func mapBusinessError(ctx context.Context, mapper BusinessErrorMapper, err error) error {
+	if err == nil || mapper == nil {
+		return err
+	}
+	code, attrs, ok := mapper(err)
+	if !ok || code == codes.OK {
+		return err
+	}
+	RecordBusinessError(ctx, code, err.Error(), attrs...)
+
+	return status.Error(code, err.Error())
+}
+
+// TagRPC implements stats.Handler.
+func (h businessErrorHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	return context.WithValue(h.Handler.TagRPC(ctx, info), businessErrorKey{}, &businessError{})
+}
+
+// HandleRPC implements stats.Handler.
+func (h businessErrorHandler) HandleRPC(ctx context.Context, rs stats.RPCStats) {
+	if end, ok := rs.(*stats.End); ok && end.Error == nil {
+		if be, ok := ctx.Value(businessErrorKey{}).(*businessError); ok {
+			be.mu.Lock()
+			if be.set {
+				withError := *end
+				withError.Error = status.Error(be.code, be.msg)
+				rs = &withError
+			}
+			be.mu.Unlock()
+		}
+	}
+
+	h.Handler.HandleRPC(ctx, rs)
+}
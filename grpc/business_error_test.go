@@ -0,0 +1,144 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var errOutOfStock = errors.New("out of stock")
+
+// checkServer is a health server whose Check is implemented by check.
+type checkServer struct {
+	healthpb.UnimplementedHealthServer
+
+	check func(ctx context.Context) error
+}
+
+func (s checkServer) Check(ctx context.Context, _ *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if err := s.check(ctx); err != nil {
+		return nil, err
+	}
+
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+// callCheck serves check with opts and a traced server handler, calls it once and
+// returns the ended server spans and the call error.
+func callCheck(
+	t *testing.T, check func(ctx context.Context) error, opts ...grpc.ServerOption,
+) ([]trace.ReadOnlySpan, error) {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := trace.NewTracerProvider(trace.WithSyncer(exporter))
+	handler := ServerHandlerWithProviders(tp, noop.NewMeterProvider(), propagation.TraceContext{})
+
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer(append(opts, grpc.StatsHandler(handler))...)
+	healthpb.RegisterHealthServer(s, checkServer{check: check})
+	go func() { _ = s.Serve(lis) }()
+	defer s.Stop()
+
+	conn, err := grpc.NewClient("passthrough://bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	s.GracefulStop() // waits for the server span to end
+
+	return exporter.GetSpans().Snapshots(), err
+}
+
+func attrValue(attrs []attribute.KeyValue, key string) attribute.Value {
+	for _, kv := range attrs {
+		if string(kv.Key) == key {
+			return kv.Value
+		}
+	}
+
+	return attribute.Value{}
+}
+
+func TestRecordBusinessError(t *testing.T) {
+	spans, err := callCheck(t, func(ctx context.Context) error {
+		RecordBusinessError(ctx, codes.FailedPrecondition, "out of stock", attribute.String("order.sku", "A-1"))
+
+		return nil
+	})
+	require.NoError(t, err, "the client still gets an OK response")
+
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, otelcodes.Error, span.Status().Code)
+	assert.Equal(t, "out of stock", span.Status().Description)
+	assert.Equal(t, int64(codes.FailedPrecondition), attrValue(span.Attributes(), AttrStatusCode).AsInt64())
+	assert.True(t, attrValue(span.Attributes(), AttrBusinessError).AsBool())
+	assert.Equal(t, "FailedPrecondition", attrValue(span.Attributes(), AttrErrorType).AsString())
+	assert.Equal(t, "A-1", attrValue(span.Attributes(), "order.sku").AsString())
+}
+
+func TestRecordBusinessError_OK(t *testing.T) {
+	spans, err := callCheck(t, func(ctx context.Context) error {
+		RecordBusinessError(ctx, codes.OK, "fine")
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, spans, 1)
+	assert.Equal(t, otelcodes.Unset, spans[0].Status().Code)
+	assert.Equal(t, int64(codes.OK), attrValue(spans[0].Attributes(), AttrStatusCode).AsInt64())
+	assert.False(t, attrValue(spans[0].Attributes(), AttrBusinessError).AsBool())
+}
+
+func TestBusinessErrorOptions(t *testing.T) {
+	mapper := func(err error) (codes.Code, []attribute.KeyValue, bool) {
+		if errors.Is(err, errOutOfStock) {
+			return codes.FailedPrecondition, []attribute.KeyValue{attribute.String("order.sku", "A-1")}, true
+		}
+
+		return codes.Unknown, nil, false
+	}
+
+	t.Run("business error", func(t *testing.T) {
+		spans, err := callCheck(t, func(context.Context) error { return errOutOfStock }, BusinessErrorOptions(mapper)...)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Equal(t, "out of stock", status.Convert(err).Message())
+
+		require.Len(t, spans, 1)
+		assert.Equal(t, otelcodes.Error, spans[0].Status().Code)
+		assert.Equal(t, int64(codes.FailedPrecondition), attrValue(spans[0].Attributes(), AttrStatusCode).AsInt64())
+		assert.Equal(t, "A-1", attrValue(spans[0].Attributes(), "order.sku").AsString())
+	})
+
+	t.Run("other error", func(t *testing.T) {
+		spans, err := callCheck(t, func(context.Context) error { return errors.New("boom") }, BusinessErrorOptions(mapper)...)
+		assert.Equal(t, codes.Unknown, status.Code(err))
+
+		require.Len(t, spans, 1)
+		assert.Equal(t, int64(codes.Unknown), attrValue(spans[0].Attributes(), AttrStatusCode).AsInt64())
+		assert.False(t, attrValue(spans[0].Attributes(), AttrBusinessError).AsBool())
+	})
+}
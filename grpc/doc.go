@@ -8,6 +8,13 @@
 //	    grpc.StatsHandler(otxgrpc.ServerHandler()),
 //	)
 //
+// # Business Errors
+//
+// Use [RecordBusinessError] to mark RPCs that return an OK response for a failed
+// business operation, or [BusinessErrorOptions] to map returned application errors:
+//
+//	otxgrpc.RecordBusinessError(ctx, codes.FailedPrecondition, "out of stock")
+//
 // # gRPC Client
 //
 // Use stats handler for gRPC clients:
@@ -15,9 +15,12 @@ import (
 // TextMapPropagator. When using this with the otx package, ensure that
 // global providers have been initialized.
 //
+// Business errors recorded with [RecordBusinessError] are reported as the status
+// of RPCs that complete with an OK response.
+//
 // For explicit provider injection, use [ServerHandlerWithProviders] instead.
 func ServerHandler(opts ...otelgrpc.Option) stats.Handler {
-	return otelgrpc.NewServerHandler(opts...)
+	return businessErrorHandler{otelgrpc.NewServerHandler(opts...)}
 }
 
 // ServerHandlerWithProviders returns a gRPC stats.Handler for server-side
@@ -39,7 +42,7 @@ func ServerHandlerWithProviders(
 	allOpts := buildProviderOptions(tp, mp, prop)
 	allOpts = append(allOpts, opts...)
 
-	return otelgrpc.NewServerHandler(allOpts...)
+	return businessErrorHandler{otelgrpc.NewServerHandler(allOpts...)}
 }
 
 // ClientHandler returns a gRPC stats.Handler for client-side tracing and metrics.
@@ -0,0 +1,49 @@
+package grpc
+
+import (
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/arloliu/otx/internal/peerservice"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+)
+
+// WithPeerService adds peer.service to client spans when the host of target, the
+// address passed to grpc.NewClient, matches a rule set with otx.SetPeerServices,
+// e.g. from traces.peerServices. gRPC does not expose the target to stats handlers,
+// so pass it to the handler of each connection. The rules are looked up once,
+// when the handler is created.
+//
+// Example:
+//
+//	conn, err := grpc.NewClient(target,
+//	    grpc.WithStatsHandler(otxgrpc.ClientHandler(otxgrpc.WithPeerService(target))),
+//	)
+func WithPeerService(target string) otelgrpc.Option {
+	attr, ok := peerservice.Attribute(targetHost(target))
+	if !ok {
+		return otelgrpc.WithSpanAttributes()
+	}
+
+	return otelgrpc.WithSpanAttributes(attr)
+}
+
+// targetHost returns the host of a gRPC target such as "payments:443",
+// "dns:///payments.internal:443" or "passthrough:///10.0.0.1:9090".
+func targetHost(target string) string {
+	if strings.Contains(target, "://") {
+		if u, err := url.Parse(target); err == nil {
+			// The endpoint is the path; the authority names the resolver's server
+			target = strings.TrimPrefix(u.Path, "/")
+			if target == "" {
+				target = u.Host
+			}
+		}
+	}
+	if host, _, err := net.SplitHostPort(target); err == nil {
+		return host
+	}
+
+	return target
+}
@@ -0,0 +1,80 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/arloliu/otx/internal/peerservice"
+)
+
+func TestTargetHost(t *testing.T) {
+	tests := map[string]string{
+		"payments:443":                  "payments",
+		"payments.internal":             "payments.internal",
+		"dns:///payments.internal:443":  "payments.internal",
+		"dns://8.8.8.8/payments:443":    "payments",
+		"passthrough:///10.0.0.1:9090":  "10.0.0.1",
+		"passthrough://bufnet":          "bufnet",
+		"[::1]:50051":                   "::1",
+		"unix:///var/run/payments.sock": "var/run/payments.sock",
+	}
+	for target, want := range tests {
+		assert.Equal(t, want, targetHost(target), target)
+	}
+}
+
+func TestWithPeerService(t *testing.T) {
+	peerservice.Set([]peerservice.Rule{
+		{Host: regexp.MustCompile(`^bufnet$`), Service: "health"},
+	})
+	t.Cleanup(func() { peerservice.Set(nil) })
+
+	tests := map[string]string{
+		"passthrough://bufnet":   "health",
+		"passthrough://unmapped": "",
+	}
+	for target, want := range tests {
+		t.Run(target, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			tp := trace.NewTracerProvider(trace.WithSyncer(exporter))
+			handler := ClientHandlerWithProviders(tp, noop.NewMeterProvider(), propagation.TraceContext{},
+				WithPeerService(target))
+
+			lis := bufconn.Listen(1024 * 1024)
+			s := grpc.NewServer()
+			healthpb.RegisterHealthServer(s, checkServer{check: func(context.Context) error { return nil }})
+			go func() { _ = s.Serve(lis) }()
+			defer s.Stop()
+
+			conn, err := grpc.NewClient(target,
+				grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+					return lis.Dial()
+				}),
+				grpc.WithTransportCredentials(insecure.NewCredentials()),
+				grpc.WithStatsHandler(handler),
+			)
+			require.NoError(t, err)
+			defer conn.Close()
+
+			_, err = healthpb.NewHealthClient(conn).Check(t.Context(), &healthpb.HealthCheckRequest{})
+			require.NoError(t, err)
+
+			spans := exporter.GetSpans().Snapshots()
+			require.Len(t, spans, 1)
+			assert.Equal(t, want, attrValue(spans[0].Attributes(), string(peerservice.Key)).AsString())
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package otx
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// HeaderProvider returns headers to add to an OTLP export request.
+// ctx is the context of the request. It may be called concurrently.
+//
+// Returned headers are sent in addition to the static OTLPConfig.Headers; for the
+// same key, HTTP requests use the provider's value while gRPC requests send both,
+// so do not set a key in both places.
+//
+// Example:
+//
+//	cfg.OTLP.HeaderProvider = func(context.Context) map[string]string {
+//	    return map[string]string{"Authorization": "Bearer " + tokens.Current()}
+//	}
+type HeaderProvider func(ctx context.Context) map[string]string
+
+// headerTransport adds the headers of provider to each HTTP request.
+type headerTransport struct {
+	base     http.RoundTripper
+	provider HeaderProvider
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	headers := t.provider(req.Context())
+	if len(headers) == 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// headerCredentials adds the headers of provider to each gRPC call.
+type headerCredentials struct {
+	provider HeaderProvider
+}
+
+func (c headerCredentials) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+	return c.provider(ctx), nil
+}
+
+// RequireTransportSecurity returns false so that providers also work with insecure
+// connections, e.g. to a local sidecar collector.
+func (headerCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// otlpHTTPClient returns the client of the OTLP/HTTP exporters for params, or nil
+// to let them build their own from the other options. A client is needed to dial a
+// unix socket endpoint and to add the headers of params.HeaderProvider to each
+// request. OTLP/HTTP exporters given a client ignore their TLS and proxy options, so
+// the client carries tlsCfg and proxy.
+func otlpHTTPClient(params exporterParams, tlsCfg *tls.Config, proxy *url.URL) *http.Client {
+	socket, isUnix := unixSocketPath(params.Endpoint)
+	if !isUnix && params.HeaderProvider == nil {
+		return nil
+	}
+
+	transport := otlpHTTPTransport(tlsCfg, proxy)
+	if isUnix {
+		dialUnixSocket(transport, socket)
+	}
+	client := &http.Client{Transport: transport, Timeout: params.Timeout}
+	if params.HeaderProvider != nil {
+		client.Transport = &headerTransport{base: transport, provider: params.HeaderProvider}
+	}
+
+	return client
+}
+
+// otlpHTTPTransport returns a copy of http.DefaultTransport using tlsCfg and proxy,
+// either of which may be nil to keep the default. If the application replaced
+// http.DefaultTransport with another RoundTripper, e.g. an instrumented one, a new
+// transport with the same settings as the net/http default is used instead.
+func otlpHTTPTransport(tlsCfg *tls.Config, proxy *url.URL) *http.Transport {
+	var transport *http.Transport
+	if t, ok := http.DefaultTransport.(*http.Transport); ok {
+		transport = t.Clone()
+	} else {
+		transport = &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   30 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+		}
+	}
+	if tlsCfg != nil {
+		transport.TLSClientConfig = tlsCfg
+	}
+	if proxy != nil {
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	return transport
+}
+
+// dialUnixSocket makes transport connect to the unix socket at path whatever the
+// request host, bypassing any proxy.
+func dialUnixSocket(transport *http.Transport, path string) {
+	var dialer net.Dialer
+	transport.Proxy = nil
+	transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+		return dialer.DialContext(ctx, "unix", path)
+	}
+}
@@ -0,0 +1,92 @@
+package otx
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// rotatingToken returns a HeaderProvider whose bearer token changes on every call.
+func rotatingToken() HeaderProvider {
+	var calls atomic.Int64
+
+	return func(context.Context) map[string]string {
+		return map[string]string{"Authorization": "Bearer token-" + strconv.FormatInt(calls.Add(1), 10)}
+	}
+}
+
+func TestHeaderProvider_GRPC(t *testing.T) {
+	traces := &fakeTraceService{}
+	endpoint := startOTLPGRPCServer(t, traces)
+	cfg := &TelemetryConfig{
+		Enabled: boolPtr(true),
+		OTLP:    &OTLPConfig{Endpoint: endpoint, HeaderProvider: rotatingToken()},
+	}
+
+	exporter, err := buildOTLPTraceExporter(t.Context(), resolveTraceExporterParams(cfg))
+	require.NoError(t, err)
+	spans := tracetest.SpanStubs{{Name: "a"}}.Snapshots()
+	require.NoError(t, exporter.ExportSpans(t.Context(), spans))
+	require.NoError(t, exporter.ExportSpans(t.Context(), spans))
+	require.NoError(t, exporter.Shutdown(t.Context()))
+
+	assert.Equal(t, []string{"Bearer token-1", "Bearer token-2"}, traces.auths, "headers are fetched per export")
+}
+
+func TestHeaderProvider_HTTP(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		auths []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		auths = append(auths, r.URL.Path+" "+r.Header.Get("Authorization")+" "+r.Header.Get("X-Tenant"))
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	cfg := &TelemetryConfig{
+		Enabled: boolPtr(true),
+		OTLP: &OTLPConfig{
+			Endpoint:       server.URL,
+			Protocol:       "http/protobuf",
+			Headers:        map[string]string{"X-Tenant": "acme"},
+			HeaderProvider: rotatingToken(),
+		},
+	}
+	require.NoError(t, CheckConnectivity(t.Context(), cfg))
+
+	exporter, err := buildOTLPTraceExporter(t.Context(), resolveTraceExporterParams(cfg))
+	require.NoError(t, err)
+	require.NoError(t, exporter.ExportSpans(t.Context(), tracetest.SpanStubs{{Name: "a"}}.Snapshots()))
+	require.NoError(t, exporter.Shutdown(t.Context()))
+
+	assert.Equal(t, []string{
+		"/v1/traces Bearer token-1 acme",
+		"/v1/traces Bearer token-2 acme",
+	}, auths, "provider headers are added to the static headers")
+}
+
+func TestOTLPHTTPTransport_ReplacedDefaultTransport(t *testing.T) {
+	prev := http.DefaultTransport
+	http.DefaultTransport = roundTripperFunc(func(*http.Request) (*http.Response, error) { return nil, errBoom })
+	t.Cleanup(func() { http.DefaultTransport = prev })
+
+	var transport *http.Transport
+	require.NotPanics(t, func() { transport = otlpHTTPTransport(&tls.Config{ServerName: "collector"}, nil) })
+	assert.Equal(t, "collector", transport.TLSClientConfig.ServerName)
+	assert.NotNil(t, transport.Proxy)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
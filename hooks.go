@@ -0,0 +1,86 @@
+package otx
+
+import (
+	"context"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// TracerProviderOption configures NewTracerProvider beyond TelemetryConfig.
+type TracerProviderOption func(*tracerProviderOptions)
+
+// tracerProviderOptions holds the options applied by NewTracerProvider.
+type tracerProviderOptions struct {
+	processors  []sdktrace.SpanProcessor
+	filters     []SpanFilter
+	idGenerator sdktrace.IDGenerator
+}
+
+// spanHooks is a SpanProcessor that forwards OnStart and OnEnd to callbacks.
+type spanHooks struct {
+	onStart func(ctx context.Context, s sdktrace.ReadWriteSpan)
+	onEnd   func(s sdktrace.ReadOnlySpan)
+}
+
+// WithSpanProcessor registers span processors on the TracerProvider, in addition to
+// traces.spanProcessors. They are added before the exporters, so attributes set in
+// OnStart are exported. Processors are shut down with the provider.
+//
+// Example:
+//
+//	tp, err := otx.NewTracerProvider(ctx, cfg,
+//	    otx.WithSpanProcessor(otx.NewSpanHooks(stampTenant, nil)),
+//	)
+func WithSpanProcessor(processors ...sdktrace.SpanProcessor) TracerProviderOption {
+	return func(o *tracerProviderOptions) {
+		o.processors = append(o.processors, processors...)
+	}
+}
+
+// NewSpanHooks returns a SpanProcessor that calls onStart when a span starts and
+// onEnd when it ends. Either callback may be nil. Callbacks run synchronously on
+// the caller's goroutine, so keep them fast.
+//
+// Attributes can only be added in onStart; spans are read-only once ended.
+//
+// Example:
+//
+//	// Stamp the tenant from baggage onto every span.
+//	stampTenant := func(ctx context.Context, s sdktrace.ReadWriteSpan) {
+//	    if tenant := otx.GetBaggage(ctx, "tenant.id"); tenant != "" {
+//	        s.SetAttributes(attribute.String("tenant.id", tenant))
+//	    }
+//	}
+//	tp, err := otx.NewTracerProvider(ctx, cfg,
+//	    otx.WithSpanProcessor(otx.NewSpanHooks(stampTenant, nil)),
+//	)
+func NewSpanHooks(
+	onStart func(ctx context.Context, s sdktrace.ReadWriteSpan),
+	onEnd func(s sdktrace.ReadOnlySpan),
+) sdktrace.SpanProcessor {
+	return &spanHooks{onStart: onStart, onEnd: onEnd}
+}
+
+// OnStart implements sdktrace.SpanProcessor.
+func (h *spanHooks) OnStart(ctx context.Context, s sdktrace.ReadWriteSpan) {
+	if h.onStart != nil {
+		h.onStart(ctx, s)
+	}
+}
+
+// OnEnd implements sdktrace.SpanProcessor.
+func (h *spanHooks) OnEnd(s sdktrace.ReadOnlySpan) {
+	if h.onEnd != nil {
+		h.onEnd(s)
+	}
+}
+
+// Shutdown implements sdktrace.SpanProcessor.
+func (*spanHooks) Shutdown(_ context.Context) error {
+	return nil
+}
+
+// ForceFlush implements sdktrace.SpanProcessor.
+func (*spanHooks) ForceFlush(_ context.Context) error {
+	return nil
+}
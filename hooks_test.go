@@ -0,0 +1,61 @@
+package otx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestNewTracerProvider_WithSpanProcessor(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	var ended []string
+	stampTenant := func(ctx context.Context, s sdktrace.ReadWriteSpan) {
+		if tenant := GetBaggage(ctx, "tenant.id"); tenant != "" {
+			s.SetAttributes(attribute.String("tenant.id", tenant))
+		}
+	}
+
+	cfg := &TelemetryConfig{
+		Enabled:     boolPtr(true),
+		ServiceName: "test-service",
+		Traces: &TracesConfig{
+			Exporter:       "none",
+			SpanProcessors: []sdktrace.SpanProcessor{nil, sdktrace.NewSimpleSpanProcessor(exporter)},
+		},
+	}
+	tp, err := NewTracerProvider(t.Context(), cfg,
+		WithSpanProcessor(NewSpanHooks(stampTenant, func(s sdktrace.ReadOnlySpan) {
+			ended = append(ended, s.Name())
+		})),
+	)
+	require.NoError(t, err)
+	defer func() { _ = tp.Shutdown(t.Context()) }()
+
+	ctx := MustSetBaggage(t.Context(), "tenant.id", "acme")
+	_, span := tp.Tracer("test").Start(ctx, "with-tenant")
+	span.End()
+	_, span = tp.Tracer("test").Start(t.Context(), "without-tenant")
+	span.End()
+
+	assert.Equal(t, []string{"with-tenant", "without-tenant"}, ended)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.True(t, hasAttribute(spans[0].Attributes, attribute.String("tenant.id", "acme")))
+	assert.False(t, hasKey(spans[1].Attributes, "tenant.id"))
+}
+
+func TestNewSpanHooks_NilCallbacks(t *testing.T) {
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(NewSpanHooks(nil, nil)))
+
+	_, span := tp.Tracer("test").Start(t.Context(), "noop")
+	span.End()
+
+	require.NoError(t, tp.ForceFlush(t.Context()))
+	require.NoError(t, tp.Shutdown(t.Context()))
+}
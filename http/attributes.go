@@ -0,0 +1,66 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// extractedAttrsKey is the context key of the *extractedAttrs of a request.
+type extractedAttrsKey struct{}
+
+// extractedAttrs collects the attributes returned by attribute extractors for one request.
+type extractedAttrs struct {
+	attrs []attribute.KeyValue
+}
+
+// WithAttributeExtractor returns an option that adds the attributes returned by fn
+// to the server span of each request, e.g. the authenticated user, tenant or A/B
+// test group. fn is called before the handler runs, so the attributes are set even
+// if it panics, and several extractors can be combined.
+//
+// It applies to [Handler], [HandlerWithProviders], [Middleware] and
+// [MiddlewareWithProviders]; elsewhere, such as client transports, it has no effect.
+// The request passed to fn must not be modified.
+//
+// Usage:
+//
+//	mux.Handle("/api", otxhttp.Middleware(
+//	    otxhttp.WithAttributeExtractor(func(r *http.Request) []attribute.KeyValue {
+//	        return []attribute.KeyValue{attribute.String("ab.group", r.Header.Get("X-AB-Group"))}
+//	    }),
+//	)(apiHandler))
+func WithAttributeExtractor(fn func(*http.Request) []attribute.KeyValue) otelhttp.Option {
+	// otelhttp has no per-request span attribute hook, but runs filters for every
+	// request before starting the span; the attributes are collected there and set
+	// once the span is in the request context.
+	return otelhttp.WithFilter(func(r *http.Request) bool {
+		if extracted, ok := r.Context().Value(extractedAttrsKey{}).(*extractedAttrs); ok {
+			extracted.attrs = append(extracted.attrs, fn(r)...)
+		}
+
+		return true
+	})
+}
+
+// withExtractedAttributes wraps a tracing middleware so that attributes collected
+// by WithAttributeExtractor options are set on the span it starts.
+func withExtractedAttributes(traced func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if extracted, ok := r.Context().Value(extractedAttrsKey{}).(*extractedAttrs); ok && len(extracted.attrs) > 0 {
+				trace.SpanFromContext(r.Context()).SetAttributes(extracted.attrs...)
+			}
+			next.ServeHTTP(w, r)
+		})
+		tracedInner := traced(inner)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), extractedAttrsKey{}, &extractedAttrs{})
+			tracedInner.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
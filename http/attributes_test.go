@@ -0,0 +1,50 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestWithAttributeExtractor(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := trace.NewTracerProvider(trace.WithSyncer(exporter))
+
+	tenant := WithAttributeExtractor(func(r *http.Request) []attribute.KeyValue {
+		return []attribute.KeyValue{attribute.String("tenant.id", r.Header.Get("X-Tenant-ID"))}
+	})
+	group := WithAttributeExtractor(func(*http.Request) []attribute.KeyValue {
+		return []attribute.KeyValue{attribute.String("ab.group", "b")}
+	})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	wrappers := map[string]http.Handler{
+		"middleware": MiddlewareWithProviders(tp, noop.NewMeterProvider(), propagation.TraceContext{},
+			tenant, group)(handler),
+		"handler": HandlerWithProviders(handler, "api", tp, noop.NewMeterProvider(), propagation.TraceContext{},
+			tenant, group),
+	}
+	for name, wrapped := range wrappers {
+		t.Run(name, func(t *testing.T) {
+			exporter.Reset()
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req.Header.Set("X-Tenant-ID", "acme")
+			wrapped.ServeHTTP(httptest.NewRecorder(), req)
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			assert.Contains(t, spans[0].Attributes, attribute.String("tenant.id", "acme"))
+			assert.Contains(t, spans[0].Attributes, attribute.String("ab.group", "b"))
+		})
+	}
+}
@@ -29,6 +29,15 @@ type clientConfig struct {
 
 	// Base transport (before OTel wrapping)
 	baseTransport http.RoundTripper
+
+	// URL redaction rules applied to url.full on client spans
+	redactionRules []RedactionRule
+
+	// Skip client spans when the caller's span is unsampled
+	suppressUnsampled bool
+
+	// Record followed redirects on the caller's span
+	redirectEvents bool
 }
 
 // ClientOption configures an HTTP client.
@@ -106,6 +115,43 @@ func WithTransport(rt http.RoundTripper) ClientOption {
 	}
 }
 
+// WithURLRedaction redacts url.full on client spans using the given rules.
+// Userinfo is always removed; rules are applied in order.
+//
+// Usage:
+//
+//	client := otxhttp.NewClient(
+//	    otxhttp.WithURLRedaction(otxhttp.StripQuery()),
+//	)
+func WithURLRedaction(rules ...RedactionRule) ClientOption {
+	return func(c *clientConfig) {
+		c.redactionRules = append(c.redactionRules, rules...)
+	}
+}
+
+// WithSuppressUnsampled skips client span creation when the request context
+// carries a valid but unsampled span. Trace context is still injected into the
+// outgoing headers, so downstream services see the unsampled decision.
+// Default is false.
+//
+// This is a middle ground between full tracing and no instrumentation for hot paths
+// under low sampling rates. Requests without a parent span are traced as usual.
+func WithSuppressUnsampled(enabled bool) ClientOption {
+	return func(c *clientConfig) {
+		c.suppressUnsampled = enabled
+	}
+}
+
+// WithRedirectEvents records each redirect followed by the client on the caller's
+// span, as an http.redirect event with the status and location, together with an
+// http.redirect_count attribute. Locations are redacted like url.full when
+// WithURLRedaction is set. Default is false. See RecordRedirects.
+func WithRedirectEvents(enabled bool) ClientOption {
+	return func(c *clientConfig) {
+		c.redirectEvents = enabled
+	}
+}
+
 // NewClient creates an http.Client with OTel tracing enabled.
 //
 // This client uses the globally registered TracerProvider, MeterProvider, and
@@ -129,12 +175,13 @@ func NewClient(opts ...ClientOption) *http.Client {
 		opt(config)
 	}
 
-	transport := buildTransport(config)
-	otelTransport := Transport(transport)
+	transport := wrapRedaction(buildTransport(config), config)
+	otelTransport := wrapSuppression(Transport(transport), transport, nil, config)
 
 	return &http.Client{
-		Transport: otelTransport,
-		Timeout:   config.timeout,
+		Transport:     otelTransport,
+		CheckRedirect: checkRedirect(config),
+		Timeout:       config.timeout,
 	}
 }
 
@@ -165,15 +212,49 @@ func NewClientWithProviders(
 		opt(config)
 	}
 
-	transport := buildTransport(config)
-	otelTransport := TransportWithProviders(transport, tp, mp, prop)
+	transport := wrapRedaction(buildTransport(config), config)
+	otelTransport := wrapSuppression(TransportWithProviders(transport, tp, mp, prop), transport, prop, config)
 
 	return &http.Client{
-		Transport: otelTransport,
-		Timeout:   config.timeout,
+		Transport:     otelTransport,
+		CheckRedirect: checkRedirect(config),
+		Timeout:       config.timeout,
 	}
 }
 
+// wrapRedaction wraps rt with URL redaction when rules are configured.
+func wrapRedaction(rt http.RoundTripper, c *clientConfig) http.RoundTripper {
+	if len(c.redactionRules) == 0 {
+		return rt
+	}
+
+	return RedactingTransport(rt, c.redactionRules...)
+}
+
+// checkRedirect returns the redirect policy recording redirects when enabled,
+// or nil for the http.Client default.
+func checkRedirect(c *clientConfig) func(req *http.Request, via []*http.Request) error {
+	if !c.redirectEvents {
+		return nil
+	}
+
+	return RecordRedirects(nil, c.redactionRules...)
+}
+
+// wrapSuppression bypasses traced for unsampled callers when enabled.
+func wrapSuppression(
+	traced http.RoundTripper,
+	base http.RoundTripper,
+	prop propagation.TextMapPropagator,
+	c *clientConfig,
+) http.RoundTripper {
+	if !c.suppressUnsampled {
+		return traced
+	}
+
+	return &suppressingTransport{traced: traced, base: base, prop: prop}
+}
+
 // buildTransport configures the underlying transport based on config
 func buildTransport(c *clientConfig) http.RoundTripper {
 	var transport *http.Transport
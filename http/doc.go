@@ -10,6 +10,8 @@
 //	// Explicit verification of providers
 //	http.Handle("/api", otxhttp.MiddlewareWithProviders(tp, mp, prop)(myHandler))
 //
+// Add per-request attributes to the server span with [WithAttributeExtractor].
+//
 // # HTTP Client
 //
 // Create an instrumented HTTP client:
@@ -20,4 +22,12 @@
 //
 //	// Use the client
 //	resp, err := client.Get("https://example.com")
+//
+// # URL Redaction
+//
+// Redact sensitive parts of url.full on client spans:
+//
+//	client := otxhttp.NewClient(
+//	    otxhttp.WithURLRedaction(otxhttp.StripQuery()),
+//	)
 package http
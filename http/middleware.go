@@ -22,7 +22,9 @@ import (
 //
 //	http.Handle("/api", http.Handler(myHandler, "api.request"))
 func Handler(handler http.Handler, operation string, opts ...otelhttp.Option) http.Handler {
-	return otelhttp.NewHandler(handler, operation, opts...)
+	return withExtractedAttributes(func(next http.Handler) http.Handler {
+		return otelhttp.NewHandler(next, operation, opts...)
+	})(handler)
 }
 
 // HandlerWithProviders wraps an http.Handler with OTel tracing and metrics
@@ -55,7 +57,9 @@ func HandlerWithProviders(
 	allOpts := buildProviderOptions(tp, mp, prop)
 	allOpts = append(allOpts, opts...)
 
-	return otelhttp.NewHandler(handler, operation, allOpts...)
+	return withExtractedAttributes(func(next http.Handler) http.Handler {
+		return otelhttp.NewHandler(next, operation, allOpts...)
+	})(handler)
 }
 
 // Middleware returns middleware that traces HTTP requests.
@@ -70,9 +74,7 @@ func HandlerWithProviders(
 //
 //	http.Handle("/api", http.Middleware()(myHandler))
 func Middleware(opts ...otelhttp.Option) func(http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		return otelhttp.NewMiddleware("http.request", opts...)(next)
-	}
+	return withExtractedAttributes(otelhttp.NewMiddleware("http.request", opts...))
 }
 
 // MiddlewareWithProviders returns middleware that traces HTTP requests
@@ -101,9 +103,7 @@ func MiddlewareWithProviders(
 	allOpts := buildProviderOptions(tp, mp, prop)
 	allOpts = append(allOpts, opts...)
 
-	return func(next http.Handler) http.Handler {
-		return otelhttp.NewMiddleware("http.request", allOpts...)(next)
-	}
+	return withExtractedAttributes(otelhttp.NewMiddleware("http.request", allOpts...))
 }
 
 // buildProviderOptions creates otelhttp.Option slice from providers.
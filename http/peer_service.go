@@ -0,0 +1,25 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/arloliu/otx/internal/peerservice"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// peerServiceTransport adds peer.service to the span started by the outer OTel
+// transport, from the rules set with otx.SetPeerServices.
+type peerServiceTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *peerServiceTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if span := trace.SpanFromContext(r.Context()); span.IsRecording() {
+		if attr, ok := peerservice.Attribute(r.URL.Hostname()); ok {
+			span.SetAttributes(attr)
+		}
+	}
+
+	return t.base.RoundTrip(r)
+}
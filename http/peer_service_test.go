@@ -0,0 +1,53 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/arloliu/otx/internal/peerservice"
+)
+
+func TestTransport_PeerService(t *testing.T) {
+	peerservice.Set([]peerservice.Rule{
+		{Host: regexp.MustCompile(`^127\.0\.0\.1$`), Service: "local"},
+	})
+	t.Cleanup(func() { peerservice.Set(nil) })
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := trace.NewTracerProvider(trace.WithSyncer(exporter))
+	client := &http.Client{Transport: TransportWithProviders(nil, tp, nil, nil)}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Contains(t, spans[0].Attributes, attribute.String("peer.service", "local"))
+
+	// Hosts without a rule are left alone
+	peerservice.Set(nil)
+	exporter.Reset()
+	resp, err = client.Get(server.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	spans = exporter.GetSpans()
+	require.Len(t, spans, 1)
+	for _, kv := range spans[0].Attributes {
+		assert.NotEqual(t, peerservice.Key, kv.Key)
+	}
+}
@@ -0,0 +1,146 @@
+package http
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RedactedValue replaces redacted query values and path segments,
+// matching the placeholder recommended by the OTel HTTP semantic conventions.
+const RedactedValue = "REDACTED"
+
+// attrURLFull is the semantic convention key recorded by otelhttp for client spans.
+const attrURLFull = "url.full"
+
+// RedactionRule rewrites a copy of an outbound URL before it is recorded as url.full.
+// Rules must not retain u after returning.
+type RedactionRule func(u *url.URL)
+
+// StripQuery removes the query string and fragment from url.full.
+// Use this for signed URLs where any query parameter may carry a credential.
+func StripQuery() RedactionRule {
+	return func(u *url.URL) {
+		u.RawQuery = ""
+		u.ForceQuery = false
+		u.Fragment = ""
+		u.RawFragment = ""
+	}
+}
+
+// RedactQueryParams replaces the values of the named query parameters with [RedactedValue].
+// Parameter names are matched case-insensitively.
+//
+// Example:
+//
+//	otxhttp.RedactQueryParams("X-Amz-Signature", "token")
+func RedactQueryParams(keys ...string) RedactionRule {
+	lowered := make([]string, 0, len(keys))
+	for _, k := range keys {
+		lowered = append(lowered, strings.ToLower(k))
+	}
+
+	return func(u *url.URL) {
+		if u.RawQuery == "" {
+			return
+		}
+
+		query := u.Query()
+		changed := false
+		for k, vals := range query {
+			if !slices.Contains(lowered, strings.ToLower(k)) {
+				continue
+			}
+			for i := range vals {
+				vals[i] = RedactedValue
+			}
+			changed = true
+		}
+
+		if changed {
+			u.RawQuery = query.Encode()
+		}
+	}
+}
+
+// MaskPathSegments replaces every path segment fully matching pattern with [RedactedValue].
+//
+// Example:
+//
+//	// "/users/8f14e45f-ceea-467f-a0d6-f0a6c3e5b1b2/orders" -> "/users/REDACTED/orders"
+//	otxhttp.MaskPathSegments(regexp.MustCompile(`^[0-9a-f-]{36}$`))
+func MaskPathSegments(pattern *regexp.Regexp) RedactionRule {
+	return func(u *url.URL) {
+		if pattern == nil || u.Path == "" {
+			return
+		}
+
+		segments := strings.Split(u.Path, "/")
+		changed := false
+		for i, seg := range segments {
+			if seg != "" && pattern.MatchString(seg) {
+				segments[i] = RedactedValue
+				changed = true
+			}
+		}
+
+		if changed {
+			u.Path = strings.Join(segments, "/")
+			u.RawPath = ""
+		}
+	}
+}
+
+// RedactURL returns the string form of u with userinfo removed and rules applied.
+// The original URL is not modified.
+func RedactURL(u *url.URL, rules ...RedactionRule) string {
+	if u == nil {
+		return ""
+	}
+
+	redacted := *u
+	redacted.User = nil
+	for _, rule := range rules {
+		rule(&redacted)
+	}
+
+	return redacted.String()
+}
+
+// RedactingTransport wraps base so that url.full on the active client span is
+// overwritten with the redacted URL before the request is sent.
+//
+// It must sit between the OTel transport and the network transport, which is
+// what [WithURLRedaction] does for clients created by [NewClient]. When using
+// [Transport] directly, pass the redacting transport as its base:
+//
+//	rt := otxhttp.Transport(otxhttp.RedactingTransport(http.DefaultTransport, otxhttp.StripQuery()))
+//
+// If base is nil, http.DefaultTransport is used.
+func RedactingTransport(base http.RoundTripper, rules ...RedactionRule) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &redactingTransport{base: base, rules: rules}
+}
+
+// redactingTransport rewrites url.full on the span started by the outer OTel transport.
+type redactingTransport struct {
+	base  http.RoundTripper
+	rules []RedactionRule
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *redactingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if span := trace.SpanFromContext(r.Context()); span.IsRecording() {
+		span.SetAttributes(attribute.String(attrURLFull, RedactURL(r.URL, t.rules...)))
+	}
+
+	return t.base.RoundTrip(r)
+}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Redirect event and attribute names recorded by RecordRedirects.
+const (
+	EventRedirect     = "http.redirect"
+	AttrRedirectCount = "http.redirect_count"
+)
+
+// maxRedirects matches the limit of the default http.Client redirect policy.
+const maxRedirects = 10
+
+// ErrTooManyRedirects is returned by the default redirect policy of
+// RecordRedirects after 10 redirects, like the one of http.Client.
+var ErrTooManyRedirects = errors.New("stopped after 10 redirects")
+
+// RecordRedirects returns an http.Client CheckRedirect function that records each
+// redirect followed by the client on the caller's span: an [EventRedirect] event
+// with the redirect status and the redacted location, and the number of redirects
+// so far in [AttrRedirectCount]. Each hop gets its own client span, so without
+// this a redirect chain only shows up as unexplained latency on the caller.
+//
+// check decides whether to follow the redirect; if nil, the default policy of
+// http.Client (stop after 10 redirects) is used. Locations are redacted with rules.
+// [WithRedirectEvents] installs it on clients created by [NewClient].
+//
+// Usage:
+//
+//	client := &http.Client{
+//	    Transport:     otxhttp.Transport(nil),
+//	    CheckRedirect: otxhttp.RecordRedirects(nil, otxhttp.StripQuery()),
+//	}
+func RecordRedirects(
+	check func(req *http.Request, via []*http.Request) error,
+	rules ...RedactionRule,
+) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		span := trace.SpanFromContext(req.Context())
+		if span.IsRecording() {
+			attrs := []attribute.KeyValue{attribute.String("url.full", RedactURL(req.URL, rules...))}
+			if req.Response != nil {
+				attrs = append(attrs, attribute.Int("http.response.status_code", req.Response.StatusCode))
+			}
+			span.AddEvent(EventRedirect, trace.WithAttributes(attrs...))
+			span.SetAttributes(attribute.Int(AttrRedirectCount, len(via)))
+		}
+
+		if check != nil {
+			return check(req, via)
+		}
+		if len(via) >= maxRedirects {
+			return ErrTooManyRedirects
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestWithRedirectEvents(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/a", http.RedirectHandler("/b?token=secret", http.StatusFound))
+	mux.Handle("/b", http.RedirectHandler("/c", http.StatusMovedPermanently))
+	mux.HandleFunc("/c", func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := trace.NewTracerProvider(trace.WithSyncer(exporter))
+	client := NewClientWithProviders(tp, noop.NewMeterProvider(), propagation.TraceContext{},
+		WithRedirectEvents(true), WithURLRedaction(StripQuery()))
+
+	ctx, span := tp.Tracer("test").Start(t.Context(), "caller")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/a", nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	span.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 4, "one client span per hop plus the caller")
+	caller := spans[3]
+	assert.Equal(t, "caller", caller.Name)
+	assert.Contains(t, caller.Attributes, attribute.Int(AttrRedirectCount, 2))
+	require.Len(t, caller.Events, 2)
+	assert.Equal(t, EventRedirect, caller.Events[0].Name)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("url.full", server.URL+"/b"),
+		attribute.Int("http.response.status_code", http.StatusFound),
+	}, caller.Events[0].Attributes)
+	assert.Contains(t, caller.Events[1].Attributes,
+		attribute.Int("http.response.status_code", http.StatusMovedPermanently))
+}
+
+func TestRecordRedirects_Policy(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	assert.NoError(t, RecordRedirects(nil)(req, make([]*http.Request, 9)))
+	require.ErrorIs(t, RecordRedirects(nil)(req, make([]*http.Request, 10)), ErrTooManyRedirects)
+
+	stop := func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	assert.ErrorIs(t, RecordRedirects(stop)(req, make([]*http.Request, 1)), http.ErrUseLastResponse)
+}
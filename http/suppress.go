@@ -0,0 +1,76 @@
+package http
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SuppressUnsampled wraps a tracing middleware so that it is bypassed for
+// requests whose incoming parent span is valid but not sampled.
+//
+// Bypassed requests create no span, but the remote parent is still extracted
+// into the request context, so outbound calls keep propagating the trace (and
+// its unsampled flag). Requests without a parent, or with a sampled parent,
+// go through traced as usual.
+//
+// If prop is nil, the global TextMapPropagator is used.
+//
+// Usage:
+//
+//	mux.Handle("/hot", otxhttp.SuppressUnsampled(otxhttp.Middleware(), nil)(hotHandler))
+func SuppressUnsampled(
+	traced func(http.Handler) http.Handler,
+	prop propagation.TextMapPropagator,
+) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		tracedNext := traced(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := propagatorOrGlobal(prop).Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			if !isUnsampled(trace.SpanContextFromContext(ctx)) {
+				tracedNext.ServeHTTP(w, r)
+
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// suppressingTransport skips the traced transport when the caller's span is unsampled.
+type suppressingTransport struct {
+	traced http.RoundTripper
+	base   http.RoundTripper
+	prop   propagation.TextMapPropagator // nil means global
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *suppressingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if !isUnsampled(trace.SpanContextFromContext(r.Context())) {
+		return t.traced.RoundTrip(r)
+	}
+
+	// RoundTrippers must not modify the caller's request.
+	r = r.Clone(r.Context())
+	propagatorOrGlobal(t.prop).Inject(r.Context(), propagation.HeaderCarrier(r.Header))
+
+	return t.base.RoundTrip(r)
+}
+
+// isUnsampled reports whether sc is a valid parent that was not sampled.
+func isUnsampled(sc trace.SpanContext) bool {
+	return sc.IsValid() && !sc.IsSampled()
+}
+
+// propagatorOrGlobal returns prop, or the current global propagator if prop is nil.
+func propagatorOrGlobal(prop propagation.TextMapPropagator) propagation.TextMapPropagator {
+	if prop != nil {
+		return prop
+	}
+
+	return otel.GetTextMapPropagator()
+}
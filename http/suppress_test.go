@@ -0,0 +1,104 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	testTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testSpanID  = "00f067aa0ba902b7"
+)
+
+func TestSuppressUnsampled_Handler(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	prop := propagation.TraceContext{}
+
+	var gotSC trace.SpanContext
+	handler := SuppressUnsampled(MiddlewareWithProviders(tp, noop.NewMeterProvider(), prop), prop)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotSC = trace.SpanContextFromContext(r.Context())
+			w.WriteHeader(http.StatusOK)
+		}))
+
+	tests := []struct {
+		name        string
+		traceparent string
+		wantSpans   int
+	}{
+		{name: "unsampled parent", traceparent: "00-" + testTraceID + "-" + testSpanID + "-00", wantSpans: 0},
+		{name: "sampled parent", traceparent: "00-" + testTraceID + "-" + testSpanID + "-01", wantSpans: 1},
+		{name: "no parent", wantSpans: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter.Reset()
+			req := httptest.NewRequest(http.MethodGet, "/hot", nil)
+			if tt.traceparent != "" {
+				req.Header.Set("traceparent", tt.traceparent)
+			}
+
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Len(t, exporter.GetSpans(), tt.wantSpans)
+			require.True(t, gotSC.IsValid())
+			if tt.traceparent != "" {
+				assert.Equal(t, testTraceID, gotSC.TraceID().String(), "remote parent is still extracted")
+			}
+		})
+	}
+}
+
+func TestNewClientWithSuppressUnsampled(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	var gotTraceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTraceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClientWithProviders(tp, noop.NewMeterProvider(), propagation.TraceContext{},
+		WithSuppressUnsampled(true),
+	)
+
+	traceID, err := trace.TraceIDFromHex(testTraceID)
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex(testSpanID)
+	require.NoError(t, err)
+
+	get := func(flags trace.TraceFlags) {
+		ctx := trace.ContextWithSpanContext(t.Context(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     spanID,
+			TraceFlags: flags,
+		}))
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Empty(t, req.Header.Get("traceparent"), "caller's request must not be modified")
+	}
+
+	get(0)
+	assert.Empty(t, exporter.GetSpans())
+	assert.Equal(t, "00-"+testTraceID+"-"+testSpanID+"-00", gotTraceparent)
+
+	get(trace.FlagsSampled)
+	require.Len(t, exporter.GetSpans(), 1)
+	assert.Contains(t, gotTraceparent, testTraceID)
+	assert.NotContains(t, gotTraceparent, testSpanID, "client span becomes the parent")
+}
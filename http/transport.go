@@ -17,6 +17,9 @@ import (
 //
 // For explicit provider injection, use [TransportWithProviders] instead.
 //
+// Client spans get a peer.service attribute when the request host matches a rule
+// set with otx.SetPeerServices, e.g. from traces.peerServices.
+//
 // If base is nil, http.DefaultTransport is used.
 //
 // Usage:
@@ -29,7 +32,7 @@ func Transport(base http.RoundTripper, opts ...otelhttp.Option) http.RoundTrippe
 		base = http.DefaultTransport
 	}
 
-	return otelhttp.NewTransport(base, opts...)
+	return otelhttp.NewTransport(&peerServiceTransport{base: base}, opts...)
 }
 
 // TransportWithProviders wraps an http.RoundTripper with OTel tracing
@@ -41,6 +44,7 @@ func Transport(base http.RoundTripper, opts ...otelhttp.Option) http.RoundTrippe
 //   - You have multiple HTTP clients with different telemetry configurations
 //
 // If any provider is nil, the corresponding global provider will be used as fallback.
+// Client spans get peer.service like with [Transport].
 // If base is nil, http.DefaultTransport is used.
 //
 // Usage:
@@ -67,5 +71,5 @@ func TransportWithProviders(
 	allOpts := buildProviderOptions(tp, mp, prop)
 	allOpts = append(allOpts, opts...)
 
-	return otelhttp.NewTransport(base, allOpts...)
+	return otelhttp.NewTransport(&peerServiceTransport{base: base}, allOpts...)
 }
@@ -0,0 +1,111 @@
+package otx
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/arloliu/otx/internal/glob"
+)
+
+// DefaultIndexHintPrefix is the key prefix of index hint attributes when none is configured.
+const DefaultIndexHintPrefix = "index."
+
+// indexHintProcessor forwards spans to next with index hint copies of matching attributes.
+type indexHintProcessor struct {
+	next   sdktrace.SpanProcessor
+	prefix string
+	keys   *regexp.Regexp
+}
+
+// indexedSpan is an ended span whose attributes include index hint copies.
+type indexedSpan struct {
+	sdktrace.ReadOnlySpan
+
+	attrs []attribute.KeyValue
+}
+
+// NewIndexHintProcessor returns a SpanProcessor that forwards spans to next with
+// a copy of every attribute whose key matches one of keys, stored under prefix +
+// key. Backends that index selectively can then be configured once to index the
+// prefix, instead of teams managing prefixed keys by hand. Keys are glob patterns,
+// e.g. "tenant.id" or "order.*"; the original attributes are kept, and attributes
+// already set under the prefix are not overwritten.
+//
+// If prefix is empty, [DefaultIndexHintPrefix] is used. The copies are only added
+// to spans passed to next, so wrap the batch processor of an exporter.
+//
+// It is applied automatically by [NewTracerProvider] for traces.indexHints.
+//
+// Example:
+//
+//	tp := sdktrace.NewTracerProvider(
+//	    sdktrace.WithSpanProcessor(otx.NewIndexHintProcessor(
+//	        sdktrace.NewBatchSpanProcessor(exporter),
+//	        "idx.", "tenant.id", "order.*",
+//	    )),
+//	)
+func NewIndexHintProcessor(next sdktrace.SpanProcessor, prefix string, keys ...string) sdktrace.SpanProcessor {
+	if prefix == "" {
+		prefix = DefaultIndexHintPrefix
+	}
+	p := &indexHintProcessor{next: next, prefix: prefix}
+	if len(keys) > 0 {
+		p.keys = glob.ListRegexp(keys...)
+	}
+
+	return p
+}
+
+// OnStart implements sdktrace.SpanProcessor.
+func (p *indexHintProcessor) OnStart(ctx context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(ctx, s)
+}
+
+// OnEnd implements sdktrace.SpanProcessor.
+func (p *indexHintProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if p.keys == nil {
+		p.next.OnEnd(s)
+
+		return
+	}
+
+	attrs := s.Attributes()
+	var hints []attribute.KeyValue
+	for _, kv := range attrs {
+		key := string(kv.Key)
+		if !strings.HasPrefix(key, p.prefix) && p.keys.MatchString(key) {
+			hints = append(hints, attribute.KeyValue{Key: attribute.Key(p.prefix + key), Value: kv.Value})
+		}
+	}
+	// Attributes already set under the prefix win over their copies.
+	hints = slices.DeleteFunc(hints, func(hint attribute.KeyValue) bool {
+		return slices.ContainsFunc(attrs, func(kv attribute.KeyValue) bool { return kv.Key == hint.Key })
+	})
+	if len(hints) == 0 {
+		p.next.OnEnd(s)
+
+		return
+	}
+
+	p.next.OnEnd(&indexedSpan{ReadOnlySpan: s, attrs: slices.Concat(attrs, hints)})
+}
+
+// Shutdown implements sdktrace.SpanProcessor.
+func (p *indexHintProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush implements sdktrace.SpanProcessor.
+func (p *indexHintProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+// Attributes returns the span attributes followed by the index hint copies.
+func (s *indexedSpan) Attributes() []attribute.KeyValue {
+	return s.attrs
+}
@@ -0,0 +1,60 @@
+package otx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestIndexHintProcessor(t *testing.T) {
+	indexed := tracetest.NewInMemoryExporter()
+	plain := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(NewIndexHintProcessor(
+			sdktrace.NewSimpleSpanProcessor(indexed), "", "tenant.id", "order.*")),
+		sdktrace.WithSpanProcessor(sdktrace.NewSimpleSpanProcessor(plain)),
+	)
+
+	_, span := tp.Tracer("test").Start(t.Context(), "checkout")
+	span.SetAttributes(
+		attribute.String("tenant.id", "acme"),
+		attribute.Int("order.items", 3),
+		attribute.String("user.id", "u1"),
+		attribute.String("index.tenant.id", "preset"),
+	)
+	span.End()
+	_, span = tp.Tracer("test").Start(t.Context(), "no-hints")
+	span.End()
+
+	spans := indexed.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("tenant.id", "acme"),
+		attribute.Int("order.items", 3),
+		attribute.String("user.id", "u1"),
+		attribute.String("index.tenant.id", "preset"),
+		attribute.Int("index.order.items", 3),
+	}, spans[0].Attributes)
+	assert.Empty(t, spans[1].Attributes)
+
+	require.Len(t, plain.GetSpans(), 2)
+	assert.Len(t, plain.GetSpans()[0].Attributes, 4, "other processors see the original attributes")
+}
+
+func TestIndexHintProcessor_Prefix(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(
+		NewIndexHintProcessor(sdktrace.NewSimpleSpanProcessor(exporter), "idx_", "tenant.id")))
+
+	_, span := tp.Tracer("test").Start(t.Context(), "checkout")
+	span.SetAttributes(attribute.String("tenant.id", "acme"))
+	span.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.True(t, hasAttribute(spans[0].Attributes, attribute.String("idx_tenant.id", "acme")))
+}
@@ -0,0 +1,61 @@
+// Package baggageattr turns baggage members into span attributes. It backs
+// CopyBaggageToSpan, the error baggage of RecordError, the baggage attribute
+// processor and the NATS process spans, so they select and name members alike.
+package baggageattr
+
+import (
+	"regexp"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+
+	"github.com/arloliu/otx/internal/glob"
+)
+
+// Selector selects baggage members by key. A nil Selector selects none.
+type Selector struct {
+	literal []string       // Keys looked up directly when no pattern has wildcards
+	pattern *regexp.Regexp // Set when any pattern has wildcards
+}
+
+// New returns a Selector for keys, glob patterns where "*" matches any sequence of
+// characters and "?" one character. It returns nil without keys.
+func New(keys ...string) *Selector {
+	if len(keys) == 0 {
+		return nil
+	}
+	if slices.IndexFunc(keys, func(k string) bool { return !glob.IsLiteral(k) }) >= 0 {
+		return &Selector{pattern: glob.ListRegexp(keys...)}
+	}
+
+	return &Selector{literal: slices.Clone(keys)}
+}
+
+// Attributes returns the selected members of bag as string attributes keyed by
+// prefix followed by the member key. Missing members are skipped.
+func (s *Selector) Attributes(bag baggage.Baggage, prefix string) []attribute.KeyValue {
+	if s == nil || bag.Len() == 0 {
+		return nil
+	}
+
+	var attrs []attribute.KeyValue
+	if s.pattern == nil {
+		// Literal keys skip listing every member, as this runs for every span.
+		for _, key := range s.literal {
+			if member := bag.Member(key); member.Key() != "" {
+				attrs = append(attrs, attribute.String(prefix+key, member.Value()))
+			}
+		}
+
+		return attrs
+	}
+
+	for _, member := range bag.Members() {
+		if s.pattern.MatchString(member.Key()) {
+			attrs = append(attrs, attribute.String(prefix+member.Key(), member.Value()))
+		}
+	}
+
+	return attrs
+}
@@ -0,0 +1,38 @@
+// Package glob compiles the glob patterns accepted across otx, where "*" matches
+// any sequence of characters and "?" matches one character, into regular
+// expressions matching whole strings.
+package glob
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Expr converts a glob pattern into an unanchored regular expression.
+func Expr(pattern string) string {
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+
+	return strings.ReplaceAll(expr, `\?`, ".")
+}
+
+// Regexp compiles a glob pattern matching whole strings.
+func Regexp(pattern string) *regexp.Regexp {
+	return regexp.MustCompile("^" + Expr(pattern) + "$")
+}
+
+// ListRegexp compiles glob patterns into one expression matching whole strings
+// that match any of them.
+func ListRegexp(patterns ...string) *regexp.Regexp {
+	exprs := make([]string, 0, len(patterns))
+	for _, p := range patterns {
+		exprs = append(exprs, Expr(p))
+	}
+
+	return regexp.MustCompile("^(?:" + strings.Join(exprs, "|") + ")$")
+}
+
+// IsLiteral reports whether pattern has no wildcards, so it only matches itself.
+func IsLiteral(pattern string) bool {
+	return !strings.ContainsAny(pattern, "*?")
+}
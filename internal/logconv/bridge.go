@@ -0,0 +1,114 @@
+package logconv
+
+import (
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+)
+
+// Source code attribute keys, recorded by the bridges when the logger reports the
+// caller or a stack trace.
+const (
+	AttrCodeFunction   = "code.function.name"
+	AttrCodeFile       = "code.file.path"
+	AttrCodeLine       = "code.line.number"
+	AttrCodeStacktrace = "code.stacktrace"
+)
+
+// Options holds the options shared by the bridges. Each bridge embeds it in its
+// own options, so WithLoggerName and WithVersion apply to them.
+type Options struct {
+	LoggerName string // Instrumentation scope name
+	Version    string // Instrumentation scope version, empty for none
+}
+
+// optionsPtr is a pointer to bridge options embedding Options.
+type optionsPtr[T any] interface {
+	*T
+	shared() *Options
+}
+
+// WithLoggerName returns an option of the bridge options T setting the
+// instrumentation scope name.
+func WithLoggerName[T any, P optionsPtr[T]](name string) func(*T) {
+	return func(o *T) {
+		P(o).shared().LoggerName = name
+	}
+}
+
+// WithVersion returns an option of the bridge options T setting the
+// instrumentation scope version.
+func WithVersion[T any, P optionsPtr[T]](version string) func(*T) {
+	return func(o *T) {
+		P(o).shared().Version = version
+	}
+}
+
+// Apply returns o with opts applied in order.
+func Apply[T any, O ~func(*T)](o T, opts []O) T {
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// Severity maps the lowercase name of a level of zap, logrus or zerolog to an OTel
+// severity. Levels stopping the program map to the fatal range, ordered by
+// severity: dpanic to Fatal1, panic to Fatal2 and fatal to Fatal3. Unknown names
+// map to SeverityUndefined.
+func Severity(level string) log.Severity {
+	switch level {
+	case "trace":
+		return log.SeverityTrace
+	case "debug":
+		return log.SeverityDebug
+	case "info":
+		return log.SeverityInfo
+	case "warn", "warning":
+		return log.SeverityWarn
+	case "error":
+		return log.SeverityError
+	case "dpanic":
+		return log.SeverityFatal1
+	case "panic":
+		return log.SeverityFatal2
+	case "fatal":
+		return log.SeverityFatal3
+	default:
+		return log.SeverityUndefined
+	}
+}
+
+// SeverityAt returns the severity offset levels above SeverityInfo, clamped to the
+// range of valid severities, for loggers with numeric levels such as slog.
+func SeverityAt(offset int) log.Severity {
+	s := int(log.SeverityInfo) + offset
+	switch {
+	case s < int(log.SeverityTrace1):
+		return log.SeverityTrace1
+	case s > int(log.SeverityFatal4):
+		return log.SeverityFatal4
+	default:
+		return log.Severity(s)
+	}
+}
+
+// Logger returns the logger of the scope described by o from lp, or from the
+// global LoggerProvider if lp is nil.
+func (o *Options) Logger(lp log.LoggerProvider) log.Logger {
+	if lp == nil {
+		lp = global.GetLoggerProvider()
+	}
+
+	var loggerOpts []log.LoggerOption
+	if o.Version != "" {
+		loggerOpts = append(loggerOpts, log.WithInstrumentationVersion(o.Version))
+	}
+
+	return lp.Logger(o.LoggerName, loggerOpts...)
+}
+
+// shared implements optionsPtr for the bridge options embedding o.
+func (o *Options) shared() *Options {
+	return o
+}
@@ -0,0 +1,117 @@
+// Package logconv converts the field values of third-party loggers into OTel log
+// values, shared by the log bridges so they encode values the same way.
+package logconv
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// Value converts a field value. Durations are nanoseconds and times Unix
+// nanoseconds, as in the OTel slog bridge. Unsigned integers overflowing int64
+// are strings, JSON numbers stay integers when they are, maps are sorted by key,
+// and other values are formatted with %+v.
+func Value(v any) log.Value {
+	switch v := v.(type) {
+	case nil:
+		return log.Value{}
+	case string:
+		return log.StringValue(v)
+	case bool:
+		return log.BoolValue(v)
+	case int:
+		return log.IntValue(v)
+	case int8:
+		return log.Int64Value(int64(v))
+	case int16:
+		return log.Int64Value(int64(v))
+	case int32:
+		return log.Int64Value(int64(v))
+	case int64:
+		return log.Int64Value(v)
+	case uint8:
+		return log.Int64Value(int64(v))
+	case uint16:
+		return log.Int64Value(int64(v))
+	case uint32:
+		return log.Int64Value(int64(v))
+	case uint:
+		return Uint(uint64(v))
+	case uint64:
+		return Uint(v)
+	case uintptr:
+		return Uint(uint64(v))
+	case float32:
+		return log.Float64Value(float64(v))
+	case float64:
+		return log.Float64Value(v)
+	case json.Number:
+		return jsonNumber(v)
+	case time.Duration:
+		return log.Int64Value(v.Nanoseconds())
+	case time.Time:
+		return log.Int64Value(v.UnixNano())
+	case []byte:
+		return log.BytesValue(v)
+	case []string:
+		values := make([]log.Value, 0, len(v))
+		for _, s := range v {
+			values = append(values, log.StringValue(s))
+		}
+
+		return log.SliceValue(values...)
+	case []any:
+		values := make([]log.Value, 0, len(v))
+		for _, e := range v {
+			values = append(values, Value(e))
+		}
+
+		return log.SliceValue(values...)
+	case map[string]any:
+		return log.MapValue(Map(v)...)
+	case error:
+		return log.StringValue(v.Error())
+	case fmt.Stringer:
+		return log.StringValue(v.String())
+	default:
+		return log.StringValue(fmt.Sprintf("%+v", v))
+	}
+}
+
+// Map converts the fields of m, sorted by key.
+func Map(m map[string]any) []log.KeyValue {
+	kvs := make([]log.KeyValue, 0, len(m))
+	for _, key := range slices.Sorted(maps.Keys(m)) {
+		kvs = append(kvs, log.KeyValue{Key: key, Value: Value(m[key])})
+	}
+
+	return kvs
+}
+
+// Uint converts an unsigned integer, as a string if it overflows int64.
+func Uint(u uint64) log.Value {
+	if u > math.MaxInt64 {
+		return log.StringValue(fmt.Sprint(u))
+	}
+
+	return log.Int64Value(int64(u))
+}
+
+// jsonNumber converts a decoded JSON number: integers stay integers, other
+// numbers are floats.
+func jsonNumber(n json.Number) log.Value {
+	if i, err := n.Int64(); err == nil {
+		return log.Int64Value(i)
+	}
+	if f, err := n.Float64(); err == nil {
+		return log.Float64Value(f)
+	}
+
+	return log.StringValue(n.String())
+}
@@ -0,0 +1,59 @@
+// Package logtest provides test fixtures shared by the log bridges.
+package logtest
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// Recorder is a log processor keeping the emitted records.
+type Recorder struct {
+	// MinLevel is the lowest severity reported as enabled.
+	MinLevel log.Severity
+
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+// OnEmit implements sdklog.Processor.
+func (r *Recorder) OnEmit(_ context.Context, record *sdklog.Record) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, record.Clone())
+
+	return nil
+}
+
+// Enabled implements sdklog.Processor.
+func (r *Recorder) Enabled(_ context.Context, param sdklog.EnabledParameters) bool {
+	return param.Severity >= r.MinLevel
+}
+
+// Shutdown implements sdklog.Processor.
+func (r *Recorder) Shutdown(context.Context) error { return nil }
+
+// ForceFlush implements sdklog.Processor.
+func (r *Recorder) ForceFlush(context.Context) error { return nil }
+
+// Records returns the emitted records.
+func (r *Recorder) Records() []sdklog.Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]sdklog.Record(nil), r.records...)
+}
+
+// Attributes returns the attributes of r by key.
+func Attributes(r sdklog.Record) map[string]log.Value {
+	attrs := make(map[string]log.Value, r.AttributesLen())
+	r.WalkAttributes(func(kv log.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+
+		return true
+	})
+
+	return attrs
+}
@@ -0,0 +1,55 @@
+package peerservice
+
+import (
+	"regexp"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Key is the attribute naming the remote service of a client span.
+const Key = attribute.Key("peer.service")
+
+// Rule maps the hosts matching Host to Service.
+type Rule struct {
+	Host    *regexp.Regexp
+	Service string
+}
+
+var global atomic.Pointer[[]Rule]
+
+// Set replaces the global rules. Rules are tried in order.
+func Set(rules []Rule) {
+	if len(rules) == 0 {
+		global.Store(nil)
+
+		return
+	}
+	global.Store(&rules)
+}
+
+// Lookup returns the service of the first rule matching host, or "" if none does.
+func Lookup(host string) string {
+	rules := global.Load()
+	if rules == nil || host == "" {
+		return ""
+	}
+	for _, r := range *rules {
+		if r.Host.MatchString(host) {
+			return r.Service
+		}
+	}
+
+	return ""
+}
+
+// Attribute returns the peer.service attribute for host, reporting false if no
+// rule matches.
+func Attribute(host string) (attribute.KeyValue, bool) {
+	service := Lookup(host)
+	if service == "" {
+		return attribute.KeyValue{}, false
+	}
+
+	return Key.String(service), true
+}
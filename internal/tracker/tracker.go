@@ -4,6 +4,7 @@ import (
 	"context"
 	"sync/atomic"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -12,6 +13,18 @@ type Namer interface {
 	Name(string) string
 }
 
+// KindInferrer is implemented by namers that also choose the span kind.
+// SpanKindUnspecified leaves the kind to the start options.
+type KindInferrer interface {
+	SpanKind(string) trace.SpanKind
+}
+
+// AttributeNamer is implemented by namers that also use the attributes of the
+// start options to format span names.
+type AttributeNamer interface {
+	NameWithAttributes(string, []attribute.KeyValue) string
+}
+
 type defaultNamer struct{}
 
 func (defaultNamer) Name(s string) string { return s }
@@ -38,13 +51,40 @@ func Set(t trace.Tracer, n Namer) {
 
 // Start begins a new span using the global tracer and namer.
 // If no tracer is configured, it returns the current span from context (no-op).
+// A kind inferred by the namer is applied before opts, so explicit kinds win.
 func Start(ctx context.Context, operation string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
 	s := global.Load()
 	if s.tracer == nil {
 		return ctx, trace.SpanFromContext(ctx)
 	}
+	if inferrer, ok := s.namer.(KindInferrer); ok {
+		if kind := inferrer.SpanKind(operation); kind != trace.SpanKindUnspecified {
+			opts = append([]trace.SpanStartOption{trace.WithSpanKind(kind)}, opts...)
+		}
+	}
+
+	return s.tracer.Start(ctx, spanName(s.namer, operation, opts), opts...)
+}
+
+// spanName formats the name of a span started with opts for operation.
+func spanName(n Namer, operation string, opts []trace.SpanStartOption) string {
+	if an, ok := n.(AttributeNamer); ok {
+		cfg := trace.NewSpanStartConfig(opts...)
+
+		return an.NameWithAttributes(operation, cfg.Attributes())
+	}
+
+	return n.Name(operation)
+}
+
+// InferKind returns the span kind the namer infers for operation, or
+// SpanKindUnspecified if it does not choose one.
+func InferKind(operation string) trace.SpanKind {
+	if inferrer, ok := global.Load().namer.(KindInferrer); ok {
+		return inferrer.SpanKind(operation)
+	}
 
-	return s.tracer.Start(ctx, s.namer.Name(operation), opts...)
+	return trace.SpanKindUnspecified
 }
 
 // Tracer returns the configured global tracer, or nil if not set.
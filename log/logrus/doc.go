@@ -0,0 +1,28 @@
+// Package logrus bridges github.com/sirupsen/logrus to the OTel logs pipeline
+// set up by otx.NewLoggerProvider, for services not yet moved to slog or zap.
+//
+// # Basic Usage
+//
+// Add the hook to the logger; its formatter and output keep working as before:
+//
+//	lp, err := otx.NewLoggerProvider(ctx, cfg)
+//	if err != nil {
+//	    return err
+//	}
+//	defer lp.Shutdown(ctx)
+//
+//	logrus.AddHook(otxlogrus.NewHook())
+//
+// # Trace Correlation
+//
+// Entries logged with a context, e.g. through logrus.WithContext, get the trace
+// and span IDs of its span:
+//
+//	logrus.WithContext(ctx).WithField("order.id", id).Info("order accepted")
+//
+// # Fields
+//
+// Fields keep their types; durations are nanoseconds, times Unix nanoseconds,
+// and errors and other values their string form. The caller recorded with
+// SetReportCaller(true) is recorded as code.* attributes.
+package logrus
@@ -0,0 +1,128 @@
+package logrus
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/arloliu/otx/internal/logconv"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/log"
+)
+
+const instrumentationName = "github.com/arloliu/otx/log/logrus"
+
+// Attribute keys of the caller, recorded when the logger reports it with
+// SetReportCaller(true).
+const (
+	AttrCodeFunction = logconv.AttrCodeFunction
+	AttrCodeFile     = logconv.AttrCodeFile
+	AttrCodeLine     = logconv.AttrCodeLine
+)
+
+// options holds configuration for the hook.
+type options struct {
+	logconv.Options
+
+	levels []logrus.Level
+}
+
+// Option configures a Hook.
+type Option func(*options)
+
+// WithLoggerName sets the instrumentation scope name of the OTel logger.
+// Default is the package import path.
+func WithLoggerName(name string) Option {
+	return logconv.WithLoggerName[options](name)
+}
+
+// WithVersion sets the instrumentation scope version of the OTel logger.
+func WithVersion(version string) Option {
+	return logconv.WithVersion[options](version)
+}
+
+// WithLevels sets the levels the hook fires for. Default is logrus.AllLevels;
+// the logger level still applies first.
+func WithLevels(levels ...logrus.Level) Option {
+	return func(o *options) {
+		o.levels = levels
+	}
+}
+
+// Hook is a logrus.Hook emitting log entries to an OTel LoggerProvider.
+type Hook struct {
+	logger log.Logger
+	levels []logrus.Level
+}
+
+var _ logrus.Hook = (*Hook)(nil)
+
+// NewHook returns a Hook emitting to the global LoggerProvider, the one installed
+// by otx.NewLoggerProvider.
+//
+// Usage:
+//
+//	lp, err := otx.NewLoggerProvider(ctx, cfg)
+//	if err != nil {
+//	    return err
+//	}
+//	defer lp.Shutdown(ctx)
+//	logrus.AddHook(otxlogrus.NewHook())
+func NewHook(opts ...Option) *Hook {
+	return NewHookWithProvider(nil, opts...)
+}
+
+// NewHookWithProvider returns a Hook emitting to lp.
+// If lp is nil, the global LoggerProvider is used.
+func NewHookWithProvider(lp log.LoggerProvider, opts ...Option) *Hook {
+	o := logconv.Apply(options{
+		Options: logconv.Options{LoggerName: instrumentationName},
+		levels:  logrus.AllLevels,
+	}, opts)
+
+	return &Hook{
+		logger: o.Logger(lp),
+		levels: o.levels,
+	}
+}
+
+// Levels implements logrus.Hook.
+func (h *Hook) Levels() []logrus.Level {
+	return h.levels
+}
+
+// Fire implements logrus.Hook. Entries are emitted with their context, so they
+// get the trace and span IDs of its span. Entries dropped by the processors of
+// the LoggerProvider are not converted.
+func (h *Hook) Fire(entry *logrus.Entry) error {
+	ctx := entry.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	level := entry.Level.String()
+	sev := logconv.Severity(level)
+	if !h.logger.Enabled(ctx, log.EnabledParameters{Severity: sev}) {
+		return nil
+	}
+
+	var record log.Record
+	record.SetTimestamp(entry.Time)
+	record.SetSeverity(sev)
+	record.SetSeverityText(level)
+	record.SetBody(log.StringValue(entry.Message))
+
+	for _, key := range slices.Sorted(maps.Keys(entry.Data)) {
+		record.AddAttributes(log.KeyValue{Key: key, Value: logconv.Value(entry.Data[key])})
+	}
+	if entry.HasCaller() {
+		record.AddAttributes(
+			log.String(AttrCodeFunction, entry.Caller.Function),
+			log.String(AttrCodeFile, entry.Caller.File),
+			log.Int(AttrCodeLine, entry.Caller.Line),
+		)
+	}
+
+	h.logger.Emit(ctx, record)
+
+	return nil
+}
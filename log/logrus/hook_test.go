@@ -0,0 +1,140 @@
+package logrus
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/arloliu/otx/internal/logconv"
+	"github.com/arloliu/otx/internal/logtest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func newLogger(t *testing.T, opts ...Option) (*logrus.Logger, *logtest.Recorder) {
+	t.Helper()
+
+	rec := &logtest.Recorder{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(rec))
+	t.Cleanup(func() { _ = lp.Shutdown(context.Background()) })
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.SetLevel(logrus.TraceLevel)
+	logger.AddHook(NewHookWithProvider(lp, opts...))
+
+	return logger, rec
+}
+
+func TestHook_Record(t *testing.T) {
+	logger, rec := newLogger(t)
+
+	logger.WithFields(logrus.Fields{"disk.free": 42, "disk.path": "/var"}).Warn("disk almost full")
+
+	records := rec.Records()
+	require.Len(t, records, 1)
+	r := records[0]
+	assert.Equal(t, "disk almost full", r.Body().AsString())
+	assert.Equal(t, log.SeverityWarn, r.Severity())
+	assert.Equal(t, "warning", r.SeverityText())
+	assert.False(t, r.Timestamp().IsZero())
+	assert.Equal(t, "github.com/arloliu/otx/log/logrus", r.InstrumentationScope().Name)
+
+	attrs := logtest.Attributes(r)
+	assert.Equal(t, int64(42), attrs["disk.free"].AsInt64())
+	assert.Equal(t, "/var", attrs["disk.path"].AsString())
+}
+
+func TestHook_Context(t *testing.T) {
+	logger, rec := newLogger(t)
+	tp := sdktrace.NewTracerProvider()
+	ctx, span := tp.Tracer("test").Start(t.Context(), "op")
+	defer span.End()
+
+	logger.WithContext(ctx).Info("with span")
+	logger.Info("without span")
+
+	records := rec.Records()
+	require.Len(t, records, 2)
+	assert.Equal(t, span.SpanContext().TraceID(), records[0].TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), records[0].SpanID())
+	assert.False(t, records[1].TraceID().IsValid())
+}
+
+func TestHook_Levels(t *testing.T) {
+	logger, rec := newLogger(t, WithLevels(logrus.ErrorLevel, logrus.WarnLevel))
+	rec.MinLevel = log.SeverityWarn
+
+	logger.Info("not a hook level")
+	logger.Warn("kept")
+	logger.Error("kept")
+
+	records := rec.Records()
+	require.Len(t, records, 2)
+	assert.Equal(t, log.SeverityError, records[1].Severity())
+
+	logger, rec = newLogger(t)
+	rec.MinLevel = log.SeverityInfo
+	logger.Debug("disabled by the provider")
+	assert.Empty(t, rec.Records())
+}
+
+func TestHook_Fields(t *testing.T) {
+	logger, rec := newLogger(t)
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	logger.WithError(errors.New("boom")).WithFields(logrus.Fields{
+		"uint":     uint64(7),
+		"float":    1.5,
+		"bool":     true,
+		"duration": time.Second,
+		"time":     ts,
+		"bytes":    []byte("raw"),
+		"strings":  []string{"a", "b"},
+		"struct":   struct{ A int }{A: 1},
+	}).Info("fields")
+
+	records := rec.Records()
+	require.Len(t, records, 1)
+	attrs := logtest.Attributes(records[0])
+	assert.Equal(t, "boom", attrs[logrus.ErrorKey].AsString())
+	assert.Equal(t, int64(7), attrs["uint"].AsInt64())
+	assert.InDelta(t, 1.5, attrs["float"].AsFloat64(), 0)
+	assert.True(t, attrs["bool"].AsBool())
+	assert.Equal(t, time.Second.Nanoseconds(), attrs["duration"].AsInt64())
+	assert.Equal(t, ts.UnixNano(), attrs["time"].AsInt64())
+	assert.Equal(t, []byte("raw"), attrs["bytes"].AsBytes())
+	assert.Equal(t, []log.Value{log.StringValue("a"), log.StringValue("b")}, attrs["strings"].AsSlice())
+	assert.Equal(t, "{A:1}", attrs["struct"].AsString())
+}
+
+func TestHook_Caller(t *testing.T) {
+	logger, rec := newLogger(t, WithLoggerName("orders"), WithVersion("1.2.3"))
+	logger.SetReportCaller(true)
+
+	logger.Error("failed")
+
+	records := rec.Records()
+	require.Len(t, records, 1)
+	assert.Equal(t, "orders", records[0].InstrumentationScope().Name)
+	assert.Equal(t, "1.2.3", records[0].InstrumentationScope().Version)
+
+	attrs := logtest.Attributes(records[0])
+	assert.Contains(t, attrs[AttrCodeFunction].AsString(), "TestHook_Caller")
+	assert.Contains(t, attrs[AttrCodeFile].AsString(), "hook_test.go")
+	assert.Positive(t, attrs[AttrCodeLine].AsInt64())
+}
+
+func TestSeverity(t *testing.T) {
+	assert.Equal(t, log.SeverityTrace, logconv.Severity(logrus.TraceLevel.String()))
+	assert.Equal(t, log.SeverityInfo, logconv.Severity(logrus.InfoLevel.String()))
+	assert.Equal(t, log.SeverityFatal2, logconv.Severity(logrus.PanicLevel.String()))
+	assert.Equal(t, log.SeverityFatal3, logconv.Severity(logrus.FatalLevel.String()))
+	assert.Equal(t, log.SeverityUndefined, logconv.Severity(logrus.Level(42).String()))
+}
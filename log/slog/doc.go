@@ -0,0 +1,31 @@
+// Package slog bridges the standard library log/slog package to the OTel logs
+// pipeline set up by otx.NewLoggerProvider.
+//
+// # Basic Usage
+//
+// Install the handler as the default slog handler once the LoggerProvider is
+// created:
+//
+//	lp, err := otx.NewLoggerProvider(ctx, cfg)
+//	if err != nil {
+//	    return err
+//	}
+//	defer lp.Shutdown(ctx)
+//
+//	slog.SetDefault(slog.New(otxslog.NewHandler()))
+//
+// # Trace Correlation
+//
+// Records logged with a context carrying a span get its trace and span IDs,
+// so the backend links logs to traces without extra attributes:
+//
+//	ctx, span := otx.Start(ctx, "ProcessOrder")
+//	defer span.End()
+//	slog.InfoContext(ctx, "order accepted", "order.id", id)
+//
+// # Attributes
+//
+// Attributes keep their types; groups become map values, durations are
+// nanoseconds, times Unix nanoseconds, and errors and other values their string
+// form. Use WithSource to record the source location of each log call.
+package slog
@@ -0,0 +1,174 @@
+package slog
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+
+	"github.com/arloliu/otx/internal/logconv"
+	"go.opentelemetry.io/otel/log"
+)
+
+const instrumentationName = "github.com/arloliu/otx/log/slog"
+
+// Source location attribute keys, recorded when WithSource is enabled.
+const (
+	AttrCodeFunction = logconv.AttrCodeFunction
+	AttrCodeFile     = logconv.AttrCodeFile
+	AttrCodeLine     = logconv.AttrCodeLine
+)
+
+// options holds configuration for the handler.
+type options struct {
+	logconv.Options
+
+	source bool
+}
+
+// Option configures a Handler.
+type Option func(*options)
+
+// WithLoggerName sets the instrumentation scope name of the OTel logger.
+// Default is the package import path.
+func WithLoggerName(name string) Option {
+	return logconv.WithLoggerName[options](name)
+}
+
+// WithVersion sets the instrumentation scope version of the OTel logger.
+func WithVersion(version string) Option {
+	return logconv.WithVersion[options](version)
+}
+
+// WithSource records the source location of the log call as code.function.name,
+// code.file.path and code.line.number attributes. Default is false.
+func WithSource(enabled bool) Option {
+	return func(o *options) {
+		o.source = enabled
+	}
+}
+
+// Handler is a slog.Handler emitting log records to an OTel LoggerProvider.
+//
+// The trace and span IDs of the span in the logging context are set on the
+// emitted records, so logs written with the Context variants of slog, such as
+// InfoContext, are correlated with the active trace.
+type Handler struct {
+	logger log.Logger
+	source bool
+
+	// attrs and groups added with WithAttrs and WithGroup, oldest first
+	goas []groupOrAttrs
+}
+
+// groupOrAttrs is either a group name or attributes added to a Handler.
+type groupOrAttrs struct {
+	group string
+	attrs []log.KeyValue
+}
+
+var _ slog.Handler = (*Handler)(nil)
+
+// NewHandler returns a Handler emitting to the global LoggerProvider, the one
+// installed by otx.NewLoggerProvider.
+//
+// Usage:
+//
+//	lp, err := otx.NewLoggerProvider(ctx, cfg)
+//	if err != nil {
+//	    return err
+//	}
+//	defer lp.Shutdown(ctx)
+//	slog.SetDefault(slog.New(otxslog.NewHandler()))
+//
+//	slog.InfoContext(ctx, "order created", "order.id", id) // carries trace_id and span_id
+func NewHandler(opts ...Option) *Handler {
+	return NewHandlerWithProvider(nil, opts...)
+}
+
+// NewHandlerWithProvider returns a Handler emitting to lp.
+// If lp is nil, the global LoggerProvider is used.
+func NewHandlerWithProvider(lp log.LoggerProvider, opts ...Option) *Handler {
+	o := logconv.Apply(options{Options: logconv.Options{LoggerName: instrumentationName}}, opts)
+
+	return &Handler{
+		logger: o.Logger(lp),
+		source: o.source,
+	}
+}
+
+// Enabled implements slog.Handler. It reports whether the LoggerProvider
+// processes records of level, e.g. false when a processor filters them out.
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.logger.Enabled(ctx, log.EnabledParameters{Severity: logconv.SeverityAt(int(level))})
+}
+
+// Handle implements slog.Handler.
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	var record log.Record
+	record.SetTimestamp(r.Time)
+	record.SetSeverity(logconv.SeverityAt(int(r.Level)))
+	record.SetSeverityText(r.Level.String())
+	record.SetBody(log.StringValue(r.Message))
+
+	kvs := make([]log.KeyValue, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		kvs = appendAttr(kvs, a)
+
+		return true
+	})
+	for i := len(h.goas) - 1; i >= 0; i-- {
+		goa := h.goas[i]
+		if goa.group == "" {
+			kvs = append(goa.attrs[:len(goa.attrs):len(goa.attrs)], kvs...)
+			continue
+		}
+		// Empty groups are omitted, as with the slog handlers
+		if len(kvs) > 0 {
+			kvs = []log.KeyValue{log.Map(goa.group, kvs...)}
+		}
+	}
+	record.AddAttributes(kvs...)
+
+	if h.source && r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		record.AddAttributes(
+			log.String(AttrCodeFunction, frame.Function),
+			log.String(AttrCodeFile, frame.File),
+			log.Int(AttrCodeLine, frame.Line),
+		)
+	}
+
+	h.logger.Emit(ctx, record)
+
+	return nil
+}
+
+// WithAttrs implements slog.Handler.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	kvs := make([]log.KeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		kvs = appendAttr(kvs, a)
+	}
+	if len(kvs) == 0 {
+		return h
+	}
+
+	return h.with(groupOrAttrs{attrs: kvs})
+}
+
+// WithGroup implements slog.Handler.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return h.with(groupOrAttrs{group: name})
+}
+
+// with returns a copy of h with goa appended.
+func (h *Handler) with(goa groupOrAttrs) *Handler {
+	h2 := *h
+	h2.goas = append(h.goas[:len(h.goas):len(h.goas)], goa)
+
+	return &h2
+}
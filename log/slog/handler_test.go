@@ -0,0 +1,158 @@
+package slog
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/arloliu/otx/internal/logconv"
+	"github.com/arloliu/otx/internal/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func newLogger(t *testing.T, opts ...Option) (*slog.Logger, *logtest.Recorder) {
+	t.Helper()
+
+	rec := &logtest.Recorder{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(rec))
+	t.Cleanup(func() { _ = lp.Shutdown(context.Background()) })
+
+	return slog.New(NewHandlerWithProvider(lp, opts...)), rec
+}
+
+func TestHandler_Record(t *testing.T) {
+	logger, rec := newLogger(t)
+
+	logger.Warn("disk almost full", "disk.free", 42, "disk.path", "/var")
+
+	records := rec.Records()
+	require.Len(t, records, 1)
+	r := records[0]
+	assert.Equal(t, "disk almost full", r.Body().AsString())
+	assert.Equal(t, log.SeverityWarn, r.Severity())
+	assert.Equal(t, "WARN", r.SeverityText())
+	assert.False(t, r.Timestamp().IsZero())
+	assert.Equal(t, "github.com/arloliu/otx/log/slog", r.InstrumentationScope().Name)
+
+	attrs := logtest.Attributes(r)
+	assert.Equal(t, int64(42), attrs["disk.free"].AsInt64())
+	assert.Equal(t, "/var", attrs["disk.path"].AsString())
+}
+
+func TestHandler_TraceContext(t *testing.T) {
+	logger, rec := newLogger(t)
+	tp := sdktrace.NewTracerProvider()
+	ctx, span := tp.Tracer("test").Start(t.Context(), "op")
+	defer span.End()
+
+	logger.InfoContext(ctx, "with span")
+	logger.Info("without span")
+
+	records := rec.Records()
+	require.Len(t, records, 2)
+	assert.Equal(t, span.SpanContext().TraceID(), records[0].TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), records[0].SpanID())
+	assert.False(t, records[1].TraceID().IsValid())
+}
+
+func TestHandler_Enabled(t *testing.T) {
+	logger, rec := newLogger(t)
+	rec.MinLevel = log.SeverityInfo
+
+	assert.False(t, logger.Enabled(t.Context(), slog.LevelDebug))
+	assert.True(t, logger.Enabled(t.Context(), slog.LevelInfo))
+}
+
+func TestHandler_AttrsAndGroups(t *testing.T) {
+	logger, rec := newLogger(t)
+
+	logger.With("service", "orders").
+		WithGroup("request").With("method", "GET").
+		WithGroup("empty").
+		Info("handled", "status", 200, slog.Group("", slog.String("inlined", "yes")))
+	logger.WithGroup("unused").Info("no attrs")
+
+	records := rec.Records()
+	require.Len(t, records, 2)
+
+	attrs := logtest.Attributes(records[0])
+	assert.Equal(t, "orders", attrs["service"].AsString())
+	require.Equal(t, log.KindMap, attrs["request"].Kind())
+	request := attrs["request"].AsMap()
+	require.Len(t, request, 2)
+	assert.Equal(t, log.String("method", "GET"), request[0])
+	assert.Equal(t, "empty", request[1].Key)
+	assert.Equal(t, []log.KeyValue{log.Int("status", 200), log.String("inlined", "yes")}, request[1].Value.AsMap())
+
+	assert.Zero(t, records[1].AttributesLen(), "empty groups are omitted")
+}
+
+type stringer struct{}
+
+func (stringer) String() string { return "stringer" }
+
+func TestHandler_Values(t *testing.T) {
+	logger, rec := newLogger(t)
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	logger.Info("values",
+		slog.Uint64("uint", 7),
+		slog.Float64("float", 1.5),
+		slog.Bool("bool", true),
+		slog.Duration("duration", time.Second),
+		slog.Time("time", ts),
+		slog.Any("error", errors.New("boom")),
+		slog.Any("bytes", []byte("raw")),
+		slog.Any("strings", []string{"a", "b"}),
+		slog.Any("stringer", stringer{}),
+		slog.Any("struct", struct{ A int }{A: 1}),
+		slog.Attr{},
+	)
+
+	records := rec.Records()
+	require.Len(t, records, 1)
+	attrs := logtest.Attributes(records[0])
+	assert.Len(t, attrs, 10)
+	assert.Equal(t, int64(7), attrs["uint"].AsInt64())
+	assert.InDelta(t, 1.5, attrs["float"].AsFloat64(), 0)
+	assert.True(t, attrs["bool"].AsBool())
+	assert.Equal(t, time.Second.Nanoseconds(), attrs["duration"].AsInt64())
+	assert.Equal(t, ts.UnixNano(), attrs["time"].AsInt64())
+	assert.Equal(t, "boom", attrs["error"].AsString())
+	assert.Equal(t, []byte("raw"), attrs["bytes"].AsBytes())
+	assert.Equal(t, []log.Value{log.StringValue("a"), log.StringValue("b")}, attrs["strings"].AsSlice())
+	assert.Equal(t, "stringer", attrs["stringer"].AsString())
+	assert.Equal(t, "{A:1}", attrs["struct"].AsString())
+}
+
+func TestHandler_Source(t *testing.T) {
+	logger, rec := newLogger(t, WithSource(true), WithLoggerName("orders"), WithVersion("1.2.3"))
+
+	logger.Info("with source")
+
+	records := rec.Records()
+	require.Len(t, records, 1)
+	assert.Equal(t, "orders", records[0].InstrumentationScope().Name)
+	assert.Equal(t, "1.2.3", records[0].InstrumentationScope().Version)
+
+	attrs := logtest.Attributes(records[0])
+	assert.Contains(t, attrs[AttrCodeFunction].AsString(), "TestHandler_Source")
+	assert.Contains(t, attrs[AttrCodeFile].AsString(), "handler_test.go")
+	assert.Positive(t, attrs[AttrCodeLine].AsInt64())
+}
+
+func TestSeverity(t *testing.T) {
+	assert.Equal(t, log.SeverityDebug, logconv.SeverityAt(int(slog.LevelDebug)))
+	assert.Equal(t, log.SeverityInfo, logconv.SeverityAt(int(slog.LevelInfo)))
+	assert.Equal(t, log.SeverityInfo2, logconv.SeverityAt(int(slog.LevelInfo+1)))
+	assert.Equal(t, log.SeverityWarn, logconv.SeverityAt(int(slog.LevelWarn)))
+	assert.Equal(t, log.SeverityError, logconv.SeverityAt(int(slog.LevelError)))
+	assert.Equal(t, log.SeverityTrace1, logconv.SeverityAt(int(slog.Level(-100))))
+	assert.Equal(t, log.SeverityFatal4, logconv.SeverityAt(int(slog.Level(100))))
+}
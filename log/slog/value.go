@@ -0,0 +1,67 @@
+package slog
+
+import (
+	"log/slog"
+	"math"
+
+	"github.com/arloliu/otx/internal/logconv"
+	"go.opentelemetry.io/otel/log"
+)
+
+// appendAttr appends the OTel form of a to kvs. Empty attributes are skipped and
+// the attributes of groups without a key are inlined, as with the slog handlers.
+func appendAttr(kvs []log.KeyValue, a slog.Attr) []log.KeyValue {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return kvs
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		group := a.Value.Group()
+		if len(group) == 0 {
+			return kvs
+		}
+		if a.Key == "" {
+			for _, ga := range group {
+				kvs = appendAttr(kvs, ga)
+			}
+
+			return kvs
+		}
+		members := make([]log.KeyValue, 0, len(group))
+		for _, ga := range group {
+			members = appendAttr(members, ga)
+		}
+
+		return append(kvs, log.Map(a.Key, members...))
+	}
+
+	return append(kvs, log.KeyValue{Key: a.Key, Value: convertValue(a.Value)})
+}
+
+// convertValue converts a resolved, non-group slog value. Durations are
+// nanoseconds and times Unix nanoseconds, as in the OTel slog bridge.
+func convertValue(v slog.Value) log.Value {
+	switch v.Kind() {
+	case slog.KindString:
+		return log.StringValue(v.String())
+	case slog.KindInt64:
+		return log.Int64Value(v.Int64())
+	case slog.KindUint64:
+		if u := v.Uint64(); u <= math.MaxInt64 {
+			return log.Int64Value(int64(u))
+		}
+
+		return log.StringValue(v.String())
+	case slog.KindFloat64:
+		return log.Float64Value(v.Float64())
+	case slog.KindBool:
+		return log.BoolValue(v.Bool())
+	case slog.KindDuration:
+		return log.Int64Value(v.Duration().Nanoseconds())
+	case slog.KindTime:
+		return log.Int64Value(v.Time().UnixNano())
+	default:
+		return logconv.Value(v.Any())
+	}
+}
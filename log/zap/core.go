@@ -0,0 +1,186 @@
+package zap
+
+import (
+	"context"
+
+	"github.com/arloliu/otx/internal/logconv"
+	"go.opentelemetry.io/otel/log"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const instrumentationName = "github.com/arloliu/otx/log/zap"
+
+// Attribute keys of entry details, recorded when the logger captures them,
+// e.g. with zap.AddCaller and zap.AddStacktrace.
+const (
+	AttrCodeFunction   = logconv.AttrCodeFunction
+	AttrCodeFile       = logconv.AttrCodeFile
+	AttrCodeLine       = logconv.AttrCodeLine
+	AttrCodeStacktrace = logconv.AttrCodeStacktrace
+	AttrLoggerName     = "logger.name"
+)
+
+// contextKey is the key of the field added by Context.
+const contextKey = "otx.context"
+
+// options holds configuration for the core.
+type options struct {
+	logconv.Options
+}
+
+// Option configures a Core.
+type Option func(*options)
+
+// WithLoggerName sets the instrumentation scope name of the OTel logger.
+// Default is the package import path.
+func WithLoggerName(name string) Option {
+	return logconv.WithLoggerName[options](name)
+}
+
+// WithVersion sets the instrumentation scope version of the OTel logger.
+func WithVersion(version string) Option {
+	return logconv.WithVersion[options](version)
+}
+
+// Context returns a field carrying ctx. Entries logged with it get the trace and
+// span IDs of the span in ctx, and are emitted with ctx. Other cores, e.g. a
+// console core combined with zapcore.NewTee, skip the field.
+//
+// Example:
+//
+//	logger.Info("order accepted", otxzap.Context(ctx), zap.String("order.id", id))
+func Context(ctx context.Context) zap.Field {
+	return zap.Field{Key: contextKey, Type: zapcore.SkipType, Interface: ctx}
+}
+
+// Core is a zapcore.Core emitting log entries to an OTel LoggerProvider.
+type Core struct {
+	logger log.Logger
+	fields []zapcore.Field // Added with With
+	ctx    context.Context // From a Context field added with With, nil if none
+}
+
+var _ zapcore.Core = (*Core)(nil)
+
+// NewCore returns a Core emitting to the global LoggerProvider, the one installed
+// by otx.NewLoggerProvider.
+//
+// Usage:
+//
+//	lp, err := otx.NewLoggerProvider(ctx, cfg)
+//	if err != nil {
+//	    return err
+//	}
+//	defer lp.Shutdown(ctx)
+//	logger := zap.New(otxzap.NewCore())
+//
+//	// Keep console output as well
+//	logger = zap.New(zapcore.NewTee(consoleCore, otxzap.NewCore()))
+func NewCore(opts ...Option) *Core {
+	return NewCoreWithProvider(nil, opts...)
+}
+
+// NewCoreWithProvider returns a Core emitting to lp.
+// If lp is nil, the global LoggerProvider is used.
+func NewCoreWithProvider(lp log.LoggerProvider, opts ...Option) *Core {
+	o := logconv.Apply(options{Options: logconv.Options{LoggerName: instrumentationName}}, opts)
+
+	return &Core{logger: o.Logger(lp)}
+}
+
+// Enabled implements zapcore.LevelEnabler. It reports whether the LoggerProvider
+// processes entries of level, e.g. false when a processor filters them out.
+func (c *Core) Enabled(level zapcore.Level) bool {
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return c.logger.Enabled(ctx, log.EnabledParameters{Severity: logconv.Severity(level.String())})
+}
+
+// With implements zapcore.Core.
+func (c *Core) With(fields []zapcore.Field) zapcore.Core {
+	c2 := *c
+	c2.fields = make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	c2.fields = append(c2.fields, c.fields...)
+	for _, f := range fields {
+		if ctx, ok := fieldContext(f); ok {
+			c2.ctx = ctx
+			continue
+		}
+		c2.fields = append(c2.fields, f)
+	}
+
+	return &c2
+}
+
+// Check implements zapcore.Core.
+func (c *Core) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+
+	return ce
+}
+
+// Write implements zapcore.Core.
+func (c *Core) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	ctx := c.ctx
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range c.fields {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		if fctx, ok := fieldContext(f); ok {
+			ctx = fctx
+			continue
+		}
+		f.AddTo(enc)
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var record log.Record
+	record.SetTimestamp(entry.Time)
+	record.SetSeverity(logconv.Severity(entry.Level.String()))
+	record.SetSeverityText(entry.Level.CapitalString())
+	record.SetBody(log.StringValue(entry.Message))
+	record.AddAttributes(logconv.Map(enc.Fields)...)
+
+	if entry.LoggerName != "" {
+		record.AddAttributes(log.String(AttrLoggerName, entry.LoggerName))
+	}
+	if entry.Caller.Defined {
+		record.AddAttributes(
+			log.String(AttrCodeFunction, entry.Caller.Function),
+			log.String(AttrCodeFile, entry.Caller.File),
+			log.Int(AttrCodeLine, entry.Caller.Line),
+		)
+	}
+	if entry.Stack != "" {
+		record.AddAttributes(log.String(AttrCodeStacktrace, entry.Stack))
+	}
+
+	c.logger.Emit(ctx, record)
+
+	return nil
+}
+
+// Sync implements zapcore.Core. Records are flushed by the LoggerProvider, on
+// its export schedule and when it is shut down.
+func (c *Core) Sync() error {
+	return nil
+}
+
+// fieldContext returns the context of a field added by Context.
+func fieldContext(f zapcore.Field) (context.Context, bool) {
+	if f.Key != contextKey || f.Type != zapcore.SkipType {
+		return nil, false
+	}
+	ctx, ok := f.Interface.(context.Context)
+
+	return ctx, ok
+}
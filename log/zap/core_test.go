@@ -0,0 +1,156 @@
+package zap
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/arloliu/otx/internal/logconv"
+	"github.com/arloliu/otx/internal/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newCore(t *testing.T, opts ...Option) (*Core, *logtest.Recorder) {
+	t.Helper()
+
+	rec := &logtest.Recorder{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(rec))
+	t.Cleanup(func() { _ = lp.Shutdown(context.Background()) })
+
+	return NewCoreWithProvider(lp, opts...), rec
+}
+
+func TestCore_Record(t *testing.T) {
+	core, rec := newCore(t)
+	logger := zap.New(core).Named("orders")
+
+	logger.Warn("disk almost full", zap.Int("disk.free", 42), zap.String("disk.path", "/var"))
+
+	records := rec.Records()
+	require.Len(t, records, 1)
+	r := records[0]
+	assert.Equal(t, "disk almost full", r.Body().AsString())
+	assert.Equal(t, log.SeverityWarn, r.Severity())
+	assert.Equal(t, "WARN", r.SeverityText())
+	assert.False(t, r.Timestamp().IsZero())
+	assert.Equal(t, "github.com/arloliu/otx/log/zap", r.InstrumentationScope().Name)
+
+	attrs := logtest.Attributes(r)
+	assert.Equal(t, int64(42), attrs["disk.free"].AsInt64())
+	assert.Equal(t, "/var", attrs["disk.path"].AsString())
+	assert.Equal(t, "orders", attrs[AttrLoggerName].AsString())
+}
+
+func TestCore_Context(t *testing.T) {
+	core, rec := newCore(t)
+	logger := zap.New(core)
+	tp := sdktrace.NewTracerProvider()
+	ctx, span := tp.Tracer("test").Start(t.Context(), "op")
+	defer span.End()
+
+	logger.Info("with span", Context(ctx))
+	logger.With(Context(ctx)).Info("with span from With")
+	logger.Info("without span")
+
+	records := rec.Records()
+	require.Len(t, records, 3)
+	for _, r := range records[:2] {
+		assert.Equal(t, span.SpanContext().TraceID(), r.TraceID())
+		assert.Equal(t, span.SpanContext().SpanID(), r.SpanID())
+		assert.Zero(t, r.AttributesLen(), "the context field is not an attribute")
+	}
+	assert.False(t, records[2].TraceID().IsValid())
+}
+
+func TestCore_ContextSkippedByOtherCores(t *testing.T) {
+	core, _ := newCore(t)
+	observed, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(zapcore.NewTee(observed, core))
+
+	logger.Info("tee", Context(t.Context()), zap.String("k", "v"))
+
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, map[string]any{"k": "v"}, logs.All()[0].ContextMap())
+}
+
+func TestCore_Enabled(t *testing.T) {
+	core, rec := newCore(t)
+	rec.MinLevel = log.SeverityInfo
+	logger := zap.New(core)
+
+	logger.Debug("dropped")
+	logger.Info("kept")
+
+	assert.False(t, core.Enabled(zapcore.DebugLevel))
+	assert.Len(t, rec.Records(), 1)
+}
+
+func TestCore_Fields(t *testing.T) {
+	core, rec := newCore(t)
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	logger := zap.New(core).With(zap.String("service", "orders"))
+
+	logger.Info("fields",
+		zap.Uint64("uint", 7),
+		zap.Float64("float", 1.5),
+		zap.Bool("bool", true),
+		zap.Duration("duration", time.Second),
+		zap.Time("time", ts),
+		zap.Error(errors.New("boom")),
+		zap.Binary("bytes", []byte("raw")),
+		zap.Strings("strings", []string{"a", "b"}),
+		zap.Any("struct", struct{ A int }{A: 1}),
+		zap.Namespace("request"),
+		zap.String("method", "GET"),
+	)
+
+	records := rec.Records()
+	require.Len(t, records, 1)
+	attrs := logtest.Attributes(records[0])
+	assert.Equal(t, "orders", attrs["service"].AsString())
+	assert.Equal(t, int64(7), attrs["uint"].AsInt64())
+	assert.InDelta(t, 1.5, attrs["float"].AsFloat64(), 0)
+	assert.True(t, attrs["bool"].AsBool())
+	assert.Equal(t, time.Second.Nanoseconds(), attrs["duration"].AsInt64())
+	assert.Equal(t, ts.UnixNano(), attrs["time"].AsInt64())
+	assert.Equal(t, "boom", attrs["error"].AsString())
+	assert.Equal(t, []byte("raw"), attrs["bytes"].AsBytes())
+	assert.Equal(t, []log.Value{log.StringValue("a"), log.StringValue("b")}, attrs["strings"].AsSlice())
+	assert.Equal(t, "{A:1}", attrs["struct"].AsString())
+	assert.Equal(t, []log.KeyValue{log.String("method", "GET")}, attrs["request"].AsMap())
+}
+
+func TestCore_CallerAndStack(t *testing.T) {
+	core, rec := newCore(t, WithLoggerName("orders"), WithVersion("1.2.3"))
+	logger := zap.New(core, zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel))
+
+	logger.Error("failed")
+
+	records := rec.Records()
+	require.Len(t, records, 1)
+	assert.Equal(t, "orders", records[0].InstrumentationScope().Name)
+	assert.Equal(t, "1.2.3", records[0].InstrumentationScope().Version)
+
+	attrs := logtest.Attributes(records[0])
+	assert.Contains(t, attrs[AttrCodeFunction].AsString(), "TestCore_CallerAndStack")
+	assert.Contains(t, attrs[AttrCodeFile].AsString(), "core_test.go")
+	assert.Positive(t, attrs[AttrCodeLine].AsInt64())
+	assert.Contains(t, attrs[AttrCodeStacktrace].AsString(), "TestCore_CallerAndStack")
+}
+
+func TestSeverity(t *testing.T) {
+	assert.Equal(t, log.SeverityDebug, logconv.Severity(zapcore.DebugLevel.String()))
+	assert.Equal(t, log.SeverityInfo, logconv.Severity(zapcore.InfoLevel.String()))
+	assert.Equal(t, log.SeverityError, logconv.Severity(zapcore.ErrorLevel.String()))
+	assert.Equal(t, log.SeverityFatal1, logconv.Severity(zapcore.DPanicLevel.String()))
+	assert.Equal(t, log.SeverityFatal3, logconv.Severity(zapcore.FatalLevel.String()))
+	assert.Equal(t, log.SeverityUndefined, logconv.Severity(zapcore.Level(42).String()))
+}
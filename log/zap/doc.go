@@ -0,0 +1,33 @@
+// Package zap bridges go.uber.org/zap to the OTel logs pipeline set up by
+// otx.NewLoggerProvider, so zap logs get the resource of the service like its
+// traces and metrics.
+//
+// # Basic Usage
+//
+// Build the logger from the core, or add it next to an existing core:
+//
+//	lp, err := otx.NewLoggerProvider(ctx, cfg)
+//	if err != nil {
+//	    return err
+//	}
+//	defer lp.Shutdown(ctx)
+//
+//	logger := zap.New(zapcore.NewTee(consoleCore, otxzap.NewCore()))
+//
+// # Trace Correlation
+//
+// zap has no context parameter, so pass the context as a field with Context.
+// Entries logged with it get the trace and span IDs of its span:
+//
+//	logger.Info("order accepted", otxzap.Context(ctx), zap.String("order.id", id))
+//
+//	// Or for every entry of a request-scoped logger
+//	reqLogger := logger.With(otxzap.Context(ctx))
+//
+// # Fields
+//
+// Fields keep their types; namespaces and objects become map values, arrays
+// slices, durations nanoseconds, times Unix nanoseconds, and errors and other
+// values their string form. The caller and stack trace captured by zap.AddCaller
+// and zap.AddStacktrace are recorded as code.* attributes.
+package zap
@@ -0,0 +1,35 @@
+// Package zerolog bridges github.com/rs/zerolog to the OTel logs pipeline set up
+// by otx.NewLoggerProvider, so zerolog logs get the resource of the service like
+// its traces and metrics.
+//
+// # Basic Usage
+//
+// Write the logger output to a Writer, next to the existing output, and add the
+// TraceHook for trace correlation:
+//
+//	lp, err := otx.NewLoggerProvider(ctx, cfg)
+//	if err != nil {
+//	    return err
+//	}
+//	defer lp.Shutdown(ctx)
+//
+//	logger := zerolog.New(zerolog.MultiLevelWriter(os.Stdout, otxzerolog.NewWriter())).
+//	    Hook(otxzerolog.TraceHook())
+//
+// # Trace Correlation
+//
+// zerolog writers only see the encoded event, so the TraceHook adds the trace_id,
+// span_id and trace_flags fields of the span in the event context. The Writer
+// emits events with them as the trace context of the record instead of as
+// attributes; other outputs keep them as fields:
+//
+//	logger.Info().Ctx(ctx).Str("order.id", id).Msg("order accepted")
+//
+// # Fields
+//
+// Fields keep their JSON types: strings, booleans, integers and floats, with
+// objects as maps and arrays as slices. The message, level and timestamp fields,
+// named by the zerolog globals, become the body, severity and timestamp of the
+// record, and the caller added by Caller() becomes code.file.path and
+// code.line.number.
+package zerolog
@@ -0,0 +1,242 @@
+package zerolog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arloliu/otx/internal/logconv"
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/arloliu/otx/log/zerolog"
+
+// Attribute keys of the caller, recorded for events logged with Caller().
+const (
+	AttrCodeFile = logconv.AttrCodeFile
+	AttrCodeLine = logconv.AttrCodeLine
+)
+
+// Trace context fields, added by TraceHook.
+const (
+	TraceIDField    = "trace_id"
+	SpanIDField     = "span_id"
+	TraceFlagsField = "trace_flags"
+)
+
+// options holds configuration for the writer.
+type options struct {
+	logconv.Options
+}
+
+// Option configures a Writer.
+type Option func(*options)
+
+// WithLoggerName sets the instrumentation scope name of the OTel logger.
+// Default is the package import path.
+func WithLoggerName(name string) Option {
+	return logconv.WithLoggerName[options](name)
+}
+
+// WithVersion sets the instrumentation scope version of the OTel logger.
+func WithVersion(version string) Option {
+	return logconv.WithVersion[options](version)
+}
+
+// TraceHook returns a hook adding the trace_id, span_id and trace_flags fields of
+// the span in the event context, set with Event.Ctx or Context.Ctx. Events
+// without a valid span are left as is.
+func TraceHook() zerolog.Hook {
+	return zerolog.HookFunc(func(e *zerolog.Event, _ zerolog.Level, _ string) {
+		sc := trace.SpanContextFromContext(e.GetCtx())
+		if !sc.IsValid() {
+			return
+		}
+		e.Str(TraceIDField, sc.TraceID().String()).
+			Str(SpanIDField, sc.SpanID().String()).
+			Str(TraceFlagsField, sc.TraceFlags().String())
+	})
+}
+
+// Writer is a zerolog.LevelWriter emitting the JSON events written by a zerolog
+// logger to an OTel LoggerProvider.
+type Writer struct {
+	logger log.Logger
+}
+
+var _ zerolog.LevelWriter = (*Writer)(nil)
+
+// NewWriter returns a Writer emitting to the global LoggerProvider, the one
+// installed by otx.NewLoggerProvider.
+//
+// Usage:
+//
+//	lp, err := otx.NewLoggerProvider(ctx, cfg)
+//	if err != nil {
+//	    return err
+//	}
+//	defer lp.Shutdown(ctx)
+//	logger := zerolog.New(otxzerolog.NewWriter()).Hook(otxzerolog.TraceHook())
+//
+//	// Keep console output as well
+//	logger = zerolog.New(zerolog.MultiLevelWriter(os.Stdout, otxzerolog.NewWriter())).
+//	    Hook(otxzerolog.TraceHook())
+func NewWriter(opts ...Option) *Writer {
+	return NewWriterWithProvider(nil, opts...)
+}
+
+// NewWriterWithProvider returns a Writer emitting to lp.
+// If lp is nil, the global LoggerProvider is used.
+func NewWriterWithProvider(lp log.LoggerProvider, opts ...Option) *Writer {
+	o := logconv.Apply(options{Options: logconv.Options{LoggerName: instrumentationName}}, opts)
+
+	return &Writer{logger: o.Logger(lp)}
+}
+
+// Write implements io.Writer, reading the level from the level field of the event.
+func (w *Writer) Write(p []byte) (int, error) {
+	return w.WriteLevel(zerolog.NoLevel, p)
+}
+
+// WriteLevel implements zerolog.LevelWriter. Events that are not JSON objects, or
+// that the LoggerProvider does not process, are dropped without an error, so they
+// never fail the other writers of a zerolog.MultiLevelWriter.
+func (w *Writer) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	fields, ok := decodeEvent(p)
+	if !ok {
+		return len(p), nil
+	}
+	if level == zerolog.NoLevel {
+		if s, ok := fields[zerolog.LevelFieldName].(string); ok {
+			if parsed, err := zerolog.ParseLevel(s); err == nil {
+				level = parsed
+			}
+		}
+	}
+	sev := logconv.Severity(level.String())
+	if !w.logger.Enabled(context.Background(), log.EnabledParameters{Severity: sev}) {
+		return len(p), nil
+	}
+
+	var record log.Record
+	record.SetSeverity(sev)
+	if level != zerolog.NoLevel {
+		record.SetSeverityText(level.String())
+	}
+	delete(fields, zerolog.LevelFieldName)
+
+	if msg, ok := fields[zerolog.MessageFieldName].(string); ok {
+		record.SetBody(log.StringValue(msg))
+		delete(fields, zerolog.MessageFieldName)
+	}
+	if ts, ok := parseTime(fields[zerolog.TimestampFieldName]); ok {
+		record.SetTimestamp(ts)
+		delete(fields, zerolog.TimestampFieldName)
+	}
+	if caller, ok := fields[zerolog.CallerFieldName].(string); ok {
+		if file, line, ok := splitCaller(caller); ok {
+			record.AddAttributes(log.String(AttrCodeFile, file), log.Int(AttrCodeLine, line))
+			delete(fields, zerolog.CallerFieldName)
+		}
+	}
+	ctx := traceContext(fields)
+
+	for _, key := range slices.Sorted(maps.Keys(fields)) {
+		record.AddAttributes(log.KeyValue{Key: key, Value: logconv.Value(fields[key])})
+	}
+
+	w.logger.Emit(ctx, record)
+
+	return len(p), nil
+}
+
+// decodeEvent decodes a JSON event, keeping numbers as json.Number.
+func decodeEvent(p []byte) (map[string]any, bool) {
+	dec := json.NewDecoder(bytes.NewReader(p))
+	dec.UseNumber()
+
+	var fields map[string]any
+	if err := dec.Decode(&fields); err != nil || fields == nil {
+		return nil, false
+	}
+
+	return fields, true
+}
+
+// traceContext returns a context with the span context of the trace fields added
+// by TraceHook, and removes them from fields. It returns context.Background()
+// if they are missing or invalid, leaving fields as is.
+func traceContext(fields map[string]any) context.Context {
+	traceIDHex, _ := fields[TraceIDField].(string)
+	spanIDHex, _ := fields[SpanIDField].(string)
+	traceID, err := trace.TraceIDFromHex(traceIDHex)
+	if err != nil {
+		return context.Background()
+	}
+	spanID, err := trace.SpanIDFromHex(spanIDHex)
+	if err != nil {
+		return context.Background()
+	}
+
+	var flags trace.TraceFlags
+	if s, ok := fields[TraceFlagsField].(string); ok {
+		if b, err := strconv.ParseUint(s, 16, 8); err == nil {
+			flags = trace.TraceFlags(b)
+		}
+	}
+	delete(fields, TraceIDField)
+	delete(fields, SpanIDField)
+	delete(fields, TraceFlagsField)
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID, TraceFlags: flags})
+
+	return trace.ContextWithSpanContext(context.Background(), sc)
+}
+
+// parseTime parses the timestamp field in the format of zerolog.TimeFieldFormat.
+func parseTime(v any) (time.Time, bool) {
+	switch v := v.(type) {
+	case string:
+		ts, err := time.Parse(zerolog.TimeFieldFormat, v)
+
+		return ts, err == nil
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return time.Time{}, false
+		}
+		switch zerolog.TimeFieldFormat {
+		case zerolog.TimeFormatUnix:
+			return time.Unix(n, 0), true
+		case zerolog.TimeFormatUnixMs:
+			return time.UnixMilli(n), true
+		case zerolog.TimeFormatUnixMicro:
+			return time.UnixMicro(n), true
+		case zerolog.TimeFormatUnixNano:
+			return time.Unix(0, n), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// splitCaller splits a caller in the default "file:line" format.
+func splitCaller(caller string) (string, int, bool) {
+	i := strings.LastIndexByte(caller, ':')
+	if i < 0 {
+		return "", 0, false
+	}
+	line, err := strconv.Atoi(caller[i+1:])
+	if err != nil {
+		return "", 0, false
+	}
+
+	return caller[:i], line, true
+}
@@ -0,0 +1,145 @@
+package zerolog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/arloliu/otx/internal/logconv"
+	"github.com/arloliu/otx/internal/logtest"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func newWriter(t *testing.T, opts ...Option) (*Writer, *logtest.Recorder) {
+	t.Helper()
+
+	rec := &logtest.Recorder{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(rec))
+	t.Cleanup(func() { _ = lp.Shutdown(context.Background()) })
+
+	return NewWriterWithProvider(lp, opts...), rec
+}
+
+func TestWriter_Record(t *testing.T) {
+	w, rec := newWriter(t)
+	logger := zerolog.New(w).With().Timestamp().Logger()
+
+	logger.Warn().Int("disk.free", 42).Str("disk.path", "/var").Msg("disk almost full")
+
+	records := rec.Records()
+	require.Len(t, records, 1)
+	r := records[0]
+	assert.Equal(t, "disk almost full", r.Body().AsString())
+	assert.Equal(t, log.SeverityWarn, r.Severity())
+	assert.Equal(t, "warn", r.SeverityText())
+	assert.WithinDuration(t, time.Now(), r.Timestamp(), time.Minute)
+	assert.Equal(t, "github.com/arloliu/otx/log/zerolog", r.InstrumentationScope().Name)
+
+	attrs := logtest.Attributes(r)
+	assert.Len(t, attrs, 2)
+	assert.Equal(t, int64(42), attrs["disk.free"].AsInt64())
+	assert.Equal(t, "/var", attrs["disk.path"].AsString())
+}
+
+func TestWriter_TraceHook(t *testing.T) {
+	w, rec := newWriter(t)
+	var console bytes.Buffer
+	logger := zerolog.New(zerolog.MultiLevelWriter(&console, w)).Hook(TraceHook())
+	tp := sdktrace.NewTracerProvider()
+	ctx, span := tp.Tracer("test").Start(t.Context(), "op")
+	defer span.End()
+
+	logger.Info().Ctx(ctx).Msg("with span")
+	logger.Info().Msg("without span")
+
+	records := rec.Records()
+	require.Len(t, records, 2)
+	assert.Equal(t, span.SpanContext().TraceID(), records[0].TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), records[0].SpanID())
+	assert.Equal(t, span.SpanContext().TraceFlags(), records[0].TraceFlags())
+	assert.Zero(t, records[0].AttributesLen(), "trace fields are not attributes")
+	assert.False(t, records[1].TraceID().IsValid())
+	assert.Contains(t, console.String(), `"trace_id":"`+span.SpanContext().TraceID().String()+`"`)
+}
+
+func TestWriter_Enabled(t *testing.T) {
+	w, rec := newWriter(t)
+	rec.MinLevel = log.SeverityInfo
+	logger := zerolog.New(w)
+
+	logger.Debug().Msg("dropped")
+	logger.Info().Msg("kept")
+
+	assert.Len(t, rec.Records(), 1)
+}
+
+func TestWriter_Fields(t *testing.T) {
+	w, rec := newWriter(t)
+	logger := zerolog.New(w).With().Str("service", "orders").Logger()
+
+	logger.Error().
+		Uint64("uint", 7).
+		Float64("float", 1.5).
+		Bool("bool", true).
+		Err(errors.New("boom")).
+		Strs("strings", []string{"a", "b"}).
+		Dict("request", zerolog.Dict().Str("method", "GET")).
+		Msg("fields")
+
+	records := rec.Records()
+	require.Len(t, records, 1)
+	attrs := logtest.Attributes(records[0])
+	assert.Equal(t, "orders", attrs["service"].AsString())
+	assert.Equal(t, int64(7), attrs["uint"].AsInt64())
+	assert.InDelta(t, 1.5, attrs["float"].AsFloat64(), 0)
+	assert.True(t, attrs["bool"].AsBool())
+	assert.Equal(t, "boom", attrs["error"].AsString())
+	assert.Equal(t, []log.Value{log.StringValue("a"), log.StringValue("b")}, attrs["strings"].AsSlice())
+	assert.Equal(t, []log.KeyValue{log.String("method", "GET")}, attrs["request"].AsMap())
+}
+
+func TestWriter_CallerAndScope(t *testing.T) {
+	w, rec := newWriter(t, WithLoggerName("orders"), WithVersion("1.2.3"))
+	logger := zerolog.New(w).With().Caller().Logger()
+
+	logger.Info().Msg("with caller")
+
+	records := rec.Records()
+	require.Len(t, records, 1)
+	assert.Equal(t, "orders", records[0].InstrumentationScope().Name)
+	assert.Equal(t, "1.2.3", records[0].InstrumentationScope().Version)
+
+	attrs := logtest.Attributes(records[0])
+	assert.Contains(t, attrs[AttrCodeFile].AsString(), "writer_test.go")
+	assert.Positive(t, attrs[AttrCodeLine].AsInt64())
+}
+
+func TestWriter_Write(t *testing.T) {
+	w, rec := newWriter(t)
+
+	_, err := w.Write([]byte(`{"level":"error","message":"raw"}`))
+	require.NoError(t, err)
+	n, err := w.Write([]byte("not json"))
+	require.NoError(t, err)
+	assert.Equal(t, 8, n)
+
+	records := rec.Records()
+	require.Len(t, records, 1)
+	assert.Equal(t, log.SeverityError, records[0].Severity())
+	assert.Equal(t, "raw", records[0].Body().AsString())
+}
+
+func TestSeverity(t *testing.T) {
+	assert.Equal(t, log.SeverityTrace, logconv.Severity(zerolog.TraceLevel.String()))
+	assert.Equal(t, log.SeverityInfo, logconv.Severity(zerolog.InfoLevel.String()))
+	assert.Equal(t, log.SeverityFatal2, logconv.Severity(zerolog.PanicLevel.String()))
+	assert.Equal(t, log.SeverityFatal3, logconv.Severity(zerolog.FatalLevel.String()))
+	assert.Equal(t, log.SeverityUndefined, logconv.Severity(zerolog.NoLevel.String()))
+}
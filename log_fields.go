@@ -0,0 +1,99 @@
+package otx
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Log correlation field names, as used by the OTel log data model and most log
+// backends to link log lines to traces.
+const (
+	LogFieldTraceID    = "trace_id"
+	LogFieldSpanID     = "span_id"
+	LogFieldTraceFlags = "trace_flags"
+)
+
+// logField is a log correlation field.
+type logField struct {
+	key   string
+	value string
+}
+
+// logFields returns the log correlation fields of ctx, in order.
+func logFields(ctx context.Context, baggageKeys []string) []logField {
+	var fields []logField
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		fields = append(fields,
+			logField{key: LogFieldTraceID, value: sc.TraceID().String()},
+			logField{key: LogFieldSpanID, value: sc.SpanID().String()},
+			logField{key: LogFieldTraceFlags, value: sc.TraceFlags().String()},
+		)
+	}
+	if len(baggageKeys) > 0 {
+		bag := baggage.FromContext(ctx)
+		for _, key := range baggageKeys {
+			if m := bag.Member(key); m.Key() != "" {
+				fields = append(fields, logField{key: key, value: m.Value()})
+			}
+		}
+	}
+
+	return fields
+}
+
+// LogFields returns the log correlation fields of ctx: the trace_id, span_id and
+// trace_flags of the span in ctx, and the baggage members named by baggageKeys,
+// under their own key. IDs are lowercase hex and the flags two hex digits, e.g.
+// "01" for a sampled span, as in the traceparent header.
+//
+// The trace fields are omitted without a valid span, and baggage members not in
+// ctx are skipped, so the result may be empty. [LogArgs] and [ContextLogAttrs]
+// return the same fields for other logger APIs.
+//
+// Example:
+//
+//	logrus.WithFields(otx.LogFields(ctx)).Info("order accepted")
+func LogFields(ctx context.Context, baggageKeys ...string) map[string]any {
+	list := logFields(ctx, baggageKeys)
+	fields := make(map[string]any, len(list))
+	for _, f := range list {
+		fields[f.key] = f.value
+	}
+
+	return fields
+}
+
+// ContextLogAttrs returns the fields of [LogFields] as slog attributes, in order:
+// the trace fields first, then baggage members in the order of baggageKeys.
+//
+// Example:
+//
+//	slog.LogAttrs(ctx, slog.LevelInfo, "order accepted", otx.ContextLogAttrs(ctx, "tenant.id")...)
+//
+//	// Or for every record of a request-scoped logger
+//	logger := slog.With(otx.LogArgs(ctx)...)
+func ContextLogAttrs(ctx context.Context, baggageKeys ...string) []slog.Attr {
+	list := logFields(ctx, baggageKeys)
+	attrs := make([]slog.Attr, 0, len(list))
+	for _, f := range list {
+		attrs = append(attrs, slog.String(f.key, f.value))
+	}
+
+	return attrs
+}
+
+// LogArgs returns the fields of [LogFields] as alternating keys and values, for
+// loggers taking variadic key-value pairs such as slog.Logger.With and
+// zap.SugaredLogger.With.
+func LogArgs(ctx context.Context, baggageKeys ...string) []any {
+	list := logFields(ctx, baggageKeys)
+	args := make([]any, 0, 2*len(list))
+	for _, f := range list {
+		args = append(args, f.key, f.value)
+	}
+
+	return args
+}
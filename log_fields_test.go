@@ -0,0 +1,51 @@
+package otx
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestLogFields(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    mustTraceID(t, "4bf92f3577b34da6a3ce929d0e0e4736"),
+		SpanID:     mustSpanID(t, "00f067aa0ba902b7"),
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(t.Context(), sc)
+	ctx = MustSetBaggage(ctx, "tenant.id", "acme")
+
+	assert.Equal(t, map[string]any{
+		LogFieldTraceID:    "4bf92f3577b34da6a3ce929d0e0e4736",
+		LogFieldSpanID:     "00f067aa0ba902b7",
+		LogFieldTraceFlags: "01",
+		"tenant.id":        "acme",
+	}, LogFields(ctx, "tenant.id", "missing"))
+
+	assert.Equal(t, []any{
+		"trace_id", "4bf92f3577b34da6a3ce929d0e0e4736",
+		"span_id", "00f067aa0ba902b7",
+		"trace_flags", "01",
+	}, LogArgs(ctx))
+
+	attrs := ContextLogAttrs(ctx, "tenant.id")
+	assert.Len(t, attrs, 4)
+	assert.Equal(t, slog.String("trace_flags", "01"), attrs[2])
+	assert.Equal(t, slog.String("tenant.id", "acme"), attrs[3])
+}
+
+func TestLogFields_NoSpan(t *testing.T) {
+	ctx := MustSetBaggage(t.Context(), "tenant.id", "acme")
+
+	assert.Empty(t, LogFields(t.Context()))
+	assert.Empty(t, LogArgs(t.Context()))
+	assert.Equal(t, []slog.Attr{slog.String("tenant.id", "acme")}, ContextLogAttrs(ctx, "tenant.id"))
+
+	unsampled := trace.ContextWithSpanContext(t.Context(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: mustTraceID(t, "4bf92f3577b34da6a3ce929d0e0e4736"),
+		SpanID:  mustSpanID(t, "00f067aa0ba902b7"),
+	}))
+	assert.Equal(t, "00", LogFields(unsampled)[LogFieldTraceFlags])
+}
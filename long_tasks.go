@@ -0,0 +1,112 @@
+package otx
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// AttrLongTask is the span attribute set to true on spans that exceed the long-task threshold.
+const AttrLongTask = "long_task"
+
+// metricLongTasks counts spans that exceeded the long-task threshold, by span name.
+const metricLongTasks = "otx.span.long_tasks"
+
+// attrSpanName is the metric attribute holding the span name.
+const attrSpanName = "span.name"
+
+// longTaskProcessor flags spans that stay open longer than a threshold.
+type longTaskProcessor struct {
+	threshold time.Duration
+	counter   metric.Int64Counter
+
+	timers spanTable[*time.Timer]
+}
+
+// NewLongTaskProcessor returns a SpanProcessor that flags spans still open after
+// threshold: it sets the [AttrLongTask] attribute to true on the span and adds one
+// to the otx.span.long_tasks counter, with the span name as the span.name attribute.
+// Alert on the counter to catch emerging slow operations without deriving metrics
+// in the backend.
+//
+// Spans are flagged by a timer while they are still open, so a stuck span is counted
+// before it ends. Each open span holds a timer, so the processor is opt-in.
+// If mp is nil, the global MeterProvider is used. A threshold <= 0 disables flagging.
+//
+// It is registered automatically by [NewTracerProvider] when traces.longTaskThreshold
+// is set.
+//
+// Example:
+//
+//	tp, err := otx.NewTracerProvider(ctx, cfg,
+//	    otx.WithSpanProcessor(otx.NewLongTaskProcessor(2*time.Second, meterProvider)),
+//	)
+func NewLongTaskProcessor(threshold time.Duration, mp metric.MeterProvider) sdktrace.SpanProcessor {
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+
+	counter, err := mp.Meter(meterName).Int64Counter(metricLongTasks,
+		metric.WithUnit("{span}"),
+		metric.WithDescription("Spans that exceeded the long-task threshold."),
+	)
+	if err != nil {
+		otel.Handle(err)
+		counter, _ = noop.NewMeterProvider().Meter(meterName).Int64Counter(metricLongTasks)
+	}
+
+	return &longTaskProcessor{
+		threshold: threshold,
+		counter:   counter,
+	}
+}
+
+// OnStart implements sdktrace.SpanProcessor.
+func (p *longTaskProcessor) OnStart(_ context.Context, s sdktrace.ReadWriteSpan) {
+	if p.threshold <= 0 {
+		return
+	}
+
+	// The timer flags the span only if it is still in the table when it fires, so a
+	// span ending as the threshold passes is either flagged or stopped, never both.
+	key := keyOf(s)
+	p.timers.start(s, func() *time.Timer {
+		return time.AfterFunc(p.threshold, func() {
+			if _, open := p.timers.remove(key); !open {
+				return
+			}
+
+			s.SetAttributes(attribute.Bool(AttrLongTask, true))
+			p.counter.Add(context.Background(), 1, metric.WithAttributes(attribute.String(attrSpanName, s.Name())))
+		})
+	})
+}
+
+// OnEnd implements sdktrace.SpanProcessor.
+func (p *longTaskProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if timer, ok := p.timers.remove(keyOf(s)); ok {
+		timer.Stop()
+	}
+}
+
+// Shutdown implements sdktrace.SpanProcessor.
+func (p *longTaskProcessor) Shutdown(_ context.Context) error {
+	p.timers.reset(func(timer *time.Timer) { timer.Stop() })
+
+	return nil
+}
+
+// ForceFlush implements sdktrace.SpanProcessor.
+func (*longTaskProcessor) ForceFlush(_ context.Context) error {
+	return nil
+}
+
+// openSpans implements spanTracker.
+func (p *longTaskProcessor) openSpans() int {
+	return p.timers.len()
+}
@@ -0,0 +1,93 @@
+package otx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// longTaskCounts collects the otx.span.long_tasks counter by span name.
+func longTaskCounts(t *testing.T, reader sdkmetric.Reader) map[string]int64 {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(t.Context(), &rm))
+
+	counts := make(map[string]int64)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if m.Name != metricLongTasks || !ok {
+				continue
+			}
+			for _, dp := range sum.DataPoints {
+				name, _ := dp.Attributes.Value(attrSpanName)
+				counts[name.AsString()] += dp.Value
+			}
+		}
+	}
+
+	return counts
+}
+
+func TestNewLongTaskProcessor(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(NewLongTaskProcessor(time.Millisecond, mp)),
+		sdktrace.WithSyncer(exporter),
+	)
+	defer func() { _ = tp.Shutdown(t.Context()) }()
+
+	_, slow := tp.Tracer("test").Start(t.Context(), "slow")
+	require.Eventually(t, func() bool {
+		return longTaskCounts(t, reader)["slow"] == 1
+	}, 5*time.Second, time.Millisecond)
+	slow.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.True(t, hasAttribute(spans[0].Attributes, attribute.Bool(AttrLongTask, true)))
+}
+
+func TestNewLongTaskProcessor_FastSpan(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(NewLongTaskProcessor(time.Hour, mp)),
+		sdktrace.WithSyncer(exporter),
+	)
+	defer func() { _ = tp.Shutdown(t.Context()) }()
+
+	_, fast := tp.Tracer("test").Start(t.Context(), "fast")
+	fast.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.False(t, hasKey(spans[0].Attributes, AttrLongTask))
+	assert.Empty(t, longTaskCounts(t, reader))
+}
+
+func TestNewTracerProvider_LongTaskThreshold(t *testing.T) {
+	cfg := &TelemetryConfig{
+		Enabled:     boolPtr(true),
+		ServiceName: "test-service",
+		Traces: &TracesConfig{
+			Exporter:          "none",
+			LongTaskThreshold: time.Second,
+		},
+	}
+
+	processors := spanProcessors(cfg.Traces, nil)
+	require.Len(t, processors, 1)
+	assert.IsType(t, &longTaskProcessor{}, processors[0])
+}
@@ -0,0 +1,157 @@
+package otx
+
+import (
+	"strings"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// Temporality preferences for MetricsConfig.Temporality, as defined for
+// OTEL_EXPORTER_OTLP_METRICS_TEMPORALITY_PREFERENCE.
+const (
+	TemporalityCumulative = "cumulative"
+	TemporalityDelta      = "delta"
+	TemporalityLowMemory  = "lowmemory"
+)
+
+// Aggregations for MetricsConfig.Aggregations.
+const (
+	AggregationDefault                         = "default"
+	AggregationDrop                            = "drop"
+	AggregationSum                             = "sum"
+	AggregationLastValue                       = "last_value"
+	AggregationExplicitBucketHistogram         = "explicit_bucket_histogram"
+	AggregationBase2ExponentialBucketHistogram = "base2_exponential_bucket_histogram"
+)
+
+// instrumentKinds maps the instrument kind keys of MetricsConfig.Aggregations to kinds.
+var instrumentKinds = map[string]sdkmetric.InstrumentKind{
+	"counter":                    sdkmetric.InstrumentKindCounter,
+	"up_down_counter":            sdkmetric.InstrumentKindUpDownCounter,
+	"histogram":                  sdkmetric.InstrumentKindHistogram,
+	"gauge":                      sdkmetric.InstrumentKindGauge,
+	"observable_counter":         sdkmetric.InstrumentKindObservableCounter,
+	"observable_up_down_counter": sdkmetric.InstrumentKindObservableUpDownCounter,
+	"observable_gauge":           sdkmetric.InstrumentKindObservableGauge,
+}
+
+// selectorExporter overrides the temporality and default aggregation of an exporter.
+type selectorExporter struct {
+	sdkmetric.Exporter
+
+	temporality sdkmetric.TemporalitySelector
+	aggregation sdkmetric.AggregationSelector
+}
+
+// withMetricSelectors applies metrics.temporality and metrics.aggregations to exporter.
+func withMetricSelectors(exporter sdkmetric.Exporter, cfg *MetricsConfig) sdkmetric.Exporter {
+	if cfg == nil || (cfg.Temporality == "" && len(cfg.Aggregations) == 0) {
+		return exporter
+	}
+
+	e := &selectorExporter{
+		Exporter:    exporter,
+		temporality: exporter.Temporality,
+		aggregation: exporter.Aggregation,
+	}
+	if cfg.Temporality != "" {
+		e.temporality = temporalitySelector(cfg.Temporality)
+	}
+	if len(cfg.Aggregations) > 0 {
+		e.aggregation = aggregationSelector(cfg.Aggregations, exporter.Aggregation)
+	}
+
+	return e
+}
+
+// Temporality implements sdkmetric.Exporter.
+func (e *selectorExporter) Temporality(k sdkmetric.InstrumentKind) metricdata.Temporality {
+	return e.temporality(k)
+}
+
+// Aggregation implements sdkmetric.Exporter.
+func (e *selectorExporter) Aggregation(k sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+	return e.aggregation(k)
+}
+
+// temporalitySelector returns the selector for a temporality preference. Delta
+// applies to monotonic sums and histograms; up-down counters and gauges stay
+// cumulative, as in the OTLP exporter specification.
+func temporalitySelector(preference string) sdkmetric.TemporalitySelector {
+	switch strings.ToLower(preference) {
+	case TemporalityDelta:
+		return func(k sdkmetric.InstrumentKind) metricdata.Temporality {
+			switch k {
+			case sdkmetric.InstrumentKindCounter, sdkmetric.InstrumentKindObservableCounter,
+				sdkmetric.InstrumentKindHistogram:
+				return metricdata.DeltaTemporality
+			default:
+				return metricdata.CumulativeTemporality
+			}
+		}
+	case TemporalityLowMemory:
+		return func(k sdkmetric.InstrumentKind) metricdata.Temporality {
+			switch k {
+			case sdkmetric.InstrumentKindCounter, sdkmetric.InstrumentKindHistogram:
+				return metricdata.DeltaTemporality
+			default:
+				return metricdata.CumulativeTemporality
+			}
+		}
+	default:
+		return sdkmetric.DefaultTemporalitySelector
+	}
+}
+
+// aggregationSelector returns a selector using aggregations for the listed
+// instrument kinds and fallback for the others.
+func aggregationSelector(
+	aggregations map[string]string, fallback sdkmetric.AggregationSelector,
+) sdkmetric.AggregationSelector {
+	byKind := make(map[sdkmetric.InstrumentKind]sdkmetric.Aggregation, len(aggregations))
+	for key, name := range aggregations {
+		kind, ok := instrumentKinds[key]
+		if !ok {
+			continue
+		}
+		if agg := buildAggregation(name, kind); agg != nil {
+			byKind[kind] = agg
+		}
+	}
+
+	return func(k sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+		if agg, ok := byKind[k]; ok {
+			return agg
+		}
+
+		return fallback(k)
+	}
+}
+
+// isAggregation reports whether name is a known aggregation.
+func isAggregation(name string) bool {
+	return buildAggregation(name, sdkmetric.InstrumentKindCounter) != nil
+}
+
+// buildAggregation returns the aggregation named name for instruments of kind,
+// or nil if the name is unknown.
+func buildAggregation(name string, kind sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+	switch strings.ToLower(name) {
+	case AggregationDefault:
+		return sdkmetric.DefaultAggregationSelector(kind)
+	case AggregationDrop:
+		return sdkmetric.AggregationDrop{}
+	case AggregationSum:
+		return sdkmetric.AggregationSum{}
+	case AggregationLastValue:
+		return sdkmetric.AggregationLastValue{}
+	case AggregationExplicitBucketHistogram:
+		// The default histogram aggregation, with the default boundaries
+		return sdkmetric.DefaultAggregationSelector(sdkmetric.InstrumentKindHistogram)
+	case AggregationBase2ExponentialBucketHistogram:
+		return sdkmetric.AggregationBase2ExponentialHistogram{MaxSize: 160, MaxScale: 20}
+	default:
+		return nil
+	}
+}
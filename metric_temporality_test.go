@@ -0,0 +1,58 @@
+package otx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestWithMetricSelectors_Temporality(t *testing.T) {
+	tests := []struct {
+		preference string
+		delta      []sdkmetric.InstrumentKind
+	}{
+		{preference: "cumulative"},
+		{preference: "delta", delta: []sdkmetric.InstrumentKind{
+			sdkmetric.InstrumentKindCounter, sdkmetric.InstrumentKindObservableCounter, sdkmetric.InstrumentKindHistogram,
+		}},
+		{preference: "LowMemory", delta: []sdkmetric.InstrumentKind{
+			sdkmetric.InstrumentKindCounter, sdkmetric.InstrumentKindHistogram,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.preference, func(t *testing.T) {
+			exporter := withMetricSelectors(newNopMetricExporter(), &MetricsConfig{Temporality: tt.preference})
+			for name, kind := range instrumentKinds {
+				want := metricdata.CumulativeTemporality
+				for _, k := range tt.delta {
+					if k == kind {
+						want = metricdata.DeltaTemporality
+					}
+				}
+				assert.Equal(t, want, exporter.Temporality(kind), name)
+			}
+		})
+	}
+}
+
+func TestWithMetricSelectors_Aggregations(t *testing.T) {
+	base := newNopMetricExporter()
+	assert.Equal(t, base, withMetricSelectors(base, &MetricsConfig{}), "nothing to override")
+
+	exporter := withMetricSelectors(base, &MetricsConfig{Aggregations: map[string]string{
+		"histogram":       AggregationBase2ExponentialBucketHistogram,
+		"up_down_counter": AggregationDrop,
+		"gauge":           AggregationDefault,
+	}})
+
+	assert.IsType(t, sdkmetric.AggregationBase2ExponentialHistogram{},
+		exporter.Aggregation(sdkmetric.InstrumentKindHistogram))
+	assert.Equal(t, sdkmetric.AggregationDrop{}, exporter.Aggregation(sdkmetric.InstrumentKindUpDownCounter))
+	assert.Equal(t, sdkmetric.AggregationLastValue{}, exporter.Aggregation(sdkmetric.InstrumentKindGauge))
+	assert.Equal(t, base.Aggregation(sdkmetric.InstrumentKindCounter),
+		exporter.Aggregation(sdkmetric.InstrumentKindCounter), "unlisted kinds keep the exporter default")
+	assert.Equal(t, metricdata.CumulativeTemporality, exporter.Temporality(sdkmetric.InstrumentKindCounter))
+}
@@ -0,0 +1,95 @@
+package otx
+
+import (
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// MetricView customizes the metric streams of matching instruments: it can rename
+// them, filter their attributes and set explicit histogram bucket boundaries.
+//
+// Instrument is required and may contain the wildcards "*" and "?"; a view whose
+// Instrument contains a wildcard cannot set Name.
+type MetricView struct {
+	// Instrument is the instrument name to match, e.g. "rpc.server.duration" or "http.*".
+	Instrument string `yaml:"instrument"`
+
+	// Meter restricts the view to instruments created by the meter with this name,
+	// e.g. "go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc".
+	Meter string `yaml:"meter,omitempty"`
+
+	// Name renames the matching stream.
+	Name string `yaml:"name,omitempty"`
+
+	// AttributeKeys keeps only these attribute keys, dropping all others.
+	AttributeKeys []string `yaml:"attributeKeys,omitempty"`
+
+	// ExcludeAttributeKeys drops these attribute keys, e.g. high-cardinality ones.
+	// It cannot be combined with AttributeKeys.
+	ExcludeAttributeKeys []string `yaml:"excludeAttributeKeys,omitempty"`
+
+	// Buckets sets explicit bucket boundaries for histograms, in increasing order,
+	// e.g. [0.0001, 0.0005, 0.001, 0.005] for sub-millisecond RPCs in seconds.
+	Buckets []float64 `yaml:"buckets,omitempty"`
+}
+
+// View returns the sdkmetric.View described by v.
+//
+// Example:
+//
+//	mp := sdkmetric.NewMeterProvider(sdkmetric.WithView(otx.MetricView{
+//	    Instrument: "rpc.server.duration",
+//	    Buckets:    []float64{0.0001, 0.0005, 0.001, 0.005, 0.01},
+//	}.View()))
+func (v MetricView) View() sdkmetric.View {
+	stream := sdkmetric.Stream{Name: v.Name}
+	switch {
+	case len(v.AttributeKeys) > 0:
+		stream.AttributeFilter = attribute.NewAllowKeysFilter(attributeKeys(v.AttributeKeys)...)
+	case len(v.ExcludeAttributeKeys) > 0:
+		stream.AttributeFilter = attribute.NewDenyKeysFilter(attributeKeys(v.ExcludeAttributeKeys)...)
+	}
+	if len(v.Buckets) > 0 {
+		stream.Aggregation = sdkmetric.AggregationExplicitBucketHistogram{
+			Boundaries: slices.Clone(v.Buckets),
+		}
+	}
+
+	return sdkmetric.NewView(sdkmetric.Instrument{
+		Name:  v.Instrument,
+		Scope: instrumentation.Scope{Name: v.Meter},
+	}, stream)
+}
+
+// hasWildcard reports whether the instrument name of v is a pattern.
+func (v MetricView) hasWildcard() bool {
+	return strings.ContainsAny(v.Instrument, "*?")
+}
+
+// attributeKeys converts strings to attribute keys.
+func attributeKeys(keys []string) []attribute.Key {
+	result := make([]attribute.Key, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, attribute.Key(key))
+	}
+
+	return result
+}
+
+// metricViewOptions converts metrics.views into MeterProvider options.
+func metricViewOptions(cfg *MetricsConfig) []sdkmetric.Option {
+	if cfg == nil || len(cfg.Views) == 0 {
+		return nil
+	}
+
+	views := make([]sdkmetric.View, 0, len(cfg.Views))
+	for _, v := range cfg.Views {
+		views = append(views, v.View())
+	}
+
+	return []sdkmetric.Option{sdkmetric.WithView(views...)}
+}
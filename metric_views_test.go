@@ -0,0 +1,86 @@
+package otx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestMetricView_View(t *testing.T) {
+	cfg := &MetricsConfig{Views: []MetricView{
+		{Instrument: "rpc.server.duration", Buckets: []float64{0.0001, 0.001, 0.01}, AttributeKeys: []string{"rpc.method"}},
+		{Instrument: "http.*", Meter: "web", ExcludeAttributeKeys: []string{"user.id"}},
+		{Instrument: "jobs", Name: "queue.jobs"},
+	}}
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(append(metricViewOptions(cfg), sdkmetric.WithReader(reader))...)
+	defer func() { _ = mp.Shutdown(t.Context()) }()
+	ctx := t.Context()
+
+	duration, err := mp.Meter("rpc").Float64Histogram("rpc.server.duration")
+	require.NoError(t, err)
+	duration.Record(ctx, 0.0005, metric.WithAttributes(
+		attribute.String("rpc.method", "Get"), attribute.String("peer", "10.0.0.1")))
+
+	requests, err := mp.Meter("web").Int64Counter("http.requests")
+	require.NoError(t, err)
+	requests.Add(ctx, 1, metric.WithAttributes(attribute.String("user.id", "u1"), attribute.String("route", "/")))
+
+	other, err := mp.Meter("other").Int64Counter("http.requests")
+	require.NoError(t, err)
+	other.Add(ctx, 1, metric.WithAttributes(attribute.String("user.id", "u1")))
+
+	jobs, err := mp.Meter("worker").Int64Counter("jobs")
+	require.NoError(t, err)
+	jobs.Add(ctx, 1)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	metrics := map[string]metricdata.Metrics{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[sm.Scope.Name+"/"+m.Name] = m
+		}
+	}
+
+	hist, ok := metrics["rpc/rpc.server.duration"].Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, hist.DataPoints, 1)
+	assert.Equal(t, []float64{0.0001, 0.001, 0.01}, hist.DataPoints[0].Bounds)
+	assert.Equal(t, []uint64{0, 1, 0, 0}, hist.DataPoints[0].BucketCounts)
+	assert.Equal(t, attribute.NewSet(attribute.String("rpc.method", "Get")), hist.DataPoints[0].Attributes)
+
+	sum, ok := metrics["web/http.requests"].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	assert.Equal(t, attribute.NewSet(attribute.String("route", "/")), sum.DataPoints[0].Attributes)
+
+	sum, ok = metrics["other/http.requests"].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	assert.Equal(t, attribute.NewSet(attribute.String("user.id", "u1")), sum.DataPoints[0].Attributes,
+		"views are scoped to their meter")
+
+	assert.Contains(t, metrics, "worker/queue.jobs")
+}
+
+func TestValidate_MetricViews(t *testing.T) {
+	cfg := &TelemetryConfig{Metrics: &MetricsConfig{Exporter: "console", Views: []MetricView{
+		{Instrument: "rpc.*", Name: "rpc"},
+		{Name: "x"},
+		{Instrument: "a", AttributeKeys: []string{"k"}, ExcludeAttributeKeys: []string{"j"}},
+		{Instrument: "b", Buckets: []float64{1, 1, 2}},
+		{Instrument: "c", Buckets: []float64{0.001, 0.01}},
+	}}}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `metrics.views[0].name cannot rename instrument pattern "rpc.*"`)
+	assert.Contains(t, err.Error(), "metrics.views[1].instrument is required")
+	assert.Contains(t, err.Error(), "metrics.views[2]: attributeKeys and excludeAttributeKeys are mutually exclusive")
+	assert.Contains(t, err.Error(), "metrics.views[3].buckets must be strictly increasing")
+	assert.NotContains(t, err.Error(), "metrics.views[4]")
+}
@@ -0,0 +1,35 @@
+// Package middleware provides a standardized, tenant-aware middleware stack for
+// HTTP and gRPC servers.
+//
+// [Standard] composes tracing, metrics, request IDs, tenant baggage extraction and
+// panic recovery in a fixed order, so every service propagates context the same way:
+//
+//  1. Tracing and metrics (otelhttp / otelgrpc) extract the remote trace context
+//     and baggage and start the server span.
+//  2. Request ID is read from the request (or generated), echoed in the response
+//     and recorded on the span.
+//  3. Tenant ID is read from baggage, falling back to the tenant header, and is
+//     added to baggage so outbound calls propagate it.
+//  4. When RecordInFlight or OverloadThreshold is set, the request is counted as
+//     in flight and the span is annotated with the count or an overload event.
+//  5. Panic recovery runs innermost, so panics are recorded on the server span
+//     before it ends and the request fails with 500 (HTTP) or Internal (gRPC).
+//
+// # HTTP Server
+//
+//	stack := middleware.Standard(middleware.Config{})
+//	http.ListenAndServe(":8080", stack.HTTP(mux))
+//
+// # gRPC Server
+//
+//	stack := middleware.Standard(middleware.Config{})
+//	server := grpc.NewServer(stack.GRPCServerOptions()...)
+//
+// # Handlers
+//
+//	func handle(w http.ResponseWriter, r *http.Request) {
+//	    requestID := middleware.RequestID(r.Context())
+//	    tenant := middleware.Tenant(r.Context())
+//	    // ...
+//	}
+package middleware
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	otxgrpc "github.com/arloliu/otx/grpc"
+)
+
+// wrappedStream overrides the context of a grpc.ServerStream.
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// GRPCServerOptions returns the server options installing the standard stack:
+// the tracing and metrics stats handler, followed by request ID, tenant baggage,
+// in-flight tracking and panic recovery interceptors.
+//
+// Further interceptors added with grpc.ChainUnaryInterceptor run inside the stack.
+//
+// Usage:
+//
+//	stack := middleware.Standard(middleware.Config{})
+//	server := grpc.NewServer(stack.GRPCServerOptions()...)
+func (s *Stack) GRPCServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.StatsHandler(otxgrpc.ServerHandlerWithProviders(s.cfg.TracerProvider, s.cfg.MeterProvider, s.cfg.Propagator)),
+		grpc.ChainUnaryInterceptor(s.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(s.StreamServerInterceptor()),
+	}
+}
+
+// UnaryServerInterceptor returns the request ID, tenant, in-flight and recovery
+// layers as a unary interceptor. It expects the otx gRPC stats handler to be
+// installed; prefer GRPCServerOptions, which installs both.
+func (s *Stack) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		ctx = s.prepareGRPC(ctx)
+		defer s.trackInFlight(ctx)()
+		defer s.recoverGRPC(ctx, &err)
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns the request ID, tenant, in-flight and recovery
+// layers as a stream interceptor. It expects the otx gRPC stats handler to be
+// installed; prefer GRPCServerOptions, which installs both.
+func (s *Stack) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		ctx := s.prepareGRPC(ss.Context())
+		defer s.trackInFlight(ctx)()
+		defer s.recoverGRPC(ctx, &err)
+
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// prepareGRPC applies the request ID and tenant layers using incoming metadata.
+// The request ID is echoed in the response header metadata.
+func (s *Stack) prepareGRPC(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	ctx, id := s.withRequestID(ctx, firstValue(md, s.cfg.RequestIDHeader))
+	_ = grpc.SetHeader(ctx, metadata.Pairs(s.cfg.RequestIDHeader, id))
+
+	return s.withTenant(ctx, firstValue(md, s.cfg.TenantHeader))
+}
+
+// recoverGRPC turns a panic into a codes.Internal error after recording it on the span.
+// It must be called directly by defer.
+func (s *Stack) recoverGRPC(ctx context.Context, err *error) {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+
+	s.recordPanic(ctx, recovered)
+	*err = status.Error(codes.Internal, "internal error")
+}
+
+// firstValue returns the first metadata value for key (case-insensitive), or "".
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(strings.ToLower(key)); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// Context returns the context carrying the request ID and tenant.
+func (w *wrappedStream) Context() context.Context {
+	return w.ctx
+}
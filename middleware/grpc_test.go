@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/arloliu/otx"
+)
+
+func TestStackGRPC(t *testing.T) {
+	stack, exporter := newTestStack(t, Config{})
+
+	var gotRequestID, gotTenant, gotBaggage string
+	capture := func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		gotRequestID = RequestID(ctx)
+		gotTenant = Tenant(ctx)
+		gotBaggage = otx.GetBaggage(ctx, DefaultTenantBaggageKey)
+
+		return handler(ctx, req)
+	}
+
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(append(stack.GRPCServerOptions(), grpc.ChainUnaryInterceptor(capture))...)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go func() { _ = server.Serve(lis) }()
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough://bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx := metadata.AppendToOutgoingContext(t.Context(),
+		"traceparent", testTraceparent,
+		"x-tenant-id", "globex",
+		"x-request-id", "req-456",
+	)
+	var header metadata.MD
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Header(&header))
+	require.NoError(t, err)
+
+	assert.Equal(t, "req-456", gotRequestID)
+	assert.Equal(t, "globex", gotTenant)
+	assert.Equal(t, "globex", gotBaggage)
+	assert.Equal(t, []string{"req-456"}, header.Get(DefaultRequestIDHeader))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, testTraceID, spans[0].SpanContext.TraceID().String())
+	assert.Equal(t, "req-456", spanAttr(spans[0].Attributes, AttrRequestID))
+	assert.Equal(t, "globex", spanAttr(spans[0].Attributes, AttrTenantID))
+}
+
+func TestStackGRPC_UnaryRecovery(t *testing.T) {
+	var panicked any
+	stack := Standard(Config{OnPanic: func(_ context.Context, recovered any) { panicked = recovered }})
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	ctx, span := tp.Tracer("test").Start(t.Context(), "server")
+
+	_, err := stack.UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{},
+		func(context.Context, any) (any, error) { panic("boom") })
+	span.End()
+
+	assert.Equal(t, grpccodes.Internal, status.Code(err))
+	assert.Equal(t, "boom", panicked)
+	require.Len(t, exporter.GetSpans(), 1)
+	assert.Equal(t, codes.Error, exporter.GetSpans()[0].Status.Code)
+}
+
+func TestStackGRPC_Overload(t *testing.T) {
+	stack := Standard(Config{OverloadThreshold: 1})
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	interceptor := stack.UnaryServerInterceptor()
+	call := func(name string, handler grpc.UnaryHandler) {
+		ctx, span := tp.Tracer("test").Start(t.Context(), name)
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+		span.End()
+		require.NoError(t, err)
+	}
+	noop := func(context.Context, any) (any, error) { return nil, nil }
+
+	call("outer", func(context.Context, any) (any, error) {
+		call("inner", noop)
+
+		return nil, nil
+	})
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "inner", spans[0].Name)
+	require.Len(t, spans[0].Events, 1)
+	assert.Equal(t, EventOverload, spans[0].Events[0].Name)
+	assert.Empty(t, spans[1].Events)
+	for _, kv := range spans[0].Attributes {
+		assert.NotEqual(t, AttrInFlight, string(kv.Key), "in-flight attribute is only recorded with RecordInFlight")
+	}
+}
+
+func TestStackGRPC_StreamContext(t *testing.T) {
+	stack := Standard(Config{})
+	ctx := metadata.NewIncomingContext(t.Context(), metadata.Pairs("x-tenant-id", "acme"))
+
+	var gotTenant, gotRequestID string
+	err := stack.StreamServerInterceptor()(nil, &fakeStream{ctx: ctx}, &grpc.StreamServerInfo{},
+		func(_ any, ss grpc.ServerStream) error {
+			gotTenant = Tenant(ss.Context())
+			gotRequestID = RequestID(ss.Context())
+
+			return nil
+		})
+
+	require.NoError(t, err)
+	assert.Equal(t, "acme", gotTenant)
+	assert.Len(t, gotRequestID, 32)
+}
+
+// fakeStream is a grpc.ServerStream with a fixed context.
+type fakeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (f *fakeStream) Context() context.Context { return f.ctx }
+
+func (*fakeStream) SetHeader(metadata.MD) error { return nil }
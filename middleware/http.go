@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+
+	otxhttp "github.com/arloliu/otx/http"
+)
+
+// HTTP wraps next with the standard stack: tracing and metrics, request ID,
+// tenant baggage, in-flight tracking and panic recovery, in that order.
+//
+// Usage:
+//
+//	stack := middleware.Standard(middleware.Config{})
+//	http.ListenAndServe(":8080", stack.HTTP(mux))
+func (s *Stack) HTTP(next http.Handler) http.Handler {
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, id := s.withRequestID(r.Context(), r.Header.Get(s.cfg.RequestIDHeader))
+		w.Header().Set(s.cfg.RequestIDHeader, id)
+		ctx = s.withTenant(ctx, r.Header.Get(s.cfg.TenantHeader))
+		defer s.trackInFlight(ctx)()
+
+		s.recoverHTTP(next).ServeHTTP(w, r.WithContext(ctx))
+	})
+
+	return otxhttp.MiddlewareWithProviders(s.cfg.TracerProvider, s.cfg.MeterProvider, s.cfg.Propagator)(inner)
+}
+
+// recoverHTTP turns panics in next into 500 responses after recording them on the span.
+// http.ErrAbortHandler is re-panicked, since net/http uses it to abort a response silently.
+func (s *Stack) recoverHTTP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+
+			s.recordPanic(r.Context(), recovered)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,183 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/arloliu/otx"
+)
+
+const (
+	testTraceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testTraceparent = "00-" + testTraceID + "-00f067aa0ba902b7-01"
+)
+
+func newTestStack(t *testing.T, cfg Config) (*Stack, *tracetest.InMemoryExporter) {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	cfg.TracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	cfg.MeterProvider = noop.NewMeterProvider()
+	cfg.Propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+	return Standard(cfg), exporter
+}
+
+func spanAttr(attrs []attribute.KeyValue, key string) string {
+	for _, kv := range attrs {
+		if string(kv.Key) == key {
+			return kv.Value.AsString()
+		}
+	}
+
+	return ""
+}
+
+func TestStackHTTP(t *testing.T) {
+	stack, exporter := newTestStack(t, Config{})
+
+	var gotRequestID, gotTenant, gotBaggage string
+	handler := stack.HTTP(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRequestID = RequestID(r.Context())
+		gotTenant = Tenant(r.Context())
+		gotBaggage = otx.GetBaggage(r.Context(), DefaultTenantBaggageKey)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		name          string
+		headers       map[string]string
+		wantRequestID string
+		wantTenant    string
+	}{
+		{
+			name:       "tenant from baggage wins over header",
+			headers:    map[string]string{"traceparent": testTraceparent, "baggage": "tenant.id=acme", "X-Tenant-ID": "other"},
+			wantTenant: "acme",
+		},
+		{
+			name:          "tenant from header, incoming request ID",
+			headers:       map[string]string{"X-Tenant-ID": "globex", "X-Request-ID": "req-123"},
+			wantRequestID: "req-123",
+			wantTenant:    "globex",
+		},
+		{name: "no tenant"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter.Reset()
+			req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusNoContent, rec.Code)
+			require.NotEmpty(t, gotRequestID)
+			if tt.wantRequestID != "" {
+				assert.Equal(t, tt.wantRequestID, gotRequestID)
+			}
+			assert.Equal(t, gotRequestID, rec.Header().Get(DefaultRequestIDHeader))
+			assert.Equal(t, tt.wantTenant, gotTenant)
+			assert.Equal(t, tt.wantTenant, gotBaggage, "tenant is propagated in baggage")
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			assert.Equal(t, gotRequestID, spanAttr(spans[0].Attributes, AttrRequestID))
+			assert.Equal(t, tt.wantTenant, spanAttr(spans[0].Attributes, AttrTenantID))
+			if tt.headers["traceparent"] != "" {
+				assert.Equal(t, testTraceID, spans[0].SpanContext.TraceID().String())
+			}
+		})
+	}
+}
+
+func TestStackHTTP_LongRequestIDReplaced(t *testing.T) {
+	stack, _ := newTestStack(t, Config{RequestIDHeader: "X-Correlation-ID"})
+
+	handler := stack.HTTP(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Correlation-ID", string(make([]byte, maxRequestIDLength+1)))
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Len(t, rec.Header().Get("X-Correlation-ID"), 32)
+}
+
+func TestStackHTTP_Recovery(t *testing.T) {
+	var panicked any
+	stack, exporter := newTestStack(t, Config{
+		OnPanic: func(_ context.Context, recovered any) { panicked = recovered },
+	})
+
+	handler := stack.HTTP(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}))
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "boom", panicked)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1, "span is ended despite the panic")
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	require.NotEmpty(t, spans[0].Events)
+	assert.Equal(t, "exception", spans[0].Events[0].Name)
+}
+
+func TestStackHTTP_AbortHandler(t *testing.T) {
+	stack, _ := newTestStack(t, Config{})
+
+	handler := stack.HTTP(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+}
+
+func TestStackHTTP_InFlight(t *testing.T) {
+	stack, exporter := newTestStack(t, Config{RecordInFlight: true, OverloadThreshold: 1})
+
+	var handler http.Handler
+	handler = stack.HTTP(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/outer" {
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/inner", nil))
+		}
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/outer", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/after", nil))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+	inner, outer, after := spans[0], spans[1], spans[2]
+
+	assert.Contains(t, inner.Attributes, attribute.Int64(AttrInFlight, 2))
+	require.Len(t, inner.Events, 1)
+	assert.Equal(t, EventOverload, inner.Events[0].Name)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.Int64(AttrInFlight, 2),
+		attribute.Int(AttrInFlightThreshold, 1),
+	}, inner.Events[0].Attributes)
+
+	assert.Contains(t, outer.Attributes, attribute.Int64(AttrInFlight, 1))
+	assert.Empty(t, outer.Events)
+	assert.Contains(t, after.Attributes, attribute.Int64(AttrInFlight, 1), "finished requests are no longer counted")
+}
@@ -0,0 +1,194 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/arloliu/otx"
+)
+
+// Default header and baggage keys used by Standard.
+const (
+	DefaultRequestIDHeader  = "X-Request-ID"
+	DefaultTenantHeader     = "X-Tenant-ID"
+	DefaultTenantBaggageKey = "tenant.id"
+)
+
+// Span attribute keys recorded by the stack.
+const (
+	AttrRequestID         = "request.id"
+	AttrTenantID          = "tenant.id"
+	AttrInFlight          = "otx.server.in_flight"
+	AttrInFlightThreshold = "otx.server.in_flight.threshold"
+)
+
+// EventOverload is the name of the span event added when OverloadThreshold is exceeded.
+const EventOverload = "otx.server.overload"
+
+// maxRequestIDLength bounds incoming request IDs to keep span attributes small.
+const maxRequestIDLength = 128
+
+// Config configures the standard middleware stack.
+// The zero value is ready to use with global providers and default keys.
+type Config struct {
+	// TracerProvider, MeterProvider and Propagator default to the global ones when nil.
+	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
+	Propagator     propagation.TextMapPropagator
+
+	// RequestIDHeader is the header (or gRPC metadata key) carrying the request ID.
+	// Defaults to "X-Request-ID".
+	RequestIDHeader string
+
+	// TenantHeader is the header (or gRPC metadata key) read when the tenant is
+	// not in baggage. Defaults to "X-Tenant-ID".
+	TenantHeader string
+
+	// TenantBaggageKey is the baggage member holding the tenant ID.
+	// Defaults to "tenant.id".
+	TenantBaggageKey string
+
+	// OnPanic is called with the recovered value after the panic has been
+	// recorded on the span, e.g. to log it or increment a counter. Optional.
+	OnPanic func(ctx context.Context, recovered any)
+
+	// RecordInFlight records the number of requests in flight on the stack,
+	// including the current one, as the otx.server.in_flight span attribute.
+	RecordInFlight bool
+
+	// OverloadThreshold, when positive, adds an otx.server.overload event to the
+	// span of every request started while more than this many requests are in
+	// flight on the stack, linking overload conditions to the affected traces.
+	OverloadThreshold int
+}
+
+// Stack is a composed middleware stack created by Standard.
+type Stack struct {
+	cfg      Config
+	inFlight atomic.Int64
+}
+
+type (
+	requestIDKey struct{}
+	tenantKey    struct{}
+)
+
+// Standard returns the standard middleware stack. See the package documentation
+// for the order in which its layers run.
+func Standard(cfg Config) *Stack {
+	if cfg.RequestIDHeader == "" {
+		cfg.RequestIDHeader = DefaultRequestIDHeader
+	}
+	if cfg.TenantHeader == "" {
+		cfg.TenantHeader = DefaultTenantHeader
+	}
+	if cfg.TenantBaggageKey == "" {
+		cfg.TenantBaggageKey = DefaultTenantBaggageKey
+	}
+
+	return &Stack{cfg: cfg}
+}
+
+// RequestID returns the request ID stored by the stack, or "" if there is none.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+
+	return id
+}
+
+// ContextWithRequestID returns a copy of ctx carrying the request ID id.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// Tenant returns the tenant ID resolved by the stack, or "" if there is none.
+// The tenant is also available in baggage under TenantBaggageKey, so it
+// propagates to outbound calls.
+func Tenant(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+
+	return tenant
+}
+
+// newRequestID returns a random 128-bit hex request ID.
+func newRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+
+	return hex.EncodeToString(b[:])
+}
+
+// withRequestID stores the incoming request ID, or a new one if it is missing or
+// too long, in ctx and records it on the current span.
+func (*Stack) withRequestID(ctx context.Context, incoming string) (context.Context, string) {
+	id := incoming
+	if id == "" || len(id) > maxRequestIDLength {
+		id = newRequestID()
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String(AttrRequestID, id))
+
+	return ContextWithRequestID(ctx, id), id
+}
+
+// withTenant ensures the tenant is in baggage, taking it from the header value
+// when baggage has none, and records it on the current span and in ctx.
+func (s *Stack) withTenant(ctx context.Context, header string) context.Context {
+	tenant := otx.GetBaggage(ctx, s.cfg.TenantBaggageKey)
+	if tenant == "" && header != "" {
+		next, err := otx.SetBaggage(ctx, s.cfg.TenantBaggageKey, header)
+		if err != nil {
+			otel.Handle(fmt.Errorf("otx/middleware: ignoring tenant %q: %w", header, err))
+
+			return ctx
+		}
+		ctx, tenant = next, header
+	}
+	if tenant == "" {
+		return ctx
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String(AttrTenantID, tenant))
+
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// trackInFlight counts a request as in flight until the returned function is
+// called, annotating the current span as configured by RecordInFlight and
+// OverloadThreshold.
+func (s *Stack) trackInFlight(ctx context.Context) func() {
+	if !s.cfg.RecordInFlight && s.cfg.OverloadThreshold <= 0 {
+		return func() {}
+	}
+
+	n := s.inFlight.Add(1)
+	span := trace.SpanFromContext(ctx)
+	if s.cfg.RecordInFlight {
+		span.SetAttributes(attribute.Int64(AttrInFlight, n))
+	}
+	if threshold := s.cfg.OverloadThreshold; threshold > 0 && n > int64(threshold) {
+		span.AddEvent(EventOverload, trace.WithAttributes(
+			attribute.Int64(AttrInFlight, n),
+			attribute.Int(AttrInFlightThreshold, threshold),
+		))
+	}
+
+	return func() { s.inFlight.Add(-1) }
+}
+
+// recordPanic records a recovered panic on the current span and calls OnPanic.
+func (s *Stack) recordPanic(ctx context.Context, recovered any) {
+	span := trace.SpanFromContext(ctx)
+	otx.RecordSpanError(span, fmt.Errorf("panic: %v", recovered), trace.WithStackTrace(true))
+
+	if s.cfg.OnPanic != nil {
+		s.cfg.OnPanic(ctx, recovered)
+	}
+}
@@ -1,5 +1,32 @@
 package otx
 
+import (
+	"maps"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// defaultKindPrefixes is the table returned by DefaultKindPrefixes, shared by
+// KindInferringNamers without Prefixes.
+var defaultKindPrefixes = map[string]trace.SpanKind{
+	"GET ":     trace.SpanKindClient,
+	"HEAD ":    trace.SpanKindClient,
+	"POST ":    trace.SpanKindClient,
+	"PUT ":     trace.SpanKindClient,
+	"PATCH ":   trace.SpanKindClient,
+	"DELETE ":  trace.SpanKindClient,
+	"OPTIONS ": trace.SpanKindClient,
+	"SELECT ":  trace.SpanKindClient,
+	"INSERT ":  trace.SpanKindClient,
+	"UPDATE ":  trace.SpanKindClient,
+	"UPSERT ":  trace.SpanKindClient,
+	"MERGE ":   trace.SpanKindClient,
+	"publish ": trace.SpanKindProducer,
+	"receive ": trace.SpanKindConsumer,
+}
+
 // SpanNamer defines how operation names are transformed into span names.
 type SpanNamer interface {
 	Name(operation string) string
@@ -38,3 +65,80 @@ func NameMessaging(verb, destination string) string {
 func NameDB(verb, table string) string {
 	return verb + " " + table
 }
+
+// SpanKindInferrer is an optional interface of a [SpanNamer] that also chooses the
+// kind of spans started with [Start]. Returning trace.SpanKindUnspecified keeps the
+// default. Kinds given explicitly, e.g. by [StartServer] or trace.WithSpanKind,
+// always take precedence.
+type SpanKindInferrer interface {
+	SpanKind(operation string) trace.SpanKind
+}
+
+// SpanAttributeNamer is an optional interface of a [SpanNamer] that also uses the
+// attributes given to [Start], e.g. with trace.WithAttributes, to format span
+// names. When implemented, it is used instead of Name.
+type SpanAttributeNamer interface {
+	NameWithAttributes(operation string, attrs []attribute.KeyValue) string
+}
+
+// KindInferringNamer is a [SpanNamer] that infers the span kind from the operation
+// name, so spans started with plain [Start] are not all recorded as internal.
+// Prefixes are matched case-sensitively and the longest matching prefix wins.
+//
+// Example:
+//
+//	otx.InitTracing(tp.Tracer("my-service"), otx.KindInferringNamer{})
+//	ctx, span := otx.Start(ctx, "SELECT orders") // client span
+type KindInferringNamer struct {
+	// Namer formats span names. Nil returns operation names unchanged.
+	Namer SpanNamer
+
+	// Prefixes maps operation name prefixes to span kinds.
+	// Nil uses the prefixes returned by DefaultKindPrefixes.
+	Prefixes map[string]trace.SpanKind
+}
+
+// DefaultKindPrefixes returns the prefixes used by a [KindInferringNamer] without
+// Prefixes: uppercase HTTP methods and SQL verbs map to client, and the OTel
+// messaging operations "publish" and "receive" to producer and consumer. Only
+// verbs unlikely to start other operation names are included, so "process order"
+// stays internal. HTTP methods map to client because server spans are usually
+// started by the HTTP middleware rather than by hand.
+//
+// The result is a copy; extend it to build custom prefixes.
+//
+// Example:
+//
+//	prefixes := otx.DefaultKindPrefixes()
+//	prefixes["cache."] = trace.SpanKindClient
+//	otx.InitTracing(tracer, otx.KindInferringNamer{Prefixes: prefixes})
+func DefaultKindPrefixes() map[string]trace.SpanKind {
+	return maps.Clone(defaultKindPrefixes)
+}
+
+// Name returns the operation name formatted by Namer.
+func (n KindInferringNamer) Name(operation string) string {
+	if n.Namer == nil {
+		return operation
+	}
+
+	return n.Namer.Name(operation)
+}
+
+// SpanKind returns the kind of the longest prefix matching operation, or
+// trace.SpanKindUnspecified if none matches.
+func (n KindInferringNamer) SpanKind(operation string) trace.SpanKind {
+	prefixes := n.Prefixes
+	if prefixes == nil {
+		prefixes = defaultKindPrefixes
+	}
+
+	kind, matched := trace.SpanKindUnspecified, 0
+	for prefix, k := range prefixes {
+		if len(prefix) > matched && strings.HasPrefix(operation, prefix) {
+			kind, matched = k, len(prefix)
+		}
+	}
+
+	return kind
+}
@@ -0,0 +1,119 @@
+package otx
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ErrInvalidTemplate is returned by [NewTemplateNamer] for a malformed template.
+var ErrInvalidTemplate = errors.New("otx: invalid span name template")
+
+// TemplateNamer is a [SpanNamer] rendering span names from a template such as
+// "{service}.{operation}". A placeholder is replaced by, in order:
+//   - the operation name for {operation};
+//   - the value given to NewTemplateNamer, e.g. the service name from the config;
+//   - the attribute with that key given to [Start], e.g. {http.route}.
+//
+// Placeholders without a value render empty. Operation names should stay
+// low-cardinality, so only reference attributes with a bounded set of values.
+//
+// Per-call attributes are only seen when the namer is installed directly with
+// InitTracing; as the Namer of a [KindInferringNamer], only Name is used.
+//
+// Example:
+//
+//	namer, err := otx.NewTemplateNamer("{service}.{operation}", map[string]string{
+//	    "service": cfg.ServiceName,
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	otx.InitTracing(tp.Tracer("my-service"), namer)
+//	ctx, span := otx.Start(ctx, "LoadUser") // "orders.LoadUser"
+type TemplateNamer struct {
+	segments []templateSegment
+	values   map[string]string
+}
+
+// templateSegment is a literal or, if placeholder is set, a placeholder of a
+// template.
+type templateSegment struct {
+	text        string
+	placeholder bool
+}
+
+// NewTemplateNamer returns a TemplateNamer for template with the init-time values.
+// It returns an error wrapping [ErrInvalidTemplate] if a brace is unbalanced or a
+// placeholder is empty.
+func NewTemplateNamer(template string, values map[string]string) (*TemplateNamer, error) {
+	var segments []templateSegment
+	for rest := template; rest != ""; {
+		open := strings.IndexAny(rest, "{}")
+		if open < 0 {
+			segments = append(segments, templateSegment{text: rest})
+
+			break
+		}
+		if rest[open] == '}' {
+			return nil, fmt.Errorf("%w: unexpected '}' in %q", ErrInvalidTemplate, template)
+		}
+		if open > 0 {
+			segments = append(segments, templateSegment{text: rest[:open]})
+		}
+
+		rest = rest[open+1:]
+		end := strings.IndexAny(rest, "{}")
+		if end < 0 || rest[end] == '{' {
+			return nil, fmt.Errorf("%w: unclosed '{' in %q", ErrInvalidTemplate, template)
+		}
+		if end == 0 {
+			return nil, fmt.Errorf("%w: empty placeholder in %q", ErrInvalidTemplate, template)
+		}
+		segments = append(segments, templateSegment{text: rest[:end], placeholder: true})
+		rest = rest[end+1:]
+	}
+
+	return &TemplateNamer{segments: segments, values: maps.Clone(values)}, nil
+}
+
+// Name renders the template for operation without per-call attributes.
+func (n *TemplateNamer) Name(operation string) string {
+	return n.NameWithAttributes(operation, nil)
+}
+
+// NameWithAttributes renders the template for operation, resolving placeholders
+// missing from the init-time values from attrs.
+func (n *TemplateNamer) NameWithAttributes(operation string, attrs []attribute.KeyValue) string {
+	var b strings.Builder
+	for _, seg := range n.segments {
+		if !seg.placeholder {
+			b.WriteString(seg.text)
+
+			continue
+		}
+		b.WriteString(n.lookup(seg.text, operation, attrs))
+	}
+
+	return b.String()
+}
+
+// lookup returns the value of the placeholder key.
+func (n *TemplateNamer) lookup(key, operation string, attrs []attribute.KeyValue) string {
+	if key == "operation" {
+		return operation
+	}
+	if v, ok := n.values[key]; ok {
+		return v
+	}
+	for _, attr := range attrs {
+		if string(attr.Key) == key {
+			return attr.Value.Emit()
+		}
+	}
+
+	return ""
+}
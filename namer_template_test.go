@@ -0,0 +1,48 @@
+package otx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTemplateNamer(t *testing.T) {
+	namer, err := NewTemplateNamer("{service}.{operation}", map[string]string{"service": "orders"})
+	require.NoError(t, err)
+	assert.Equal(t, "orders.LoadUser", namer.Name("LoadUser"))
+
+	namer, err = NewTemplateNamer("{operation} {http.route}/{missing}", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "GET /users/{id}/", namer.NameWithAttributes("GET", []attribute.KeyValue{
+		attribute.String("http.route", "/users/{id}"),
+	}))
+	assert.Equal(t, "GET /", namer.Name("GET"))
+}
+
+func TestNewTemplateNamer_Invalid(t *testing.T) {
+	for _, template := range []string{"{operation", "operation}", "{}", "{a{b}}"} {
+		_, err := NewTemplateNamer(template, nil)
+		require.ErrorIs(t, err, ErrInvalidTemplate, template)
+	}
+}
+
+func TestStart_TemplateNamer(t *testing.T) {
+	namer, err := NewTemplateNamer("{service}.{operation}.{queue}", map[string]string{"service": "billing"})
+	require.NoError(t, err)
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	InitTracing(tp.Tracer("test"), namer)
+	defer InitTracing(nil, nil)
+
+	_, span := Start(t.Context(), "consume", trace.WithAttributes(attribute.String("queue", "invoices")))
+	span.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "billing.consume.invoices", spans[0].Name)
+}
@@ -4,6 +4,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestNamerHelpers(t *testing.T) {
@@ -22,3 +26,56 @@ func TestNamerHelpers(t *testing.T) {
 	// DB helper
 	assert.Equal(t, "SELECT users", NameDB("SELECT", "users"))
 }
+
+func TestKindInferringNamer(t *testing.T) {
+	namer := KindInferringNamer{}
+	assert.Equal(t, "SELECT users", namer.Name("SELECT users"))
+	assert.Equal(t, trace.SpanKindClient, namer.SpanKind("GET /users/{id}"))
+	assert.Equal(t, trace.SpanKindUnspecified, namer.SpanKind("select users"), "prefixes are case-sensitive")
+	assert.Equal(t, trace.SpanKindProducer, namer.SpanKind("publish orders"))
+	assert.Equal(t, trace.SpanKindConsumer, namer.SpanKind("receive orders"))
+	assert.Equal(t, trace.SpanKindUnspecified, namer.SpanKind("process order"), "ambiguous verbs stay internal")
+	assert.Equal(t, trace.SpanKindUnspecified, namer.SpanKind("GET"))
+
+	custom := KindInferringNamer{
+		Namer:    prefixNamer{},
+		Prefixes: map[string]trace.SpanKind{"cache.": trace.SpanKindClient, "cache.local.": trace.SpanKindInternal},
+	}
+	assert.Equal(t, "svc.cache.get", custom.Name("cache.get"))
+	assert.Equal(t, trace.SpanKindClient, custom.SpanKind("cache.get"))
+	assert.Equal(t, trace.SpanKindInternal, custom.SpanKind("cache.local.get"), "longest prefix wins")
+	assert.Equal(t, trace.SpanKindUnspecified, custom.SpanKind("SELECT users"))
+}
+
+func TestDefaultKindPrefixes(t *testing.T) {
+	prefixes := DefaultKindPrefixes()
+	assert.Equal(t, trace.SpanKindClient, prefixes["SELECT "])
+	prefixes["SELECT "] = trace.SpanKindInternal
+	assert.Equal(t, trace.SpanKindClient, DefaultKindPrefixes()["SELECT "], "callers get a copy")
+	assert.Equal(t, trace.SpanKindClient, KindInferringNamer{}.SpanKind("SELECT users"))
+}
+
+func TestStart_InfersKind(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	InitTracing(tp.Tracer("test"), KindInferringNamer{})
+	defer InitTracing(nil, nil)
+
+	_, span := Start(t.Context(), "SELECT orders")
+	span.End()
+	_, span = Start(t.Context(), "ProcessOrder")
+	span.End()
+	_, span = StartServer(t.Context(), "GET /orders")
+	span.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+	assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind)
+	assert.Equal(t, trace.SpanKindInternal, spans[1].SpanKind)
+	assert.Equal(t, trace.SpanKindServer, spans[2].SpanKind, "explicit kinds win")
+}
+
+// prefixNamer prefixes span names with "svc.".
+type prefixNamer struct{}
+
+func (prefixNamer) Name(operation string) string { return "svc." + operation }
@@ -1,7 +1,11 @@
 package nats
 
 import (
+	"context"
+
+	"github.com/arloliu/otx/internal/baggageattr"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
 )
 
 // Messaging system identifier for NATS.
@@ -16,7 +20,13 @@ const (
 	attrMessagingConsumerGroup   = "messaging.consumer.group.name"
 	attrMessagingMessageID       = "messaging.message.id"
 	attrMessagingMessageBodySize = "messaging.message.body.size"
+	attrMessagingBatchCount      = "messaging.batch.message_count"
 	attrNATSStream               = "nats.stream"
+	attrMessagingClientID        = "messaging.client.id"
+	attrServerAddress            = "server.address"
+	attrServerPort               = "server.port"
+	attrNATSCluster              = "nats.cluster.name"
+	attrNATSDomain               = "nats.jetstream.domain"
 )
 
 // Operation types per OTel messaging semantic conventions.
@@ -49,6 +59,25 @@ func publishAttributes(subject string, msgID string, bodySize int) []attribute.K
 	return attrs
 }
 
+// batchPublishAttributes returns attributes for a batch publish span.
+// subject is omitted when the batch spans multiple subjects.
+func batchPublishAttributes(subject string, count int) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, 5)
+
+	attrs = append(attrs,
+		attribute.String(attrMessagingSystem, messagingSystem),
+		attribute.String(attrMessagingOperationName, opTypePublish),
+		attribute.String(attrMessagingOperationType, opTypeSend),
+		attribute.Int(attrMessagingBatchCount, count),
+	)
+
+	if subject != "" {
+		attrs = append(attrs, attribute.String(attrMessagingDestinationName, subject))
+	}
+
+	return attrs
+}
+
 // receiveAttributes returns attributes for a receive/fetch operation span.
 func receiveAttributes(stream, consumerName string, bodySize int) []attribute.KeyValue {
 	attrs := make([]attribute.KeyValue, 0, 6)
@@ -100,3 +129,9 @@ func processAttributes(stream, consumerName, subject, msgID string, bodySize int
 
 	return attrs
 }
+
+// baggageAttributes returns the baggage members of ctx selected by keys as string
+// attributes keyed by the baggage key. Missing members are skipped.
+func baggageAttributes(ctx context.Context, keys *baggageattr.Selector) []attribute.KeyValue {
+	return keys.Attributes(baggage.FromContext(ctx), "")
+}
@@ -0,0 +1,190 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/arloliu/otx"
+)
+
+// BatchPublisher buffers messages and publishes them under a single PRODUCER span.
+//
+// Each message carries the trace context of the code that added it, so consumers
+// continue the originating trace. The batch span links to every originating span
+// instead of creating one span per message, which reduces span volume for
+// high-frequency small publishes.
+//
+// BatchPublisher is safe for concurrent use. Messages are only sent by Flush,
+// or by Add when the batch reaches the size set with WithMaxBatchSize.
+type BatchPublisher struct {
+	pub     *Publisher
+	maxSize int
+
+	mu      sync.Mutex
+	pending []batchEntry
+}
+
+// batchEntry is a buffered message with its publish options and originating span link.
+type batchEntry struct {
+	msg  *nats.Msg
+	opts []jetstream.PublishOpt
+	link trace.Link
+}
+
+// NewBatchPublisher creates a BatchPublisher with tracing using the global providers.
+func NewBatchPublisher(js jetstream.JetStream, opts ...Option) *BatchPublisher {
+	return NewBatchPublisherWithProviders(js, nil, nil, opts...)
+}
+
+// NewBatchPublisherWithProviders creates a BatchPublisher with explicit providers.
+// If tp is nil, the global TracerProvider is used.
+// If prop is nil, the global TextMapPropagator is used (or opts.prop if set).
+//
+// Publisher options such as WithBaggageAllowlist and WithHeaderSizeGuard apply
+// to every buffered message.
+//
+// Panics if js is nil.
+func NewBatchPublisherWithProviders(
+	js jetstream.JetStream,
+	tp trace.TracerProvider,
+	prop propagation.TextMapPropagator,
+	opts ...Option,
+) *BatchPublisher {
+	pub := NewPublisherWithProviders(js, tp, prop, opts...)
+
+	return &BatchPublisher{
+		pub:     pub,
+		maxSize: pub.opts.maxBatchSize,
+	}
+}
+
+// Add buffers a message for subject. Trace context from ctx is injected into
+// the message headers immediately and ctx's span is linked from the batch span.
+//
+// If the batch reaches the size set by WithMaxBatchSize, it is flushed
+// synchronously using ctx and any flush error is returned.
+func (b *BatchPublisher) Add(ctx context.Context, subject string, data []byte, opts ...jetstream.PublishOpt) error {
+	return b.AddMsg(ctx, &nats.Msg{Subject: subject, Data: data}, opts...)
+}
+
+// AddMsg buffers msg. If msg.Header is nil, it will be initialized before
+// injecting trace context. See Add for flushing behavior.
+func (b *BatchPublisher) AddMsg(ctx context.Context, msg *nats.Msg, opts ...jetstream.PublishOpt) error {
+	if msg.Header == nil {
+		msg.Header = make(nats.Header)
+	}
+	b.pub.inject(ctx, msg.Header)
+
+	b.mu.Lock()
+	b.pending = append(b.pending, batchEntry{
+		msg:  msg,
+		opts: opts,
+		link: trace.LinkFromContext(ctx),
+	})
+	full := b.maxSize > 0 && len(b.pending) >= b.maxSize
+	b.mu.Unlock()
+
+	if full {
+		_, err := b.Flush(ctx)
+		return err
+	}
+
+	return nil
+}
+
+// Len returns the number of buffered messages.
+func (b *BatchPublisher) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.pending)
+}
+
+// Flush publishes all buffered messages under one PRODUCER span.
+//
+// The span is a child of ctx and links to the span of every message in the batch.
+// With WithSuppressUnsampled, no span is created if ctx's span is unsampled.
+// Messages are published in the order they were added. Publishing continues after
+// a failure; the returned acks are index-aligned with the batch (nil for failed
+// messages) and the error joins all failures.
+//
+// Flushing an empty batch is a no-op.
+func (b *BatchPublisher) Flush(ctx context.Context) ([]*jetstream.PubAck, error) {
+	b.mu.Lock()
+	entries := b.pending
+	b.pending = nil
+	b.mu.Unlock()
+
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	if skipSpan(ctx, b.pub.opts) {
+		acks, errs := b.publishEntries(ctx, entries)
+
+		return acks, errors.Join(errs...)
+	}
+
+	links := make([]trace.Link, 0, len(entries))
+	for _, e := range entries {
+		if e.link.SpanContext.IsValid() {
+			links = append(links, e.link)
+		}
+	}
+
+	subject := batchSubject(entries)
+	spanName := b.pub.opts.spanName(opTypePublish, subject, subject)
+
+	ctx, span := b.pub.tracer.Start(ctx, spanName,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithLinks(links...),
+		trace.WithAttributes(batchPublishAttributes(subject, len(entries))...),
+		trace.WithAttributes(b.pub.connectionAttributes()...),
+	)
+	defer span.End()
+
+	acks, errs := b.publishEntries(ctx, entries)
+	if err := errors.Join(errs...); err != nil {
+		otx.RecordSpanError(span, fmt.Errorf("%d of %d messages failed: %w", len(errs), len(entries), err))
+
+		return acks, err
+	}
+
+	return acks, nil
+}
+
+// publishEntries publishes entries in order, continuing after failures.
+// The returned acks are index-aligned with entries.
+func (b *BatchPublisher) publishEntries(ctx context.Context, entries []batchEntry) ([]*jetstream.PubAck, []error) {
+	acks := make([]*jetstream.PubAck, len(entries))
+	var errs []error
+	for i, e := range entries {
+		ack, err := b.pub.js.PublishMsg(ctx, e.msg, e.opts...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("publish %s: %w", e.msg.Subject, err))
+			continue
+		}
+		acks[i] = ack
+	}
+
+	return acks, errs
+}
+
+// batchSubject returns the common subject of all entries, or "" if they differ.
+func batchSubject(entries []batchEntry) string {
+	subject := entries[0].msg.Subject
+	for _, e := range entries[1:] {
+		if e.msg.Subject != subject {
+			return ""
+		}
+	}
+
+	return subject
+}
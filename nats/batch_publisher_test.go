@@ -0,0 +1,138 @@
+package nats
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/arloliu/otx"
+)
+
+func setupBatchPublisher(
+	t *testing.T,
+	js *stubJetStream,
+	opts ...Option,
+) (*BatchPublisher, *tracetest.InMemoryExporter, *trace.TracerProvider) {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := trace.NewTracerProvider(trace.WithSyncer(exporter))
+
+	return NewBatchPublisherWithProviders(js, tp, propagation.TraceContext{}, opts...), exporter, tp
+}
+
+func TestBatchPublisher_Flush_LinksOriginatingSpans(t *testing.T) {
+	js := &stubJetStream{}
+	bp, exporter, tp := setupBatchPublisher(t, js)
+	tracer := tp.Tracer("test")
+
+	var origins []oteltrace.SpanContext
+	for range 3 {
+		ctx, span := tracer.Start(t.Context(), "read sensor")
+		require.NoError(t, bp.Add(ctx, "sensors.temp", []byte("21.5")))
+		origins = append(origins, span.SpanContext())
+		span.End()
+	}
+	assert.Equal(t, 3, bp.Len())
+	assert.Empty(t, js.published, "nothing is sent before Flush")
+
+	acks, err := bp.Flush(t.Context())
+	require.NoError(t, err)
+	require.Len(t, acks, 3)
+	assert.Equal(t, 0, bp.Len())
+
+	// Each message carries its own originating trace context
+	require.Len(t, js.published, 3)
+	for i, msg := range js.published {
+		assert.Contains(t, msg.Header.Get("traceparent"), origins[i].TraceID().String())
+	}
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 4)
+	batch := spans[3]
+	assert.Equal(t, "publish sensors.temp", batch.Name)
+	assert.Equal(t, oteltrace.SpanKindProducer, batch.SpanKind)
+	require.Len(t, batch.Links, 3)
+	for i, link := range batch.Links {
+		assert.Equal(t, origins[i], link.SpanContext)
+	}
+
+	attrs := spanAttrMap(batch)
+	assert.Equal(t, int64(3), attrs[attrMessagingBatchCount])
+	assert.Equal(t, "sensors.temp", attrs[attrMessagingDestinationName])
+}
+
+func TestBatchPublisher_Flush_Empty(t *testing.T) {
+	bp, exporter, _ := setupBatchPublisher(t, &stubJetStream{})
+
+	acks, err := bp.Flush(t.Context())
+	require.NoError(t, err)
+	assert.Nil(t, acks)
+	assert.Empty(t, exporter.GetSpans())
+}
+
+func TestBatchPublisher_Flush_MixedSubjectsAndErrors(t *testing.T) {
+	errBoom := errors.New("boom")
+	js := &stubJetStream{failOn: map[string]error{"b": errBoom}}
+	bp, exporter, _ := setupBatchPublisher(t, js)
+
+	require.NoError(t, bp.Add(t.Context(), "a", []byte("1")))
+	require.NoError(t, bp.Add(t.Context(), "b", []byte("2")))
+	require.NoError(t, bp.Add(t.Context(), "c", []byte("3")))
+
+	acks, err := bp.Flush(t.Context())
+	require.ErrorIs(t, err, errBoom)
+	require.Len(t, acks, 3)
+	assert.NotNil(t, acks[0])
+	assert.Nil(t, acks[1])
+	assert.NotNil(t, acks[2])
+	assert.Len(t, js.published, 2)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "publish", spans[0].Name)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Empty(t, spans[0].Links, "background contexts have no span to link")
+	assert.NotContains(t, spanAttrMap(spans[0]), attrMessagingDestinationName)
+	assert.Contains(t, spans[0].Status.Description, "1 of 3 messages failed")
+}
+
+func TestBatchPublisher_Flush_ErrorClassified(t *testing.T) {
+	otx.SetErrorClassifier(func(err error) ([]attribute.KeyValue, bool) {
+		return []attribute.KeyValue{attribute.Bool("classified", true)}, false
+	})
+	t.Cleanup(func() { otx.SetErrorClassifier(nil) })
+
+	js := &stubJetStream{failOn: map[string]error{"a": errors.New("boom")}}
+	bp, exporter, _ := setupBatchPublisher(t, js)
+	require.NoError(t, bp.Add(t.Context(), "a", []byte("1")))
+
+	_, err := bp.Flush(t.Context())
+	require.Error(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Unset, spans[0].Status.Code, "the classifier decides the status")
+	assert.Contains(t, spans[0].Attributes, attribute.Bool("classified", true))
+}
+
+func TestBatchPublisher_WithMaxBatchSize(t *testing.T) {
+	js := &stubJetStream{}
+	bp, exporter, _ := setupBatchPublisher(t, js, WithMaxBatchSize(2))
+
+	require.NoError(t, bp.Add(t.Context(), "s", []byte("1")))
+	assert.Empty(t, js.published)
+
+	require.NoError(t, bp.Add(t.Context(), "s", []byte("2")))
+	assert.Len(t, js.published, 2)
+	assert.Equal(t, 0, bp.Len())
+	assert.Len(t, exporter.GetSpans(), 1)
+}
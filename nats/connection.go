@@ -0,0 +1,91 @@
+package nats
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// WithConnectionAttributes adds attributes describing the NATS connection to spans:
+// messaging.client.id, server.address, server.port, nats.cluster.name and
+// nats.jetstream.domain. They tell which server and cluster handled a message when
+// an application talks to several NATS clusters. Values are read when each span
+// starts, so they follow reconnects to another server.
+//
+// Publisher and BatchPublisher read the connection and domain from their JetStream,
+// and nc may be nil. Consumer-side wrappers (MessageHandlerWithTracing,
+// TracedMsg.StartProcessSpan, WrapConsumer) cannot reach the connection from a
+// message, so nc must be the connection the messages are consumed on; process spans
+// take the domain from the message metadata.
+//
+// The client ID is the connection name (nats.Name) if set, otherwise the ID the
+// server assigned to the connection. Use WithClientID to set it explicitly.
+// Default is disabled.
+//
+// Example:
+//
+//	handler := nats.MessageHandlerWithTracing(process, nats.WithConnectionAttributes(nc))
+func WithConnectionAttributes(nc *nats.Conn) Option {
+	return func(o *options) {
+		o.connAttributes = true
+		o.conn = nc
+	}
+}
+
+// WithClientID sets the messaging.client.id attribute of all spans.
+// It works with or without WithConnectionAttributes, and takes precedence over the
+// client ID derived from the connection.
+func WithClientID(id string) Option {
+	return func(o *options) {
+		o.clientID = id
+	}
+}
+
+// connectionAttributes returns the client ID set with WithClientID and, if
+// connection attributes are enabled, the attributes of nc and the JetStream domain.
+// nc may be nil and domain may be empty.
+func (o *options) connectionAttributes(nc *nats.Conn, domain string) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	if o.clientID != "" {
+		attrs = append(attrs, attribute.String(attrMessagingClientID, o.clientID))
+	}
+	if !o.connAttributes {
+		return attrs
+	}
+
+	if nc != nil {
+		if o.clientID == "" {
+			if id := connClientID(nc); id != "" {
+				attrs = append(attrs, attribute.String(attrMessagingClientID, id))
+			}
+		}
+		if server, err := url.Parse(nc.ConnectedUrlRedacted()); err == nil && server.Hostname() != "" {
+			attrs = append(attrs, attribute.String(attrServerAddress, server.Hostname()))
+			if port, err := strconv.Atoi(server.Port()); err == nil {
+				attrs = append(attrs, attribute.Int(attrServerPort, port))
+			}
+		}
+		if cluster := nc.ConnectedClusterName(); cluster != "" {
+			attrs = append(attrs, attribute.String(attrNATSCluster, cluster))
+		}
+	}
+	if domain != "" {
+		attrs = append(attrs, attribute.String(attrNATSDomain, domain))
+	}
+
+	return attrs
+}
+
+// connClientID returns the connection name of nc, or the ID the server assigned to it.
+func connClientID(nc *nats.Conn) string {
+	if nc.Opts.Name != "" {
+		return nc.Opts.Name
+	}
+	if id, err := nc.GetClientID(); err == nil {
+		return strconv.FormatUint(id, 10)
+	}
+
+	return ""
+}
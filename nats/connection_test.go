@@ -0,0 +1,135 @@
+package nats
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// connectFakeServer connects to a minimal NATS server that sends info as its INFO
+// message and answers PINGs.
+func connectFakeServer(t *testing.T, info string, opts ...nats.Option) *nats.Conn {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		_, _ = fmt.Fprintf(conn, "INFO %s\r\n", info)
+		reader := bufio.NewReader(conn)
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			if strings.HasPrefix(line, "PING") {
+				_, _ = fmt.Fprint(conn, "PONG\r\n")
+			}
+		}
+	}()
+
+	nc, err := nats.Connect("nats://"+ln.Addr().String(), opts...)
+	require.NoError(t, err)
+	t.Cleanup(nc.Close)
+
+	return nc
+}
+
+// connJetStream is a stubJetStream bound to a connection and domain.
+type connJetStream struct {
+	stubJetStream
+	nc     *nats.Conn
+	domain string
+}
+
+func (s *connJetStream) Conn() *nats.Conn { return s.nc }
+
+func (s *connJetStream) Options() jetstream.JetStreamOptions {
+	return jetstream.JetStreamOptions{Domain: s.domain}
+}
+
+func TestConnectionAttributes_Publisher(t *testing.T) {
+	nc := connectFakeServer(t, `{"server_id":"S1","cluster":"east","client_id":42,"headers":true}`)
+	port := nc.ConnectedUrl()[strings.LastIndex(nc.ConnectedUrl(), ":")+1:]
+	exporter := tracetest.NewInMemoryExporter()
+	tp := trace.NewTracerProvider(trace.WithSyncer(exporter))
+	js := &connJetStream{nc: nc, domain: "hub"}
+
+	pub := NewPublisherWithProviders(js, tp, propagation.TraceContext{}, WithConnectionAttributes(nil))
+	_, err := pub.Publish(t.Context(), "orders.created", []byte("data"))
+	require.NoError(t, err)
+
+	batch := NewBatchPublisherWithProviders(js, tp, propagation.TraceContext{}, WithConnectionAttributes(nil))
+	require.NoError(t, batch.Add(t.Context(), "orders.created", []byte("data")))
+	_, err = batch.Flush(t.Context())
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	for _, span := range spans {
+		attrMap := spanAttrMap(span)
+		assert.Equal(t, "42", attrMap["messaging.client.id"], "server-assigned ID without a connection name")
+		assert.Equal(t, "127.0.0.1", attrMap["server.address"])
+		assert.Equal(t, port, fmt.Sprint(attrMap["server.port"]))
+		assert.Equal(t, "east", attrMap["nats.cluster.name"])
+		assert.Equal(t, "hub", attrMap["nats.jetstream.domain"])
+	}
+
+	exporter.Reset()
+	pub = NewPublisherWithProviders(&stubJetStream{}, tp, propagation.TraceContext{})
+	_, err = pub.Publish(t.Context(), "orders.created", []byte("data"))
+	require.NoError(t, err)
+	spans = exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.NotContains(t, spanAttrMap(spans[0]), "server.address", "disabled by default")
+}
+
+func TestConnectionAttributes_Consumer(t *testing.T) {
+	exporter, _ := setupHandlerTest(t)
+	nc := connectFakeServer(t, `{"server_id":"S1","client_id":42}`, nats.Name("billing-worker"))
+	msg := &mockMsg{
+		subject:  "orders.created",
+		metadata: &jetstream.MsgMetadata{Stream: "ORDERS", Domain: "leaf"},
+	}
+
+	MessageHandlerWithTracing(func(_ *TracedMsg) {}, WithConnectionAttributes(nc))(msg)
+	_, end := NewTracedMsg(msg).StartProcessSpan(WithConnectionAttributes(nc), WithClientID("override"))
+	end(nil)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	handlerAttrs, msgAttrs := spanAttrMap(spans[0]), spanAttrMap(spans[1])
+	assert.Equal(t, "billing-worker", handlerAttrs["messaging.client.id"], "connection name is preferred")
+	assert.Equal(t, "override", msgAttrs["messaging.client.id"])
+	for _, attrMap := range []map[string]any{handlerAttrs, msgAttrs} {
+		assert.Equal(t, "127.0.0.1", attrMap["server.address"])
+		assert.Equal(t, "leaf", attrMap["nats.jetstream.domain"], "domain comes from the message metadata")
+		assert.NotContains(t, attrMap, "nats.cluster.name", "server is not clustered")
+	}
+
+	exporter.Reset()
+	MessageHandlerWithTracing(func(_ *TracedMsg) {}, WithClientID("svc-a"))(msg)
+	spans = exporter.GetSpans()
+	require.Len(t, spans, 1)
+	attrMap := spanAttrMap(spans[0])
+	assert.Equal(t, "svc-a", attrMap["messaging.client.id"], "client ID works without connection attributes")
+	assert.NotContains(t, attrMap, "server.address")
+	assert.NotContains(t, attrMap, "nats.jetstream.domain")
+}
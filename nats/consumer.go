@@ -4,9 +4,10 @@ import (
 	"context"
 
 	"github.com/nats-io/nats.go/jetstream"
-	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
+
+	"github.com/arloliu/otx"
 )
 
 // TracedConsumer wraps a jetstream.Consumer with OpenTelemetry tracing.
@@ -79,6 +80,7 @@ func (tc *TracedConsumer) startFetchSpan() (context.Context, trace.Span) {
 	return tc.tracer.Start(context.Background(), spanName,
 		trace.WithSpanKind(trace.SpanKindClient),
 		trace.WithAttributes(receiveAttributes(tc.stream, consumerName, 0)...),
+		trace.WithAttributes(tc.opts.connectionAttributes(tc.opts.conn, "")...),
 	)
 }
 
@@ -100,8 +102,7 @@ func (tc *TracedConsumer) Fetch(batch int, opts ...jetstream.FetchOpt) (*TracedM
 
 	msgBatch, err := tc.consumer.Fetch(batch, opts...)
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		otx.RecordSpanError(span, err)
 		span.End()
 
 		return nil, err
@@ -118,8 +119,7 @@ func (tc *TracedConsumer) FetchBytes(maxBytes int, opts ...jetstream.FetchOpt) (
 
 	msgBatch, err := tc.consumer.FetchBytes(maxBytes, opts...)
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		otx.RecordSpanError(span, err)
 		span.End()
 
 		return nil, err
@@ -136,8 +136,7 @@ func (tc *TracedConsumer) FetchNoWait(batch int) (*TracedMessageBatch, error) {
 
 	msgBatch, err := tc.consumer.FetchNoWait(batch)
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		otx.RecordSpanError(span, err)
 		span.End()
 
 		return nil, err
@@ -170,8 +169,7 @@ func (tc *TracedConsumer) Next(opts ...jetstream.FetchOpt) (*TracedMsg, error) {
 
 	msg, err := tc.consumer.Next(opts...)
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		otx.RecordSpanError(span, err)
 		span.End()
 
 		return nil, err
@@ -13,6 +13,18 @@
 //	// Traced publish - context propagated via headers
 //	publisher.Publish(ctx, "orders.created", data)
 //
+// # Batch Publishing
+//
+// Use BatchPublisher to publish many small messages under one batch span
+// linked to each message's originating span:
+//
+//	batch := nats.NewBatchPublisher(js, nats.WithMaxBatchSize(100))
+//	batch.Add(ctx, "sensors.temp", data)
+//	acks, err := batch.Flush(ctx)
+//
+// Use WithSuppressUnsampled to skip spans on hot paths when the parent span is
+// unsampled; trace context is still propagated.
+//
 // # Consumer Usage
 //
 // Wrap a Consumer to add tracing to consume operations:
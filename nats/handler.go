@@ -6,9 +6,10 @@ import (
 
 	"github.com/nats-io/nats.go/jetstream"
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
+
+	"github.com/arloliu/otx"
 )
 
 // MessageHandlerWithTracing wraps a handler function to add process spans.
@@ -62,14 +63,22 @@ func MessageHandlerWithTracingProviders(
 			parentCtx = propagator.Extract(parentCtx, headerCarrier(headers))
 		}
 
+		if skipSpan(parentCtx, o) {
+			handler(&TracedMsg{Msg: msg, ctx: parentCtx})
+
+			return
+		}
+
 		// Extract message metadata for span attributes
 		stream := ""
 		consumerName := ""
 		subject := ""
+		domain := ""
 
 		if metadata, err := msg.Metadata(); err == nil && metadata != nil {
 			stream = metadata.Stream
 			consumerName = metadata.Consumer
+			domain = metadata.Domain
 		}
 
 		if msg.Subject() != "" {
@@ -82,10 +91,12 @@ func MessageHandlerWithTracingProviders(
 		}
 
 		// Start process span
-		spanName := opTypeProcess + " " + stream
+		spanName := o.spanName(opTypeProcess, subject, stream)
 		spanCtx, span := tracer.Start(parentCtx, spanName,
 			trace.WithSpanKind(trace.SpanKindConsumer),
 			trace.WithAttributes(processAttributes(stream, consumerName, subject, "", len(msg.Data()))...),
+			trace.WithAttributes(baggageAttributes(parentCtx, o.baggageAttributes)...),
+			trace.WithAttributes(o.connectionAttributes(o.conn, domain)...),
 		)
 
 		// Create traced message with span context
@@ -97,8 +108,7 @@ func MessageHandlerWithTracingProviders(
 		// Call handler with deferred span end and panic recovery
 		defer func() {
 			if r := recover(); r != nil {
-				span.RecordError(fmt.Errorf("panic: %v", r))
-				span.SetStatus(codes.Error, "panic in handler")
+				otx.RecordSpanError(span, fmt.Errorf("panic in handler: %v", r))
 				span.End()
 				panic(r) // Re-panic after recording
 			}
@@ -230,3 +230,71 @@ func TestMessageHandlerWithTracingProviders_ExplicitProviders(t *testing.T) {
 	spans := exporter.GetSpans()
 	require.Len(t, spans, 1)
 }
+
+func TestMessageHandlerWithTracing_WithSuppressUnsampled(t *testing.T) {
+	exporter, _ := setupHandlerTest(t)
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	var received *TracedMsg
+	handler := MessageHandlerWithTracing(func(msg *TracedMsg) {
+		received = msg
+	}, WithSuppressUnsampled(true))
+
+	for _, flags := range []string{"00", "01"} {
+		handler(&mockMsg{
+			subject: "orders.created",
+			headers: nats.Header{"traceparent": []string{"00-" + traceID + "-00f067aa0ba902b7-" + flags}},
+		})
+
+		require.NotNil(t, received)
+		sc := oteltrace.SpanContextFromContext(received.Context())
+		assert.Equal(t, traceID, sc.TraceID().String())
+	}
+
+	// Only the sampled message produced a process span
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.True(t, spans[0].SpanContext.IsSampled())
+
+	// StartProcessSpan honors the option as well
+	unsampled := NewTracedMsgWithPropagator(&mockMsg{
+		headers: nats.Header{"traceparent": []string{"00-" + traceID + "-00f067aa0ba902b7-00"}},
+	}, propagation.TraceContext{})
+	ctx, end := unsampled.StartProcessSpan(WithSuppressUnsampled(true))
+	end(nil)
+	assert.Equal(t, unsampled.Context(), ctx)
+	assert.Len(t, exporter.GetSpans(), 1)
+}
+
+func TestMessageHandlerWithTracing_BaggageAttributes(t *testing.T) {
+	exporter, _ := setupHandlerTest(t)
+	otel.SetTextMapPropagator(propagation.Baggage{})
+
+	msg := &mockMsg{
+		subject: "orders.created",
+		headers: nats.Header{"baggage": []string{"tenant.id=acme,user.id=42"}},
+	}
+
+	handler := MessageHandlerWithTracing(func(_ *TracedMsg) {}, WithBaggageAttributes("tenant.id", "missing"))
+	handler(msg)
+
+	traced := NewTracedMsg(msg)
+	_, end := traced.StartProcessSpan(WithBaggageAttributes("tenant.id"))
+	end(nil)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	for _, span := range spans {
+		attrMap := spanAttrMap(span)
+		assert.Equal(t, "acme", attrMap["tenant.id"])
+		assert.NotContains(t, attrMap, "user.id", "only listed keys are copied")
+		assert.NotContains(t, attrMap, "missing")
+	}
+
+	exporter.Reset()
+	MessageHandlerWithTracing(func(_ *TracedMsg) {})(msg)
+	spans = exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.NotContains(t, spanAttrMap(spans[0]), "tenant.id", "no keys are copied by default")
+}
@@ -0,0 +1,129 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// metricPropagationOverhead is the histogram of bytes added to headers by propagation.
+const metricPropagationOverhead = "otx.nats.propagation.overhead"
+
+// attrPropagationTruncated marks overhead measurements where fields were dropped to fit the limit.
+const attrPropagationTruncated = "otx.nats.propagation.truncated"
+
+// traceContextFields are the propagation fields carrying the trace context itself, for the
+// W3C, B3 and X-Ray propagators. They are never dropped individually; if they alone exceed
+// the limit, nothing is injected.
+var traceContextFields = map[string]bool{
+	"traceparent":     true,
+	"b3":              true,
+	"x-b3-traceid":    true,
+	"x-b3-spanid":     true,
+	"x-b3-sampled":    true,
+	"x-b3-flags":      true,
+	"x-amzn-trace-id": true,
+}
+
+// droppableFields returns the fields of prop removed, in order, when the header limit is
+// exceeded: baggage first, then the other fields that are not trace context, last declared first.
+func droppableFields(prop propagation.TextMapPropagator) []string {
+	var fields []string
+	for _, field := range slices.Backward(prop.Fields()) {
+		if traceContextFields[strings.ToLower(field)] || slices.Contains(fields, field) {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	if i := slices.Index(fields, "baggage"); i > 0 {
+		fields = slices.Insert(slices.Delete(fields, i, i+1), 0, "baggage")
+	}
+
+	return fields
+}
+
+// headerGuard measures and optionally caps the header bytes added by propagation.
+type headerGuard struct {
+	maxBytes int
+	overhead metric.Int64Histogram
+	warnOnce sync.Once
+}
+
+// newHeaderGuard creates a headerGuard recording to the configured meter provider.
+func newHeaderGuard(opts options) *headerGuard {
+	mp := opts.meterProvider
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+
+	hist, err := mp.Meter(opts.tracerName).Int64Histogram(metricPropagationOverhead,
+		metric.WithUnit("By"),
+		metric.WithDescription("Bytes added to NATS message headers by context propagation."),
+	)
+	if err != nil {
+		otel.Handle(err)
+		hist, _ = noop.NewMeterProvider().Meter(opts.tracerName).Int64Histogram(metricPropagationOverhead)
+	}
+
+	return &headerGuard{
+		maxBytes: opts.maxHeaderBytes,
+		overhead: hist,
+	}
+}
+
+// inject propagates ctx into header, dropping optional fields when the limit is exceeded.
+func (g *headerGuard) inject(ctx context.Context, prop propagation.TextMapPropagator, header nats.Header) {
+	fields := make(nats.Header)
+	prop.Inject(ctx, headerCarrier(fields))
+
+	size := headerBytes(fields)
+	truncated := false
+	if g.maxBytes > 0 && size > g.maxBytes {
+		original := size
+		truncated = true
+
+		for _, key := range droppableFields(prop) {
+			fields.Del(key)
+			if size = headerBytes(fields); size <= g.maxBytes {
+				break
+			}
+		}
+		if size > g.maxBytes {
+			clear(fields)
+			size = 0
+		}
+
+		g.warnOnce.Do(func() {
+			otel.Handle(fmt.Errorf(
+				"otx/nats: propagation headers need %d bytes, exceeding limit of %d; dropping fields to fit",
+				original, g.maxBytes))
+		})
+	}
+
+	for k, v := range fields {
+		header[k] = v
+	}
+
+	g.overhead.Record(ctx, int64(size), metric.WithAttributes(attribute.Bool(attrPropagationTruncated, truncated)))
+}
+
+// headerBytes returns the wire size of header lines ("Key: Value\r\n") in h.
+func headerBytes(h nats.Header) int {
+	n := 0
+	for k, vals := range h {
+		for _, v := range vals {
+			n += len(k) + len(v) + 4
+		}
+	}
+
+	return n
+}
@@ -0,0 +1,121 @@
+package nats
+
+import (
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestHeaderBytes(t *testing.T) {
+	h := nats.Header{"traceparent": {"abc"}, "baggage": {"k=v"}}
+	// "traceparent: abc\r\n" + "baggage: k=v\r\n"
+	assert.Equal(t, 11+3+4+7+3+4, headerBytes(h))
+	assert.Equal(t, 0, headerBytes(nil))
+}
+
+func TestDroppableFields(t *testing.T) {
+	tests := []struct {
+		name string
+		prop propagation.TextMapPropagator
+		want []string
+	}{
+		{
+			name: "w3c",
+			prop: propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}),
+			want: []string{"baggage", "tracestate"},
+		},
+		{
+			name: "baggage declared first",
+			prop: propagation.NewCompositeTextMapPropagator(propagation.Baggage{}, propagation.TraceContext{}),
+			want: []string{"baggage", "tracestate"},
+		},
+		{
+			name: "b3 keeps trace context",
+			prop: propagation.NewCompositeTextMapPropagator(b3.New(), propagation.Baggage{}),
+			want: []string{"baggage"},
+		},
+		{
+			name: "trace context only",
+			prop: b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader)),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, droppableFields(tt.prop))
+		})
+	}
+}
+
+func TestPublisher_WithHeaderSizeGuard(t *testing.T) {
+	prop := propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+	tp := trace.NewTracerProvider()
+
+	member, err := baggage.NewMember("tenant.id", "acme-corporation")
+	require.NoError(t, err)
+	bag, err := baggage.New(member)
+	require.NoError(t, err)
+	ctx := baggage.ContextWithBaggage(t.Context(), bag)
+
+	// traceparent line is 11 + 55 + 4 = 70 bytes
+	tests := []struct {
+		name          string
+		maxBytes      int
+		wantBaggage   bool
+		wantParent    bool
+		wantTruncated bool
+	}{
+		{name: "measure only", maxBytes: 0, wantBaggage: true, wantParent: true},
+		{name: "drops baggage", maxBytes: 80, wantParent: true, wantTruncated: true},
+		{name: "drops everything", maxBytes: 10, wantTruncated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+			js := &stubJetStream{}
+			pub := NewPublisherWithProviders(js, tp, prop,
+				WithHeaderSizeGuard(tt.maxBytes),
+				WithMeterProvider(mp),
+			)
+
+			msg := &nats.Msg{Subject: "sensors.temp", Data: []byte("21.5"), Header: nats.Header{"App": {"x"}}}
+			_, err := pub.PublishMsg(ctx, msg)
+			require.NoError(t, err)
+
+			assert.Equal(t, "x", msg.Header.Get("App"), "caller headers are preserved")
+			assert.Equal(t, tt.wantParent, msg.Header.Get("traceparent") != "")
+			assert.Equal(t, tt.wantBaggage, msg.Header.Get("baggage") != "")
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(t.Context(), &rm))
+			require.Len(t, rm.ScopeMetrics, 1)
+			require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+
+			m := rm.ScopeMetrics[0].Metrics[0]
+			assert.Equal(t, metricPropagationOverhead, m.Name)
+
+			hist, ok := m.Data.(metricdata.Histogram[int64])
+			require.True(t, ok)
+			require.Len(t, hist.DataPoints, 1)
+
+			dp := hist.DataPoints[0]
+			delete(msg.Header, "App")
+			assert.Equal(t, int64(headerBytes(msg.Header)), dp.Sum)
+			truncated, _ := dp.Attributes.Value(attribute.Key(attrPropagationTruncated))
+			assert.Equal(t, tt.wantTruncated, truncated.AsBool())
+		})
+	}
+}
@@ -5,9 +5,10 @@ import (
 
 	"github.com/nats-io/nats.go/jetstream"
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
+
+	"github.com/arloliu/otx"
 )
 
 // TracedMsg wraps a jetstream.Msg with trace context.
@@ -60,6 +61,9 @@ func (m *TracedMsg) StartProcessSpanWithTracer(
 	opts ...Option,
 ) (context.Context, func(error)) {
 	o := applyOptions(opts)
+	if skipSpan(m.Context(), o) {
+		return m.Context(), func(error) {}
+	}
 	tracer := getTracer(tp, o)
 
 	// Extract message metadata for attributes
@@ -67,12 +71,14 @@ func (m *TracedMsg) StartProcessSpanWithTracer(
 	consumerName := ""
 	subject := ""
 	messageID := ""
+	domain := ""
 	bodySize := 0
 
 	if m.Msg != nil {
 		if metadata, err := m.Msg.Metadata(); err == nil && metadata != nil {
 			stream = metadata.Stream
 			consumerName = metadata.Consumer
+			domain = metadata.Domain
 		}
 
 		if m.Msg.Subject() != "" {
@@ -88,19 +94,20 @@ func (m *TracedMsg) StartProcessSpanWithTracer(
 	}
 
 	// Create span name following semconv
-	spanName := opTypeProcess + " " + stream
+	spanName := o.spanName(opTypeProcess, subject, stream)
 
 	// Start span with proper kind and attributes
 	ctx, span := tracer.Start(m.Context(), spanName,
 		trace.WithSpanKind(trace.SpanKindConsumer),
 		trace.WithAttributes(processAttributes(stream, consumerName, subject, messageID, bodySize)...),
+		trace.WithAttributes(baggageAttributes(m.Context(), o.baggageAttributes)...),
+		trace.WithAttributes(o.connectionAttributes(o.conn, domain)...),
 	)
 
 	// Return context and end function
 	endFunc := func(err error) {
 		if err != nil {
-			span.RecordError(err)
-			span.SetStatus(codes.Error, err.Error())
+			otx.RecordSpanError(span, err)
 		}
 
 		span.End()
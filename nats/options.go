@@ -1,8 +1,13 @@
 package nats
 
 import (
+	"context"
+
+	"github.com/arloliu/otx/internal/baggageattr"
 	"github.com/arloliu/otx/internal/tracker"
+	"github.com/nats-io/nats.go"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -16,6 +21,28 @@ type options struct {
 	processSpans bool   // Enable per-message process spans
 	asyncSpans   bool   // Enable spans for async publish operations
 	stream       string // Override stream name for spans
+
+	// Baggage filtering for injected headers (publisher only)
+	filterBaggage    bool     // Restrict injected baggage to baggageAllowlist
+	baggageAllowlist []string // Baggage keys allowed in message headers
+
+	// Header size guard (publisher only)
+	headerGuard    bool                 // Measure propagation header overhead
+	maxHeaderBytes int                  // Cap on propagation header bytes, 0 = unlimited
+	meterProvider  metric.MeterProvider // Meter provider for package metrics
+
+	maxBatchSize int // Auto-flush threshold for BatchPublisher, 0 = manual flush only
+
+	suppressUnsampled bool // Skip spans when the parent is valid but unsampled
+
+	baggageAttributes *baggageattr.Selector // Baggage keys copied onto process spans (consumer only)
+
+	spanNameMode     SpanNameMode // What follows the operation in process and publish span names
+	subjectTemplates []string     // Subject patterns for the Template span name mode
+
+	connAttributes bool       // Add connection attributes to spans
+	conn           *nats.Conn // Connection of consumer-side wrappers, nil if unknown
+	clientID       string     // Explicit messaging.client.id, overrides the connection's
 }
 
 // defaultOptions returns the default configuration.
@@ -74,6 +101,99 @@ func WithStream(stream string) Option {
 	}
 }
 
+// WithBaggageAllowlist restricts which baggage members a Publisher injects into
+// message headers. Only members whose keys match one of keys, glob patterns such as
+// "request.*" as for propagation.baggageAllowlist, are propagated; calling it with
+// no keys disables baggage injection entirely. Trace context is unaffected.
+//
+// The caller's context is not modified, so the publish span and downstream code
+// still see the full baggage.
+// By default, all baggage members are injected.
+//
+// Example:
+//
+//	publisher := nats.NewPublisher(js, nats.WithBaggageAllowlist("tenant.id"))
+func WithBaggageAllowlist(keys ...string) Option {
+	return func(o *options) {
+		o.filterBaggage = true
+		o.baggageAllowlist = append([]string{}, keys...)
+	}
+}
+
+// WithHeaderSizeGuard measures the bytes a Publisher adds to message headers
+// through propagation (traceparent, tracestate, baggage) and records them in the
+// otx.nats.propagation.overhead histogram.
+//
+// If maxBytes > 0, propagation headers are capped at that size: baggage is
+// dropped first, then the other fields of the propagator that do not carry the
+// trace context itself, e.g. tracestate; if the trace context headers
+// (traceparent, b3, x-b3-*, X-Amzn-Trace-Id) alone do not fit, no propagation
+// headers are injected. The first time a message exceeds the limit
+// a warning is reported via otel.Handle. Headers set by the caller are not counted.
+//
+// Example:
+//
+//	// Keep propagation overhead of small sensor readings under 128 bytes
+//	publisher := nats.NewPublisher(js, nats.WithHeaderSizeGuard(128))
+func WithHeaderSizeGuard(maxBytes int) Option {
+	return func(o *options) {
+		o.headerGuard = true
+		o.maxHeaderBytes = max(maxBytes, 0)
+	}
+}
+
+// WithMeterProvider sets the MeterProvider used for package metrics.
+// If not set, the global MeterProvider is used.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(o *options) {
+		o.meterProvider = mp
+	}
+}
+
+// WithMaxBatchSize makes BatchPublisher flush automatically once n messages
+// are buffered. The flush runs synchronously inside the Add call that fills the batch.
+// Default is 0 (flush only when Flush is called).
+func WithMaxBatchSize(n int) Option {
+	return func(o *options) {
+		o.maxBatchSize = max(n, 0)
+	}
+}
+
+// WithSuppressUnsampled skips span creation when the parent span is valid but
+// not sampled. Trace context is still injected into published messages and
+// extracted from consumed ones, so the unsampled decision keeps propagating.
+// Default is false.
+//
+// It applies to Publish, PublishMsg, BatchPublisher.Flush, MessageHandlerWithTracing
+// and TracedMsg.StartProcessSpan. PublishAsync has no parent context and is unaffected.
+//
+// Example:
+//
+//	// Hot path under 1% sampling: avoid allocating 99 non-recording spans per 100 messages
+//	publisher := nats.NewPublisher(js, nats.WithSuppressUnsampled(true))
+func WithSuppressUnsampled(enabled bool) Option {
+	return func(o *options) {
+		o.suppressUnsampled = enabled
+	}
+}
+
+// WithBaggageAttributes copies the baggage members matching keys, extracted from
+// message headers, onto process spans as string attributes keyed by the baggage key,
+// like otx.NewBaggageAttributeProcessor does for spans in general. Keys are glob
+// patterns. Members missing from the message baggage are skipped.
+//
+// It applies to MessageHandlerWithTracing and TracedMsg.StartProcessSpan. Only
+// allowlisted keys are copied, since baggage comes from the publisher.
+//
+// Example:
+//
+//	handler := nats.MessageHandlerWithTracing(process, nats.WithBaggageAttributes("tenant.id"))
+func WithBaggageAttributes(keys ...string) Option {
+	return func(o *options) {
+		o.baggageAttributes = baggageattr.New(keys...)
+	}
+}
+
 // applyOptions applies option functions to the default options.
 func applyOptions(opts []Option) options {
 	o := defaultOptions()
@@ -108,6 +228,16 @@ func getTracer(tp trace.TracerProvider, opts options) trace.Tracer {
 	return tp.Tracer(opts.tracerName)
 }
 
+// skipSpan reports whether span creation should be skipped for parent ctx.
+func skipSpan(ctx context.Context, opts options) bool {
+	if !opts.suppressUnsampled {
+		return false
+	}
+	sc := trace.SpanContextFromContext(ctx)
+
+	return sc.IsValid() && !sc.IsSampled()
+}
+
 // getPropagator returns the configured or global propagator.
 func getPropagator(opts options) propagation.TextMapPropagator {
 	if opts.prop != nil {
@@ -6,9 +6,11 @@ import (
 
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/nats.go/jetstream"
-	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
+
+	"github.com/arloliu/otx"
 )
 
 // Publisher wraps JetStream publish operations with OpenTelemetry tracing.
@@ -17,6 +19,7 @@ type Publisher struct {
 	tracer trace.Tracer
 	prop   propagation.TextMapPropagator
 	opts   options
+	guard  *headerGuard // nil unless WithHeaderSizeGuard is set
 }
 
 // NewPublisher creates a Publisher with tracing using the global providers.
@@ -45,12 +48,22 @@ func NewPublisherWithProviders(
 		o.prop = prop
 	}
 
-	return &Publisher{
+	propagator := getPropagator(o)
+	if o.filterBaggage {
+		propagator = otx.NewBaggageAllowlistPropagator(propagator, o.baggageAllowlist...)
+	}
+
+	p := &Publisher{
 		js:     js,
 		tracer: getTracer(tp, o),
-		prop:   getPropagator(o),
+		prop:   propagator,
 		opts:   o,
 	}
+	if o.headerGuard {
+		p.guard = newHeaderGuard(o)
+	}
+
+	return p
 }
 
 // JetStream returns the underlying JetStream client for non-traced operations.
@@ -58,6 +71,19 @@ func (p *Publisher) JetStream() jetstream.JetStream {
 	return p.js
 }
 
+// connectionAttributes returns the connection attributes of publish spans.
+func (p *Publisher) connectionAttributes() []attribute.KeyValue {
+	if !p.opts.connAttributes {
+		return p.opts.connectionAttributes(nil, "")
+	}
+	nc := p.opts.conn
+	if nc == nil {
+		nc = p.js.Conn()
+	}
+
+	return p.opts.connectionAttributes(nc, p.js.Options().Domain)
+}
+
 // Publish publishes a message with tracing.
 // A producer span is created and trace context is injected into message headers.
 func (p *Publisher) Publish(
@@ -66,26 +92,32 @@ func (p *Publisher) Publish(
 	data []byte,
 	opts ...jetstream.PublishOpt,
 ) (*jetstream.PubAck, error) {
-	spanName := opTypePublish + " " + subject
+	// Create message with injected trace context
+	msg := &nats.Msg{
+		Subject: subject,
+		Data:    data,
+		Header:  make(nats.Header),
+	}
+	if skipSpan(ctx, p.opts) {
+		p.inject(ctx, msg.Header)
+
+		return p.js.PublishMsg(ctx, msg, opts...)
+	}
+
+	spanName := p.opts.spanName(opTypePublish, subject, subject)
 
 	ctx, span := p.tracer.Start(ctx, spanName,
 		trace.WithSpanKind(trace.SpanKindProducer),
 		trace.WithAttributes(publishAttributes(subject, "", len(data))...),
+		trace.WithAttributes(p.connectionAttributes()...),
 	)
 	defer span.End()
 
-	// Create message with injected trace context
-	msg := &nats.Msg{
-		Subject: subject,
-		Data:    data,
-		Header:  make(nats.Header),
-	}
-	p.prop.Inject(ctx, headerCarrier(msg.Header))
+	p.inject(ctx, msg.Header)
 
 	ack, err := p.js.PublishMsg(ctx, msg, opts...)
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		otx.RecordSpanError(span, err)
 
 		return nil, err
 	}
@@ -105,26 +137,31 @@ func (p *Publisher) PublishMsg(
 	msg *nats.Msg,
 	opts ...jetstream.PublishOpt,
 ) (*jetstream.PubAck, error) {
+	// Inject trace context
+	if msg.Header == nil {
+		msg.Header = make(nats.Header)
+	}
+	if skipSpan(ctx, p.opts) {
+		p.inject(ctx, msg.Header)
+
+		return p.js.PublishMsg(ctx, msg, opts...)
+	}
+
 	subject := msg.Subject
-	spanName := opTypePublish + " " + subject
+	spanName := p.opts.spanName(opTypePublish, subject, subject)
 
 	ctx, span := p.tracer.Start(ctx, spanName,
 		trace.WithSpanKind(trace.SpanKindProducer),
 		trace.WithAttributes(publishAttributes(subject, "", len(msg.Data))...),
+		trace.WithAttributes(p.connectionAttributes()...),
 	)
 	defer span.End()
 
-	// Inject trace context
-	if msg.Header == nil {
-		msg.Header = make(nats.Header)
-	}
-
-	p.prop.Inject(ctx, headerCarrier(msg.Header))
+	p.inject(ctx, msg.Header)
 
 	ack, err := p.js.PublishMsg(ctx, msg, opts...)
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		otx.RecordSpanError(span, err)
 
 		return nil, err
 	}
@@ -149,11 +186,12 @@ func (p *Publisher) PublishAsync(
 	}
 
 	ctx := context.Background()
-	spanName := opTypePublish + " " + subject
+	spanName := p.opts.spanName(opTypePublish, subject, subject)
 
 	ctx, span := p.tracer.Start(ctx, spanName,
 		trace.WithSpanKind(trace.SpanKindProducer),
 		trace.WithAttributes(publishAttributes(subject, "", len(data))...),
+		trace.WithAttributes(p.connectionAttributes()...),
 	)
 	// Note: span.End() is deferred here, not after future resolves
 	// This captures the publish initiation, not the ack receipt
@@ -164,12 +202,11 @@ func (p *Publisher) PublishAsync(
 		Data:    data,
 		Header:  make(nats.Header),
 	}
-	p.prop.Inject(ctx, headerCarrier(msg.Header))
+	p.inject(ctx, msg.Header)
 
 	future, err := p.js.PublishMsgAsync(msg, opts...)
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		otx.RecordSpanError(span, err)
 
 		return nil, err
 	}
@@ -190,11 +227,12 @@ func (p *Publisher) PublishAsyncMsg(
 
 	ctx := context.Background()
 	subject := msg.Subject
-	spanName := opTypePublish + " " + subject
+	spanName := p.opts.spanName(opTypePublish, subject, subject)
 
 	ctx, span := p.tracer.Start(ctx, spanName,
 		trace.WithSpanKind(trace.SpanKindProducer),
 		trace.WithAttributes(publishAttributes(subject, "", len(msg.Data))...),
+		trace.WithAttributes(p.connectionAttributes()...),
 	)
 	defer span.End()
 
@@ -202,12 +240,11 @@ func (p *Publisher) PublishAsyncMsg(
 		msg.Header = make(nats.Header)
 	}
 
-	p.prop.Inject(ctx, headerCarrier(msg.Header))
+	p.inject(ctx, msg.Header)
 
 	future, err := p.js.PublishMsgAsync(msg, opts...)
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		otx.RecordSpanError(span, err)
 
 		return nil, err
 	}
@@ -215,5 +252,17 @@ func (p *Publisher) PublishAsyncMsg(
 	return future, nil
 }
 
+// inject writes trace context into header, applying the header size guard if
+// configured. The baggage allowlist is applied by p.prop.
+func (p *Publisher) inject(ctx context.Context, header nats.Header) {
+	if p.guard != nil {
+		p.guard.inject(ctx, p.prop, header)
+
+		return
+	}
+
+	p.prop.Inject(ctx, headerCarrier(header))
+}
+
 // Compile-time check that Publisher doesn't accidentally claim to implement JetStream.
 var _ interface{ JetStream() jetstream.JetStream } = (*Publisher)(nil)
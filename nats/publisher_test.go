@@ -3,6 +3,7 @@ package nats
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/nats-io/nats.go"
@@ -11,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/trace"
@@ -589,3 +591,111 @@ func TestPublisher_WithCustomPropagator(t *testing.T) {
 	// Custom propagator should have been used
 	assert.True(t, customProp.injected)
 }
+
+// stubJetStream implements only the publish methods of jetstream.JetStream.
+// Calling any other method panics via the nil embedded interface.
+type stubJetStream struct {
+	jetstream.JetStream
+	published []*nats.Msg
+	failOn    map[string]error // Subject -> error returned by PublishMsg
+}
+
+func (s *stubJetStream) PublishMsg(
+	_ context.Context,
+	msg *nats.Msg,
+	_ ...jetstream.PublishOpt,
+) (*jetstream.PubAck, error) {
+	if err := s.failOn[msg.Subject]; err != nil {
+		return nil, err
+	}
+	s.published = append(s.published, msg)
+
+	return &jetstream.PubAck{Sequence: uint64(len(s.published))}, nil
+}
+
+func TestPublisher_WithBaggageAllowlist(t *testing.T) {
+	prop := propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+	tp := trace.NewTracerProvider()
+
+	tenant, err := baggage.NewMember("tenant.id", "acme")
+	require.NoError(t, err)
+	user, err := baggage.NewMember("user.id", "42")
+	require.NoError(t, err)
+	bag, err := baggage.New(tenant, user)
+	require.NoError(t, err)
+	ctx := baggage.ContextWithBaggage(t.Context(), bag)
+
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{name: "default injects all", want: "tenant.id=acme,user.id=42"},
+		{name: "allowlist", opts: []Option{WithBaggageAllowlist("tenant.id", "missing")}, want: "tenant.id=acme"},
+		{name: "glob allowlist", opts: []Option{WithBaggageAllowlist("user.*")}, want: "user.id=42"},
+		{name: "empty allowlist", opts: []Option{WithBaggageAllowlist()}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			js := &stubJetStream{}
+			pub := NewPublisherWithProviders(js, tp, prop, tt.opts...)
+
+			_, err := pub.Publish(ctx, "orders.created", []byte("data"))
+			require.NoError(t, err)
+
+			require.Len(t, js.published, 1)
+			header := js.published[0].Header
+			assert.NotEmpty(t, header.Get("traceparent"))
+			assert.ElementsMatch(t, splitBaggageHeader(tt.want), splitBaggageHeader(header.Get("baggage")))
+		})
+	}
+
+	// Caller baggage is untouched
+	assert.Equal(t, 2, baggage.FromContext(ctx).Len())
+}
+
+func splitBaggageHeader(v string) []string {
+	if v == "" {
+		return nil
+	}
+
+	return strings.Split(v, ",")
+}
+
+func TestPublisher_WithSuppressUnsampled(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := trace.NewTracerProvider(trace.WithSyncer(exporter))
+	prop := propagation.TraceContext{}
+
+	parent := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID: oteltrace.TraceID{0x01},
+		SpanID:  oteltrace.SpanID{0x02},
+	})
+	unsampledCtx := oteltrace.ContextWithSpanContext(t.Context(), parent)
+	sampledCtx := oteltrace.ContextWithSpanContext(t.Context(),
+		parent.WithTraceFlags(oteltrace.FlagsSampled))
+
+	js := &stubJetStream{}
+	pub := NewPublisherWithProviders(js, tp, prop, WithSuppressUnsampled(true))
+
+	_, err := pub.Publish(unsampledCtx, "orders.created", []byte("data"))
+	require.NoError(t, err)
+	_, err = pub.PublishMsg(unsampledCtx, &nats.Msg{Subject: "orders.created"})
+	require.NoError(t, err)
+
+	assert.Empty(t, exporter.GetSpans())
+	require.Len(t, js.published, 2)
+	for _, msg := range js.published {
+		assert.Equal(t, "00-"+parent.TraceID().String()+"-"+parent.SpanID().String()+"-00",
+			msg.Header.Get("traceparent"), "parent context is still injected")
+	}
+
+	_, err = pub.Publish(sampledCtx, "orders.created", []byte("data"))
+	require.NoError(t, err)
+	assert.Len(t, exporter.GetSpans(), 1)
+
+	_, err = pub.Publish(t.Context(), "orders.created", []byte("data"))
+	require.NoError(t, err)
+	assert.Len(t, exporter.GetSpans(), 2, "root publishes are left to the sampler")
+}
@@ -0,0 +1,107 @@
+package nats
+
+import "strings"
+
+// SpanNameMode selects what follows the operation in process and publish span names.
+type SpanNameMode int
+
+const (
+	// StreamBased names process spans "process {stream}" and publish spans
+	// "publish {subject}", since publishers do not know the stream. This is the default.
+	StreamBased SpanNameMode = iota
+
+	// SubjectBased names process and publish spans "{operation} {subject}".
+	// Subjects carrying IDs make span names high-cardinality; prefer Template for them.
+	SubjectBased
+
+	// Template names process and publish spans "{operation} {template}", where template
+	// is the first pattern set with WithSubjectTemplates that matches the subject.
+	// Spans whose subject matches no pattern keep their StreamBased name.
+	Template
+)
+
+// WithSpanNameMode selects how process and publish span names are built.
+// Default is StreamBased.
+//
+// Example:
+//
+//	// "process orders.*.created" instead of "process ORDERS"
+//	handler := nats.MessageHandlerWithTracing(process,
+//	    nats.WithSpanNameMode(nats.Template),
+//	    nats.WithSubjectTemplates("orders.*.created", "orders.>"),
+//	)
+func WithSpanNameMode(mode SpanNameMode) Option {
+	return func(o *options) {
+		o.spanNameMode = mode
+	}
+}
+
+// WithSubjectTemplates sets the subject patterns used by the Template span name mode.
+// Patterns use NATS wildcards: "*" matches one token and a trailing ">" matches one
+// or more tokens. Patterns are tried in order.
+func WithSubjectTemplates(patterns ...string) Option {
+	return func(o *options) {
+		o.subjectTemplates = append([]string{}, patterns...)
+	}
+}
+
+// spanName returns the name of an op span for a message on subject. fallback is the
+// StreamBased target: the stream for process spans and the subject for publish spans.
+// If the target is empty, the name is op alone.
+func (o *options) spanName(op, subject, fallback string) string {
+	target := fallback
+	switch o.spanNameMode {
+	case SubjectBased:
+		if subject != "" {
+			target = subject
+		}
+	case Template:
+		if template := matchTemplate(o.subjectTemplates, subject); template != "" {
+			target = template
+		}
+	case StreamBased:
+	}
+
+	if target == "" {
+		return op
+	}
+
+	return op + " " + target
+}
+
+// matchTemplate returns the first pattern matching subject, or "".
+func matchTemplate(patterns []string, subject string) string {
+	if subject == "" {
+		return ""
+	}
+	for _, pattern := range patterns {
+		if matchSubject(pattern, subject) {
+			return pattern
+		}
+	}
+
+	return ""
+}
+
+// matchSubject reports whether subject matches the NATS subject pattern.
+func matchSubject(pattern, subject string) bool {
+	for pattern != "" {
+		patToken, patRest, patMore := strings.Cut(pattern, ".")
+		if patToken == ">" {
+			return !patMore && subject != ""
+		}
+		if subject == "" {
+			return false
+		}
+		subToken, subRest, subMore := strings.Cut(subject, ".")
+		if patToken != "*" && patToken != subToken {
+			return false
+		}
+		if patMore != subMore {
+			return false
+		}
+		pattern, subject = patRest, subRest
+	}
+
+	return subject == ""
+}
@@ -0,0 +1,87 @@
+package nats
+
+import (
+	"testing"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchSubject(t *testing.T) {
+	tests := []struct {
+		pattern string
+		subject string
+		want    bool
+	}{
+		{"orders.created", "orders.created", true},
+		{"orders.created", "orders.updated", false},
+		{"orders.*.created", "orders.42.created", true},
+		{"orders.*.created", "orders.42.eu.created", false},
+		{"orders.*", "orders", false},
+		{"orders.>", "orders.42.created", true},
+		{"orders.>", "orders", false},
+		{">", "orders.42", true},
+		{"orders.*", "orders.42.created", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.subject, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchSubject(tt.pattern, tt.subject))
+		})
+	}
+}
+
+func TestSpanName(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{name: "default", want: "process ORDERS"},
+		{name: "subject", opts: []Option{WithSpanNameMode(SubjectBased)}, want: "process orders.42.created"},
+		{
+			name: "template",
+			opts: []Option{WithSpanNameMode(Template), WithSubjectTemplates("orders.*.updated", "orders.*.created")},
+			want: "process orders.*.created",
+		},
+		{
+			name: "template without match",
+			opts: []Option{WithSpanNameMode(Template), WithSubjectTemplates("payments.>")},
+			want: "process ORDERS",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := applyOptions(tt.opts)
+			assert.Equal(t, tt.want, o.spanName(opTypeProcess, "orders.42.created", "ORDERS"))
+		})
+	}
+
+	o := applyOptions([]Option{WithSpanNameMode(SubjectBased)})
+	assert.Equal(t, "publish", o.spanName(opTypePublish, "", ""), "batches spanning several subjects")
+}
+
+func TestSpanNameMode_Spans(t *testing.T) {
+	exporter, _ := setupHandlerTest(t)
+	opts := []Option{WithSpanNameMode(Template), WithSubjectTemplates("orders.*.created")}
+
+	msg := &mockMsg{
+		subject:  "orders.42.created",
+		metadata: &jetstream.MsgMetadata{Stream: "ORDERS"},
+	}
+	MessageHandlerWithTracing(func(_ *TracedMsg) {}, opts...)(msg)
+	_, end := NewTracedMsg(msg).StartProcessSpan(opts...)
+	end(nil)
+
+	_, err := NewPublisher(&stubJetStream{}, opts...).Publish(t.Context(), "orders.7.created", nil)
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+	for _, span := range spans[:2] {
+		assert.Equal(t, "process orders.*.created", span.Name)
+	}
+	assert.Equal(t, "publish orders.*.created", spans[2].Name)
+}
@@ -0,0 +1,197 @@
+package otxtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Outcome is the result a conformance test server must produce for a call.
+type Outcome int
+
+const (
+	// OutcomeOK is a successful call (HTTP 200, gRPC OK).
+	OutcomeOK Outcome = iota
+	// OutcomeClientError is a failure caused by the caller (HTTP 404, gRPC NotFound).
+	// Per semantic conventions it marks only the client span as an error.
+	OutcomeClientError
+	// OutcomeServerError is a failure of the server (HTTP 500, gRPC Internal).
+	// It marks both spans as errors.
+	OutcomeServerError
+)
+
+// conformanceBaggageKey is the baggage member the suite expects to reach the server.
+const conformanceBaggageKey = "otxtest.conformance"
+
+// spanWaitTimeout bounds how long the suite waits for spans to end.
+// Server spans may end shortly after the client has received the response.
+const spanWaitTimeout = 5 * time.Second
+
+// Providers are the telemetry providers a harness must instrument its client and server with.
+type Providers struct {
+	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
+	Propagator     propagation.TextMapPropagator
+}
+
+// Observer must be called by the server handler with its request context.
+type Observer func(ctx context.Context)
+
+// Call performs one instrumented client call asking the server for outcome.
+// It returns the transport error, if any; error outcomes may return an error.
+type Call func(ctx context.Context, outcome Outcome) error
+
+// Harness describes an instrumented client/server pair under test.
+type Harness struct {
+	// Name identifies the transport in subtest names.
+	Name string
+
+	// Start starts an instrumented server and returns an instrumented client call.
+	// The server handler must call observe with its request context and respond with
+	// the requested outcome. Use t.Cleanup to stop the server.
+	Start func(t testing.TB, p Providers, observe Observer) Call
+
+	// ServerAttributes and ClientAttributes list attribute keys every server and
+	// client span must have.
+	ServerAttributes []attribute.Key
+	ClientAttributes []attribute.Key
+}
+
+// String returns the subtest name of o.
+func (o Outcome) String() string {
+	switch o {
+	case OutcomeOK:
+		return "ok"
+	case OutcomeClientError:
+		return "client_error"
+	case OutcomeServerError:
+		return "server_error"
+	default:
+		return "unknown"
+	}
+}
+
+// RunConformance runs the conformance suite against h. For every outcome it checks:
+//   - exactly one client and one server span are recorded, in the same trace
+//   - the server span is a child of the client span (trace context propagation)
+//   - baggage set by the caller is visible in the server handler
+//   - the required attributes are present on both spans
+//   - span status follows semantic conventions: client errors mark only the client
+//     span as an error, server errors mark both
+func RunConformance(t *testing.T, h Harness) {
+	t.Helper()
+	require.NotNil(t, h.Start, "Harness.Start is required")
+
+	for _, outcome := range []Outcome{OutcomeOK, OutcomeClientError, OutcomeServerError} {
+		t.Run(h.Name+"/"+outcome.String(), func(t *testing.T) {
+			runConformanceCase(t, h, outcome)
+		})
+	}
+}
+
+// runConformanceCase runs one call with outcome and checks the recorded spans.
+func runConformanceCase(t *testing.T, h Harness, outcome Outcome) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	providers := Providers{
+		TracerProvider: tp,
+		MeterProvider:  noop.NewMeterProvider(),
+		Propagator:     propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}),
+	}
+
+	observed := make(chan context.Context, 1)
+	call := h.Start(t, providers, func(ctx context.Context) {
+		select {
+		case observed <- ctx:
+		default:
+		}
+	})
+
+	member, err := baggage.NewMember(conformanceBaggageKey, outcome.String())
+	require.NoError(t, err)
+	bag, err := baggage.New(member)
+	require.NoError(t, err)
+	ctx := baggage.ContextWithBaggage(context.Background(), bag)
+
+	err = call(ctx, outcome)
+	if outcome == OutcomeOK {
+		require.NoError(t, err, "successful call failed")
+	}
+
+	var serverCtx context.Context
+	select {
+	case serverCtx = <-observed:
+	case <-time.After(spanWaitTimeout):
+		require.FailNow(t, "server handler did not call observe")
+	}
+	assert.Equal(t, outcome.String(), baggage.FromContext(serverCtx).Member(conformanceBaggageKey).Value(),
+		"baggage must propagate to the server")
+
+	require.Eventually(t, func() bool { return len(exporter.GetSpans()) >= 2 }, spanWaitTimeout, 5*time.Millisecond,
+		"expected a client and a server span")
+
+	spans := exporter.GetSpans()
+	client, server := findSpan(spans, trace.SpanKindClient), findSpan(spans, trace.SpanKindServer)
+	require.NotNil(t, client, "missing client span")
+	require.NotNil(t, server, "missing server span")
+	assert.Len(t, spans, 2, "expected exactly one client and one server span")
+
+	assert.Equal(t, client.SpanContext.TraceID(), server.SpanContext.TraceID(), "spans must share the trace")
+	assert.Equal(t, client.SpanContext.SpanID(), server.Parent.SpanID(), "server span must be a child of the client span")
+	assert.True(t, server.Parent.IsRemote(), "server parent must be remote")
+	assert.Equal(t, server.SpanContext.SpanID(), trace.SpanContextFromContext(serverCtx).SpanID(),
+		"handler context must carry the server span")
+
+	assertAttributes(t, "server", server.Attributes, h.ServerAttributes)
+	assertAttributes(t, "client", client.Attributes, h.ClientAttributes)
+
+	wantClient, wantServer := codes.Unset, codes.Unset
+	switch outcome {
+	case OutcomeClientError:
+		wantClient = codes.Error
+	case OutcomeServerError:
+		wantClient, wantServer = codes.Error, codes.Error
+	case OutcomeOK:
+	}
+	assert.Equal(t, wantClient, client.Status.Code, "client span status")
+	assert.Equal(t, wantServer, server.Status.Code, "server span status")
+}
+
+// findSpan returns the first span of the given kind, or nil.
+func findSpan(spans tracetest.SpanStubs, kind trace.SpanKind) *tracetest.SpanStub {
+	for i := range spans {
+		if spans[i].SpanKind == kind {
+			return &spans[i]
+		}
+	}
+
+	return nil
+}
+
+// assertAttributes checks that every required key is present in attrs.
+func assertAttributes(t *testing.T, side string, attrs []attribute.KeyValue, required []attribute.Key) {
+	t.Helper()
+
+	present := make(map[attribute.Key]struct{}, len(attrs))
+	for _, kv := range attrs {
+		present[kv.Key] = struct{}{}
+	}
+	for _, key := range required {
+		_, ok := present[key]
+		assert.True(t, ok, "%s span is missing attribute %q", side, key)
+	}
+}
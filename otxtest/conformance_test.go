@@ -0,0 +1,13 @@
+package otxtest
+
+import (
+	"testing"
+)
+
+func TestConformance_HTTP(t *testing.T) {
+	RunConformance(t, HTTPHarness())
+}
+
+func TestConformance_GRPC(t *testing.T) {
+	RunConformance(t, GRPCHarness())
+}
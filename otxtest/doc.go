@@ -0,0 +1,26 @@
+// Package otxtest provides test helpers for verifying otx instrumentation.
+//
+// # Conformance Suite
+//
+// [RunConformance] checks that an instrumented client/server pair behaves like the
+// otx http and grpc packages: client and server spans with the right kinds, trace
+// context and baggage propagation, required semantic convention attributes, and
+// status mapping for successful, client-error and server-error calls.
+//
+// The otx transports are available as [HTTPHarness] and [GRPCHarness]. Teams writing
+// their own adapters (for another framework or RPC system) describe it with a
+// [Harness] and run the same suite:
+//
+//	func TestMyAdapterConformance(t *testing.T) {
+//	    otxtest.RunConformance(t, otxtest.Harness{
+//	        Name:             "myrpc",
+//	        ServerAttributes: []attribute.Key{"rpc.system", "rpc.method"},
+//	        ClientAttributes: []attribute.Key{"rpc.system", "rpc.method"},
+//	        Start: func(t testing.TB, p otxtest.Providers, observe otxtest.Observer) otxtest.Call {
+//	            // start an instrumented server whose handler calls observe(ctx)
+//	            // and responds according to the requested outcome
+//	            return func(ctx context.Context, outcome otxtest.Outcome) error { ... }
+//	        },
+//	    })
+//	}
+package otxtest
@@ -0,0 +1,168 @@
+package otxtest
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	otxgrpc "github.com/arloliu/otx/grpc"
+	otxhttp "github.com/arloliu/otx/http"
+)
+
+// bufconnSize is the in-memory listener buffer used by GRPCHarness.
+const bufconnSize = 1024 * 1024
+
+// httpOutcomePaths maps outcomes to the paths served by HTTPHarness.
+var httpOutcomePaths = map[Outcome]string{
+	OutcomeOK:          "/ok",
+	OutcomeClientError: "/missing",
+	OutcomeServerError: "/fail",
+}
+
+// grpcOutcomeCodes maps outcomes to the status codes returned by GRPCHarness.
+var grpcOutcomeCodes = map[Outcome]codes.Code{
+	OutcomeOK:          codes.OK,
+	OutcomeClientError: codes.NotFound,
+	OutcomeServerError: codes.Internal,
+}
+
+// HTTPHarness returns the harness for the otx http package: an httptest server
+// wrapped with otxhttp.MiddlewareWithProviders, called by a client from
+// otxhttp.NewClientWithProviders. Outcomes map to 200, 404 and 500 responses.
+func HTTPHarness() Harness {
+	return Harness{
+		Name:  "http",
+		Start: startHTTP,
+		ServerAttributes: []attribute.Key{
+			"http.request.method",
+			"http.response.status_code",
+			"url.path",
+			"url.scheme",
+			"server.address",
+			"network.protocol.version",
+		},
+		ClientAttributes: []attribute.Key{
+			"http.request.method",
+			"http.response.status_code",
+			"url.full",
+			"server.address",
+			"server.port",
+		},
+	}
+}
+
+// GRPCHarness returns the harness for the otx grpc package: an in-memory gRPC
+// health server using otxgrpc.ServerHandlerWithProviders, called through
+// otxgrpc.ClientHandlerWithProviders. Outcomes map to OK, NotFound and Internal.
+func GRPCHarness() Harness {
+	keys := []attribute.Key{
+		"rpc.system",
+		"rpc.service",
+		"rpc.method",
+		"rpc.grpc.status_code",
+	}
+
+	return Harness{
+		Name:             "grpc",
+		Start:            startGRPC,
+		ServerAttributes: keys,
+		ClientAttributes: keys,
+	}
+}
+
+// startHTTP starts the HTTPHarness server and returns its client call.
+func startHTTP(t testing.TB, p Providers, observe Observer) Call {
+	mux := http.NewServeMux()
+	mux.HandleFunc(httpOutcomePaths[OutcomeOK], func(w http.ResponseWriter, r *http.Request) {
+		observe(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc(httpOutcomePaths[OutcomeServerError], func(w http.ResponseWriter, r *http.Request) {
+		observe(r.Context())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		observe(r.Context())
+		http.NotFound(w, r)
+	})
+
+	server := httptest.NewServer(otxhttp.MiddlewareWithProviders(p.TracerProvider, p.MeterProvider, p.Propagator)(mux))
+	t.Cleanup(server.Close)
+
+	client := otxhttp.NewClientWithProviders(p.TracerProvider, p.MeterProvider, p.Propagator)
+
+	return func(ctx context.Context, outcome Outcome) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+httpOutcomePaths[outcome], nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+
+		return resp.Body.Close()
+	}
+}
+
+// healthServer answers Check with the status code named by the request's service field.
+type healthServer struct {
+	healthpb.UnimplementedHealthServer
+	observe Observer
+}
+
+// Check implements healthpb.HealthServer.
+func (s *healthServer) Check(
+	ctx context.Context,
+	req *healthpb.HealthCheckRequest,
+) (*healthpb.HealthCheckResponse, error) {
+	s.observe(ctx)
+
+	for outcome, code := range grpcOutcomeCodes {
+		if code != codes.OK && req.GetService() == outcome.String() {
+			return nil, status.Error(code, outcome.String())
+		}
+	}
+
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+// startGRPC starts the GRPCHarness server and returns its client call.
+func startGRPC(t testing.TB, p Providers, observe Observer) Call {
+	lis := bufconn.Listen(bufconnSize)
+	handler := otxgrpc.ServerHandlerWithProviders(p.TracerProvider, p.MeterProvider, p.Propagator)
+	server := grpc.NewServer(grpc.StatsHandler(handler))
+	healthpb.RegisterHealthServer(server, &healthServer{observe: observe})
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough://bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otxgrpc.ClientHandlerWithProviders(p.TracerProvider, p.MeterProvider, p.Propagator)),
+	)
+	if err != nil {
+		t.Fatalf("otxtest: creating gRPC client: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	client := healthpb.NewHealthClient(conn)
+
+	return func(ctx context.Context, outcome Outcome) error {
+		_, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: outcome.String()})
+
+		return err
+	}
+}
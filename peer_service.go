@@ -0,0 +1,53 @@
+package otx
+
+import (
+	"regexp"
+
+	"github.com/arloliu/otx/internal/glob"
+	"github.com/arloliu/otx/internal/peerservice"
+)
+
+// PeerServiceRule names the downstream service behind the hosts matching Host,
+// recorded as peer.service on client spans so service maps show "stripe" instead
+// of "api.stripe.com". Host is a glob pattern for the host name without port,
+// where "*" matches any sequence of characters and "?" matches one character.
+// Host names are compared case-insensitively.
+type PeerServiceRule struct {
+	// Host is a glob pattern for the server host, e.g. "api.stripe.com" or "*.s3.amazonaws.com".
+	Host string `yaml:"host"`
+
+	// Service is the peer.service value, e.g. "stripe".
+	Service string `yaml:"service"`
+}
+
+// SetPeerServices sets the rules the HTTP and gRPC client instrumentation of
+// the otx/http and otx/grpc packages use to add peer.service to client spans.
+// Rules are tried in order and the first match wins. Calling it without rules
+// disables the mapping. It is called by [NewTracerProvider] and [Reconfigure]
+// for traces.peerServices.
+//
+// Example:
+//
+//	otx.SetPeerServices(
+//	    otx.PeerServiceRule{Host: "api.stripe.com", Service: "stripe"},
+//	    otx.PeerServiceRule{Host: "*.s3.amazonaws.com", Service: "s3"},
+//	)
+func SetPeerServices(rules ...PeerServiceRule) {
+	compiled := make([]peerservice.Rule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Host == "" || rule.Service == "" {
+			continue
+		}
+		compiled = append(compiled, peerservice.Rule{
+			Host:    regexp.MustCompile("(?i)^" + glob.Expr(rule.Host) + "$"),
+			Service: rule.Service,
+		})
+	}
+	peerservice.Set(compiled)
+}
+
+// PeerService returns the peer.service value for host set with SetPeerServices,
+// or "" if no rule matches. Use it to label client spans of other instrumentation.
+func PeerService(host string) string {
+	return peerservice.Lookup(host)
+}
@@ -0,0 +1,52 @@
+package otx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetPeerServices(t *testing.T) {
+	t.Cleanup(func() { SetPeerServices() })
+
+	SetPeerServices(
+		PeerServiceRule{Host: "api.stripe.com", Service: "stripe"},
+		PeerServiceRule{Host: "*.s3.amazonaws.com", Service: "s3"},
+		PeerServiceRule{Host: "*.amazonaws.com", Service: "aws"},
+		PeerServiceRule{Host: "ignored.example.com"},
+	)
+
+	assert.Equal(t, "stripe", PeerService("api.stripe.com"))
+	assert.Equal(t, "stripe", PeerService("API.Stripe.com"), "hosts are case-insensitive")
+	assert.Equal(t, "s3", PeerService("bucket.s3.amazonaws.com"), "first match wins")
+	assert.Equal(t, "aws", PeerService("sqs.us-east-1.amazonaws.com"))
+	assert.Empty(t, PeerService("files.stripe.com"))
+	assert.Empty(t, PeerService("ignored.example.com"), "rules without service are skipped")
+	assert.Empty(t, PeerService(""))
+
+	SetPeerServices()
+	assert.Empty(t, PeerService("api.stripe.com"))
+}
+
+func TestNewTracerProvider_PeerServices(t *testing.T) {
+	t.Cleanup(func() { SetPeerServices() })
+
+	cfg := &TelemetryConfig{
+		Enabled:     boolPtr(true),
+		ServiceName: "test",
+		Traces: &TracesConfig{
+			Exporter:     "none",
+			PeerServices: []PeerServiceRule{{Host: "api.stripe.com", Service: "stripe"}},
+		},
+	}
+	tp, err := NewTracerProvider(t.Context(), cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+	assert.Equal(t, "stripe", PeerService("api.stripe.com"))
+
+	cfg.Traces.PeerServices = nil
+	require.NoError(t, Reconfigure(t.Context(), cfg))
+	assert.Empty(t, PeerService("api.stripe.com"))
+}
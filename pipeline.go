@@ -0,0 +1,186 @@
+package otx
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Pipeline stage attributes, set on the spans started by [Stage].
+const (
+	// AttrPipelineStage is the name of the stage processing the item.
+	AttrPipelineStage = "otx.pipeline.stage"
+
+	// AttrPipelineQueueWait is the time, in milliseconds, the item spent in the
+	// channel between being sent and received.
+	AttrPipelineQueueWait = "otx.pipeline.queue_wait_ms"
+)
+
+// tracedItem is a value in a TracedChannel with the trace context it was sent with.
+type tracedItem[T any] struct {
+	value  T
+	span   trace.SpanContext
+	bag    baggage.Baggage
+	sentAt time.Time
+}
+
+// TracedChannel is a channel carrying the trace context of each value from the
+// sender to the receiver, so traces continue across the stages of an in-process
+// pipeline instead of ending at every channel.
+//
+// Only the span context and baggage travel with a value, never the sender's
+// context: receivers keep their own deadlines and cancellation.
+type TracedChannel[T any] struct {
+	ch chan tracedItem[T]
+}
+
+// NewTracedChannel returns a TracedChannel buffering size values; zero makes it unbuffered.
+//
+// Example:
+//
+//	orders := otx.NewTracedChannel[Order](100)
+//	go func() {
+//	    defer orders.Close()
+//	    for _, o := range batch {
+//	        _ = orders.Send(ctx, o)
+//	    }
+//	}()
+//	for {
+//	    ctx, order, ok := orders.Receive(ctx)
+//	    if !ok {
+//	        break
+//	    }
+//	    process(ctx, order) // spans started here join the sender's trace
+//	}
+func NewTracedChannel[T any](size int) *TracedChannel[T] {
+	return &TracedChannel[T]{ch: make(chan tracedItem[T], size)}
+}
+
+// Send sends v with the span context and baggage of ctx, blocking until the value
+// is buffered or received, or ctx is done. It returns ctx.Err() in the latter case.
+// Like a channel send, it panics if the channel is closed.
+func (c *TracedChannel[T]) Send(ctx context.Context, v T) error {
+	item := tracedItem[T]{
+		value:  v,
+		span:   trace.SpanContextFromContext(ctx),
+		bag:    baggage.FromContext(ctx),
+		sentAt: time.Now(),
+	}
+	select {
+	case c.ch <- item:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Receive waits for a value, returning it with ctx carrying the span context and
+// baggage it was sent with. ok is false once the channel is closed and drained,
+// or when ctx is done.
+func (c *TracedChannel[T]) Receive(ctx context.Context) (itemCtx context.Context, v T, ok bool) {
+	itemCtx, v, _, ok = c.receive(ctx)
+
+	return itemCtx, v, ok
+}
+
+// receive is Receive also returning the time the value spent in the channel.
+func (c *TracedChannel[T]) receive(ctx context.Context) (context.Context, T, time.Duration, bool) {
+	select {
+	case item, ok := <-c.ch:
+		if !ok {
+			return ctx, item.value, 0, false
+		}
+
+		return item.context(ctx), item.value, time.Since(item.sentAt), true
+	case <-ctx.Done():
+		var zero T
+
+		return ctx, zero, 0, false
+	}
+}
+
+// context returns ctx with the span context and baggage of the item.
+func (i tracedItem[T]) context(ctx context.Context) context.Context {
+	if i.span.IsValid() {
+		ctx = trace.ContextWithSpanContext(ctx, i.span)
+	}
+	if i.bag.Len() > 0 {
+		ctx = baggage.ContextWithBaggage(ctx, i.bag)
+	}
+
+	return ctx
+}
+
+// Close closes the channel. Receivers get the buffered values, then ok == false.
+func (c *TracedChannel[T]) Close() {
+	close(c.ch)
+}
+
+// Len returns the number of values buffered in the channel.
+func (c *TracedChannel[T]) Len() int {
+	return len(c.ch)
+}
+
+// Cap returns the buffer size of the channel.
+func (c *TracedChannel[T]) Cap() int {
+	return cap(c.ch)
+}
+
+// Stage runs a pipeline stage: it receives values from in, calls fn for each one
+// in a span named name, and sends the results to out, until in is closed or ctx
+// is done. The span is a child of the span the value was sent with and records
+// [AttrPipelineStage] and [AttrPipelineQueueWait]. Results are sent with the
+// span's context, so the next stage continues the trace.
+//
+// When fn returns an error, the error is recorded on the span and the value is
+// dropped. A nil out discards results, for the last stage. Stage returns ctx.Err()
+// if ctx is done and nil once in is closed and drained; it does not close out, so
+// several Stage goroutines can share the channels as parallel workers.
+//
+// Example:
+//
+//	raw := otx.NewTracedChannel[[]byte](100)
+//	events := otx.NewTracedChannel[Event](100)
+//	go func() {
+//	    defer events.Close()
+//	    _ = otx.Stage(ctx, "decode", raw, events, decode)
+//	}()
+//	go otx.Stage(ctx, "store", events, nil, func(ctx context.Context, e Event) (struct{}, error) {
+//	    return struct{}{}, store.Save(ctx, e)
+//	})
+func Stage[In, Out any](
+	ctx context.Context,
+	name string,
+	in *TracedChannel[In],
+	out *TracedChannel[Out],
+	fn func(ctx context.Context, v In) (Out, error),
+) error {
+	for {
+		itemCtx, v, wait, ok := in.receive(ctx)
+		if !ok {
+			return ctx.Err()
+		}
+
+		spanCtx, span := Start(itemCtx, name, trace.WithAttributes(
+			attribute.String(AttrPipelineStage, name),
+			attribute.Float64(AttrPipelineQueueWait, float64(wait)/float64(time.Millisecond)),
+		))
+		result, err := fn(spanCtx, v)
+		if err != nil {
+			RecordError(spanCtx, err)
+			span.End()
+
+			continue
+		}
+		if out != nil {
+			err = out.Send(spanCtx, result)
+		}
+		span.End()
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package otx
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func setupTracing(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	InitTracing(tp.Tracer("otx"), nil)
+	t.Cleanup(func() {
+		InitTracing(nil, nil)
+		_ = tp.Shutdown(context.Background())
+	})
+
+	return exporter
+}
+
+func TestTracedChannel_SendReceive(t *testing.T) {
+	setupTracing(t)
+
+	ch := NewTracedChannel[int](1)
+	assert.Equal(t, 1, ch.Cap())
+
+	ctx, span := Start(MustSetBaggage(t.Context(), "tenant.id", "acme"), "producer")
+	defer span.End()
+	require.NoError(t, ch.Send(ctx, 42))
+	assert.Equal(t, 1, ch.Len())
+
+	recvCtx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	itemCtx, v, ok := ch.Receive(recvCtx)
+	require.True(t, ok)
+	assert.Equal(t, 42, v)
+	assert.Equal(t, span.SpanContext(), trace.SpanContextFromContext(itemCtx))
+	assert.Equal(t, "acme", GetBaggage(itemCtx, "tenant.id"))
+
+	cancel()
+	assert.ErrorIs(t, itemCtx.Err(), context.Canceled, "receiver keeps its own cancellation")
+}
+
+func TestTracedChannel_Done(t *testing.T) {
+	ch := NewTracedChannel[int](0)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	require.ErrorIs(t, ch.Send(ctx, 1), context.Canceled)
+	_, _, ok := ch.Receive(ctx)
+	assert.False(t, ok)
+
+	ch.Close()
+	_, _, ok = ch.Receive(t.Context())
+	assert.False(t, ok, "closed channel")
+}
+
+func TestStage(t *testing.T) {
+	exporter := setupTracing(t)
+
+	in := NewTracedChannel[string](2)
+	out := NewTracedChannel[int](2)
+
+	ctx, parent := Start(t.Context(), "request")
+	require.NoError(t, in.Send(ctx, "7"))
+	require.NoError(t, in.Send(ctx, "x"))
+	parent.End()
+	in.Close()
+
+	err := Stage(t.Context(), "parse", in, out, func(_ context.Context, s string) (int, error) {
+		return strconv.Atoi(s)
+	})
+	require.NoError(t, err)
+	out.Close()
+
+	err = Stage(t.Context(), "store", out, nil, func(_ context.Context, v int) (struct{}, error) {
+		assert.Equal(t, 7, v)
+
+		return struct{}{}, nil
+	})
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 4)
+	request, parseOK, parseErr, store := spans[0], spans[1], spans[2], spans[3]
+
+	assert.Equal(t, "parse", parseOK.Name)
+	assert.Equal(t, request.SpanContext.SpanID(), parseOK.Parent.SpanID())
+	assert.True(t, hasAttribute(parseOK.Attributes, attribute.String(AttrPipelineStage, "parse")))
+	assert.Equal(t, codes.Unset, parseOK.Status.Code)
+
+	assert.Equal(t, codes.Error, parseErr.Status.Code, "failing value is recorded and dropped")
+	assert.Contains(t, parseErr.Status.Description, "invalid syntax")
+	require.Len(t, parseErr.Events, 1)
+
+	assert.Equal(t, "store", store.Name)
+	assert.Equal(t, parseOK.SpanContext.SpanID(), store.Parent.SpanID(), "next stage continues the trace")
+	assert.Equal(t, request.SpanContext.TraceID(), store.SpanContext.TraceID())
+	var hasWait bool
+	for _, kv := range store.Attributes {
+		if kv.Key == AttrPipelineQueueWait {
+			hasWait = true
+			assert.GreaterOrEqual(t, kv.Value.AsFloat64(), 0.0)
+		}
+	}
+	assert.True(t, hasWait)
+}
+
+func TestStage_Canceled(t *testing.T) {
+	setupTracing(t)
+
+	in := NewTracedChannel[int](0)
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	err := Stage(ctx, "idle", in, nil, func(context.Context, int) (struct{}, error) {
+		return struct{}{}, nil
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
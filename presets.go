@@ -0,0 +1,103 @@
+package otx
+
+import "time"
+
+// ProductionSampleRatio is the share of new traces sampled by ProductionConfig.
+const ProductionSampleRatio = "0.1"
+
+// DevelopmentConfig returns a complete configuration for local development:
+// traces and logs are printed to stdout as indented JSON, every trace is sampled,
+// and log records are exported as they are emitted, so nothing is lost when the
+// process exits. Metrics are configured for the console but disabled, as periodic
+// metric dumps drown the other output; set Metrics.Enabled to see them.
+//
+// Environment variables are not applied; adjust the returned config in code, or
+// use LoadConfig for file and environment based configuration.
+//
+// Example:
+//
+//	tp, err := otx.NewTracerProvider(ctx, otx.DevelopmentConfig("orders"))
+func DevelopmentConfig(serviceName string) *TelemetryConfig {
+	return &TelemetryConfig{
+		Enabled:     boolPtr(true),
+		ServiceName: serviceName,
+		Environment: "development",
+		Traces: &TracesConfig{
+			Enabled:  boolPtr(true),
+			Exporter: "console",
+			Sampling: &SamplingConfig{Sampler: "always_on", SamplerArg: "1.0"},
+		},
+		Logs: &LogsConfig{
+			Enabled:   boolPtr(true),
+			Exporter:  "console",
+			Processor: LogProcessorSimple,
+		},
+		Metrics: &MetricsConfig{
+			Enabled:  boolPtr(false),
+			Exporter: "console",
+			Interval: 10 * time.Second,
+		},
+		Propagation:  &PropConfig{Propagators: "tracecontext,baggage"},
+		Console:      &ConsoleConfig{Output: "stdout", PrettyPrint: boolPtr(true)},
+		ErrorHandler: ErrorHandlerDefault,
+	}
+}
+
+// ProductionConfig returns a complete configuration exporting traces, logs and
+// metrics over OTLP/gRPC with TLS and gzip to endpoint ("host:port"). New traces
+// are sampled at [ProductionSampleRatio] and child spans follow the decision of
+// their parent, so traces started upstream stay complete. Failed exports are
+// retried, and otel errors are logged with slog.Default. Host, process, container
+// and Kubernetes resource attributes are detected.
+//
+// Environment variables are not applied; adjust the returned config in code, e.g.
+// set OTLP.Insecure for a collector sidecar without TLS.
+//
+// Example:
+//
+//	cfg := otx.ProductionConfig("orders", "otel-collector.observability:4317")
+//	cfg.Version = buildVersion
+//	tp, err := otx.NewTracerProvider(ctx, cfg)
+func ProductionConfig(serviceName, endpoint string) *TelemetryConfig {
+	return &TelemetryConfig{
+		Enabled:     boolPtr(true),
+		ServiceName: serviceName,
+		Environment: "production",
+		ResourceDetectors: &ResourceDetectorsConfig{
+			Host:       true,
+			Process:    true,
+			Container:  true,
+			Kubernetes: true,
+		},
+		OTLP: &OTLPConfig{
+			Endpoint:    endpoint,
+			Insecure:    boolPtr(false),
+			Protocol:    "grpc",
+			Timeout:     10 * time.Second,
+			Compression: "gzip",
+			Retry: &RetryConfig{
+				Enabled:         boolPtr(true),
+				InitialInterval: 5 * time.Second,
+				MaxInterval:     30 * time.Second,
+				MaxElapsedTime:  time.Minute,
+			},
+		},
+		Traces: &TracesConfig{
+			Enabled:  boolPtr(true),
+			Exporter: "otlp",
+			Sampling: &SamplingConfig{Sampler: "parentbased_traceidratio", SamplerArg: ProductionSampleRatio},
+		},
+		Logs: &LogsConfig{
+			Enabled:   boolPtr(true),
+			Exporter:  "otlp",
+			Processor: LogProcessorBatch,
+		},
+		Metrics: &MetricsConfig{
+			Enabled:  boolPtr(true),
+			Exporter: "otlp",
+			Interval: 60 * time.Second,
+		},
+		Propagation:  &PropConfig{Propagators: "tracecontext,baggage"},
+		ErrorHandler: ErrorHandlerSlog,
+	}
+}
@@ -0,0 +1,47 @@
+package otx
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDevelopmentConfig(t *testing.T) {
+	cfg := DevelopmentConfig("orders")
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, "orders", cfg.ServiceName)
+	assert.Equal(t, []string{"console"}, cfg.GetTracesExporters())
+	assert.Equal(t, "always_on", cfg.GetSamplingConfig().Sampler)
+	assert.True(t, cfg.Logs.IsEnabled())
+	assert.False(t, cfg.Metrics.IsEnabled())
+
+	var out bytes.Buffer
+	cfg.Console.Writer = &out
+	ctx := t.Context()
+	tp, err := NewTracerProvider(ctx, cfg)
+	require.NoError(t, err)
+	_, span := tp.Tracer("test").Start(ctx, "dev-span")
+	span.End()
+	require.NoError(t, tp.Shutdown(ctx))
+	assert.Contains(t, out.String(), "dev-span")
+}
+
+func TestProductionConfig(t *testing.T) {
+	cfg := ProductionConfig("orders", "collector:4317")
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, "collector:4317", cfg.GetOTLPEndpoint())
+	assert.False(t, cfg.GetOTLPConfig().IsInsecure())
+	assert.Equal(t, []string{"otlp"}, cfg.GetTracesExporters())
+
+	sampling := cfg.GetSamplingConfig()
+	assert.Equal(t, "parentbased_traceidratio", sampling.Sampler)
+	ratio, ok := sampling.Ratio()
+	assert.True(t, ok)
+	assert.InDelta(t, 0.1, ratio, 0)
+	assert.True(t, cfg.Logs.IsEnabled())
+	assert.True(t, cfg.Metrics.IsEnabled())
+
+	assert.NotSame(t, cfg, ProductionConfig("orders", "collector:4317"), "every call returns a new config")
+}
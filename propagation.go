@@ -3,15 +3,24 @@ package otx
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
+	"sync"
 
+	"go.opentelemetry.io/contrib/propagators/aws/xray"
+	"go.opentelemetry.io/contrib/propagators/b3"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/metadata"
 )
 
+// ErrInvalidRemoteParent is returned by [ContextWithRemoteParent] when an ID is malformed or all zeros.
+var ErrInvalidRemoteParent = errors.New("otx: invalid remote parent")
+
 // knownPropagators lists the propagator names supported by this package.
-// b3, b3multi, jaeger, xray, ottrace require additional contrib packages.
+// jaeger and ottrace require additional contrib packages.
 var knownPropagators = map[string]bool{
 	"tracecontext": true,
 	"baggage":      true,
@@ -23,19 +32,57 @@ var knownPropagators = map[string]bool{
 	"none":         true,
 }
 
+// customPropagators holds the propagators added with RegisterPropagator.
+var customPropagators sync.Map // string -> propagation.TextMapPropagator
+
+// RegisterPropagator makes p available under name in PropConfig.Propagators
+// (OTEL_PROPAGATORS), e.g. for proprietary or legacy header formats. Registered
+// propagators run after the built-in ones, in the order they are listed.
+//
+// Register propagators before creating the TracerProvider, typically in an init
+// function. Registering a name again replaces the previous propagator.
+// RegisterPropagator panics if p is nil or name is a built-in propagator name.
+//
+// Example:
+//
+//	otx.RegisterPropagator("legacy", legacyCorrelationPropagator{})
+//	// OTEL_PROPAGATORS=tracecontext,baggage,legacy
+func RegisterPropagator(name string, p propagation.TextMapPropagator) {
+	if p == nil {
+		panic("otx: RegisterPropagator propagator is nil")
+	}
+	if knownPropagators[name] {
+		panic("otx: RegisterPropagator called for built-in propagator " + name)
+	}
+	customPropagators.Store(name, p)
+}
+
+// isKnownPropagator reports whether name is a built-in or registered propagator.
+func isKnownPropagator(name string) bool {
+	if knownPropagators[name] {
+		return true
+	}
+	_, ok := customPropagators.Load(name)
+
+	return ok
+}
+
 // buildPropagator creates a text map propagator based on configuration.
-// Supports OTel standard OTEL_PROPAGATORS values: tracecontext, baggage, b3, b3multi, jaeger, xray, ottrace
+// Supports OTel standard OTEL_PROPAGATORS values: tracecontext, baggage, b3, b3multi, xray
+// plus those added with RegisterPropagator.
 // Unknown propagator names are reported via otel.Handle and ignored.
 func buildPropagator(cfg *PropConfig) propagation.TextMapPropagator {
 	if cfg == nil {
 		cfg = &PropConfig{Propagators: "tracecontext,baggage"}
 	}
 
-	var propagators []propagation.TextMapPropagator
+	var propagators, custom []propagation.TextMapPropagator
 
-	// Check for unknown propagators and warn
+	// Collect registered propagators, warn about unknown ones
 	for _, name := range splitPropagators(cfg.Propagators) {
-		if !knownPropagators[name] {
+		if p, ok := customPropagators.Load(name); ok {
+			custom = append(custom, p.(propagation.TextMapPropagator)) //nolint:forcetypeassert // stored by RegisterPropagator
+		} else if !knownPropagators[name] {
 			otel.Handle(errors.New("otx: unknown propagator \"" + name + "\" in OTEL_PROPAGATORS, ignoring"))
 		}
 	}
@@ -46,14 +93,95 @@ func buildPropagator(cfg *PropConfig) propagation.TextMapPropagator {
 	if cfg.HasBaggage() {
 		propagators = append(propagators, propagation.Baggage{})
 	}
-	// Note: b3, b3multi, jaeger, xray, ottrace require additional contrib packages
+	if encoding := b3Encoding(cfg); encoding != b3.B3Unspecified {
+		propagators = append(propagators, b3.New(b3.WithInjectEncoding(encoding)))
+	}
+	if cfg.HasXRay() {
+		propagators = append(propagators, xray.Propagator{})
+	}
+	// Note: jaeger, ottrace require additional contrib packages
 	// go.opentelemetry.io/contrib/propagators/*
+	propagators = append(propagators, custom...)
 
 	if len(propagators) == 0 {
 		return propagation.NewCompositeTextMapPropagator()
 	}
 
-	return propagation.NewCompositeTextMapPropagator(propagators...)
+	composite := propagation.NewCompositeTextMapPropagator(propagators...)
+	if len(cfg.BaggageAllowlist) > 0 {
+		return NewBaggageAllowlistPropagator(composite, cfg.BaggageAllowlist...)
+	}
+
+	return composite
+}
+
+// b3Encoding returns the B3 header encodings to inject, or b3.B3Unspecified if B3 is disabled.
+// Extraction accepts both encodings regardless, so a single propagator covers
+// "b3" and "b3multi" listed together.
+func b3Encoding(cfg *PropConfig) b3.Encoding {
+	var encoding b3.Encoding
+	if cfg.HasB3() {
+		encoding |= b3.B3SingleHeader
+	}
+	if cfg.HasB3Multi() {
+		encoding |= b3.B3MultipleHeader
+	}
+
+	return encoding
+}
+
+// ContextWithRemoteParent returns a copy of ctx carrying a remote span context built
+// from raw hex IDs, so spans started from it continue the remote trace.
+//
+// Use this when integrating with frameworks that only hand over ID strings, such as
+// legacy headers or IDs stored in job rows. IDs are case-insensitive and surrounding
+// whitespace is ignored; 64-bit trace IDs (16 hex characters, as used by older B3 and
+// Jaeger clients) are left-padded with zeros to 128 bits.
+//
+// If either ID is malformed or all zeros, ctx is returned unchanged together with an
+// error wrapping [ErrInvalidRemoteParent].
+//
+// Example:
+//
+//	ctx, err := otx.ContextWithRemoteParent(ctx, job.TraceID, job.SpanID, true)
+//	if err != nil {
+//	    log.Printf("starting new trace: %v", err)
+//	}
+//	ctx, span := otx.StartConsumer(ctx, "job.run")
+//	defer span.End()
+func ContextWithRemoteParent(
+	ctx context.Context,
+	traceID string,
+	spanID string,
+	sampled bool,
+) (context.Context, error) {
+	tid := strings.ToLower(strings.TrimSpace(traceID))
+	if len(tid) == 16 {
+		tid = strings.Repeat("0", 16) + tid
+	}
+	parsedTraceID, err := trace.TraceIDFromHex(tid)
+	if err != nil {
+		return ctx, fmt.Errorf("%w: trace ID %q: %w", ErrInvalidRemoteParent, traceID, err)
+	}
+
+	parsedSpanID, err := trace.SpanIDFromHex(strings.ToLower(strings.TrimSpace(spanID)))
+	if err != nil {
+		return ctx, fmt.Errorf("%w: span ID %q: %w", ErrInvalidRemoteParent, spanID, err)
+	}
+
+	var flags trace.TraceFlags
+	if sampled {
+		flags = trace.FlagsSampled
+	}
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    parsedTraceID,
+		SpanID:     parsedSpanID,
+		TraceFlags: flags,
+		Remote:     true,
+	})
+
+	return trace.ContextWithRemoteSpanContext(ctx, sc), nil
 }
 
 // InjectHTTP injects trace context and baggage into HTTP headers.
@@ -76,6 +204,30 @@ func ExtractGRPC(ctx context.Context, md metadata.MD) context.Context {
 	return otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
 }
 
+// InjectMap injects trace context and baggage into m, e.g. the metadata of a job
+// queue message or a Redis payload. Keys are the lowercase header names of the
+// configured propagators, such as "traceparent". m must not be nil.
+//
+// Example:
+//
+//	job := Job{Payload: payload, Meta: map[string]string{}}
+//	otx.InjectMap(ctx, job.Meta)
+//	queue.Enqueue(job)
+func InjectMap(ctx context.Context, m map[string]string) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(m))
+}
+
+// ExtractMap extracts trace context and baggage from m, as written by [InjectMap].
+//
+// Example:
+//
+//	ctx = otx.ExtractMap(ctx, job.Meta)
+//	ctx, span := otx.StartConsumer(ctx, "job.run")
+//	defer span.End()
+func ExtractMap(ctx context.Context, m map[string]string) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(m))
+}
+
 // metadataCarrier adapts gRPC metadata to propagation.TextMapCarrier.
 type metadataCarrier metadata.MD
 
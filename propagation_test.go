@@ -0,0 +1,175 @@
+package otx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestContextWithRemoteParent(t *testing.T) {
+	ctx, err := ContextWithRemoteParent(t.Context(),
+		" 4BF92F3577B34DA6A3CE929D0E0E4736 ", "00f067aa0ba902b7", true)
+	require.NoError(t, err)
+
+	sc := trace.SpanContextFromContext(ctx)
+	assert.True(t, sc.IsRemote())
+	assert.True(t, sc.IsSampled())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", sc.SpanID().String())
+
+	// Spans started from the context continue the remote trace
+	tp := sdktrace.NewTracerProvider()
+	_, span := tp.Tracer("test").Start(ctx, "child")
+	defer span.End()
+	assert.Equal(t, sc.TraceID(), span.SpanContext().TraceID())
+	assert.True(t, span.SpanContext().IsSampled())
+
+	// 64-bit trace IDs are padded, unsampled flag is preserved
+	ctx, err = ContextWithRemoteParent(t.Context(), "a3ce929d0e0e4736", "00f067aa0ba902b7", false)
+	require.NoError(t, err)
+	sc = trace.SpanContextFromContext(ctx)
+	assert.Equal(t, "0000000000000000a3ce929d0e0e4736", sc.TraceID().String())
+	assert.False(t, sc.IsSampled())
+}
+
+func TestContextWithRemoteParent_Invalid(t *testing.T) {
+	cases := []struct {
+		name    string
+		traceID string
+		spanID  string
+	}{
+		{name: "empty", traceID: "", spanID: ""},
+		{name: "malformed trace ID", traceID: "not-a-trace-id", spanID: "00f067aa0ba902b7"},
+		{name: "zero trace ID", traceID: "00000000000000000000000000000000", spanID: "00f067aa0ba902b7"},
+		{name: "short span ID", traceID: "4bf92f3577b34da6a3ce929d0e0e4736", spanID: "00f067"},
+		{name: "zero span ID", traceID: "4bf92f3577b34da6a3ce929d0e0e4736", spanID: "0000000000000000"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := t.Context()
+			ctx, err := ContextWithRemoteParent(parent, tt.traceID, tt.spanID, true)
+			require.ErrorIs(t, err, ErrInvalidRemoteParent)
+			assert.Equal(t, parent, ctx)
+		})
+	}
+}
+
+func TestBuildPropagator_B3(t *testing.T) {
+	ctx, err := ContextWithRemoteParent(t.Context(),
+		"4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7", true)
+	require.NoError(t, err)
+	sc := trace.SpanContextFromContext(ctx)
+
+	cases := []struct {
+		name        string
+		propagators string
+		want        []string
+	}{
+		{name: "single header", propagators: "b3", want: []string{"b3"}},
+		{name: "multi header", propagators: "b3multi", want: []string{"x-b3-traceid", "x-b3-spanid", "x-b3-sampled"}},
+		{name: "both", propagators: "tracecontext,b3,b3multi", want: []string{"traceparent", "b3", "x-b3-traceid"}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			prop := buildPropagator(&PropConfig{Propagators: tt.propagators})
+			carrier := propagation.MapCarrier{}
+			prop.Inject(ctx, carrier)
+			for _, key := range tt.want {
+				assert.Contains(t, carrier.Keys(), key)
+			}
+
+			extracted := trace.SpanContextFromContext(prop.Extract(t.Context(), carrier))
+			assert.Equal(t, sc.TraceID(), extracted.TraceID())
+			assert.Equal(t, sc.SpanID(), extracted.SpanID())
+			assert.True(t, extracted.IsSampled())
+		})
+	}
+
+	carrier := propagation.MapCarrier{}
+	buildPropagator(nil).Inject(ctx, carrier)
+	assert.NotContains(t, carrier.Keys(), "b3", "b3 is not enabled by default")
+}
+
+func TestBuildPropagator_XRay(t *testing.T) {
+	ctx, err := ContextWithRemoteParent(t.Context(),
+		"4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7", true)
+	require.NoError(t, err)
+
+	prop := buildPropagator(&PropConfig{Propagators: "tracecontext,xray"})
+	carrier := propagation.MapCarrier{}
+	prop.Inject(ctx, carrier)
+	assert.Equal(t, "Root=1-4bf92f35-77b34da6a3ce929d0e0e4736;Parent=00f067aa0ba902b7;Sampled=1",
+		carrier.Get("X-Amzn-Trace-Id"))
+
+	extracted := trace.SpanContextFromContext(prop.Extract(t.Context(), propagation.MapCarrier{
+		"X-Amzn-Trace-Id": carrier.Get("X-Amzn-Trace-Id"),
+	}))
+	assert.Equal(t, trace.SpanContextFromContext(ctx).TraceID(), extracted.TraceID())
+}
+
+// correlationPropagator propagates the trace ID in a legacy X-Correlation-ID header.
+type correlationPropagator struct{}
+
+func (correlationPropagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		carrier.Set("X-Correlation-ID", sc.TraceID().String())
+	}
+}
+
+func (correlationPropagator) Extract(ctx context.Context, _ propagation.TextMapCarrier) context.Context {
+	return ctx
+}
+
+func (correlationPropagator) Fields() []string { return []string{"X-Correlation-ID"} }
+
+func TestRegisterPropagator(t *testing.T) {
+	RegisterPropagator("test-correlation", correlationPropagator{})
+	defer customPropagators.Delete("test-correlation")
+
+	cfg := &TelemetryConfig{Propagation: &PropConfig{Propagators: "tracecontext,test-correlation"}}
+	require.NoError(t, cfg.Validate())
+
+	ctx, err := ContextWithRemoteParent(t.Context(),
+		"4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7", true)
+	require.NoError(t, err)
+
+	prop := buildPropagator(cfg.Propagation)
+	carrier := propagation.MapCarrier{}
+	prop.Inject(ctx, carrier)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", carrier.Get("X-Correlation-ID"))
+	assert.NotEmpty(t, carrier.Get("traceparent"))
+	assert.ElementsMatch(t, []string{"traceparent", "tracestate", "X-Correlation-ID"}, prop.Fields())
+
+	assert.Panics(t, func() { RegisterPropagator("tracecontext", correlationPropagator{}) })
+	assert.Panics(t, func() { RegisterPropagator("nil", nil) })
+}
+
+func TestInjectExtractMap(t *testing.T) {
+	prev := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	t.Cleanup(func() { otel.SetTextMapPropagator(prev) })
+
+	ctx, err := ContextWithRemoteParent(t.Context(),
+		"4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7", true)
+	require.NoError(t, err)
+	ctx = MustSetBaggage(ctx, "tenant.id", "acme")
+
+	meta := map[string]string{}
+	InjectMap(ctx, meta)
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", meta["traceparent"])
+	assert.Equal(t, "tenant.id=acme", meta["baggage"])
+
+	extracted := ExtractMap(t.Context(), meta)
+	sc := trace.SpanContextFromContext(extracted)
+	assert.True(t, sc.IsRemote())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID().String())
+	assert.Equal(t, "acme", GetBaggage(extracted, "tenant.id"))
+}
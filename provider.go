@@ -4,18 +4,47 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/runtime"
+	"go.opentelemetry.io/contrib/propagators/aws/xray"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/log/global"
 	sdklog "go.opentelemetry.io/otel/sdk/log"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
 )
 
+// meterName is the instrumentation scope of the metrics recorded by span
+// processors and span helpers, such as the long-task counter and the span
+// duration histogram.
+const meterName = "github.com/arloliu/otx"
+
+// ID generators for TracesConfig.IDGenerator.
+const (
+	IDGeneratorRandom = "random"
+	IDGeneratorXRay   = "xray"
+)
+
+// Exemplar filters for MetricsConfig.ExemplarFilter.
+const (
+	ExemplarFilterAlwaysOn   = "always_on"
+	ExemplarFilterAlwaysOff  = "always_off"
+	ExemplarFilterTraceBased = "trace_based"
+)
+
+// Log processors for LogsConfig.Processor.
+const (
+	LogProcessorBatch  = "batch"
+	LogProcessorSimple = "simple"
+)
+
 // ErrDisabled is returned when telemetry is disabled.
 var ErrDisabled = errors.New("otx: telemetry is disabled")
 
@@ -34,7 +63,14 @@ var ErrServiceNameRequired = errors.New("otx: service name is required")
 
 // NewTracerProvider initializes the OpenTelemetry TracerProvider.
 // Returns ErrDisabled if telemetry is not enabled in config.
-func NewTracerProvider(ctx context.Context, cfg *TelemetryConfig) (*sdktrace.TracerProvider, error) {
+//
+// Options register extra span processors and filters or replace the ID generator;
+// see WithSpanProcessor, WithSpanFilter and WithIDGenerator.
+func NewTracerProvider(
+	ctx context.Context,
+	cfg *TelemetryConfig,
+	providerOpts ...TracerProviderOption,
+) (*sdktrace.TracerProvider, error) {
 	if !cfg.IsEnabled() {
 		return nil, ErrDisabled
 	}
@@ -44,37 +80,82 @@ func NewTracerProvider(ctx context.Context, cfg *TelemetryConfig) (*sdktrace.Tra
 		return nil, ErrDisabled
 	}
 
-	// Build resource
-	res, err := buildResource(ctx, cfg)
-	if err != nil {
-		return nil, err
-	}
+	installErrorHandler(cfg)
 
-	// Build sampler
-	sampler := buildSampler(cfg.GetSamplingConfig())
-
-	// Build exporter using new config structure
-	exporter, err := buildTraceExporter(ctx, cfg)
+	tp, reloadable, err := buildTracerProvider(ctx, cfg, providerOpts)
 	if err != nil {
-		return nil, fmt.Errorf("build trace exporter: %w", err)
+		return nil, err
 	}
-
-	// Create provider
-	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithResource(res),
-		sdktrace.WithSampler(sampler),
-		sdktrace.WithBatcher(exporter),
-	)
+	pipeline := reloadable.current.Load()
 
 	// Set global provider
 	otel.SetTracerProvider(tp)
 
 	// Set global propagator
-	otel.SetTextMapPropagator(buildPropagator(cfg.Propagation))
+	globalPropagator.swap(pipeline.propagator)
+	otel.SetTextMapPropagator(globalPropagator)
+
+	reconfigureTarget.Store(reloadable)
+
+	applyTracesGlobals(cfg.Traces)
+
+	if cfg.Traces != nil && cfg.Traces.StartupSpan {
+		emitStartupSpan(ctx, tp, cfg, pipeline.sampler)
+	}
 
 	return tp, nil
 }
 
+// applyTracesGlobals sets the process-wide span helpers from cfg, resetting those
+// it does not enable, so NewTracerProvider and Reconfigure leave the same state.
+func applyTracesGlobals(cfg *TracesConfig) {
+	if cfg == nil {
+		cfg = &TracesConfig{}
+	}
+
+	SetErrorBaggage(cfg.ErrorBaggage...)
+	SetPeerServices(cfg.PeerServices...)
+	SetErrorStackTrace(cfg.ErrorStackTrace)
+	if cfg.SpanMetrics {
+		EnableSpanMetrics(nil)
+	} else {
+		DisableSpanMetrics()
+	}
+}
+
+// buildTracerProvider creates a TracerProvider from cfg without touching the
+// globals, returning it with the reloadable pipeline behind it.
+func buildTracerProvider(
+	ctx context.Context,
+	cfg *TelemetryConfig,
+	providerOpts []TracerProviderOption,
+) (*sdktrace.TracerProvider, *reloadablePipeline, error) {
+	res, err := buildResource(ctx, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pipeline, err := buildSpanPipeline(ctx, cfg, providerOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+	reloadable := &reloadablePipeline{providerOpts: providerOpts}
+	reloadable.current.Store(pipeline)
+
+	// The sampler and processors are reached through reloadable, so Reconfigure
+	// can swap them without replacing tp
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(reloadable),
+		sdktrace.WithSpanProcessor(reloadable),
+	}
+	if gen := buildIDGenerator(cfg.Traces, providerOpts); gen != nil {
+		opts = append(opts, sdktrace.WithIDGenerator(gen))
+	}
+
+	return sdktrace.NewTracerProvider(opts...), reloadable, nil
+}
+
 // ============================================================================
 // Logger Provider
 // ============================================================================
@@ -92,23 +173,36 @@ func NewLoggerProvider(ctx context.Context, cfg *TelemetryConfig) (*sdklog.Logge
 		return nil, ErrLogsDisabled
 	}
 
+	installErrorHandler(cfg)
+
 	// Build resource
 	res, err := buildResource(ctx, cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	// Build log exporter
-	exporter, err := buildLogExporter(ctx, cfg)
+	// Build log exporters
+	exporters, err := buildLogExporters(ctx, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("build log exporter: %w", err)
 	}
 
-	// Create provider with batching processor
-	lp := sdklog.NewLoggerProvider(
-		sdklog.WithResource(res),
-		sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter)),
-	)
+	// Create provider with one batching processor per exporter, each with its own queue,
+	// or one simple processor per exporter
+	opts := []sdklog.LoggerProviderOption{sdklog.WithResource(res)}
+	opts = append(opts, buildLogLimits(cfg.Logs)...)
+	batchOpts := buildLogBatchOptions(cfg.Logs)
+	st := selfTelemetryFor(cfg)
+	names := exporterTypes(logExporterTypes(cfg))
+	capacity := queueCapacity(cfg.Logs.Batch, "OTEL_BLRP_MAX_QUEUE_SIZE")
+	for i, exporter := range exporters {
+		if strings.EqualFold(cfg.Logs.Processor, LogProcessorSimple) {
+			opts = append(opts, sdklog.WithProcessor(st.logSimple(exporter, names[i])))
+			continue
+		}
+		opts = append(opts, sdklog.WithProcessor(st.logBatcher(exporter, names[i], capacity, batchOpts...)))
+	}
+	lp := sdklog.NewLoggerProvider(opts...)
 
 	// Set global logger provider
 	global.SetLoggerProvider(lp)
@@ -132,14 +226,16 @@ func NewMeterProvider(ctx context.Context, cfg *TelemetryConfig) (*sdkmetric.Met
 		return nil, ErrMetricsDisabled
 	}
 
+	installErrorHandler(cfg)
+
 	// Build resource
 	res, err := buildResource(ctx, cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	// Build metric exporter
-	exporter, err := buildMetricExporter(ctx, cfg)
+	// Build metric exporters
+	exporters, err := buildMetricExporters(ctx, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("build metric exporter: %w", err)
 	}
@@ -147,13 +243,29 @@ func NewMeterProvider(ctx context.Context, cfg *TelemetryConfig) (*sdkmetric.Met
 	// Parse export interval
 	interval := normalizeMetricInterval(cfg.Metrics.Interval, 60*time.Second)
 
-	// Create provider with periodic reader
-	mp := sdkmetric.NewMeterProvider(
-		sdkmetric.WithResource(res),
-		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter,
+	// Create provider with one periodic reader per exporter
+	opts := []sdkmetric.Option{sdkmetric.WithResource(res)}
+	opts = append(opts, metricViewOptions(cfg.Metrics)...)
+	if filter := buildExemplarFilter(cfg.Metrics.ExemplarFilter); filter != nil {
+		opts = append(opts, sdkmetric.WithExemplarFilter(filter))
+	}
+	st := selfTelemetryFor(cfg)
+	names := exporterTypes(metricExporterTypes(cfg))
+	for i, exporter := range exporters {
+		exporter = withMetricSelectors(exporter, cfg.Metrics)
+		opts = append(opts, sdkmetric.WithReader(sdkmetric.NewPeriodicReader(st.metricExporter(exporter, names[i]),
 			sdkmetric.WithInterval(interval),
-		)),
-	)
+		)))
+	}
+	mp := sdkmetric.NewMeterProvider(opts...)
+
+	if cfg.Metrics.Runtime {
+		if err := runtime.Start(runtime.WithMeterProvider(mp)); err != nil {
+			_ = mp.Shutdown(ctx)
+
+			return nil, fmt.Errorf("start runtime metrics: %w", err)
+		}
+	}
 
 	// Set global meter provider
 	otel.SetMeterProvider(mp)
@@ -183,8 +295,10 @@ func buildResource(ctx context.Context, cfg *TelemetryConfig) (*resource.Resourc
 		baseAttrs = append(baseAttrs, attribute.String(key, value))
 	}
 
+	// Detected attributes come first so that explicitly configured ones win.
 	attrs := []resource.Option{
 		resource.WithSchemaURL(semconv.SchemaURL),
+		resource.WithAttributes(detectAttributes(ctx, cfg.ResourceDetectors)...),
 		resource.WithAttributes(baseAttrs...),
 	}
 
@@ -213,28 +327,215 @@ func normalizeMetricInterval(value time.Duration, defaultValue time.Duration) ti
 	return value
 }
 
-func buildSampler(cfg *SamplingConfig) sdktrace.Sampler {
+// buildExemplarFilter returns the exemplar filter named name, or nil to keep the
+// SDK default (trace_based, or OTEL_METRICS_EXEMPLAR_FILTER).
+func buildExemplarFilter(name string) exemplar.Filter {
+	switch name {
+	case ExemplarFilterAlwaysOn:
+		return exemplar.AlwaysOnFilter
+	case ExemplarFilterAlwaysOff:
+		return exemplar.AlwaysOffFilter
+	case ExemplarFilterTraceBased:
+		return exemplar.TraceBasedFilter
+	default:
+		return nil
+	}
+}
+
+// spanProcessors returns the baggage attribute processor for traces.baggageAttributes,
+// the long-task processor for traces.longTaskThreshold, the critical-path processor
+// for traces.criticalPath, the child span processor for traces.childSpanStats, the
+// processors from traces.spanProcessors and those registered with WithSpanProcessor,
+// in that order. Nil processors are skipped.
+func spanProcessors(cfg *TracesConfig, providerOpts []TracerProviderOption) []sdktrace.SpanProcessor {
+	return append(builtinSpanProcessors(cfg), suppliedSpanProcessors(cfg, providerOpts)...)
+}
+
+// builtinSpanProcessors returns the processors spanProcessors creates from cfg.
+func builtinSpanProcessors(cfg *TracesConfig) []sdktrace.SpanProcessor {
 	if cfg == nil {
-		cfg = &SamplingConfig{Sampler: "parentbased_always_on", SamplerArg: 1.0}
+		return nil
+	}
+
+	var processors []sdktrace.SpanProcessor
+	if len(cfg.BaggageAttributes) > 0 {
+		processors = append(processors, NewBaggageAttributeProcessor(cfg.BaggageAttributes...))
+	}
+	if cfg.LongTaskThreshold > 0 {
+		processors = append(processors, NewLongTaskProcessor(cfg.LongTaskThreshold, nil))
+	}
+	if cfg.CriticalPath {
+		processors = append(processors, NewCriticalPathProcessor())
+	}
+	if cfg.ChildSpanStats {
+		processors = append(processors, NewChildSpanProcessor())
+	}
+
+	return processors
+}
+
+// suppliedSpanProcessors returns the processors spanProcessors takes from the
+// caller: traces.spanProcessors and those registered with WithSpanProcessor.
+func suppliedSpanProcessors(cfg *TracesConfig, providerOpts []TracerProviderOption) []sdktrace.SpanProcessor {
+	var o tracerProviderOptions
+	if cfg != nil {
+		o.processors = append(o.processors, cfg.SpanProcessors...)
+	}
+	for _, opt := range providerOpts {
+		opt(&o)
+	}
+
+	return slices.DeleteFunc(o.processors, func(sp sdktrace.SpanProcessor) bool { return sp == nil })
+}
+
+// spanFilters returns the filters for traces.dropSpans followed by those registered
+// with WithSpanFilter. Nil filters are skipped.
+func spanFilters(cfg *TracesConfig, providerOpts []TracerProviderOption) []SpanFilter {
+	var o tracerProviderOptions
+	if cfg != nil {
+		for _, rule := range cfg.DropSpans {
+			o.filters = append(o.filters, rule.Filter())
+		}
+	}
+	for _, opt := range providerOpts {
+		opt(&o)
+	}
+
+	return compactFilters(o.filters)
+}
+
+// buildBatchOptions converts traces.batch into batch span processor options.
+// Zero values are skipped so the SDK defaults and OTEL_BSP_* variables apply.
+func buildBatchOptions(cfg *TracesConfig) []sdktrace.BatchSpanProcessorOption {
+	if cfg == nil || cfg.Batch == nil {
+		return nil
+	}
+
+	b := cfg.Batch
+	var opts []sdktrace.BatchSpanProcessorOption
+	if b.MaxQueueSize > 0 {
+		opts = append(opts, sdktrace.WithMaxQueueSize(b.MaxQueueSize))
+	}
+	if b.MaxExportBatchSize > 0 {
+		opts = append(opts, sdktrace.WithMaxExportBatchSize(b.MaxExportBatchSize))
+	}
+	if b.ScheduleDelay > 0 {
+		opts = append(opts, sdktrace.WithBatchTimeout(b.ScheduleDelay))
+	}
+	if b.ExportTimeout > 0 {
+		opts = append(opts, sdktrace.WithExportTimeout(b.ExportTimeout))
+	}
+
+	return opts
+}
+
+// buildLogBatchOptions converts logs.batch into batch log processor options.
+// Zero values are skipped so the SDK defaults and OTEL_BLRP_* variables apply.
+func buildLogBatchOptions(cfg *LogsConfig) []sdklog.BatchProcessorOption {
+	if cfg == nil || cfg.Batch == nil {
+		return nil
+	}
+
+	b := cfg.Batch
+	var opts []sdklog.BatchProcessorOption
+	if b.MaxQueueSize > 0 {
+		opts = append(opts, sdklog.WithMaxQueueSize(b.MaxQueueSize))
+	}
+	if b.MaxExportBatchSize > 0 {
+		opts = append(opts, sdklog.WithExportMaxBatchSize(b.MaxExportBatchSize))
+	}
+	if b.ScheduleDelay > 0 {
+		opts = append(opts, sdklog.WithExportInterval(b.ScheduleDelay))
+	}
+	if b.ExportTimeout > 0 {
+		opts = append(opts, sdklog.WithExportTimeout(b.ExportTimeout))
+	}
+
+	return opts
+}
+
+// buildLogLimits converts the log record limits of cfg into provider options.
+// Zero limits are left out, keeping the SDK defaults.
+func buildLogLimits(cfg *LogsConfig) []sdklog.LoggerProviderOption {
+	var opts []sdklog.LoggerProviderOption
+	if cfg.AttributeCountLimit != 0 {
+		opts = append(opts, sdklog.WithAttributeCountLimit(cfg.AttributeCountLimit))
+	}
+	if cfg.AttributeValueLengthLimit != 0 {
+		opts = append(opts, sdklog.WithAttributeValueLengthLimit(cfg.AttributeValueLengthLimit))
+	}
+
+	return opts
+}
+
+// WithIDGenerator sets the generator of trace and span IDs, overriding
+// traces.idGenerator, e.g. for time-prefixed IDs that a storage backend
+// partitions on. gen must be safe for concurrent use.
+//
+// Example:
+//
+//	tp, err := otx.NewTracerProvider(ctx, cfg, otx.WithIDGenerator(timePrefixedIDs{}))
+func WithIDGenerator(gen sdktrace.IDGenerator) TracerProviderOption {
+	return func(o *tracerProviderOptions) {
+		o.idGenerator = gen
+	}
+}
+
+// buildIDGenerator returns the generator set with WithIDGenerator, else the one
+// for traces.idGenerator, or nil to keep the SDK's random generator.
+func buildIDGenerator(cfg *TracesConfig, providerOpts []TracerProviderOption) sdktrace.IDGenerator {
+	var o tracerProviderOptions
+	for _, opt := range providerOpts {
+		opt(&o)
+	}
+	if o.idGenerator != nil {
+		return o.idGenerator
+	}
+	if cfg == nil {
+		return nil
+	}
+
+	switch cfg.IDGenerator {
+	case IDGeneratorXRay:
+		return xray.NewIDGenerator()
+	default:
+		return nil
+	}
+}
+
+// buildSampler returns the sampler of cfg. SamplerArg is parsed only for the
+// ratio-based samplers; a ratio that is not a number within [0, 1] is an error
+// rather than falling back to sampling every trace.
+func buildSampler(cfg *SamplingConfig) (sdktrace.Sampler, error) {
+	if cfg == nil {
+		cfg = &SamplingConfig{Sampler: "parentbased_always_on", SamplerArg: "1.0"}
+	}
+
+	var ratio float64
+	if strings.HasSuffix(cfg.Sampler, "traceidratio") {
+		var ok bool
+		if ratio, ok = cfg.Ratio(); !ok {
+			return nil, invalidf("sampling.samplerArg must be a number within [0, 1], got %q", cfg.SamplerArg)
+		}
 	}
 
 	// OTel standard sampler names per specification
 	// https://opentelemetry.io/docs/specs/otel/configuration/sdk-environment-variables/
 	switch cfg.Sampler {
 	case "always_on":
-		return sdktrace.AlwaysSample()
+		return sdktrace.AlwaysSample(), nil
 	case "always_off":
-		return sdktrace.NeverSample()
+		return sdktrace.NeverSample(), nil
 	case "traceidratio":
-		return sdktrace.TraceIDRatioBased(cfg.SamplerArg)
+		return sdktrace.TraceIDRatioBased(ratio), nil
 	case "parentbased_always_on":
-		return sdktrace.ParentBased(sdktrace.AlwaysSample())
+		return sdktrace.ParentBased(sdktrace.AlwaysSample()), nil
 	case "parentbased_always_off":
-		return sdktrace.ParentBased(sdktrace.NeverSample())
+		return sdktrace.ParentBased(sdktrace.NeverSample()), nil
 	case "parentbased_traceidratio":
-		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SamplerArg))
+		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio)), nil
 	default:
 		// Default to parentbased_always_on per OTel spec
-		return sdktrace.ParentBased(sdktrace.AlwaysSample())
+		return sdktrace.ParentBased(sdktrace.AlwaysSample()), nil
 	}
 }
@@ -0,0 +1,396 @@
+package otx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/embedded"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// ErrRegistryClosed is returned by ProviderRegistry.Provider after Shutdown.
+var ErrRegistryClosed = errors.New("otx: provider registry is shut down")
+
+// DefaultRegistryRetryInterval is how long a ProviderRegistry keeps the failure
+// to create the provider of a key before trying again.
+const DefaultRegistryRetryInterval = time.Minute
+
+// maxRegistryFailures bounds the failures a ProviderRegistry keeps, as routing keys
+// from baggage are caller-controlled.
+const maxRegistryFailures = 1024
+
+// ProviderConfigFunc returns the configuration of the TracerProvider for key,
+// e.g. a copy of a base configuration with the tenant's collector endpoint.
+// Returning an error, e.g. for an unknown tenant, routes the spans of key to the
+// fallback provider, and is reported once to the OTel error handler and cached
+// for the retry interval; a disabled configuration drops them.
+type ProviderConfigFunc func(ctx context.Context, key string) (*TelemetryConfig, error)
+
+// RegistryOption configures a ProviderRegistry.
+type RegistryOption func(*ProviderRegistry)
+
+// WithRegistryFallback sets the TracerProvider receiving spans without a routing
+// key, or whose key has no provider. Default is a noop provider, dropping them.
+// It must not be the registry itself, e.g. through the global TracerProvider.
+func WithRegistryFallback(tp trace.TracerProvider) RegistryOption {
+	return func(r *ProviderRegistry) {
+		if tp != nil {
+			r.fallback = tp
+		}
+	}
+}
+
+// WithRegistryRetryInterval sets how long the failure to create the provider of a
+// key, e.g. for an unknown tenant, is cached before the ProviderConfigFunc is
+// called again for it. Until then, spans of the key go to the fallback provider
+// without retrying. Default is DefaultRegistryRetryInterval.
+func WithRegistryRetryInterval(d time.Duration) RegistryOption {
+	return func(r *ProviderRegistry) {
+		if d > 0 {
+			r.retryInterval = d
+		}
+	}
+}
+
+// WithRegistryProviderOptions sets options applied to every TracerProvider the
+// registry creates, e.g. WithSpanProcessor.
+func WithRegistryProviderOptions(opts ...TracerProviderOption) RegistryOption {
+	return func(r *ProviderRegistry) {
+		r.providerOpts = append(r.providerOpts, opts...)
+	}
+}
+
+// ProviderRegistry creates and caches one TracerProvider per routing key, e.g. per
+// tenant or per destination, and is itself a trace.TracerProvider routing each span
+// to the provider of its key. Install it as the global TracerProvider, or pass it to
+// the HTTP and gRPC middleware, to export each tenant's spans to its own collector.
+//
+// The key of a span is, in order: the key of its parent span when the parent was
+// started through the registry, the baggage member named by the routing key, and
+// the span attribute of that name given at start. Providers are created on first
+// use from the configuration returned by the ProviderConfigFunc, without changing
+// the globals set by [NewTracerProvider].
+//
+// Baggage comes from upstream callers, so the ProviderConfigFunc should reject
+// unknown keys instead of creating a provider for every value it is given.
+type ProviderRegistry struct {
+	embedded.TracerProvider
+
+	key           string
+	configFor     ProviderConfigFunc
+	fallback      trace.TracerProvider
+	providerOpts  []TracerProviderOption
+	retryInterval time.Duration
+	now           func() time.Time // Clock for failure expiry; tests replace it
+
+	mu        sync.Mutex
+	providers map[string]*registryEntry
+	failures  map[string]*registryFailure
+	closed    bool
+}
+
+// registryFailure is a cached failure to create a provider.
+type registryFailure struct {
+	err   error
+	until time.Time // Retry after
+}
+
+// registryEntry is a cached provider.
+type registryEntry struct {
+	tp  trace.TracerProvider
+	sdk interface { // Nil for disabled configurations
+		Shutdown(ctx context.Context) error
+		ForceFlush(ctx context.Context) error
+	}
+}
+
+var _ trace.TracerProvider = (*ProviderRegistry)(nil)
+
+// NewProviderRegistry returns a ProviderRegistry routing spans by the baggage
+// member or span attribute named key, with providers configured by configFor.
+//
+// Example:
+//
+//	registry := otx.NewProviderRegistry("tenant.id",
+//	    func(_ context.Context, tenant string) (*otx.TelemetryConfig, error) {
+//	        endpoint, ok := endpoints[tenant]
+//	        if !ok {
+//	            return nil, fmt.Errorf("unknown tenant %q", tenant)
+//	        }
+//	        cfg := baseConfig()
+//	        cfg.OTLP.Endpoint = endpoint
+//	        return cfg, nil
+//	    },
+//	    otx.WithRegistryFallback(tp),
+//	)
+//	defer registry.Shutdown(ctx)
+//	otel.SetTracerProvider(registry)
+func NewProviderRegistry(key string, configFor ProviderConfigFunc, opts ...RegistryOption) *ProviderRegistry {
+	r := &ProviderRegistry{
+		key:           key,
+		configFor:     configFor,
+		fallback:      noop.NewTracerProvider(),
+		retryInterval: DefaultRegistryRetryInterval,
+		now:           time.Now,
+		providers:     make(map[string]*registryEntry),
+		failures:      make(map[string]*registryFailure),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Tracer implements trace.TracerProvider. The tracer picks the provider of each
+// span when it starts.
+func (r *ProviderRegistry) Tracer(name string, opts ...trace.TracerOption) trace.Tracer {
+	return &registryTracer{registry: r, name: name, opts: opts}
+}
+
+// Provider returns the TracerProvider of key, creating it on first use. A failed
+// creation is cached for the retry interval set with WithRegistryRetryInterval,
+// during which its error is returned without trying again.
+func (r *ProviderRegistry) Provider(ctx context.Context, key string) (trace.TracerProvider, error) {
+	tp, _, err := r.provider(ctx, key)
+
+	return tp, err
+}
+
+// provider is Provider also reporting whether err is the first failure of key,
+// as opposed to a cached or repeated one.
+func (r *ProviderRegistry) provider(
+	ctx context.Context,
+	key string,
+) (tp trace.TracerProvider, firstFailure bool, err error) {
+	r.mu.Lock()
+	entry, ok := r.providers[key]
+	failure := r.failures[key]
+	closed := r.closed
+	r.mu.Unlock()
+	if closed {
+		return nil, false, ErrRegistryClosed
+	}
+	if ok {
+		return entry.tp, false, nil
+	}
+	if failure != nil && r.now().Before(failure.until) {
+		return nil, false, failure.err
+	}
+
+	// Created without the lock, so a slow exporter setup does not hold back other
+	// keys; a concurrent creation for the same key is shut down by the loser
+	created, err := r.create(ctx, key)
+	if err != nil {
+		return nil, r.recordFailure(key, err), err
+	}
+
+	r.mu.Lock()
+	entry, ok = r.providers[key]
+	if !ok && !r.closed {
+		r.providers[key] = created
+		entry = created
+	}
+	delete(r.failures, key)
+	closed = r.closed
+	r.mu.Unlock()
+
+	if entry != created {
+		_ = created.shutdown(ctx)
+	}
+	if closed && !ok {
+		return nil, false, ErrRegistryClosed
+	}
+
+	return entry.tp, false, nil
+}
+
+// recordFailure caches the failure to create the provider of key and reports
+// whether key had no failure cached yet. When the cache is full, expired
+// failures are dropped, or all of them if none has expired.
+func (r *ProviderRegistry) recordFailure(key string, err error) bool {
+	now := r.now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, known := r.failures[key]
+	if !known && len(r.failures) >= maxRegistryFailures {
+		for k, f := range r.failures {
+			if !now.Before(f.until) {
+				delete(r.failures, k)
+			}
+		}
+		if len(r.failures) >= maxRegistryFailures {
+			clear(r.failures)
+		}
+	}
+	r.failures[key] = &registryFailure{err: err, until: now.Add(r.retryInterval)}
+
+	return !known
+}
+
+// create builds the provider of key.
+func (r *ProviderRegistry) create(ctx context.Context, key string) (*registryEntry, error) {
+	cfg, err := r.configFor(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("otx: provider config for %q: %w", key, err)
+	}
+	if !cfg.IsEnabled() || (cfg.Traces != nil && !cfg.Traces.IsEnabled()) {
+		return &registryEntry{tp: noop.NewTracerProvider()}, nil
+	}
+
+	tp, _, err := buildTracerProvider(ctx, cfg, r.providerOpts)
+	if err != nil {
+		return nil, fmt.Errorf("otx: provider for %q: %w", key, err)
+	}
+
+	return &registryEntry{tp: tp, sdk: tp}, nil
+}
+
+// Remove shuts down and forgets the provider of key, e.g. when a tenant is
+// removed or its endpoint changes, or forgets the cached failure to create it,
+// e.g. when a tenant is added. The next span of key creates a new one.
+func (r *ProviderRegistry) Remove(ctx context.Context, key string) error {
+	r.mu.Lock()
+	entry, ok := r.providers[key]
+	delete(r.providers, key)
+	delete(r.failures, key)
+	r.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	return entry.shutdown(ctx)
+}
+
+// Keys returns the keys of the providers created so far, in no particular order.
+func (r *ProviderRegistry) Keys() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	keys := make([]string, 0, len(r.providers))
+	for key := range r.providers {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// ForceFlush flushes every provider of the registry.
+func (r *ProviderRegistry) ForceFlush(ctx context.Context) error {
+	var errs []error
+	for _, entry := range r.entries() {
+		if entry.sdk != nil {
+			errs = append(errs, entry.sdk.ForceFlush(ctx))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Shutdown shuts down every provider of the registry. Spans started afterwards go
+// to the fallback provider, which is not shut down.
+func (r *ProviderRegistry) Shutdown(ctx context.Context) error {
+	r.mu.Lock()
+	r.closed = true
+	r.mu.Unlock()
+
+	var errs []error
+	for _, entry := range r.entries() {
+		errs = append(errs, entry.shutdown(ctx))
+	}
+
+	r.mu.Lock()
+	clear(r.providers)
+	clear(r.failures)
+	r.mu.Unlock()
+
+	return errors.Join(errs...)
+}
+
+// entries returns the created providers.
+func (r *ProviderRegistry) entries() []*registryEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entries := make([]*registryEntry, 0, len(r.providers))
+	for _, entry := range r.providers {
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// shutdown shuts down the provider of the entry.
+func (e *registryEntry) shutdown(ctx context.Context) error {
+	if e.sdk == nil {
+		return nil
+	}
+
+	return e.sdk.Shutdown(ctx)
+}
+
+// routeKey returns the routing key of a span started in ctx with opts.
+func (r *ProviderRegistry) routeKey(ctx context.Context, opts []trace.SpanStartOption) string {
+	if key, ok := ctx.Value(registryKey{r}).(string); ok {
+		return key
+	}
+	if m := baggage.FromContext(ctx).Member(r.key); m.Value() != "" {
+		return m.Value()
+	}
+	cfg := trace.NewSpanStartConfig(opts...)
+	for _, kv := range cfg.Attributes() {
+		if string(kv.Key) == r.key {
+			return kv.Value.Emit()
+		}
+	}
+
+	return ""
+}
+
+// registryKey is the context key of the routing key of spans started through a
+// registry, so their children are routed with them.
+type registryKey struct{ registry *ProviderRegistry }
+
+// registryTracer is a tracer routing each span to the provider of its key.
+type registryTracer struct {
+	embedded.Tracer
+
+	registry *ProviderRegistry
+	name     string
+	opts     []trace.TracerOption
+}
+
+// Start implements trace.Tracer.
+func (t *registryTracer) Start(
+	ctx context.Context,
+	spanName string,
+	opts ...trace.SpanStartOption,
+) (context.Context, trace.Span) {
+	r := t.registry
+	key := r.routeKey(ctx, opts)
+	if key == "" {
+		return r.fallback.Tracer(t.name, t.opts...).Start(ctx, spanName, opts...)
+	}
+
+	// Failures are reported once per key: the key comes from upstream baggage, so
+	// a bad value would otherwise reach the error handler on every span
+	tp, first, err := r.provider(ctx, key)
+	if err != nil {
+		if first {
+			otel.Handle(err)
+		}
+		tp = r.fallback
+	}
+	ctx, span := tp.Tracer(t.name, t.opts...).Start(ctx, spanName, opts...)
+
+	return context.WithValue(ctx, registryKey{r}, key), span
+}
@@ -0,0 +1,196 @@
+package otx
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newTestRegistry returns a registry with providers for tenants "acme" and
+// "globex", named after them, recording to the returned recorder, and a fallback
+// recording to its own recorder.
+func newTestRegistry(t *testing.T) (*ProviderRegistry, *tracetest.SpanRecorder, *tracetest.SpanRecorder) {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	fallbackRecorder := tracetest.NewSpanRecorder()
+	fallback := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(fallbackRecorder))
+	registry := NewProviderRegistry("tenant.id",
+		func(_ context.Context, tenant string) (*TelemetryConfig, error) {
+			if tenant != "acme" && tenant != "globex" {
+				return nil, fmt.Errorf("unknown tenant %q", tenant)
+			}
+
+			return &TelemetryConfig{
+				Enabled:     boolPtr(true),
+				ServiceName: "svc-" + tenant,
+				Exporter:    &ExporterConfig{Type: "nop"},
+			}, nil
+		},
+		WithRegistryFallback(fallback),
+		WithRegistryProviderOptions(WithSpanProcessor(recorder)),
+	)
+	t.Cleanup(func() {
+		_ = registry.Shutdown(context.Background())
+		_ = fallback.Shutdown(context.Background())
+	})
+
+	return registry, recorder, fallbackRecorder
+}
+
+func serviceName(s sdktrace.ReadOnlySpan) string {
+	v, _ := s.Resource().Set().Value(semconv.ServiceNameKey)
+
+	return v.AsString()
+}
+
+func TestProviderRegistry_Routing(t *testing.T) {
+	registry, recorder, fallback := newTestRegistry(t)
+	tracer := registry.Tracer("test")
+
+	ctx, acme := tracer.Start(MustSetBaggage(t.Context(), "tenant.id", "acme"), "acme-request")
+	_, child := tracer.Start(DeleteBaggage(ctx, "tenant.id"), "acme-child")
+	child.End()
+	acme.End()
+
+	ctx, globex := tracer.Start(t.Context(), "globex-request",
+		trace.WithAttributes(attribute.String("tenant.id", "globex")))
+	_, child = tracer.Start(ctx, "globex-child")
+	child.End()
+	globex.End()
+
+	_, none := tracer.Start(t.Context(), "no-tenant")
+	none.End()
+	_, unknown := tracer.Start(MustSetBaggage(t.Context(), "tenant.id", "initech"), "unknown-tenant")
+	unknown.End()
+
+	services := make(map[string]string)
+	for _, s := range recorder.Ended() {
+		services[s.Name()] = serviceName(s)
+	}
+	assert.Equal(t, map[string]string{
+		"acme-request":   "svc-acme",
+		"acme-child":     "svc-acme",
+		"globex-request": "svc-globex",
+		"globex-child":   "svc-globex",
+	}, services, "children follow the key of their parent")
+	assert.ElementsMatch(t, []string{"acme", "globex"}, registry.Keys())
+
+	ended := fallback.Ended()
+	require.Len(t, ended, 2)
+	assert.Equal(t, "no-tenant", ended[0].Name())
+	assert.Equal(t, "unknown-tenant", ended[1].Name())
+}
+
+func TestProviderRegistry_Provider(t *testing.T) {
+	registry, _, _ := newTestRegistry(t)
+	ctx := t.Context()
+
+	tp, err := registry.Provider(ctx, "acme")
+	require.NoError(t, err)
+	again, err := registry.Provider(ctx, "acme")
+	require.NoError(t, err)
+	assert.Same(t, tp, again, "providers are cached")
+
+	_, err = registry.Provider(ctx, "initech")
+	require.ErrorContains(t, err, `unknown tenant "initech"`)
+	assert.Equal(t, []string{"acme"}, registry.Keys(), "failures do not create providers")
+
+	require.NoError(t, registry.Remove(ctx, "acme"))
+	assert.Empty(t, registry.Keys())
+	recreated, err := registry.Provider(ctx, "acme")
+	require.NoError(t, err)
+	assert.NotSame(t, tp, recreated)
+
+	require.NoError(t, registry.ForceFlush(ctx))
+	require.NoError(t, registry.Shutdown(ctx))
+	_, err = registry.Provider(ctx, "acme")
+	require.ErrorIs(t, err, ErrRegistryClosed)
+}
+
+func TestProviderRegistry_Disabled(t *testing.T) {
+	registry := NewProviderRegistry("tenant.id", func(context.Context, string) (*TelemetryConfig, error) {
+		return &TelemetryConfig{}, nil
+	})
+
+	_, span := registry.Tracer("test").Start(MustSetBaggage(t.Context(), "tenant.id", "acme"), "op")
+	span.End()
+	assert.False(t, span.IsRecording())
+	require.NoError(t, registry.Shutdown(t.Context()))
+}
+
+func TestProviderRegistry_CachesFailures(t *testing.T) {
+	var calls int
+	registry := NewProviderRegistry("tenant.id",
+		func(_ context.Context, tenant string) (*TelemetryConfig, error) {
+			calls++
+
+			return nil, fmt.Errorf("unknown tenant %q", tenant)
+		},
+		WithRegistryRetryInterval(time.Hour),
+	)
+	t.Cleanup(func() { _ = registry.Shutdown(context.Background()) })
+
+	var handled []error
+	prev := otel.GetErrorHandler()
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) { handled = append(handled, err) }))
+	t.Cleanup(func() { otel.SetErrorHandler(prev) })
+
+	tracer := registry.Tracer("test")
+	ctx := MustSetBaggage(t.Context(), "tenant.id", "initech")
+	for range 3 {
+		_, span := tracer.Start(ctx, "op")
+		span.End()
+	}
+	assert.Equal(t, 1, calls, "the failure is cached")
+	require.Len(t, handled, 1, "the failure is reported once")
+	assert.ErrorContains(t, handled[0], `unknown tenant "initech"`)
+
+	_, err := registry.Provider(t.Context(), "initech")
+	require.ErrorContains(t, err, `unknown tenant "initech"`)
+	assert.Equal(t, 1, calls)
+
+	require.NoError(t, registry.Remove(t.Context(), "initech"))
+	_, err = registry.Provider(t.Context(), "initech")
+	require.Error(t, err)
+	assert.Equal(t, 2, calls, "Remove forgets the failure")
+}
+
+func TestProviderRegistry_RetriesAfterInterval(t *testing.T) {
+	fail := true
+	registry := NewProviderRegistry("tenant.id",
+		func(context.Context, string) (*TelemetryConfig, error) {
+			if fail {
+				return nil, errBoom
+			}
+
+			return &TelemetryConfig{}, nil
+		},
+		WithRegistryRetryInterval(time.Second),
+	)
+	t.Cleanup(func() { _ = registry.Shutdown(context.Background()) })
+	now := time.Now()
+	registry.now = func() time.Time { return now }
+
+	_, err := registry.Provider(t.Context(), "acme")
+	require.ErrorIs(t, err, errBoom)
+
+	fail = false
+	now = now.Add(time.Second - time.Nanosecond)
+	_, err = registry.Provider(t.Context(), "acme")
+	require.ErrorIs(t, err, errBoom, "the failure is cached until the interval passes")
+
+	now = now.Add(time.Nanosecond)
+	_, err = registry.Provider(t.Context(), "acme")
+	require.NoError(t, err)
+}
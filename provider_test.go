@@ -1,8 +1,11 @@
 package otx
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
 	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -10,6 +13,9 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestNewTracerProvider(t *testing.T) {
@@ -57,6 +63,59 @@ func TestNewLoggerProvider(t *testing.T) {
 	assert.ErrorIs(t, err, ErrLogsDisabled)
 }
 
+func TestNewLoggerProvider_SimpleProcessor(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := &TelemetryConfig{
+		Enabled:       boolPtr(true),
+		ServiceName:   "test-service",
+		SelfTelemetry: true,
+		Console:       &ConsoleConfig{Writer: &buf, PrettyPrint: boolPtr(false)},
+		Logs: &LogsConfig{
+			Enabled:   boolPtr(true),
+			Exporter:  "console",
+			Processor: LogProcessorSimple,
+		},
+	}
+	lp, err := NewLoggerProvider(t.Context(), cfg)
+	require.NoError(t, err)
+	defer func() { _ = lp.Shutdown(t.Context()) }()
+
+	var record log.Record
+	record.SetBody(log.StringValue("exported right away"))
+	lp.Logger("test").Emit(t.Context(), record)
+
+	assert.Contains(t, buf.String(), "exported right away", "no flush or shutdown needed")
+}
+
+func TestNewLoggerProvider_Limits(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := &TelemetryConfig{
+		Enabled:     boolPtr(true),
+		ServiceName: "test-service",
+		Console:     &ConsoleConfig{Writer: &buf, PrettyPrint: boolPtr(false)},
+		Logs: &LogsConfig{
+			Enabled:                   boolPtr(true),
+			Exporter:                  "console",
+			Processor:                 LogProcessorSimple,
+			AttributeCountLimit:       2,
+			AttributeValueLengthLimit: 5,
+		},
+	}
+	lp, err := NewLoggerProvider(t.Context(), cfg)
+	require.NoError(t, err)
+	defer func() { _ = lp.Shutdown(t.Context()) }()
+
+	var record log.Record
+	record.AddAttributes(log.String("a", "truncated"), log.String("b", "kept"), log.String("c", "dropped"))
+	lp.Logger("test").Emit(t.Context(), record)
+
+	out := buf.String()
+	assert.Contains(t, out, `"trunc"`)
+	assert.Contains(t, out, `"kept"`)
+	assert.NotContains(t, out, `"dropped"`)
+	assert.Contains(t, out, `"DroppedAttributes":1`)
+}
+
 func TestNewMeterProvider(t *testing.T) {
 	cfg := &TelemetryConfig{
 		Enabled:     boolPtr(true),
@@ -77,6 +136,24 @@ func TestNewMeterProvider(t *testing.T) {
 	assert.ErrorIs(t, err, ErrMetricsDisabled)
 }
 
+func TestNewMeterProvider_Runtime(t *testing.T) {
+	cfg := &TelemetryConfig{
+		Enabled:     boolPtr(true),
+		ServiceName: "test-service",
+		Metrics: &MetricsConfig{
+			Enabled:  boolPtr(true),
+			Exporter: "none",
+			Runtime:  true,
+		},
+	}
+
+	mp, err := NewMeterProvider(t.Context(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, mp)
+	assert.NoError(t, mp.ForceFlush(t.Context()), "runtime callbacks run on collection")
+	assert.NoError(t, mp.Shutdown(t.Context()))
+}
+
 func TestResourceAttributesApplied(t *testing.T) {
 	cfg := &TelemetryConfig{
 		Enabled:     boolPtr(true),
@@ -149,3 +226,140 @@ func TestNewMeterProvider_MissingServiceName(t *testing.T) {
 	assert.Nil(t, mp)
 	assert.ErrorIs(t, err, ErrServiceNameRequired)
 }
+
+func TestBuildExemplarFilter(t *testing.T) {
+	assert.Nil(t, buildExemplarFilter(""), "empty keeps the SDK default")
+
+	tp := sdktrace.NewTracerProvider()
+	sampled, span := tp.Tracer("test").Start(t.Context(), "op")
+	defer span.End()
+	unsampled := t.Context()
+
+	tests := []struct {
+		name               string
+		sampled, unsampled bool
+	}{
+		{ExemplarFilterAlwaysOn, true, true},
+		{ExemplarFilterAlwaysOff, false, false},
+		{ExemplarFilterTraceBased, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := buildExemplarFilter(tt.name)
+			require.NotNil(t, filter)
+			assert.Equal(t, tt.sampled, filter(sampled))
+			assert.Equal(t, tt.unsampled, filter(unsampled))
+		})
+	}
+}
+
+func TestBuildBatchOptions(t *testing.T) {
+	assert.Nil(t, buildBatchOptions(nil))
+	assert.Nil(t, buildBatchOptions(&TracesConfig{}))
+
+	opts := buildBatchOptions(&TracesConfig{Batch: &BatchConfig{
+		MaxQueueSize:       4096,
+		MaxExportBatchSize: 1024,
+		ScheduleDelay:      500 * time.Millisecond,
+		ExportTimeout:      10 * time.Second,
+	}})
+	assert.Len(t, opts, 4)
+
+	var applied sdktrace.BatchSpanProcessorOptions
+	for _, opt := range opts {
+		opt(&applied)
+	}
+	assert.Equal(t, 4096, applied.MaxQueueSize)
+	assert.Equal(t, 1024, applied.MaxExportBatchSize)
+	assert.Equal(t, 500*time.Millisecond, applied.BatchTimeout)
+	assert.Equal(t, 10*time.Second, applied.ExportTimeout)
+
+	assert.Len(t, buildBatchOptions(&TracesConfig{Batch: &BatchConfig{MaxQueueSize: 10}}), 1)
+}
+
+func TestBuildLogBatchOptions(t *testing.T) {
+	assert.Nil(t, buildLogBatchOptions(nil))
+	assert.Nil(t, buildLogBatchOptions(&LogsConfig{}))
+
+	opts := buildLogBatchOptions(&LogsConfig{Batch: &BatchConfig{
+		MaxQueueSize:       4096,
+		MaxExportBatchSize: 1024,
+		ScheduleDelay:      500 * time.Millisecond,
+		ExportTimeout:      10 * time.Second,
+	}})
+	assert.Len(t, opts, 4)
+	assert.Len(t, buildLogBatchOptions(&LogsConfig{Batch: &BatchConfig{ScheduleDelay: time.Second}}), 1)
+}
+
+func TestNewTracerProvider_MultipleExporters(t *testing.T) {
+	cfg := &TelemetryConfig{
+		Enabled:     boolPtr(true),
+		ServiceName: "test-service",
+		Traces:      &TracesConfig{Exporters: []string{"none", "nop"}},
+	}
+	tp, err := NewTracerProvider(t.Context(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, tp)
+	require.NoError(t, tp.Shutdown(t.Context()))
+
+	cfg.Metrics = &MetricsConfig{Enabled: boolPtr(true), Exporters: []string{"none", "console"}}
+	mp, err := NewMeterProvider(t.Context(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, mp.Shutdown(t.Context()))
+}
+
+func TestNewTracerProvider_XRayIDGenerator(t *testing.T) {
+	cfg := &TelemetryConfig{
+		Enabled:     boolPtr(true),
+		ServiceName: "test-service",
+		Traces:      &TracesConfig{Exporter: "none", IDGenerator: IDGeneratorXRay},
+	}
+	tp, err := NewTracerProvider(t.Context(), cfg)
+	require.NoError(t, err)
+	defer func() { _ = tp.Shutdown(t.Context()) }()
+
+	before := time.Now().Unix()
+	_, span := tp.Tracer("test").Start(t.Context(), "request")
+	span.End()
+
+	// X-Ray trace IDs start with the epoch seconds at which they were generated
+	traceID := span.SpanContext().TraceID()
+	epoch := int64(binary.BigEndian.Uint32(traceID[:4]))
+	assert.InDelta(t, before, epoch, 1)
+}
+
+// sequentialIDGenerator returns increasing trace and span IDs.
+type sequentialIDGenerator struct {
+	next atomic.Uint64
+}
+
+func (g *sequentialIDGenerator) NewIDs(context.Context) (trace.TraceID, trace.SpanID) {
+	var traceID trace.TraceID
+	binary.BigEndian.PutUint64(traceID[8:], g.next.Add(1))
+
+	return traceID, g.NewSpanID(context.Background(), traceID)
+}
+
+func (g *sequentialIDGenerator) NewSpanID(context.Context, trace.TraceID) trace.SpanID {
+	var spanID trace.SpanID
+	binary.BigEndian.PutUint64(spanID[:], g.next.Add(1))
+
+	return spanID
+}
+
+func TestNewTracerProvider_WithIDGenerator(t *testing.T) {
+	cfg := &TelemetryConfig{
+		Enabled:     boolPtr(true),
+		ServiceName: "test-service",
+		Traces:      &TracesConfig{Exporter: "none", IDGenerator: IDGeneratorXRay},
+	}
+	tp, err := NewTracerProvider(t.Context(), cfg, WithIDGenerator(&sequentialIDGenerator{}))
+	require.NoError(t, err)
+	defer func() { _ = tp.Shutdown(t.Context()) }()
+
+	_, span := tp.Tracer("test").Start(t.Context(), "request")
+	span.End()
+
+	assert.Equal(t, "00000000000000000000000000000001", span.SpanContext().TraceID().String())
+	assert.Equal(t, "0000000000000002", span.SpanContext().SpanID().String())
+}
@@ -0,0 +1,299 @@
+package otx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// ErrNotReconfigurable is returned by Reconfigure when no TracerProvider was
+// created with NewTracerProvider.
+var ErrNotReconfigurable = errors.New("otx: no tracer provider to reconfigure")
+
+// reconfigureTarget is the pipeline of the TracerProvider last created by
+// NewTracerProvider.
+var reconfigureTarget atomic.Pointer[reloadablePipeline]
+
+// globalPropagator is installed as the global propagator by NewTracerProvider, so
+// instrumentation that captured it follows Reconfigure.
+var globalPropagator = &reloadablePropagator{}
+
+// spanPipeline is the part of a TracerProvider built from the config.
+type spanPipeline struct {
+	sampler    sdktrace.Sampler
+	processors []sdktrace.SpanProcessor // In registration order
+	owned      []sdktrace.SpanProcessor // Created from the config, shut down when replaced
+	propagator propagation.TextMapPropagator
+}
+
+// spanTracker is a span processor keeping state for each span between OnStart and
+// OnEnd, such as the active-span, critical-path, child-span and long-task ones.
+type spanTracker interface {
+	sdktrace.SpanProcessor
+
+	// openSpans returns the number of spans started but not yet ended.
+	openSpans() int
+}
+
+// buildSpanPipeline builds the sampler, span processors and exporters, and the
+// propagator for cfg. Disabled traces get a pipeline that samples nothing.
+func buildSpanPipeline(
+	ctx context.Context,
+	cfg *TelemetryConfig,
+	providerOpts []TracerProviderOption,
+) (*spanPipeline, error) {
+	p := &spanPipeline{propagator: buildPropagator(cfg.Propagation)}
+	if !cfg.IsEnabled() || (cfg.Traces != nil && !cfg.Traces.IsEnabled()) {
+		p.sampler = sdktrace.NeverSample()
+
+		return p, nil
+	}
+	sampler, err := buildSampler(cfg.GetSamplingConfig())
+	if err != nil {
+		return nil, err
+	}
+	p.sampler = sampler
+
+	// Build exporters using new config structure
+	exporters, err := buildTraceExporters(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("build trace exporter: %w", err)
+	}
+
+	st := selfTelemetryFor(cfg)
+	if st != nil {
+		p.owned = append(p.owned, selfTelemetryProcessor{st: st})
+	}
+	if cfg.Traces != nil && cfg.Traces.TrackActiveSpans {
+		p.owned = append(p.owned, NewActiveSpanProcessor())
+	}
+	p.owned = append(p.owned, builtinSpanProcessors(cfg.Traces)...)
+	p.processors = append(slices.Clone(p.owned), suppliedSpanProcessors(cfg.Traces, providerOpts)...)
+
+	// One batcher per exporter so a slow destination does not hold back the others
+	batchOpts := buildBatchOptions(cfg.Traces)
+	filters := spanFilters(cfg.Traces, providerOpts)
+	names := exporterTypes(cfg.GetTracesExporters())
+	capacity := queueCapacity(tracesBatch(cfg.Traces), "OTEL_BSP_MAX_QUEUE_SIZE")
+	for i, exporter := range exporters {
+		batcher := st.spanBatcher(exporter, names[i], capacity, batchOpts...)
+		if cfg.Traces != nil && len(cfg.Traces.IndexHints) > 0 {
+			batcher = NewIndexHintProcessor(batcher, cfg.Traces.IndexHintPrefix, cfg.Traces.IndexHints...)
+		}
+		if len(filters) > 0 {
+			batcher = NewFilterProcessor(batcher, filters...)
+		}
+		p.processors = append(p.processors, batcher)
+		p.owned = append(p.owned, batcher)
+	}
+
+	return p, nil
+}
+
+// Reconfigure rebuilds the sampler, span processors, exporters and propagator of
+// the TracerProvider last created by NewTracerProvider from cfg, e.g. on SIGHUP.
+// The new pipeline replaces the old one atomically, so tracers already handed out,
+// by the provider or the globals, keep working. The replaced exporters are then
+// flushed and shut down, bounded by ctx.
+//
+// Processors that track spans between start and end, such as the long-task and
+// child-span ones, are drained instead: they keep receiving the end of the spans
+// they saw start and are shut down once all of them have ended. Spans started
+// after the reload are tracked by the new processors only, so children started
+// after a reload are not counted for a parent started before it.
+//
+// The resource and ID generator are kept; changing them needs a new provider.
+// Options passed to NewTracerProvider, such as WithSpanProcessor, still apply.
+// If cfg is invalid, or disables traces, the current pipeline is kept or replaced
+// by one sampling nothing, respectively.
+//
+// Example:
+//
+//	hup := make(chan os.Signal, 1)
+//	signal.Notify(hup, syscall.SIGHUP)
+//	for range hup {
+//	    cfg, err := otx.LoadConfig("otel.yaml")
+//	    if err == nil {
+//	        err = otx.Reconfigure(ctx, cfg)
+//	    }
+//	    if err != nil {
+//	        log.Printf("telemetry reload: %v", err)
+//	    }
+//	}
+func Reconfigure(ctx context.Context, cfg *TelemetryConfig) error {
+	target := reconfigureTarget.Load()
+	if target == nil {
+		return ErrNotReconfigurable
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	installErrorHandler(cfg)
+	next, err := buildSpanPipeline(ctx, cfg, target.providerOpts)
+	if err != nil {
+		return err
+	}
+
+	prev := target.current.Swap(next)
+	globalPropagator.swap(next.propagator)
+	applyTracesGlobals(cfg.Traces)
+
+	var errs []error
+	for _, sp := range prev.owned {
+		if tracker, ok := sp.(spanTracker); ok && target.drain(tracker) {
+			continue
+		}
+		errs = append(errs, sp.ForceFlush(ctx), sp.Shutdown(ctx))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("shut down previous pipeline: %w", err)
+	}
+
+	return nil
+}
+
+// reloadablePipeline is the sampler and span processor of a TracerProvider,
+// delegating to the current spanPipeline.
+type reloadablePipeline struct {
+	current      atomic.Pointer[spanPipeline]
+	providerOpts []TracerProviderOption
+
+	draining    atomic.Bool // Set while drainingSet is not empty
+	drainMu     sync.Mutex
+	drainingSet []spanTracker // Replaced trackers with spans still open
+}
+
+// ShouldSample implements sdktrace.Sampler.
+func (r *reloadablePipeline) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	return r.current.Load().sampler.ShouldSample(p)
+}
+
+// Description implements sdktrace.Sampler.
+func (r *reloadablePipeline) Description() string {
+	return r.current.Load().sampler.Description()
+}
+
+// OnStart implements sdktrace.SpanProcessor.
+func (r *reloadablePipeline) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	for _, sp := range r.current.Load().processors {
+		sp.OnStart(parent, s)
+	}
+}
+
+// OnEnd implements sdktrace.SpanProcessor.
+func (r *reloadablePipeline) OnEnd(s sdktrace.ReadOnlySpan) {
+	for _, sp := range r.current.Load().processors {
+		sp.OnEnd(s)
+	}
+	if r.draining.Load() {
+		r.endDraining(s)
+	}
+}
+
+// Shutdown implements sdktrace.SpanProcessor.
+func (r *reloadablePipeline) Shutdown(ctx context.Context) error {
+	r.drainMu.Lock()
+	drained := r.drainingSet
+	r.drainingSet = nil
+	r.draining.Store(false)
+	r.drainMu.Unlock()
+
+	var errs []error
+	for _, tracker := range drained {
+		errs = append(errs, tracker.Shutdown(ctx))
+	}
+	for _, sp := range r.current.Load().processors {
+		errs = append(errs, sp.Shutdown(ctx))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ForceFlush implements sdktrace.SpanProcessor.
+func (r *reloadablePipeline) ForceFlush(ctx context.Context) error {
+	var errs []error
+	for _, sp := range r.current.Load().processors {
+		errs = append(errs, sp.ForceFlush(ctx))
+	}
+
+	return errors.Join(errs...)
+}
+
+// drain keeps tracker receiving span ends until its open spans have ended,
+// reporting false if it has none and can be shut down right away.
+func (r *reloadablePipeline) drain(tracker spanTracker) bool {
+	if tracker.openSpans() == 0 {
+		return false
+	}
+
+	r.drainMu.Lock()
+	r.drainingSet = append(r.drainingSet, tracker)
+	r.draining.Store(true)
+	r.drainMu.Unlock()
+
+	return true
+}
+
+// endDraining passes the end of s to the draining trackers and shuts down
+// those left without open spans.
+func (r *reloadablePipeline) endDraining(s sdktrace.ReadOnlySpan) {
+	var drained []spanTracker
+
+	r.drainMu.Lock()
+	r.drainingSet = slices.DeleteFunc(r.drainingSet, func(tracker spanTracker) bool {
+		tracker.OnEnd(s)
+		if tracker.openSpans() > 0 {
+			return false
+		}
+		drained = append(drained, tracker)
+
+		return true
+	})
+	r.draining.Store(len(r.drainingSet) > 0)
+	r.drainMu.Unlock()
+
+	for _, tracker := range drained {
+		_ = tracker.Shutdown(context.Background())
+	}
+}
+
+// reloadablePropagator delegates to the propagator set with swap.
+type reloadablePropagator struct {
+	current atomic.Pointer[propagation.TextMapPropagator]
+}
+
+// swap replaces the propagator.
+func (r *reloadablePropagator) swap(p propagation.TextMapPropagator) {
+	r.current.Store(&p)
+}
+
+// load returns the current propagator.
+func (r *reloadablePropagator) load() propagation.TextMapPropagator {
+	if p := r.current.Load(); p != nil {
+		return *p
+	}
+
+	return propagation.NewCompositeTextMapPropagator()
+}
+
+// Inject implements propagation.TextMapPropagator.
+func (r *reloadablePropagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	r.load().Inject(ctx, carrier)
+}
+
+// Extract implements propagation.TextMapPropagator.
+func (r *reloadablePropagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return r.load().Extract(ctx, carrier)
+}
+
+// Fields implements propagation.TextMapPropagator.
+func (r *reloadablePropagator) Fields() []string {
+	return r.load().Fields()
+}
@@ -0,0 +1,118 @@
+package otx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestReconfigure(t *testing.T) {
+	ctx := t.Context()
+	recorder := tracetest.NewSpanRecorder()
+	cfg := &TelemetryConfig{
+		Enabled:     boolPtr(true),
+		ServiceName: "test-service",
+		Traces: &TracesConfig{
+			Exporter: "none",
+			Sampling: &SamplingConfig{Sampler: "always_on"},
+		},
+		Propagation: &PropConfig{Propagators: "tracecontext"},
+	}
+	tp, err := NewTracerProvider(ctx, cfg, WithSpanProcessor(recorder))
+	require.NoError(t, err)
+	defer func() { _ = tp.Shutdown(ctx) }()
+
+	tracer := tp.Tracer("otx")
+	propagator := otel.GetTextMapPropagator()
+	_, span := tracer.Start(ctx, "before")
+	assert.True(t, span.IsRecording())
+	span.End()
+	assert.ElementsMatch(t, []string{"traceparent", "tracestate"}, propagator.Fields())
+
+	cfg.Traces.Sampling.Sampler = "always_off"
+	cfg.Propagation.Propagators = "b3"
+	require.NoError(t, Reconfigure(ctx, cfg))
+
+	_, span = tracer.Start(ctx, "after")
+	assert.False(t, span.IsRecording(), "existing tracers use the new sampler")
+	span.End()
+	assert.Equal(t, []string{"b3"}, propagator.Fields(), "captured propagators follow too")
+
+	cfg.Traces.Sampling.Sampler = "always_on"
+	require.NoError(t, Reconfigure(ctx, cfg))
+	_, span = tracer.Start(ctx, "again")
+	span.End()
+	require.Len(t, recorder.Ended(), 2, "supplied processors are kept across reconfigurations")
+	assert.Equal(t, "again", recorder.Ended()[1].Name())
+
+	cfg.Traces.Sampling.Sampler = "sometimes"
+	require.ErrorIs(t, Reconfigure(ctx, cfg), ErrInvalidConfig)
+	_, span = tracer.Start(ctx, "still on")
+	assert.True(t, span.IsRecording(), "an invalid config keeps the current pipeline")
+	span.End()
+}
+
+func TestReconfigure_NoProvider(t *testing.T) {
+	prev := reconfigureTarget.Swap(nil)
+	t.Cleanup(func() { reconfigureTarget.Store(prev) })
+
+	assert.ErrorIs(t, Reconfigure(t.Context(), &TelemetryConfig{}), ErrNotReconfigurable)
+}
+
+func TestReconfigure_DrainsSpanTrackers(t *testing.T) {
+	ctx := t.Context()
+	recorder := tracetest.NewSpanRecorder()
+	cfg := &TelemetryConfig{
+		Enabled:     boolPtr(true),
+		ServiceName: "test-service",
+		Traces: &TracesConfig{
+			Exporter:       "none",
+			Sampling:       &SamplingConfig{Sampler: "always_on"},
+			ChildSpanStats: true,
+		},
+	}
+	tp, err := NewTracerProvider(ctx, cfg, WithSpanProcessor(recorder))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	tracer := tp.Tracer("otx")
+	parentCtx, parent := tracer.Start(ctx, "parent")
+	_, child := tracer.Start(parentCtx, "child")
+
+	require.NoError(t, Reconfigure(ctx, cfg))
+	target := reconfigureTarget.Load()
+	assert.True(t, target.draining.Load(), "the replaced tracker keeps its open spans")
+
+	child.End()
+	parent.End()
+	assert.False(t, target.draining.Load(), "the tracker is released once its spans ended")
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 2)
+	assert.Contains(t, ended[1].Attributes(), attribute.Int(AttrChildSpanCount, 1),
+		"spans started before the reload keep their stats")
+}
+
+func TestNewTracerProvider_ResetsTracesGlobals(t *testing.T) {
+	ctx := t.Context()
+	cfg := &TelemetryConfig{
+		Enabled:     boolPtr(true),
+		ServiceName: "test-service",
+		Traces:      &TracesConfig{Exporter: "none", ErrorStackTrace: true},
+	}
+	tp, err := NewTracerProvider(ctx, cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+	assert.True(t, errorStackTrace.Load())
+
+	cfg.Traces.ErrorStackTrace = false
+	tp2, err := NewTracerProvider(ctx, cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tp2.Shutdown(context.Background()) })
+	assert.False(t, errorStackTrace.Load(), "a second provider applies its own config")
+}
@@ -0,0 +1,134 @@
+package otx
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+// serviceAccountNamespaceFile holds the pod namespace inside Kubernetes pods.
+var serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// kubernetesEnvAttributes maps downward API environment variables to resource attribute keys.
+var kubernetesEnvAttributes = []struct {
+	env string
+	key attribute.Key
+}{
+	{env: "K8S_POD_NAME", key: semconv.K8SPodNameKey},
+	{env: "K8S_POD_UID", key: semconv.K8SPodUIDKey},
+	{env: "K8S_NAMESPACE_NAME", key: semconv.K8SNamespaceNameKey},
+	{env: "K8S_NODE_NAME", key: semconv.K8SNodeNameKey},
+	{env: "K8S_CONTAINER_NAME", key: semconv.K8SContainerNameKey},
+	{env: "K8S_DEPLOYMENT_NAME", key: semconv.K8SDeploymentNameKey},
+}
+
+// kubernetesDetector detects k8s.* resource attributes from the environment.
+//
+// Values come from the K8S_* variables, typically exposed through the downward API:
+//
+//	env:
+//	  - name: K8S_POD_NAME
+//	    valueFrom:
+//	      fieldRef:
+//	        fieldPath: metadata.name
+//
+// When running in a pod (KUBERNETES_SERVICE_HOST is set), k8s.pod.name falls back
+// to the hostname and k8s.namespace.name to the service account namespace file.
+type kubernetesDetector struct{}
+
+// detectAttributes runs the enabled resource detectors and returns the detected attributes.
+// Detector failures (e.g., no container ID outside a container) are reported to the
+// global error handler and never fail provider creation.
+//
+// Only attributes are kept: the SDK detectors use a newer semantic conventions
+// schema than buildResource, and resources with different schema URLs cannot be merged.
+func detectAttributes(ctx context.Context, cfg *ResourceDetectorsConfig) []attribute.KeyValue {
+	opts := detectorOptions(cfg)
+	if len(opts) == 0 {
+		return nil
+	}
+
+	res, err := resource.New(ctx, opts...)
+	if err != nil {
+		otel.Handle(fmt.Errorf("otx: resource detection: %w", err))
+	}
+	if res == nil {
+		return nil
+	}
+
+	return res.Attributes()
+}
+
+// detectorOptions returns the resource options for the enabled detectors.
+func detectorOptions(cfg *ResourceDetectorsConfig) []resource.Option {
+	if cfg == nil {
+		return nil
+	}
+
+	var opts []resource.Option
+	if cfg.Host {
+		opts = append(opts, resource.WithHost(), resource.WithHostID())
+	}
+	if cfg.Process {
+		// resource.WithProcess also records the command line, which may contain secrets.
+		opts = append(opts,
+			resource.WithProcessPID(),
+			resource.WithProcessExecutableName(),
+			resource.WithProcessExecutablePath(),
+			resource.WithProcessOwner(),
+			resource.WithProcessRuntimeName(),
+			resource.WithProcessRuntimeVersion(),
+			resource.WithProcessRuntimeDescription(),
+		)
+	}
+	if cfg.Container {
+		opts = append(opts, resource.WithContainer())
+	}
+	if cfg.Kubernetes {
+		opts = append(opts, resource.WithDetectors(kubernetesDetector{}))
+	}
+
+	return opts
+}
+
+// Detect implements resource.Detector.
+func (kubernetesDetector) Detect(_ context.Context) (*resource.Resource, error) {
+	values := make(map[attribute.Key]string, len(kubernetesEnvAttributes))
+	for _, a := range kubernetesEnvAttributes {
+		if v := strings.TrimSpace(os.Getenv(a.env)); v != "" {
+			values[a.key] = v
+		}
+	}
+
+	if os.Getenv("KUBERNETES_SERVICE_HOST") != "" {
+		if _, ok := values[semconv.K8SPodNameKey]; !ok {
+			if hostname, err := os.Hostname(); err == nil && hostname != "" {
+				values[semconv.K8SPodNameKey] = hostname
+			}
+		}
+		if _, ok := values[semconv.K8SNamespaceNameKey]; !ok {
+			if ns, err := os.ReadFile(serviceAccountNamespaceFile); err == nil {
+				if v := strings.TrimSpace(string(ns)); v != "" {
+					values[semconv.K8SNamespaceNameKey] = v
+				}
+			}
+		}
+	}
+
+	if len(values) == 0 {
+		return resource.Empty(), nil
+	}
+
+	attrs := make([]attribute.KeyValue, 0, len(values))
+	for key, value := range values {
+		attrs = append(attrs, key.String(value))
+	}
+
+	return resource.NewSchemaless(attrs...), nil
+}
@@ -0,0 +1,110 @@
+package otx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+func TestKubernetesDetector(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	t.Setenv("K8S_POD_NAME", "checkout-7d9f")
+	t.Setenv("K8S_NAMESPACE_NAME", "shop")
+	t.Setenv("K8S_NODE_NAME", " node-1 ")
+
+	res, err := kubernetesDetector{}.Detect(t.Context())
+	require.NoError(t, err)
+
+	attrs := res.Attributes()
+	assert.Len(t, attrs, 3)
+	assert.True(t, hasAttribute(attrs, semconv.K8SPodName("checkout-7d9f")))
+	assert.True(t, hasAttribute(attrs, semconv.K8SNamespaceName("shop")))
+	assert.True(t, hasAttribute(attrs, semconv.K8SNodeName("node-1")))
+	assert.Empty(t, res.SchemaURL())
+}
+
+func TestKubernetesDetector_InClusterFallback(t *testing.T) {
+	nsFile := filepath.Join(t.TempDir(), "namespace")
+	require.NoError(t, os.WriteFile(nsFile, []byte("payments\n"), 0o600))
+
+	orig := serviceAccountNamespaceFile
+	serviceAccountNamespaceFile = nsFile
+	t.Cleanup(func() { serviceAccountNamespaceFile = orig })
+
+	t.Setenv("KUBERNETES_SERVICE_HOST", "10.0.0.1")
+	t.Setenv("K8S_POD_NAME", "")
+	t.Setenv("K8S_NAMESPACE_NAME", "")
+
+	res, err := kubernetesDetector{}.Detect(t.Context())
+	require.NoError(t, err)
+
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+	assert.True(t, hasAttribute(res.Attributes(), semconv.K8SPodName(hostname)))
+	assert.True(t, hasAttribute(res.Attributes(), semconv.K8SNamespaceName("payments")))
+}
+
+func TestKubernetesDetector_NotInCluster(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	for _, a := range kubernetesEnvAttributes {
+		t.Setenv(a.env, "")
+	}
+
+	res, err := kubernetesDetector{}.Detect(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, res.Attributes())
+}
+
+func TestBuildResource_Detectors(t *testing.T) {
+	t.Setenv("K8S_POD_NAME", "detected-pod")
+
+	cfg := &TelemetryConfig{
+		ServiceName: "test-service",
+		ResourceAttributes: map[string]string{
+			string(semconv.K8SPodNameKey): "explicit-pod",
+		},
+		ResourceDetectors: &ResourceDetectorsConfig{Host: true, Process: true, Kubernetes: true},
+	}
+
+	res, err := buildResource(t.Context(), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, semconv.SchemaURL, res.SchemaURL())
+
+	attrs := res.Attributes()
+	assert.True(t, hasAttribute(attrs, semconv.K8SPodName("explicit-pod")), "explicit attributes win")
+	assert.True(t, hasKey(attrs, semconv.HostNameKey))
+	assert.True(t, hasKey(attrs, semconv.ProcessPIDKey))
+	assert.False(t, hasKey(attrs, semconv.ProcessCommandArgsKey), "command line may contain secrets")
+}
+
+func TestBuildResource_NoDetectors(t *testing.T) {
+	res, err := buildResource(t.Context(), &TelemetryConfig{ServiceName: "test-service"})
+	require.NoError(t, err)
+	assert.False(t, hasKey(res.Attributes(), semconv.HostNameKey))
+	assert.False(t, hasKey(res.Attributes(), semconv.ProcessPIDKey))
+}
+
+func TestLoadConfigResourceDetectorsFromEnv(t *testing.T) {
+	t.Setenv("OTX_RESOURCE_DETECTORS_KUBERNETES", "true")
+
+	cfg, err := ParseConfig([]byte(`serviceName: "svc"`))
+	require.NoError(t, err)
+	require.NotNil(t, cfg.ResourceDetectors)
+	assert.True(t, cfg.ResourceDetectors.Kubernetes)
+	assert.False(t, cfg.ResourceDetectors.Host)
+}
+
+func hasKey(attrs []attribute.KeyValue, key attribute.Key) bool {
+	for _, attr := range attrs {
+		if attr.Key == key {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,192 @@
+package otx
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Retry defaults, used when RetryPolicy leaves a field at zero.
+const (
+	DefaultRetryMaxAttempts    = 3
+	DefaultRetryInitialBackoff = 100 * time.Millisecond
+	DefaultRetryMaxBackoff     = 10 * time.Second
+	DefaultRetryMultiplier     = 2.0
+)
+
+// Retry span attributes, set on the spans started by [Retry].
+const (
+	// AttrRetryAttempt is the number of the attempt, starting at 1, on attempt spans.
+	AttrRetryAttempt = "otx.retry.attempt"
+
+	// AttrRetryBackoff is the time, in milliseconds, waited before the attempt, on
+	// attempt spans.
+	AttrRetryBackoff = "otx.retry.backoff_ms"
+
+	// AttrRetryAttempts is the number of attempts made, on the retry span.
+	AttrRetryAttempts = "otx.retry.attempts"
+
+	// AttrRetryOutcome is how the retries ended, on the retry span: one of the
+	// RetryOutcome values.
+	AttrRetryOutcome = "otx.retry.outcome"
+)
+
+// Values of [AttrRetryOutcome].
+const (
+	RetryOutcomeSuccess      = "success"       // An attempt succeeded
+	RetryOutcomeExhausted    = "exhausted"     // Every attempt failed
+	RetryOutcomeNonRetryable = "non_retryable" // An attempt failed with an error Retryable rejected
+	RetryOutcomeCanceled     = "canceled"      // ctx was done before an attempt succeeded
+)
+
+// RetryPolicy configures [Retry]. The zero value makes 3 attempts with
+// exponential backoff from 100ms, retrying every error.
+type RetryPolicy struct {
+	// Name is the name of the retry span; attempt spans are named Name + ".attempt".
+	// Defaults to "retry".
+	Name string
+
+	// MaxAttempts is the number of attempts, including the first one.
+	// Defaults to 3.
+	MaxAttempts int
+
+	// InitialBackoff is the time to wait after the first failed attempt.
+	// Defaults to 100ms.
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the upper bound of the time to wait between attempts.
+	// Defaults to 10s.
+	MaxBackoff time.Duration
+
+	// Multiplier is the factor applied to the backoff after each failed attempt.
+	// Defaults to 2.
+	Multiplier float64
+
+	// Jitter randomly shortens each backoff by up to this fraction, from 0 to 1,
+	// so clients failing together do not retry together. Defaults to 0, no jitter.
+	Jitter float64
+
+	// Retryable reports whether an attempt failing with err is retried.
+	// Defaults to retrying every error.
+	Retryable func(err error) bool
+}
+
+// Retry calls fn until it succeeds, the policy runs out of attempts, fn returns an
+// error the policy does not retry, or ctx is done. It returns nil on success and
+// the error of the last attempt otherwise, joined with ctx.Err() if ctx is done.
+//
+// The retries show up in the trace as a retry span with one child span per attempt.
+// Attempt spans record [AttrRetryAttempt] and [AttrRetryBackoff], and the error of
+// a failed attempt; the retry span records [AttrRetryAttempts] and
+// [AttrRetryOutcome], and the final error. fn gets the context of its attempt span.
+//
+// Example:
+//
+//	err := otx.Retry(ctx, otx.RetryPolicy{Name: "FetchRates", MaxAttempts: 5}, func(ctx context.Context) error {
+//	    return client.FetchRates(ctx)
+//	})
+func Retry(ctx context.Context, policy RetryPolicy, fn func(ctx context.Context) error) error {
+	policy = policy.withDefaults()
+
+	ctx, span := Start(ctx, policy.Name)
+	defer span.End()
+
+	var (
+		err     error
+		backoff time.Duration
+		outcome string
+		attempt int
+	)
+	for attempt = 1; ; attempt++ {
+		attemptCtx, attemptSpan := Start(ctx, policy.Name+".attempt", trace.WithAttributes(
+			attribute.Int(AttrRetryAttempt, attempt),
+			attribute.Float64(AttrRetryBackoff, float64(backoff)/float64(time.Millisecond)),
+		))
+		err = fn(attemptCtx)
+		RecordError(attemptCtx, err)
+		attemptSpan.End()
+
+		if err == nil {
+			outcome = RetryOutcomeSuccess
+
+			break
+		}
+		if policy.Retryable != nil && !policy.Retryable(err) {
+			outcome = RetryOutcomeNonRetryable
+
+			break
+		}
+		if attempt >= policy.MaxAttempts {
+			outcome = RetryOutcomeExhausted
+
+			break
+		}
+
+		backoff = policy.backoff(attempt)
+		if waitErr := sleep(ctx, backoff); waitErr != nil {
+			err = errors.Join(waitErr, err)
+			outcome = RetryOutcomeCanceled
+
+			break
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int(AttrRetryAttempts, attempt),
+		attribute.String(AttrRetryOutcome, outcome),
+	)
+	RecordError(ctx, err)
+
+	return err
+}
+
+// withDefaults returns the policy with zero fields set to their defaults.
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.Name == "" {
+		p.Name = "retry"
+	}
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = DefaultRetryMaxAttempts
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = DefaultRetryInitialBackoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = DefaultRetryMaxBackoff
+	}
+	if p.Multiplier <= 0 {
+		p.Multiplier = DefaultRetryMultiplier
+	}
+	p.Jitter = min(max(p.Jitter, 0), 1)
+
+	return p
+}
+
+// backoff returns the time to wait after the given failed attempt.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(attempt-1))
+	d = min(d, float64(p.MaxBackoff))
+	if p.Jitter > 0 {
+		d -= d * p.Jitter * rand.Float64() //nolint:gosec // Jitter needs no cryptographic randomness
+	}
+
+	return time.Duration(d)
+}
+
+// sleep waits for d, returning ctx.Err() if ctx is done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,121 @@
+package otx
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+var errBoom = errors.New("boom")
+
+func TestRetry_Success(t *testing.T) {
+	exporter := setupTracing(t)
+
+	calls := 0
+	policy := RetryPolicy{Name: "fetch", InitialBackoff: time.Millisecond}
+	err := Retry(t.Context(), policy, func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return errBoom
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 4)
+	retry := spans[3]
+	assert.Equal(t, "fetch", retry.Name)
+	assert.True(t, hasAttribute(retry.Attributes, attribute.Int(AttrRetryAttempts, 3)))
+	assert.True(t, hasAttribute(retry.Attributes, attribute.String(AttrRetryOutcome, RetryOutcomeSuccess)))
+	assert.Equal(t, codes.Unset, retry.Status.Code)
+
+	for i, attempt := range spans[:3] {
+		assert.Equal(t, "fetch.attempt", attempt.Name)
+		assert.Equal(t, retry.SpanContext.SpanID(), attempt.Parent.SpanID())
+		assert.True(t, hasAttribute(attempt.Attributes, attribute.Int(AttrRetryAttempt, i+1)))
+	}
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.True(t, hasAttribute(spans[0].Attributes, attribute.Float64(AttrRetryBackoff, 0)))
+	assert.True(t, hasAttribute(spans[1].Attributes, attribute.Float64(AttrRetryBackoff, 1)))
+	assert.True(t, hasAttribute(spans[2].Attributes, attribute.Float64(AttrRetryBackoff, 2)))
+	assert.Equal(t, codes.Unset, spans[2].Status.Code)
+}
+
+func TestRetry_Exhausted(t *testing.T) {
+	exporter := setupTracing(t)
+
+	err := Retry(t.Context(), RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond},
+		func(context.Context) error { return errBoom })
+	require.ErrorIs(t, err, errBoom)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+	retry := spans[2]
+	assert.Equal(t, "retry", retry.Name)
+	assert.True(t, hasAttribute(retry.Attributes, attribute.String(AttrRetryOutcome, RetryOutcomeExhausted)))
+	assert.Equal(t, codes.Error, retry.Status.Code)
+}
+
+func TestRetry_NonRetryable(t *testing.T) {
+	exporter := setupTracing(t)
+
+	calls := 0
+	policy := RetryPolicy{Retryable: func(err error) bool { return !errors.Is(err, errBoom) }}
+	err := Retry(t.Context(), policy, func(context.Context) error {
+		calls++
+
+		return errBoom
+	})
+	require.ErrorIs(t, err, errBoom)
+	assert.Equal(t, 1, calls)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.True(t, hasAttribute(spans[1].Attributes, attribute.String(AttrRetryOutcome, RetryOutcomeNonRetryable)))
+}
+
+func TestRetry_Canceled(t *testing.T) {
+	exporter := setupTracing(t)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	err := Retry(ctx, RetryPolicy{InitialBackoff: time.Hour}, func(context.Context) error {
+		cancel()
+
+		return errBoom
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.ErrorIs(t, err, errBoom)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.True(t, hasAttribute(spans[1].Attributes, attribute.String(AttrRetryOutcome, RetryOutcomeCanceled)))
+	assert.True(t, hasAttribute(spans[1].Attributes, attribute.Int(AttrRetryAttempts, 1)))
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}.withDefaults()
+	assert.Equal(t, time.Second, p.backoff(1))
+	assert.Equal(t, 2*time.Second, p.backoff(2))
+	assert.Equal(t, 4*time.Second, p.backoff(3))
+	assert.Equal(t, 5*time.Second, p.backoff(4), "capped at MaxBackoff")
+
+	p.Jitter = 0.5
+	for range 10 {
+		d := p.backoff(1)
+		assert.GreaterOrEqual(t, d, 500*time.Millisecond)
+		assert.LessOrEqual(t, d, time.Second)
+	}
+
+	p = RetryPolicy{Jitter: 3}.withDefaults()
+	assert.Equal(t, DefaultRetryMaxAttempts, p.MaxAttempts)
+	assert.InDelta(t, 1.0, p.Jitter, 0)
+}
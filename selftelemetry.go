@@ -0,0 +1,403 @@
+package otx
+
+import (
+	"context"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Self-telemetry metric names.
+const (
+	metricSpansStarted     = "otx.sdk.spans.started"
+	metricSpansEnded       = "otx.sdk.spans.ended"
+	metricSpansDropped     = "otx.sdk.spans.dropped"
+	metricLogsDropped      = "otx.sdk.logs.dropped"
+	metricExports          = "otx.sdk.exports"
+	metricExportedItems    = "otx.sdk.exported_items"
+	metricQueueUtilization = "otx.sdk.queue.utilization"
+)
+
+// Self-telemetry metric attributes.
+const (
+	attrSignal   = "signal"
+	attrExporter = "exporter"
+	attrOutcome  = "outcome"
+)
+
+// selfTelemetryMeterName is the instrumentation scope of the self-telemetry metrics.
+const selfTelemetryMeterName = "github.com/arloliu/otx/selftelemetry"
+
+// defaultQueueSize is the SDK default batch queue size for spans and log records.
+const defaultQueueSize = 2048
+
+// Export outcomes recorded in the outcome attribute.
+const (
+	outcomeSuccess = "success"
+	outcomeFailure = "failure"
+)
+
+// selfTelemetry records the health of the telemetry pipelines built by otx.
+type selfTelemetry struct {
+	started  metric.Int64Counter
+	ended    metric.Int64Counter
+	dropped  map[string]metric.Int64Counter // by signal
+	exports  metric.Int64Counter
+	exported metric.Int64Counter
+
+	mu     sync.Mutex
+	queues []*queueTracker
+}
+
+// queueTracker estimates the fill level of one batch processor queue from the
+// items handed to the processor and the items its exporter has received.
+type queueTracker struct {
+	attrs    attribute.Set
+	capacity int64
+	pending  atomic.Int64
+}
+
+// selfTelemetryProcessor counts started and ended spans.
+type selfTelemetryProcessor struct {
+	st *selfTelemetry
+}
+
+// queuedSpanProcessor tracks the queue of the batch processor it wraps.
+type queuedSpanProcessor struct {
+	sdktrace.SpanProcessor
+	st    *selfTelemetry
+	queue *queueTracker
+}
+
+// queuedLogProcessor tracks the queue of the batch processor it wraps.
+type queuedLogProcessor struct {
+	sdklog.Processor
+	st    *selfTelemetry
+	queue *queueTracker
+}
+
+// observedSpanExporter records export outcomes of the wrapped exporter.
+type observedSpanExporter struct {
+	sdktrace.SpanExporter
+	st    *selfTelemetry
+	queue *queueTracker
+}
+
+// observedLogExporter records export outcomes of the wrapped exporter.
+type observedLogExporter struct {
+	sdklog.Exporter
+	st    *selfTelemetry
+	queue *queueTracker
+}
+
+// observedMetricExporter records export outcomes of the wrapped exporter.
+type observedMetricExporter struct {
+	sdkmetric.Exporter
+	st    *selfTelemetry
+	attrs attribute.Set
+}
+
+// sharedSelfTelemetry is used by all providers created by otx, so the instruments and the
+// queue utilization callback are registered once on the global MeterProvider.
+var sharedSelfTelemetry = sync.OnceValue(func() *selfTelemetry {
+	return newSelfTelemetry(nil)
+})
+
+// newSelfTelemetry creates the self-telemetry instruments from mp, or the global
+// MeterProvider if mp is nil. The global provider may be set later; instruments
+// created before that are forwarded once it is.
+func newSelfTelemetry(mp metric.MeterProvider) *selfTelemetry {
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	meter := mp.Meter(selfTelemetryMeterName)
+	fallback := noop.NewMeterProvider().Meter(selfTelemetryMeterName)
+
+	counter := func(name, unit, description string) metric.Int64Counter {
+		c, err := meter.Int64Counter(name, metric.WithUnit(unit), metric.WithDescription(description))
+		if err != nil {
+			otel.Handle(err)
+			c, _ = fallback.Int64Counter(name)
+		}
+
+		return c
+	}
+
+	st := &selfTelemetry{
+		started: counter(metricSpansStarted, "{span}", "Spans started by otx tracer providers."),
+		ended:   counter(metricSpansEnded, "{span}", "Spans ended by otx tracer providers."),
+		dropped: map[string]metric.Int64Counter{
+			"traces": counter(metricSpansDropped, "{span}",
+				"Spans dropped because an export queue was full (estimated)."),
+			"logs": counter(metricLogsDropped, "{record}",
+				"Log records dropped because an export queue was full (estimated)."),
+		},
+		exports:  counter(metricExports, "{export}", "Export calls, by signal, exporter and outcome."),
+		exported: counter(metricExportedItems, "{item}", "Items passed to exporters, by signal, exporter and outcome."),
+	}
+
+	_, err := meter.Float64ObservableGauge(metricQueueUtilization,
+		metric.WithUnit("1"),
+		metric.WithDescription("Estimated fill ratio of export queues, by signal and exporter."),
+		metric.WithFloat64Callback(st.observeQueues),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return st
+}
+
+// queue registers and returns the tracker of a batch processor queue.
+func (st *selfTelemetry) queue(signal, exporter string, capacity int) *queueTracker {
+	q := &queueTracker{
+		attrs:    attribute.NewSet(attribute.String(attrSignal, signal), attribute.String(attrExporter, exporter)),
+		capacity: int64(capacity),
+	}
+
+	st.mu.Lock()
+	st.queues = append(st.queues, q)
+	st.mu.Unlock()
+
+	return q
+}
+
+// removeQueue unregisters q, once its processor has shut down.
+func (st *selfTelemetry) removeQueue(q *queueTracker) {
+	st.mu.Lock()
+	st.queues = slices.DeleteFunc(st.queues, func(other *queueTracker) bool { return other == q })
+	st.mu.Unlock()
+}
+
+// observeQueues reports the utilization of every registered queue.
+func (st *selfTelemetry) observeQueues(_ context.Context, o metric.Float64Observer) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	for _, q := range st.queues {
+		o.Observe(float64(q.pending.Load())/float64(q.capacity), metric.WithAttributeSet(q.attrs))
+	}
+
+	return nil
+}
+
+// recordExport records the outcome of an export of n items.
+func (st *selfTelemetry) recordExport(ctx context.Context, attrs attribute.Set, n int, err error) {
+	outcome := outcomeSuccess
+	if err != nil {
+		outcome = outcomeFailure
+	}
+	opt := metric.WithAttributeSet(attribute.NewSet(append(attrs.ToSlice(), attribute.String(attrOutcome, outcome))...))
+
+	st.exports.Add(ctx, 1, opt)
+	st.exported.Add(ctx, int64(n), opt)
+}
+
+// enqueue counts one item handed to the queue and reports whether it fits.
+// When the queue is estimated full, the batch processor drops the item.
+func (q *queueTracker) enqueue() bool {
+	if q.pending.Load() >= q.capacity {
+		return false
+	}
+	q.pending.Add(1)
+
+	return true
+}
+
+// dequeue counts n items received by the exporter, never going below zero since
+// the estimate can miss items accepted while an export was running.
+func (q *queueTracker) dequeue(n int) {
+	for {
+		current := q.pending.Load()
+		next := max(current-int64(n), 0)
+		if q.pending.CompareAndSwap(current, next) {
+			return
+		}
+	}
+}
+
+// selfTelemetryFor returns the shared self-telemetry when cfg enables it, or nil.
+func selfTelemetryFor(cfg *TelemetryConfig) *selfTelemetry {
+	if !cfg.SelfTelemetry {
+		return nil
+	}
+
+	return sharedSelfTelemetry()
+}
+
+// tracesBatch returns traces.batch, or nil.
+func tracesBatch(cfg *TracesConfig) *BatchConfig {
+	if cfg == nil {
+		return nil
+	}
+
+	return cfg.Batch
+}
+
+// queueCapacity returns the queue size configured in b, the environment variable
+// envKey, or the SDK default, in that order.
+func queueCapacity(b *BatchConfig, envKey string) int {
+	if b != nil && b.MaxQueueSize > 0 {
+		return b.MaxQueueSize
+	}
+	if n, err := strconv.Atoi(os.Getenv(envKey)); err == nil && n > 0 {
+		return n
+	}
+
+	return defaultQueueSize
+}
+
+// OnStart implements sdktrace.SpanProcessor.
+func (p selfTelemetryProcessor) OnStart(ctx context.Context, _ sdktrace.ReadWriteSpan) {
+	p.st.started.Add(ctx, 1)
+}
+
+// OnEnd implements sdktrace.SpanProcessor.
+func (p selfTelemetryProcessor) OnEnd(_ sdktrace.ReadOnlySpan) {
+	p.st.ended.Add(context.Background(), 1)
+}
+
+// Shutdown implements sdktrace.SpanProcessor.
+func (selfTelemetryProcessor) Shutdown(_ context.Context) error {
+	return nil
+}
+
+// ForceFlush implements sdktrace.SpanProcessor.
+func (selfTelemetryProcessor) ForceFlush(_ context.Context) error {
+	return nil
+}
+
+// OnEnd implements sdktrace.SpanProcessor.
+func (p *queuedSpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	// The batch processor only queues sampled spans.
+	if s.SpanContext().IsSampled() && !p.queue.enqueue() {
+		p.st.dropped["traces"].Add(context.Background(), 1, metric.WithAttributeSet(p.queue.attrs))
+	}
+	p.SpanProcessor.OnEnd(s)
+}
+
+// Shutdown implements sdktrace.SpanProcessor.
+func (p *queuedSpanProcessor) Shutdown(ctx context.Context) error {
+	p.st.removeQueue(p.queue)
+
+	return p.SpanProcessor.Shutdown(ctx)
+}
+
+// OnEmit implements sdklog.Processor.
+func (p *queuedLogProcessor) OnEmit(ctx context.Context, r *sdklog.Record) error {
+	if !p.queue.enqueue() {
+		p.st.dropped["logs"].Add(ctx, 1, metric.WithAttributeSet(p.queue.attrs))
+	}
+
+	return p.Processor.OnEmit(ctx, r)
+}
+
+// Shutdown implements sdklog.Processor.
+func (p *queuedLogProcessor) Shutdown(ctx context.Context) error {
+	p.st.removeQueue(p.queue)
+
+	return p.Processor.Shutdown(ctx)
+}
+
+// ExportSpans implements sdktrace.SpanExporter.
+func (e *observedSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.queue.dequeue(len(spans))
+	err := e.SpanExporter.ExportSpans(ctx, spans)
+	e.st.recordExport(ctx, e.queue.attrs, len(spans), err)
+
+	return err
+}
+
+// Export implements sdklog.Exporter.
+func (e *observedLogExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	e.queue.dequeue(len(records))
+	err := e.Exporter.Export(ctx, records)
+	e.st.recordExport(ctx, e.queue.attrs, len(records), err)
+
+	return err
+}
+
+// Export implements sdkmetric.Exporter.
+func (e *observedMetricExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	err := e.Exporter.Export(ctx, rm)
+
+	n := 0
+	for _, sm := range rm.ScopeMetrics {
+		n += len(sm.Metrics)
+	}
+	e.st.recordExport(ctx, e.attrs, n, err)
+
+	return err
+}
+
+// spanBatcher returns the batch processor for exporter, instrumented when st is not nil.
+func (st *selfTelemetry) spanBatcher(
+	exporter sdktrace.SpanExporter,
+	name string,
+	capacity int,
+	opts ...sdktrace.BatchSpanProcessorOption,
+) sdktrace.SpanProcessor {
+	if st == nil {
+		return sdktrace.NewBatchSpanProcessor(exporter, opts...)
+	}
+
+	q := st.queue("traces", name, capacity)
+	batcher := sdktrace.NewBatchSpanProcessor(&observedSpanExporter{SpanExporter: exporter, st: st, queue: q}, opts...)
+
+	return &queuedSpanProcessor{SpanProcessor: batcher, st: st, queue: q}
+}
+
+// logBatcher returns the batch processor for exporter, instrumented when st is not nil.
+func (st *selfTelemetry) logBatcher(
+	exporter sdklog.Exporter,
+	name string,
+	capacity int,
+	opts ...sdklog.BatchProcessorOption,
+) sdklog.Processor {
+	if st == nil {
+		return sdklog.NewBatchProcessor(exporter, opts...)
+	}
+
+	q := st.queue("logs", name, capacity)
+	batcher := sdklog.NewBatchProcessor(&observedLogExporter{Exporter: exporter, st: st, queue: q}, opts...)
+
+	return &queuedLogProcessor{Processor: batcher, st: st, queue: q}
+}
+
+// logSimple returns a simple processor exporting each record to exporter when it is
+// emitted, instrumented when st is not nil.
+func (st *selfTelemetry) logSimple(exporter sdklog.Exporter, name string) sdklog.Processor {
+	if st == nil {
+		return sdklog.NewSimpleProcessor(exporter)
+	}
+
+	// Records leave the queue when the export returns, so it never holds more than one
+	q := st.queue("logs", name, 1)
+	simple := sdklog.NewSimpleProcessor(&observedLogExporter{Exporter: exporter, st: st, queue: q})
+
+	return &queuedLogProcessor{Processor: simple, st: st, queue: q}
+}
+
+// metricExporter returns exporter, instrumented when st is not nil.
+func (st *selfTelemetry) metricExporter(exporter sdkmetric.Exporter, name string) sdkmetric.Exporter {
+	if st == nil {
+		return exporter
+	}
+
+	return &observedMetricExporter{
+		Exporter: exporter,
+		st:       st,
+		attrs:    attribute.NewSet(attribute.String(attrSignal, "metrics"), attribute.String(attrExporter, name)),
+	}
+}
@@ -0,0 +1,139 @@
+package otx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// failingSpanExporter rejects every export.
+type failingSpanExporter struct{}
+
+func (failingSpanExporter) ExportSpans(context.Context, []sdktrace.ReadOnlySpan) error {
+	return errors.New("collector unavailable")
+}
+
+func (failingSpanExporter) Shutdown(context.Context) error { return nil }
+
+// collectSums returns the int64 sums of the given metric keyed by the value of attribute key.
+func collectSums(t *testing.T, rm metricdata.ResourceMetrics, name string, key attribute.Key) map[string]int64 {
+	t.Helper()
+
+	sums := make(map[string]int64)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if m.Name != name || !ok {
+				continue
+			}
+			for _, dp := range sum.DataPoints {
+				v, _ := dp.Attributes.Value(key)
+				sums[v.AsString()] += dp.Value
+			}
+		}
+	}
+
+	return sums
+}
+
+func TestSelfTelemetry_Spans(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	st := newSelfTelemetry(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(selfTelemetryProcessor{st: st}),
+		sdktrace.WithSpanProcessor(st.spanBatcher(tracetest.NewInMemoryExporter(), "console", 2)),
+		sdktrace.WithSpanProcessor(st.spanBatcher(failingSpanExporter{}, "otlp", 2)),
+	)
+	t.Cleanup(func() { SetErrorHandler(nil) })
+	SetErrorHandler(func(error) {})
+	for range 3 {
+		_, span := tp.Tracer("test").Start(t.Context(), "op")
+		span.End()
+	}
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(t.Context(), &rm))
+	assert.Equal(t, map[string]int64{"": 3}, collectSums(t, rm, metricSpansStarted, attrExporter))
+	assert.Equal(t, map[string]int64{"": 3}, collectSums(t, rm, metricSpansEnded, attrExporter))
+	assert.Equal(t, map[string]int64{"console": 1, "otlp": 1}, collectSums(t, rm, metricSpansDropped, attrExporter),
+		"the third span does not fit in an estimated queue of 2")
+
+	_ = tp.Shutdown(t.Context())
+
+	rm = metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(t.Context(), &rm))
+	assert.Equal(t, map[string]int64{"success": 1, "failure": 1}, collectSums(t, rm, metricExports, attrOutcome))
+	assert.Equal(t, map[string]int64{"success": 3, "failure": 3}, collectSums(t, rm, metricExportedItems, attrOutcome),
+		"the processors' real queues hold all spans")
+}
+
+func TestSelfTelemetry_QueueUtilization(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	st := newSelfTelemetry(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	q := st.queue("traces", "otlp", 4)
+	assert.True(t, q.enqueue())
+	assert.True(t, q.enqueue())
+	assert.True(t, q.enqueue())
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(t.Context(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	var gauge metricdata.Gauge[float64]
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if m.Name == metricQueueUtilization {
+			gauge, _ = m.Data.(metricdata.Gauge[float64])
+		}
+	}
+	require.Len(t, gauge.DataPoints, 1)
+	assert.InDelta(t, 0.75, gauge.DataPoints[0].Value, 1e-9)
+
+	q.dequeue(5)
+	assert.Zero(t, q.pending.Load(), "the estimate never goes negative")
+
+	st.removeQueue(q)
+	rm = metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(t.Context(), &rm))
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			assert.NotEqual(t, metricQueueUtilization, m.Name, "removed queues are not reported")
+		}
+	}
+}
+
+func TestQueueCapacity(t *testing.T) {
+	assert.Equal(t, defaultQueueSize, queueCapacity(nil, "OTEL_BSP_MAX_QUEUE_SIZE"))
+
+	t.Setenv("OTEL_BSP_MAX_QUEUE_SIZE", "100")
+	assert.Equal(t, 100, queueCapacity(nil, "OTEL_BSP_MAX_QUEUE_SIZE"))
+	assert.Equal(t, 50, queueCapacity(&BatchConfig{MaxQueueSize: 50}, "OTEL_BSP_MAX_QUEUE_SIZE"))
+}
+
+func TestNewTracerProvider_SelfTelemetry(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := &TelemetryConfig{
+		Enabled:       boolPtr(true),
+		ServiceName:   "test-service",
+		SelfTelemetry: true,
+		Traces:        &TracesConfig{Exporter: "console"},
+		Console:       &ConsoleConfig{Writer: &buf, PrettyPrint: boolPtr(false)},
+	}
+	tp, err := NewTracerProvider(t.Context(), cfg)
+	require.NoError(t, err)
+
+	_, span := tp.Tracer("test").Start(t.Context(), "observed")
+	span.End()
+	require.NoError(t, tp.Shutdown(t.Context()))
+
+	assert.Contains(t, buf.String(), `"Name":"observed"`)
+}
@@ -0,0 +1,110 @@
+package otx
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+)
+
+// ShutdownHook is a callback registered with OnShutdown.
+type ShutdownHook func(ctx context.Context) error
+
+// shutdownHooks holds the hooks registered with OnShutdown, in registration order.
+var shutdownHooks struct {
+	mu      sync.Mutex
+	next    uint64
+	entries []shutdownHookEntry
+}
+
+// shutdownHookEntry is a registered hook with the ID unregistering it.
+type shutdownHookEntry struct {
+	id   uint64
+	hook ShutdownHook
+}
+
+// OnShutdown registers hook to run when [Shutdown] executes, directly or through
+// [HandleSignals], so instrumentation and application code can flush buffers and
+// release resources without plumbing their own lifecycle. Hooks run before the
+// providers are flushed, so spans and records they emit are exported, in reverse
+// order of registration, like deferred calls. Each hook runs at most once.
+//
+// The returned function unregisters the hook, e.g. when the component closes
+// before the process exits.
+//
+// Example:
+//
+//	remove := otx.OnShutdown(func(ctx context.Context) error {
+//	    return batcher.Flush(ctx)
+//	})
+//	defer remove()
+func OnShutdown(hook ShutdownHook) (remove func()) {
+	if hook == nil {
+		return func() {}
+	}
+
+	shutdownHooks.mu.Lock()
+	defer shutdownHooks.mu.Unlock()
+
+	id := shutdownHooks.next
+	shutdownHooks.next++
+	shutdownHooks.entries = append(shutdownHooks.entries, shutdownHookEntry{id: id, hook: hook})
+
+	return func() {
+		shutdownHooks.mu.Lock()
+		defer shutdownHooks.mu.Unlock()
+
+		shutdownHooks.entries = slices.DeleteFunc(shutdownHooks.entries, func(e shutdownHookEntry) bool {
+			return e.id == id
+		})
+	}
+}
+
+// takeShutdownHooks unregisters the registered hooks and returns them in the
+// order they run.
+func takeShutdownHooks() []ShutdownHook {
+	shutdownHooks.mu.Lock()
+	defer shutdownHooks.mu.Unlock()
+
+	hooks := make([]ShutdownHook, 0, len(shutdownHooks.entries))
+	for _, e := range slices.Backward(shutdownHooks.entries) {
+		hooks = append(hooks, e.hook)
+	}
+	shutdownHooks.entries = nil
+
+	return hooks
+}
+
+// Shutdown runs the hooks registered with [OnShutdown], then force-flushes every
+// provider and shuts them down in reverse order. It returns the joined errors of
+// the hooks, flushes and shutdowns; ctx bounds the whole sequence. Nil providers
+// are skipped, so providers that returned ErrDisabled can be passed as is.
+//
+// HandleSignals calls Shutdown with its grace period; call it directly when the
+// application handles signals itself.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//	defer cancel()
+//	if err := otx.Shutdown(ctx, tp, mp, lp); err != nil {
+//	    log.Print(err)
+//	}
+func Shutdown(ctx context.Context, providers ...Provider) error {
+	var errs []error
+	for _, hook := range takeShutdownHooks() {
+		errs = append(errs, hook(ctx))
+	}
+	for _, p := range providers {
+		if !isNilProvider(p) {
+			errs = append(errs, p.ForceFlush(ctx))
+		}
+	}
+	for _, p := range slices.Backward(providers) {
+		if !isNilProvider(p) {
+			errs = append(errs, p.Shutdown(ctx))
+		}
+	}
+
+	return errors.Join(errs...)
+}
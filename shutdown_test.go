@@ -0,0 +1,78 @@
+package otx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShutdown_RunsHooksBeforeProviders(t *testing.T) {
+	var calls []string
+	hook := func(name string) ShutdownHook {
+		return func(context.Context) error {
+			calls = append(calls, "hook "+name)
+
+			return nil
+		}
+	}
+	OnShutdown(hook("first"))
+	OnShutdown(hook("second"))
+	remove := OnShutdown(hook("removed"))
+	remove()
+
+	tp := &fakeProvider{name: "tp", calls: &calls}
+	mp := &fakeProvider{name: "mp", calls: &calls}
+	require.NoError(t, Shutdown(t.Context(), tp, nil, mp))
+
+	assert.Equal(t, []string{
+		"hook second", "hook first",
+		"flush tp", "flush mp",
+		"shutdown mp", "shutdown tp",
+	}, calls)
+
+	// Hooks run once
+	calls = nil
+	require.NoError(t, Shutdown(t.Context()))
+	assert.Empty(t, calls)
+}
+
+func TestShutdown_JoinsErrors(t *testing.T) {
+	OnShutdown(func(context.Context) error { return errBoom })
+	OnShutdown(nil)
+
+	var calls []string
+	tp := &fakeProvider{name: "tp", calls: &calls}
+	err := Shutdown(t.Context(), tp)
+	require.ErrorIs(t, err, errBoom)
+	assert.Equal(t, []string{"flush tp", "shutdown tp"}, calls, "providers are shut down despite hook errors")
+}
+
+func TestHandleSignals_RunsShutdownHooks(t *testing.T) {
+	var ran bool
+	OnShutdown(func(ctx context.Context) error {
+		_, ran = ctx.Deadline()
+
+		return nil
+	})
+
+	_, shutdown := HandleSignals(t.Context())
+	require.NoError(t, shutdown())
+	assert.True(t, ran, "hook runs within the grace period")
+}
+
+func TestOnShutdown_RemoveReleasesHook(t *testing.T) {
+	registered := func() int {
+		shutdownHooks.mu.Lock()
+		defer shutdownHooks.mu.Unlock()
+
+		return len(shutdownHooks.entries)
+	}
+
+	before := registered()
+	for range 100 {
+		OnShutdown(func(context.Context) error { return nil })()
+	}
+	assert.Equal(t, before, registered(), "removed hooks are not kept until Shutdown")
+}
@@ -0,0 +1,182 @@
+package otx
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/arloliu/otx/internal/tracker"
+)
+
+// DefaultGracePeriod is the time HandleSignals allows for flushing and shutting down providers.
+const DefaultGracePeriod = 10 * time.Second
+
+// Provider is a telemetry provider that can be flushed and shut down, such as
+// *sdktrace.TracerProvider, *sdklog.LoggerProvider and *sdkmetric.MeterProvider.
+type Provider interface {
+	ForceFlush(ctx context.Context) error
+	Shutdown(ctx context.Context) error
+}
+
+// signalOptions holds the options applied by HandleSignalsWithOptions.
+type signalOptions struct {
+	gracePeriod  time.Duration
+	stopNewSpans bool
+	signals      []os.Signal
+}
+
+// SignalOption configures HandleSignalsWithOptions.
+type SignalOption func(*signalOptions)
+
+// WithGracePeriod bounds the time spent flushing and shutting down providers.
+// Defaults to DefaultGracePeriod; values <= 0 keep the default.
+func WithGracePeriod(d time.Duration) SignalOption {
+	return func(o *signalOptions) {
+		if d > 0 {
+			o.gracePeriod = d
+		}
+	}
+}
+
+// WithStopNewSpans stops starting new spans once shutdown begins, so the flush only
+// carries spans already in flight. It disables the otx helpers (see InitTracing)
+// and replaces the global TracerProvider with a no-op one; tracers obtained from
+// a provider directly keep working until it is shut down.
+func WithStopNewSpans() SignalOption {
+	return func(o *signalOptions) {
+		o.stopNewSpans = true
+	}
+}
+
+// WithSignals replaces the signals that trigger shutdown. Defaults to SIGINT and SIGTERM.
+func WithSignals(signals ...os.Signal) SignalOption {
+	return func(o *signalOptions) {
+		o.signals = signals
+	}
+}
+
+// HandleSignals shuts providers down when the process receives SIGINT or SIGTERM.
+// See HandleSignalsWithOptions.
+//
+// Example:
+//
+//	ctx, shutdown := otx.HandleSignals(context.Background(), tp, mp, lp)
+//	defer shutdown()
+//
+//	if err := run(ctx); err != nil { // run returns once ctx is canceled
+//	    log.Print(err)
+//	}
+func HandleSignals(ctx context.Context, providers ...Provider) (context.Context, func() error) {
+	return HandleSignalsWithOptions(ctx, nil, providers...)
+}
+
+// HandleSignalsWithOptions installs signal handling that shuts providers down,
+// replacing the signal and flush code otherwise repeated in every main().
+//
+// When a signal arrives, it optionally stops new spans (WithStopNewSpans), cancels
+// the returned context so the application can stop, then runs the OnShutdown hooks,
+// force-flushes every provider and shuts them down in reverse order, all within the
+// grace period; see Shutdown.
+//
+// The returned function starts the same shutdown if no signal has arrived, waits
+// for it to finish and returns the joined flush and shutdown errors. It is safe to
+// call more than once; defer it in main so telemetry is flushed on every exit path.
+// Nil providers are skipped, so providers that returned ErrDisabled can be passed as is.
+// If ctx is canceled before a signal arrives, signal handling is uninstalled.
+//
+// Example:
+//
+//	ctx, shutdown := otx.HandleSignalsWithOptions(context.Background(),
+//	    []otx.SignalOption{otx.WithGracePeriod(5 * time.Second), otx.WithStopNewSpans()},
+//	    tp, mp, lp,
+//	)
+//	defer shutdown()
+func HandleSignalsWithOptions(
+	ctx context.Context,
+	opts []SignalOption,
+	providers ...Provider,
+) (context.Context, func() error) {
+	o := signalOptions{
+		gracePeriod: DefaultGracePeriod,
+		signals:     []os.Signal{os.Interrupt, syscall.SIGTERM},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, o.signals...)
+
+	var (
+		once sync.Once
+		err  error
+	)
+	shutdown := func() error {
+		once.Do(func() {
+			signal.Stop(signals)
+			if o.stopNewSpans {
+				stopNewSpans()
+			}
+			cancel()
+			err = shutdownWithin(o.gracePeriod, providers)
+		})
+
+		return err
+	}
+
+	go func() {
+		select {
+		case <-signals:
+			_ = shutdown()
+		case <-ctx.Done():
+			// The parent was canceled first: stop relaying signals so they reach
+			// the default handlers again instead of the unread channel.
+			signal.Stop(signals)
+		}
+	}()
+
+	return ctx, shutdown
+}
+
+// stopNewSpans disables the otx helpers and the global TracerProvider.
+func stopNewSpans() {
+	tracker.Set(nil, nil)
+	otel.SetTracerProvider(noop.NewTracerProvider())
+}
+
+// shutdownWithin calls Shutdown with a context bounded by gracePeriod.
+func shutdownWithin(gracePeriod time.Duration, providers []Provider) error {
+	ctx, cancel := context.WithTimeout(context.Background(), gracePeriod)
+	defer cancel()
+
+	return Shutdown(ctx, providers...)
+}
+
+// isNilProvider reports whether p is nil. Besides a nil interface, this covers
+// the nil SDK providers returned with ErrDisabled by NewTracerProvider,
+// NewLoggerProvider and NewMeterProvider; other typed nil pointers are not
+// detected.
+func isNilProvider(p Provider) bool {
+	switch p := p.(type) {
+	case nil:
+		return true
+	case *sdktrace.TracerProvider:
+		return p == nil
+	case *sdklog.LoggerProvider:
+		return p == nil
+	case *sdkmetric.MeterProvider:
+		return p == nil
+	default:
+		return false
+	}
+}
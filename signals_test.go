@@ -0,0 +1,95 @@
+package otx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// fakeProvider records ForceFlush and Shutdown calls into a shared log.
+type fakeProvider struct {
+	name     string
+	calls    *[]string
+	err      error
+	deadline bool
+}
+
+func (p *fakeProvider) ForceFlush(ctx context.Context) error {
+	_, p.deadline = ctx.Deadline()
+	*p.calls = append(*p.calls, "flush "+p.name)
+
+	return nil
+}
+
+func (p *fakeProvider) Shutdown(_ context.Context) error {
+	*p.calls = append(*p.calls, "shutdown "+p.name)
+
+	return p.err
+}
+
+func TestHandleSignals_Shutdown(t *testing.T) {
+	var calls []string
+	errShutdown := errors.New("shutdown failed")
+	tp := &fakeProvider{name: "traces", calls: &calls}
+	mp := &fakeProvider{name: "metrics", calls: &calls, err: errShutdown}
+	var disabled *sdktrace.TracerProvider
+
+	ctx, shutdown := HandleSignals(t.Context(), tp, disabled, mp)
+
+	err := shutdown()
+	require.ErrorIs(t, err, errShutdown)
+	assert.Equal(t, []string{"flush traces", "flush metrics", "shutdown metrics", "shutdown traces"}, calls)
+	assert.True(t, tp.deadline, "flush runs within the grace period")
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+
+	require.ErrorIs(t, shutdown(), errShutdown, "later calls return the same result")
+	assert.Len(t, calls, 4, "providers are shut down once")
+}
+
+func TestHandleSignalsWithOptions_Signal(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("signals cannot be sent to the current process on windows")
+	}
+
+	prevProvider := otel.GetTracerProvider()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		InitTracing(nil, nil)
+	})
+
+	sdkProvider := sdktrace.NewTracerProvider()
+	InitTracing(sdkProvider.Tracer("test"), nil)
+
+	var calls []string
+	ctx, shutdown := HandleSignalsWithOptions(t.Context(),
+		[]SignalOption{WithSignals(syscall.SIGHUP), WithGracePeriod(time.Second), WithStopNewSpans()},
+		&fakeProvider{name: "traces", calls: &calls},
+	)
+
+	process, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	require.NoError(t, process.Signal(syscall.SIGHUP))
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "context was not canceled by the signal")
+	}
+	require.NoError(t, shutdown())
+	assert.Equal(t, []string{"flush traces", "shutdown traces"}, calls)
+
+	_, span := Start(t.Context(), "after-shutdown")
+	assert.False(t, span.SpanContext().IsValid(), "otx helpers stop starting spans")
+	_, span = otel.Tracer("test").Start(t.Context(), "after-shutdown")
+	assert.Equal(t, trace.SpanContext{}, span.SpanContext(), "global provider is a no-op")
+}
@@ -2,13 +2,38 @@ package otx
 
 import (
 	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
 
+	"github.com/arloliu/otx/internal/baggageattr"
 	"github.com/arloliu/otx/internal/tracker"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
+// Link attributes describing how a linked span relates to the span it links to.
+// The keys follow the OpenTracing compatibility convention, which backends use to
+// render FOLLOWS_FROM references.
+const (
+	AttrRefType        = "opentracing.ref_type"
+	RefTypeFollowsFrom = "follows_from"
+)
+
+// ErrorBaggagePrefix is the key prefix of baggage members copied onto spans by RecordError.
+const ErrorBaggagePrefix = "baggage."
+
+var (
+	// errorBaggage selects the baggage members copied onto spans by RecordError, nil for none.
+	errorBaggage atomic.Pointer[baggageattr.Selector]
+
+	// errorStackTrace reports whether RecordError records stack traces.
+	errorStackTrace atomic.Bool
+)
+
 // InitTracing sets up the global tracer and namer.
 // Called once during application initialization.
 func InitTracing(tracer trace.Tracer, namer SpanNamer) {
@@ -16,37 +41,56 @@ func InitTracing(tracer trace.Tracer, namer SpanNamer) {
 }
 
 // Start begins a new span with the configured namer applied.
+// With EnableSpanMetrics, the span also records its duration when it ends.
 func Start(ctx context.Context, operation string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
-	return tracker.Start(ctx, operation, opts...)
+	if spanDuration.Load() == nil || tracker.Tracer() == nil {
+		return tracker.Start(ctx, operation, opts...)
+	}
+
+	start := time.Now()
+	ctx, span := tracker.Start(ctx, operation, opts...)
+
+	return withSpanMetrics(ctx, span, start, operation, opts)
 }
 
 // StartServer begins a new server span (e.g., handling an incoming request).
 func StartServer(ctx context.Context, operation string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
-	opts = append([]trace.SpanStartOption{trace.WithSpanKind(trace.SpanKindServer)}, opts...)
-	return Start(ctx, operation, opts...)
+	return startWithKind(ctx, operation, trace.SpanKindServer, opts)
 }
 
 // StartClient begins a new client span (e.g., making an outgoing request).
 func StartClient(ctx context.Context, operation string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
-	opts = append([]trace.SpanStartOption{trace.WithSpanKind(trace.SpanKindClient)}, opts...)
-	return Start(ctx, operation, opts...)
+	return startWithKind(ctx, operation, trace.SpanKindClient, opts)
 }
 
 // StartInternal begins a new internal span (e.g., local processing).
 func StartInternal(ctx context.Context, operation string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
-	opts = append([]trace.SpanStartOption{trace.WithSpanKind(trace.SpanKindInternal)}, opts...)
-	return Start(ctx, operation, opts...)
+	return startWithKind(ctx, operation, trace.SpanKindInternal, opts)
 }
 
 // StartProducer begins a new producer span (e.g., publishing a message to Kafka/NATS).
 func StartProducer(ctx context.Context, operation string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
-	opts = append([]trace.SpanStartOption{trace.WithSpanKind(trace.SpanKindProducer)}, opts...)
-	return Start(ctx, operation, opts...)
+	return startWithKind(ctx, operation, trace.SpanKindProducer, opts)
 }
 
 // StartConsumer begins a new consumer span (e.g., processing a message from a queue).
 func StartConsumer(ctx context.Context, operation string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
-	opts = append([]trace.SpanStartOption{trace.WithSpanKind(trace.SpanKindConsumer)}, opts...)
+	return startWithKind(ctx, operation, trace.SpanKindConsumer, opts)
+}
+
+// startWithKind begins a span of kind. Without a tracer it returns before building
+// the start options, so disabled services do not allocate.
+func startWithKind(
+	ctx context.Context,
+	operation string,
+	kind trace.SpanKind,
+	opts []trace.SpanStartOption,
+) (context.Context, trace.Span) {
+	if tracker.Tracer() == nil {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+	opts = append([]trace.SpanStartOption{trace.WithSpanKind(kind)}, opts...)
+
 	return Start(ctx, operation, opts...)
 }
 
@@ -82,13 +126,75 @@ func SpanFromContext(ctx context.Context) trace.Span {
 
 // RecordError records an error on the current span and sets status.
 // If err is nil, this is a no-op.
+//
+// Baggage members allowlisted with SetErrorBaggage are copied onto the span. With
+// SetErrorStackTrace, the error event carries a stack trace and the span the
+// error.type attribute. The classifier set with SetErrorClassifier decides
+// whether the status is set and which attributes are added.
 func RecordError(ctx context.Context, err error, opts ...trace.EventOption) {
 	if err == nil {
 		return
 	}
 	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	RecordSpanError(span, err, opts...)
+	snapshotErrorBaggage(ctx, span)
+}
+
+// RecordSpanError is RecordError for a span without its context, as held by
+// instrumentation wrapping a client call. Baggage is not copied onto the span.
+// If err is nil, this is a no-op.
+func RecordSpanError(span trace.Span, err error, opts ...trace.EventOption) {
+	if err == nil || !span.IsRecording() {
+		return
+	}
+	if errorStackTrace.Load() {
+		opts = append([]trace.EventOption{trace.WithStackTrace(true)}, opts...)
+		span.SetAttributes(semconv.ErrorTypeKey.String(fmt.Sprintf("%T", err)))
+	}
+	attrs, isError := classifyError(err)
+	span.SetAttributes(attrs...)
 	span.RecordError(err, opts...)
-	span.SetStatus(codes.Error, err.Error())
+	if isError {
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// SetErrorStackTrace makes RecordError record the stack trace of the caller with
+// each error event, and set the error.type attribute to the Go type of the error,
+// e.g. "*net.OpError", on the span. Passing trace.WithStackTrace(false) to
+// RecordError turns the stack trace off for one call. It is called by
+// [NewTracerProvider] for traces.errorStackTrace.
+func SetErrorStackTrace(enabled bool) {
+	errorStackTrace.Store(enabled)
+}
+
+// SetErrorBaggage makes RecordError copy the baggage members whose keys match one
+// of keys onto the span as string attributes under [ErrorBaggagePrefix], so error
+// spans carry the full request context even when normal spans don't. Keys are glob
+// patterns; "*" copies every member. Calling it without keys disables the copy.
+//
+// Baggage comes from upstream callers and may carry values that must not reach the
+// backend, so prefer listing keys over "*". It is called by [NewTracerProvider]
+// for traces.errorBaggage.
+//
+// Example:
+//
+//	otx.SetErrorBaggage("tenant.id", "request.*")
+//	otx.RecordError(ctx, err) // adds baggage.tenant.id, baggage.request.path, ...
+func SetErrorBaggage(keys ...string) {
+	errorBaggage.Store(baggageattr.New(keys...))
+}
+
+// snapshotErrorBaggage copies the allowlisted baggage members of ctx onto span.
+func snapshotErrorBaggage(ctx context.Context, span trace.Span) {
+	keys := errorBaggage.Load()
+	if keys == nil || !span.IsRecording() {
+		return
+	}
+	span.SetAttributes(keys.Attributes(baggage.FromContext(ctx), ErrorBaggagePrefix)...)
 }
 
 // SetSuccess marks the current span as successful.
@@ -98,10 +204,100 @@ func SetSuccess(ctx context.Context) {
 
 // AddEvent adds an event to the current span.
 func AddEvent(ctx context.Context, name string, attrs ...attribute.KeyValue) {
-	trace.SpanFromContext(ctx).AddEvent(name, trace.WithAttributes(attrs...))
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	span.AddEvent(name, trace.WithAttributes(attrs...))
 }
 
 // SetAttributes sets attributes on the current span.
 func SetAttributes(ctx context.Context, attrs ...attribute.KeyValue) {
 	trace.SpanFromContext(ctx).SetAttributes(attrs...)
 }
+
+// IsRecording reports whether the current span records data, i.e. is sampled or
+// kept for processors. Use it to skip work whose only purpose is telemetry.
+func IsRecording(ctx context.Context) bool {
+	return trace.SpanFromContext(ctx).IsRecording()
+}
+
+// SetAttributesIfRecording sets the attributes returned by fn on the current span,
+// calling fn only if the span is recording, so expensive values such as serialized
+// payloads are not computed for unsampled spans.
+//
+// Example:
+//
+//	otx.SetAttributesIfRecording(ctx, func() []attribute.KeyValue {
+//	    return []attribute.KeyValue{attribute.String("order.json", mustJSON(order))}
+//	})
+func SetAttributesIfRecording(ctx context.Context, fn func() []attribute.KeyValue) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	span.SetAttributes(fn()...)
+}
+
+// FollowFrom returns a start option linking the new span to the span in ctx with a
+// FOLLOWS_FROM reference (opentracing.ref_type=follows_from). Use it for
+// fire-and-forget work whose latency should not extend the caller's critical path.
+//
+// The link alone does not stop the new span from becoming a child of the span in
+// ctx; combine it with trace.WithNewRoot, or use StartFollowing. If ctx has no
+// valid span, the option adds no link.
+func FollowFrom(ctx context.Context) trace.SpanStartOption {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return trace.WithLinks()
+	}
+
+	return trace.WithLinks(trace.Link{
+		SpanContext: sc,
+		Attributes:  []attribute.KeyValue{attribute.String(AttrRefType, RefTypeFollowsFrom)},
+	})
+}
+
+// StartFollowing begins a new root span that follows from the span in ctx (see FollowFrom).
+// The returned context keeps the values of ctx, such as baggage.
+//
+// Example:
+//
+//	go func() {
+//	    ctx, span := otx.StartFollowing(context.WithoutCancel(ctx), "audit.publish")
+//	    defer span.End()
+//	    publishAudit(ctx, event)
+//	}()
+func StartFollowing(
+	ctx context.Context,
+	operation string,
+	opts ...trace.SpanStartOption,
+) (context.Context, trace.Span) {
+	opts = append([]trace.SpanStartOption{trace.WithNewRoot(), FollowFrom(ctx)}, opts...)
+
+	return Start(ctx, operation, opts...)
+}
+
+// StartDetached begins a span for work that outlives the caller, such as a
+// fire-and-forget goroutine. The returned context keeps the values of ctx, such
+// as baggage, but not its deadline or cancellation (see context.WithoutCancel),
+// so the work is not canceled when the request that started it completes.
+//
+// The span is a child of the span in ctx, keeping the work in the caller's trace.
+// To start a new trace linked to the caller instead, pass trace.WithNewRoot() and
+// FollowFrom(ctx), or use StartFollowing with a detached context.
+//
+// Example:
+//
+//	go func() {
+//	    ctx, span := otx.StartDetached(ctx, "cache.warm")
+//	    defer span.End()
+//	    warmCache(ctx, keys)
+//	}()
+func StartDetached(
+	ctx context.Context,
+	operation string,
+	opts ...trace.SpanStartOption,
+) (context.Context, trace.Span) {
+	return Start(context.WithoutCancel(ctx), operation, opts...)
+}
@@ -0,0 +1,136 @@
+package otx
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/arloliu/otx/internal/tracker"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// metricSpanDuration is the histogram of the durations of spans started by Start.
+const metricSpanDuration = "otx.span.duration"
+
+// Span duration metric attributes.
+const (
+	// attrOperation is the operation passed to Start, before the namer is applied.
+	attrOperation = "operation"
+
+	// attrSpanKind is the span kind, e.g. "server" or "internal".
+	attrSpanKind = "span.kind"
+)
+
+// spanDurationBuckets are the histogram boundaries, in seconds, of the span duration
+// metric: those of the semantic conventions for request durations.
+var spanDurationBuckets = []float64{
+	0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10,
+}
+
+// spanDuration is the histogram spans started by Start record to, nil when disabled.
+var spanDuration atomic.Pointer[metric.Float64Histogram]
+
+// EnableSpanMetrics makes every span started by [Start] and the StartXxx helpers
+// record its duration, in seconds, to the otx.span.duration histogram when it ends,
+// with the operation passed to Start as the operation attribute and the span kind
+// as the span.kind attribute. Code that is only traced gets latency and throughput
+// metrics per operation, including for spans that are not sampled.
+//
+// Operations become metric attributes, so they must have low cardinality; use
+// span attributes for IDs. If mp is nil, the global MeterProvider is used. It is
+// called by [NewTracerProvider] when traces.spanMetrics is enabled.
+//
+// Example:
+//
+//	otx.EnableSpanMetrics(meterProvider)
+//	defer otx.DisableSpanMetrics()
+func EnableSpanMetrics(mp metric.MeterProvider) {
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+
+	hist, err := mp.Meter(meterName).Float64Histogram(metricSpanDuration,
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of spans started by otx.Start."),
+		metric.WithExplicitBucketBoundaries(spanDurationBuckets...),
+	)
+	if err != nil {
+		otel.Handle(err)
+
+		return
+	}
+	spanDuration.Store(&hist)
+}
+
+// DisableSpanMetrics stops spans started afterwards from recording their duration.
+func DisableSpanMetrics() {
+	spanDuration.Store(nil)
+}
+
+// metricSpan is a span recording its duration to the span duration histogram when
+// it ends.
+type metricSpan struct {
+	trace.Span
+	hist  metric.Float64Histogram
+	start time.Time
+	attrs metric.MeasurementOption
+	ended atomic.Bool
+}
+
+// withSpanMetrics wraps a span started by Start to record its duration, and puts
+// the wrapper in ctx, if span metrics are enabled.
+func withSpanMetrics(
+	ctx context.Context,
+	span trace.Span,
+	start time.Time,
+	operation string,
+	opts []trace.SpanStartOption,
+) (context.Context, trace.Span) {
+	hist := spanDuration.Load()
+	if hist == nil {
+		return ctx, span
+	}
+
+	cfg := trace.NewSpanStartConfig(opts...)
+	kind := cfg.SpanKind()
+	if kind == trace.SpanKindUnspecified {
+		kind = tracker.InferKind(operation)
+	}
+	if kind == trace.SpanKindUnspecified {
+		kind = trace.SpanKindInternal
+	}
+	if !cfg.Timestamp().IsZero() {
+		start = cfg.Timestamp()
+	}
+
+	ms := &metricSpan{
+		Span:  span,
+		hist:  *hist,
+		start: start,
+		attrs: metric.WithAttributeSet(attribute.NewSet(
+			attribute.String(attrOperation, operation),
+			attribute.String(attrSpanKind, kind.String()),
+		)),
+	}
+
+	return trace.ContextWithSpan(ctx, ms), ms
+}
+
+// End ends the span and records its duration, once.
+func (s *metricSpan) End(opts ...trace.SpanEndOption) {
+	s.Span.End(opts...)
+	if s.ended.Swap(true) {
+		return
+	}
+
+	cfg := trace.NewSpanEndConfig(opts...)
+	end := cfg.Timestamp()
+	if end.IsZero() {
+		end = time.Now()
+	}
+	// The span context lets trace-based exemplars link to the span
+	s.hist.Record(trace.ContextWithSpan(context.Background(), s.Span), end.Sub(s.start).Seconds(), s.attrs)
+}
@@ -0,0 +1,90 @@
+package otx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// spanDurations collects the otx.span.duration data points by operation and kind.
+func spanDurations(t *testing.T, reader sdkmetric.Reader) map[[2]string]metricdata.HistogramDataPoint[float64] {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(t.Context(), &rm))
+
+	points := make(map[[2]string]metricdata.HistogramDataPoint[float64])
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			hist, ok := m.Data.(metricdata.Histogram[float64])
+			if m.Name != metricSpanDuration || !ok {
+				continue
+			}
+			for _, dp := range hist.DataPoints {
+				op, _ := dp.Attributes.Value(attrOperation)
+				kind, _ := dp.Attributes.Value(attrSpanKind)
+				points[[2]string{op.AsString(), kind.AsString()}] = dp
+			}
+		}
+	}
+
+	return points
+}
+
+func TestEnableSpanMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.NeverSample()))
+	InitTracing(tp.Tracer("otx"), nil)
+	EnableSpanMetrics(mp)
+	t.Cleanup(func() {
+		DisableSpanMetrics()
+		InitTracing(nil, nil)
+	})
+
+	start := time.Now().Add(-2 * time.Second)
+	ctx, span := Start(t.Context(), "ProcessOrder", trace.WithTimestamp(start))
+	assert.Same(t, span, trace.SpanFromContext(ctx), "the context holds the recording wrapper")
+	span.End(trace.WithTimestamp(start.Add(time.Second)))
+	span.End()
+
+	_, client := StartClient(ctx, "FetchRates")
+	client.End()
+	_, client = StartClient(ctx, "FetchRates")
+	client.End()
+
+	points := spanDurations(t, reader)
+	require.Len(t, points, 2)
+	order := points[[2]string{"ProcessOrder", "internal"}]
+	assert.Equal(t, uint64(1), order.Count, "ended twice, recorded once, unsampled span included")
+	assert.InDelta(t, 1.0, order.Sum, 0.001)
+	assert.Equal(t, uint64(2), points[[2]string{"FetchRates", "client"}].Count)
+
+	DisableSpanMetrics()
+	_, span = Start(t.Context(), "ProcessOrder")
+	span.End()
+	assert.Equal(t, uint64(1), spanDurations(t, reader)[[2]string{"ProcessOrder", "internal"}].Count)
+}
+
+func TestEnableSpanMetrics_InferredKind(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	tp := sdktrace.NewTracerProvider()
+	InitTracing(tp.Tracer("otx"), KindInferringNamer{})
+	EnableSpanMetrics(mp)
+	t.Cleanup(func() {
+		DisableSpanMetrics()
+		InitTracing(nil, nil)
+	})
+
+	_, span := Start(t.Context(), "publish orders")
+	span.End()
+
+	assert.Contains(t, spanDurations(t, reader), [2]string{"publish orders", "producer"})
+}
@@ -0,0 +1,271 @@
+package otx
+
+import (
+	"context"
+	"encoding/binary"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// EndedSpan describes a span that has ended, as delivered by a SpanRecorder.
+// Attributes must not be modified, since all subscribers share them.
+type EndedSpan struct {
+	Name              string
+	Kind              trace.SpanKind
+	TraceID           trace.TraceID
+	SpanID            trace.SpanID
+	ParentSpanID      trace.SpanID // Zero for root spans
+	Scope             string       // Instrumentation scope (tracer) name
+	StartTime         time.Time
+	EndTime           time.Time
+	StatusCode        codes.Code
+	StatusDescription string
+	Attributes        []attribute.KeyValue
+}
+
+// SpanRecorder is a SpanProcessor that delivers ended spans to in-process
+// subscribers, e.g. anomaly detectors alerting on first-seen span names or error
+// bursts. Register it like any processor with [WithSpanProcessor].
+//
+// Subscribers receive an [EndedSpan] instead of the SDK's ReadOnlySpan. Spans are
+// only converted while there are subscribers, so an idle recorder costs one atomic
+// load per span.
+type SpanRecorder struct {
+	mu      sync.Mutex                        // serializes subscription changes
+	subs    atomic.Pointer[[]*spanSubscriber] // copy-on-write, read by OnEnd
+	dropped atomic.Uint64
+}
+
+// spanSubscriber is one Subscribe or SubscribeChan registration.
+type spanSubscriber struct {
+	threshold uint64 // trace ID bound for sampling, see SampleRatio
+	sampled   bool
+	fn        func(EndedSpan)
+
+	mu     sync.Mutex // guards ch against close during send
+	ch     chan EndedSpan
+	closed bool
+}
+
+// SubscribeOption configures a SpanRecorder subscription.
+type SubscribeOption func(*spanSubscriber)
+
+// SampleRatio delivers only spans of traces selected with probability ratio, based
+// on the trace ID like the TraceIDRatioBased sampler, so a trace is delivered whole
+// or not at all. A ratio >= 1 delivers every span, and a ratio <= 0 none.
+func SampleRatio(ratio float64) SubscribeOption {
+	return func(s *spanSubscriber) {
+		s.sampled = ratio < 1
+		if ratio > 0 && ratio < 1 {
+			s.threshold = uint64(ratio * (1 << 63))
+		}
+	}
+}
+
+// NewSpanRecorder returns a SpanRecorder without subscribers.
+//
+// Example:
+//
+//	recorder := otx.NewSpanRecorder()
+//	tp, err := otx.NewTracerProvider(ctx, cfg, otx.WithSpanProcessor(recorder))
+//
+//	seen := map[string]bool{}
+//	recorder.Subscribe(func(s otx.EndedSpan) {
+//	    if !seen[s.Name] {
+//	        seen[s.Name] = true
+//	        log.Printf("first %q span", s.Name)
+//	    }
+//	})
+func NewSpanRecorder() *SpanRecorder {
+	return &SpanRecorder{}
+}
+
+// Subscribe calls fn for every ended span. fn runs synchronously on the goroutine
+// that ends the span, possibly concurrently for spans ended on different
+// goroutines, so it must be fast and safe for concurrent use.
+//
+// The returned function cancels the subscription. It is safe to call more than once.
+func (r *SpanRecorder) Subscribe(fn func(EndedSpan), opts ...SubscribeOption) func() {
+	sub := &spanSubscriber{fn: fn}
+	for _, opt := range opts {
+		opt(sub)
+	}
+
+	return r.add(sub)
+}
+
+// SubscribeChan delivers ended spans to a channel with the given buffer size.
+// Delivery never blocks: spans arriving while the buffer is full are dropped and
+// counted by [SpanRecorder.Dropped].
+//
+// The returned function cancels the subscription and closes the channel, as does
+// shutting the recorder down. It is safe to call more than once.
+//
+// Example:
+//
+//	spans, cancel := recorder.SubscribeChan(1024, otx.SampleRatio(0.1))
+//	defer cancel()
+//	for s := range spans {
+//	    if s.StatusCode == codes.Error {
+//	        errorBurst.Add(s.EndTime)
+//	    }
+//	}
+func (r *SpanRecorder) SubscribeChan(size int, opts ...SubscribeOption) (<-chan EndedSpan, func()) {
+	sub := &spanSubscriber{ch: make(chan EndedSpan, max(size, 0))}
+	for _, opt := range opts {
+		opt(sub)
+	}
+
+	return sub.ch, r.add(sub)
+}
+
+// Dropped returns how many spans were dropped because a subscriber channel was full.
+func (r *SpanRecorder) Dropped() uint64 {
+	return r.dropped.Load()
+}
+
+// add registers sub and returns the function removing it.
+func (r *SpanRecorder) add(sub *spanSubscriber) func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var subs []*spanSubscriber
+	if current := r.subs.Load(); current != nil {
+		subs = slices.Clone(*current)
+	}
+	subs = append(subs, sub)
+	r.subs.Store(&subs)
+
+	return func() { r.remove(sub) }
+}
+
+// remove unregisters sub and closes its channel, if any.
+func (r *SpanRecorder) remove(sub *spanSubscriber) {
+	r.mu.Lock()
+	if current := r.subs.Load(); current != nil {
+		subs := slices.DeleteFunc(slices.Clone(*current), func(s *spanSubscriber) bool { return s == sub })
+		r.subs.Store(&subs)
+	}
+	r.mu.Unlock()
+
+	sub.close()
+}
+
+// OnStart implements sdktrace.SpanProcessor.
+func (*SpanRecorder) OnStart(_ context.Context, _ sdktrace.ReadWriteSpan) {}
+
+// OnEnd implements sdktrace.SpanProcessor.
+func (r *SpanRecorder) OnEnd(s sdktrace.ReadOnlySpan) {
+	subs := r.subs.Load()
+	if subs == nil || len(*subs) == 0 {
+		return
+	}
+
+	var (
+		ended     EndedSpan
+		converted bool
+	)
+	for _, sub := range *subs {
+		if !sub.accepts(s.SpanContext().TraceID()) {
+			continue
+		}
+		if !converted {
+			ended, converted = newEndedSpan(s), true
+		}
+		if sub.fn != nil {
+			sub.fn(ended)
+		} else if !sub.send(ended) {
+			r.dropped.Add(1)
+		}
+	}
+}
+
+// Shutdown implements sdktrace.SpanProcessor. It removes all subscriptions and
+// closes their channels.
+func (r *SpanRecorder) Shutdown(_ context.Context) error {
+	r.mu.Lock()
+	subs := r.subs.Swap(nil)
+	r.mu.Unlock()
+
+	if subs != nil {
+		for _, sub := range *subs {
+			sub.close()
+		}
+	}
+
+	return nil
+}
+
+// ForceFlush implements sdktrace.SpanProcessor.
+func (*SpanRecorder) ForceFlush(_ context.Context) error {
+	return nil
+}
+
+// accepts reports whether spans of traceID pass the subscription's sampling.
+func (s *spanSubscriber) accepts(traceID trace.TraceID) bool {
+	if !s.sampled {
+		return true
+	}
+
+	return binary.BigEndian.Uint64(traceID[8:16])>>1 < s.threshold
+}
+
+// send delivers span to the channel without blocking. It returns false if the
+// span was dropped because the channel is full.
+func (s *spanSubscriber) send(span EndedSpan) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return true
+	}
+	select {
+	case s.ch <- span:
+		return true
+	default:
+		return false
+	}
+}
+
+// close closes the subscriber's channel, if any, once.
+func (s *spanSubscriber) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ch != nil && !s.closed {
+		close(s.ch)
+	}
+	s.closed = true
+}
+
+// newEndedSpan converts s into an EndedSpan.
+func newEndedSpan(s sdktrace.ReadOnlySpan) EndedSpan {
+	sc := s.SpanContext()
+	status := s.Status()
+
+	return EndedSpan{
+		Name:              s.Name(),
+		Kind:              s.SpanKind(),
+		TraceID:           sc.TraceID(),
+		SpanID:            sc.SpanID(),
+		ParentSpanID:      s.Parent().SpanID(),
+		Scope:             s.InstrumentationScope().Name,
+		StartTime:         s.StartTime(),
+		EndTime:           s.EndTime(),
+		StatusCode:        status.Code,
+		StatusDescription: status.Description,
+		Attributes:        s.Attributes(),
+	}
+}
+
+// Duration returns how long the span was open.
+func (s EndedSpan) Duration() time.Duration {
+	return s.EndTime.Sub(s.StartTime)
+}
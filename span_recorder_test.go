@@ -0,0 +1,102 @@
+package otx
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestSpanRecorder_Subscribe(t *testing.T) {
+	recorder := NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := tp.Tracer("checkout")
+
+	var (
+		mu    sync.Mutex
+		ended []EndedSpan
+	)
+	cancel := recorder.Subscribe(func(s EndedSpan) {
+		mu.Lock()
+		ended = append(ended, s)
+		mu.Unlock()
+	})
+
+	ctx, parent := tracer.Start(t.Context(), "parent")
+	_, child := tracer.Start(ctx, "child")
+	child.SetAttributes(attribute.String("order.id", "42"))
+	child.RecordError(errors.New("boom"))
+	child.SetStatus(codes.Error, "boom")
+	child.End()
+	parent.End()
+
+	require.Len(t, ended, 2)
+	got := ended[0]
+	assert.Equal(t, "child", got.Name)
+	assert.Equal(t, "checkout", got.Scope)
+	assert.Equal(t, parent.SpanContext().SpanID(), got.ParentSpanID)
+	assert.Equal(t, parent.SpanContext().TraceID(), got.TraceID)
+	assert.Equal(t, codes.Error, got.StatusCode)
+	assert.Equal(t, "boom", got.StatusDescription)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("order.id", "42")}, got.Attributes)
+	assert.Equal(t, got.EndTime.Sub(got.StartTime), got.Duration())
+	assert.False(t, ended[1].ParentSpanID.IsValid(), "root span has no parent")
+
+	cancel()
+	cancel()
+	_, span := tracer.Start(t.Context(), "after-cancel")
+	span.End()
+	assert.Len(t, ended, 2)
+}
+
+func TestSpanRecorder_SubscribeChan(t *testing.T) {
+	recorder := NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := tp.Tracer("test")
+
+	spans, cancel := recorder.SubscribeChan(1)
+	for _, name := range []string{"first", "second"} {
+		_, span := tracer.Start(t.Context(), name)
+		span.End()
+	}
+
+	assert.Equal(t, "first", (<-spans).Name)
+	assert.Equal(t, uint64(1), recorder.Dropped(), "the full channel drops instead of blocking")
+
+	cancel()
+	_, open := <-spans
+	assert.False(t, open, "cancel closes the channel")
+
+	spans, _ = recorder.SubscribeChan(1)
+	require.NoError(t, tp.Shutdown(t.Context()))
+	_, open = <-spans
+	assert.False(t, open, "shutdown closes the channel")
+}
+
+func TestSpanRecorder_SampleRatio(t *testing.T) {
+	recorder := NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := tp.Tracer("test")
+
+	var none, all, half int
+	recorder.Subscribe(func(EndedSpan) { none++ }, SampleRatio(0))
+	recorder.Subscribe(func(EndedSpan) { all++ }, SampleRatio(1))
+	recorder.Subscribe(func(EndedSpan) { half++ }, SampleRatio(0.5))
+
+	for range 1000 {
+		ctx, parent := tracer.Start(t.Context(), "parent")
+		_, child := tracer.Start(ctx, "child")
+		child.End()
+		parent.End()
+	}
+
+	assert.Zero(t, none)
+	assert.Equal(t, 2000, all)
+	assert.InDelta(t, 1000, half, 200)
+	assert.Zero(t, half%2, "traces are delivered whole")
+}
@@ -0,0 +1,126 @@
+package otx
+
+import (
+	"sync"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// spanKey identifies a span within the process.
+type spanKey struct {
+	traceID trace.TraceID
+	spanID  trace.SpanID
+}
+
+// spanTable holds per-span state of type V for the spans a processor has seen
+// start but not end. It is the OnStart/OnEnd bookkeeping shared by the processors
+// that track open spans, and reports their number for draining on Reconfigure.
+// The zero value is an empty table.
+type spanTable[V any] struct {
+	mu    sync.Mutex
+	spans map[spanKey]V
+}
+
+// keyOf returns the table key of s.
+func keyOf(s sdktrace.ReadOnlySpan) spanKey {
+	sc := s.SpanContext()
+
+	return spanKey{traceID: sc.TraceID(), spanID: sc.SpanID()}
+}
+
+// parentKeyOf returns the table key of the parent of s, which is only tracked if
+// the parent is local.
+func parentKeyOf(s sdktrace.ReadOnlySpan) spanKey {
+	return spanKey{traceID: s.SpanContext().TraceID(), spanID: s.Parent().SpanID()}
+}
+
+// start adds s with the state returned by newState. newState runs under the table
+// lock, so state that refers back to the table, such as a timer, cannot observe
+// the table before s is added.
+func (t *spanTable[V]) start(s sdktrace.ReadOnlySpan, newState func() V) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.spans == nil {
+		t.spans = make(map[spanKey]V)
+	}
+	t.spans[keyOf(s)] = newState()
+}
+
+// end removes s and, if fn is not nil, calls it with the removed state under the
+// table lock. fn reads the state of other open spans, e.g. the parent of s, with
+// open, and may update it without racing with their other children.
+func (t *spanTable[V]) end(s sdktrace.ReadOnlySpan, fn func(state V, ok bool, open func(spanKey) (V, bool))) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := keyOf(s)
+	state, ok := t.spans[key]
+	delete(t.spans, key)
+	if fn == nil {
+		return
+	}
+
+	fn(state, ok, func(k spanKey) (V, bool) {
+		v, found := t.spans[k]
+
+		return v, found
+	})
+}
+
+// remove removes key and returns its state, if it was open.
+func (t *spanTable[V]) remove(key spanKey) (V, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.spans[key]
+	delete(t.spans, key)
+
+	return state, ok
+}
+
+// read calls fn with the state of the open span key under the table lock, so fn
+// can copy state that end callbacks update. It reports whether key is open.
+func (t *spanTable[V]) read(key spanKey, fn func(state V)) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.spans[key]
+	if ok {
+		fn(state)
+	}
+
+	return ok
+}
+
+// each calls fn for every open span under the table lock.
+func (t *spanTable[V]) each(fn func(key spanKey, state V)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for key, state := range t.spans {
+		fn(key, state)
+	}
+}
+
+// reset forgets every open span, calling release, if not nil, with each state.
+func (t *spanTable[V]) reset(release func(V)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if release != nil {
+		for _, state := range t.spans {
+			release(state)
+		}
+	}
+	clear(t.spans)
+}
+
+// len returns the number of open spans.
+func (t *spanTable[V]) len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return len(t.spans)
+}
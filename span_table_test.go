@@ -0,0 +1,50 @@
+package otx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSpanTable(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+	ctx, parent := tracer.Start(t.Context(), "parent")
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+	parent.End()
+	ended := recorder.Ended()
+	require.Len(t, ended, 2)
+	childSpan, parentSpan := ended[0], ended[1]
+
+	var table spanTable[string]
+	assert.Zero(t, table.len())
+	table.start(parentSpan, func() string { return "parent" })
+	table.start(childSpan, func() string { return "child" })
+	assert.Equal(t, 2, table.len())
+
+	table.end(childSpan, func(state string, ok bool, open func(spanKey) (string, bool)) {
+		assert.True(t, ok)
+		assert.Equal(t, "child", state)
+		parentState, found := open(parentKeyOf(childSpan))
+		assert.True(t, found)
+		assert.Equal(t, "parent", parentState)
+		_, found = open(keyOf(childSpan))
+		assert.False(t, found, "the ended span is removed before fn runs")
+	})
+	assert.Equal(t, 1, table.len())
+
+	assert.True(t, table.read(keyOf(parentSpan), func(state string) { assert.Equal(t, "parent", state) }))
+	assert.False(t, table.read(keyOf(childSpan), func(string) { t.Error("read an ended span") }))
+
+	var released []string
+	table.reset(func(state string) { released = append(released, state) })
+	assert.Equal(t, []string{"parent"}, released)
+	assert.Zero(t, table.len())
+
+	_, ok := table.remove(keyOf(parentSpan))
+	assert.False(t, ok)
+}
@@ -2,11 +2,16 @@ package otx
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestSpanHelpers(t *testing.T) {
@@ -103,3 +108,159 @@ func TestInitTracing_NilTracer(t *testing.T) {
 	// With nil tracer, Start returns the span from context (which is a no-op span)
 	assert.Equal(t, ctx, ctx2) // context unchanged when tracer is nil
 }
+
+func TestStartFollowing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer func() { _ = tp.Shutdown(t.Context()) }()
+	InitTracing(tp.Tracer("otx"), nil)
+	defer InitTracing(nil, nil)
+
+	ctx, parent := Start(MustSetBaggage(t.Context(), "tenant.id", "acme"), "request")
+	followCtx, follower := StartFollowing(ctx, "publish")
+	follower.End()
+	parent.End()
+
+	assert.Equal(t, "acme", GetBaggage(followCtx, "tenant.id"))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	publish, request := spans[0], spans[1]
+	assert.False(t, publish.Parent.IsValid(), "follower is a new root")
+	assert.NotEqual(t, request.SpanContext.TraceID(), publish.SpanContext.TraceID())
+	require.Len(t, publish.Links, 1)
+	assert.Equal(t, request.SpanContext, publish.Links[0].SpanContext)
+	assert.True(t, hasAttribute(publish.Links[0].Attributes, attribute.String(AttrRefType, RefTypeFollowsFrom)))
+
+	_, orphan := StartFollowing(t.Context(), "orphan")
+	orphan.End()
+	require.Len(t, exporter.GetSpans(), 3)
+	assert.Empty(t, exporter.GetSpans()[2].Links, "no link without a span in ctx")
+}
+
+func TestStartDetached(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer func() { _ = tp.Shutdown(t.Context()) }()
+	InitTracing(tp.Tracer("otx"), nil)
+	defer InitTracing(nil, nil)
+
+	reqCtx, cancel := context.WithCancel(MustSetBaggage(t.Context(), "tenant.id", "acme"))
+	reqCtx, parent := Start(reqCtx, "request")
+	detachedCtx, detached := StartDetached(reqCtx, "warm")
+	parent.End()
+	cancel()
+
+	require.NoError(t, detachedCtx.Err(), "the caller's cancellation does not reach detached work")
+	assert.Equal(t, "acme", GetBaggage(detachedCtx, "tenant.id"))
+	detached.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	request, warm := spans[0], spans[1]
+	assert.Equal(t, request.SpanContext.SpanID(), warm.Parent.SpanID(), "detached span keeps its parent")
+	assert.Equal(t, request.SpanContext.TraceID(), warm.SpanContext.TraceID())
+
+	_, linked := StartDetached(reqCtx, "linked", trace.WithNewRoot(), FollowFrom(reqCtx))
+	linked.End()
+	require.Len(t, exporter.GetSpans(), 3)
+	assert.False(t, exporter.GetSpans()[2].Parent.IsValid())
+	require.Len(t, exporter.GetSpans()[2].Links, 1)
+}
+
+func TestRecordError_StackTrace(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	SetErrorStackTrace(true)
+	defer SetErrorStackTrace(false)
+
+	ctx, span := tp.Tracer("test").Start(t.Context(), "stack")
+	RecordError(ctx, errBoom)
+	RecordError(ctx, errBoom, trace.WithStackTrace(false))
+	span.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.True(t, hasAttribute(spans[0].Attributes, attribute.String("error.type", "*errors.errorString")))
+	require.Len(t, spans[0].Events, 2)
+	assert.True(t, hasKey(spans[0].Events[0].Attributes, "exception.stacktrace"))
+	assert.False(t, hasKey(spans[0].Events[1].Attributes, "exception.stacktrace"), "per-call option wins")
+
+	SetErrorStackTrace(false)
+	ctx, span = tp.Tracer("test").Start(t.Context(), "plain")
+	RecordError(ctx, errBoom)
+	span.End()
+	plain := exporter.GetSpans()[1]
+	assert.False(t, hasKey(plain.Attributes, "error.type"))
+	assert.False(t, hasKey(plain.Events[0].Attributes, "exception.stacktrace"))
+}
+
+func TestSetAttributesIfRecording(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.AlwaysSample())),
+	)
+	tracer := tp.Tracer("test")
+
+	calls := 0
+	attrs := func() []attribute.KeyValue {
+		calls++
+
+		return []attribute.KeyValue{attribute.String("payload", "expensive")}
+	}
+
+	ctx, span := tracer.Start(t.Context(), "sampled")
+	assert.True(t, IsRecording(ctx))
+	SetAttributesIfRecording(ctx, attrs)
+	span.End()
+
+	unsampled := trace.ContextWithSpanContext(t.Context(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: mustTraceID(t, "0af7651916cd43dd8448eb211c80319c"),
+		SpanID:  mustSpanID(t, "b7ad6b7169203331"),
+	}))
+	ctx, span = tracer.Start(unsampled, "unsampled")
+	assert.False(t, IsRecording(ctx))
+	SetAttributesIfRecording(ctx, attrs)
+	span.End()
+
+	assert.False(t, IsRecording(t.Context()))
+	SetAttributesIfRecording(t.Context(), attrs)
+
+	assert.Equal(t, 1, calls, "fn is only called for recording spans")
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.True(t, hasAttribute(spans[0].Attributes, attribute.String("payload", "expensive")))
+}
+
+func TestRecordError_ErrorBaggage(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	SetErrorBaggage("tenant.id", "request.*")
+	defer SetErrorBaggage()
+
+	ctx := MustSetBaggage(t.Context(), "tenant.id", "acme")
+	ctx = MustSetBaggage(ctx, "request.path", "/orders")
+	ctx = MustSetBaggage(ctx, "session.token", "secret")
+
+	ctx, span := tp.Tracer("test").Start(ctx, "ok")
+	span.End()
+	ctx, span = tp.Tracer("test").Start(ctx, "failed")
+	RecordError(ctx, errors.New("boom"))
+	span.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Empty(t, spans[0].Attributes, "spans without errors get no baggage")
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String("baggage.tenant.id", "acme"),
+		attribute.String("baggage.request.path", "/orders"),
+	}, spans[1].Attributes)
+
+	SetErrorBaggage()
+	ctx, span = tp.Tracer("test").Start(ctx, "disabled")
+	RecordError(ctx, errors.New("boom"))
+	span.End()
+	require.Len(t, exporter.GetSpans(), 3)
+	assert.Empty(t, exporter.GetSpans()[2].Attributes)
+}
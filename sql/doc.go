@@ -0,0 +1,24 @@
+// Package sql provides OpenTelemetry instrumentation for database/sql.
+//
+// # Opening a Database
+//
+// Open and OpenDB return a *sql.DB whose queries and statements create client
+// spans named after the SQL operation, e.g. "SELECT", with db.system,
+// db.operation and db.statement attributes:
+//
+//	db, err := otxsql.Open("pgx", dsn, otxsql.WithDBSystem("postgresql"))
+//	if err != nil {
+//	    return err
+//	}
+//	rows, err := db.QueryContext(ctx, "SELECT id FROM orders WHERE user_id = $1", userID)
+//
+// # Database-Side Correlation
+//
+// WithSQLComment appends the trace context as a sqlcommenter comment, so
+// database tools such as Cloud SQL Insights link statements to traces:
+//
+//	db, err := otxsql.Open("pgx", dsn, otxsql.WithSQLComment(true))
+//	// SELECT id FROM orders /*traceparent='00-4bf92f...-00f067...-01'*/
+//
+// Use otx.SQLComment to add the comment to queries sent through other clients.
+package sql
@@ -0,0 +1,408 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/arloliu/otx"
+)
+
+var (
+	// ErrTxOptionsUnsupported is returned when a transaction with options is begun
+	// on a driver that does not implement driver.ConnBeginTx.
+	ErrTxOptionsUnsupported = errors.New("otxsql: driver does not support transaction options")
+
+	// ErrNamedArgsUnsupported is returned when named arguments are passed to a
+	// driver without context support, which only takes positional arguments.
+	ErrNamedArgsUnsupported = errors.New("otxsql: driver does not support named arguments")
+)
+
+// Open opens a database like sql.Open, with client spans for queries and
+// statements using the global TracerProvider.
+//
+// Usage:
+//
+//	db, err := otxsql.Open("pgx", dsn,
+//	    otxsql.WithDBSystem("postgresql"),
+//	    otxsql.WithSQLComment(true),
+//	)
+func Open(driverName, dsn string, opts ...Option) (*sql.DB, error) {
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+	d := db.Driver()
+	if err := db.Close(); err != nil {
+		return nil, err
+	}
+
+	var connector driver.Connector = dsnConnector{dsn: dsn, driver: d}
+	if dc, ok := d.(driver.DriverContext); ok {
+		if connector, err = dc.OpenConnector(dsn); err != nil {
+			return nil, err
+		}
+	}
+
+	return OpenDB(connector, opts...), nil
+}
+
+// OpenDB opens a database from a connector like sql.OpenDB, with client spans for
+// queries and statements using the global TracerProvider.
+func OpenDB(c driver.Connector, opts ...Option) *sql.DB {
+	return OpenDBWithProviders(c, nil, opts...)
+}
+
+// OpenDBWithProviders opens a database from a connector like sql.OpenDB, with
+// client spans for queries and statements using tp.
+// If tp is nil, the global TracerProvider is used.
+func OpenDBWithProviders(c driver.Connector, tp trace.TracerProvider, opts ...Option) *sql.DB {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+
+	return sql.OpenDB(&connector{
+		base:   c,
+		tracer: tp.Tracer(o.tracerName),
+		opts:   o,
+	})
+}
+
+// dsnConnector is the connector of drivers not implementing driver.DriverContext.
+type dsnConnector struct {
+	dsn    string
+	driver driver.Driver
+}
+
+// Connect implements driver.Connector.
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+// Driver implements driver.Connector.
+func (c dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// connector wraps the connections of base.
+type connector struct {
+	base   driver.Connector
+	tracer trace.Tracer
+	opts   options
+}
+
+// Connect implements driver.Connector.
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	cn, err := c.base.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &conn{base: cn, connector: c}, nil
+}
+
+// Driver implements driver.Connector.
+func (c *connector) Driver() driver.Driver {
+	return c.base.Driver()
+}
+
+// start starts the client span of query.
+func (c *connector) start(
+	ctx context.Context, query string, opts ...trace.SpanStartOption,
+) (context.Context, trace.Span) {
+	operation := queryOperation(query)
+	attrs := make([]attribute.KeyValue, 0, 3)
+	if c.opts.system.Valid() {
+		attrs = append(attrs, c.opts.system)
+	}
+	if operation != "" {
+		attrs = append(attrs, semconv.DBOperation(operation))
+	}
+	if c.opts.queryText {
+		attrs = append(attrs, semconv.DBStatement(query))
+	}
+
+	name := operation
+	if name == "" {
+		name = "sql.query"
+	}
+
+	opts = append(opts, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+
+	return c.tracer.Start(ctx, name, opts...)
+}
+
+// record records the span of a query sent without preparing, which started at
+// start. The span is created after the call because drivers may return
+// driver.ErrSkip, making database/sql prepare the query instead.
+func (c *connector) record(ctx context.Context, query string, start time.Time, err error) {
+	_, span := c.start(ctx, query, trace.WithTimestamp(start))
+	end(span, err)
+}
+
+// end ends span, recording err.
+func end(span trace.Span, err error) {
+	if err != nil {
+		otx.RecordSpanError(span, err)
+	}
+	span.End()
+}
+
+// queryOperation returns the upper-cased first keyword of query, e.g. "SELECT".
+func queryOperation(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	return strings.ToUpper(strings.TrimSuffix(fields[0], ";"))
+}
+
+// conn traces the queries and statements of base.
+type conn struct {
+	base      driver.Conn
+	connector *connector
+}
+
+var (
+	_ driver.ExecerContext      = (*conn)(nil)
+	_ driver.QueryerContext     = (*conn)(nil)
+	_ driver.ConnPrepareContext = (*conn)(nil)
+	_ driver.ConnBeginTx        = (*conn)(nil)
+	_ driver.Pinger             = (*conn)(nil)
+	_ driver.SessionResetter    = (*conn)(nil)
+	_ driver.Validator          = (*conn)(nil)
+	_ driver.NamedValueChecker  = (*conn)(nil)
+)
+
+// Prepare implements driver.Conn.
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+// PrepareContext implements driver.ConnPrepareContext.
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var (
+		st  driver.Stmt
+		err error
+	)
+	if p, ok := c.base.(driver.ConnPrepareContext); ok {
+		st, err = p.PrepareContext(ctx, query)
+	} else {
+		st, err = c.base.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &stmt{base: st, query: query, connector: c.connector}, nil
+}
+
+// Close implements driver.Conn.
+func (c *conn) Close() error {
+	return c.base.Close()
+}
+
+// Begin implements driver.Conn.
+func (c *conn) Begin() (driver.Tx, error) {
+	return c.base.Begin() //nolint:staticcheck // required by driver.Conn
+}
+
+// BeginTx implements driver.ConnBeginTx.
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if b, ok := c.base.(driver.ConnBeginTx); ok {
+		return b.BeginTx(ctx, opts)
+	}
+	if opts.Isolation != driver.IsolationLevel(sql.LevelDefault) || opts.ReadOnly {
+		return nil, ErrTxOptionsUnsupported
+	}
+
+	return c.base.Begin() //nolint:staticcheck // fallback for drivers without BeginTx
+}
+
+// ExecContext implements driver.ExecerContext. Drivers without it make
+// database/sql prepare the statement, which is traced by stmt.
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	e, ok := c.base.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	start := time.Now()
+	sent := query
+	if c.connector.opts.comment {
+		sent = otx.SQLComment(ctx, query)
+	}
+	res, err := e.ExecContext(ctx, sent, args)
+	if errors.Is(err, driver.ErrSkip) {
+		return nil, err
+	}
+	c.connector.record(ctx, query, start, err)
+
+	return res, err
+}
+
+// QueryContext implements driver.QueryerContext. The span ends when the query
+// returns, before the rows are read.
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	q, ok := c.base.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	start := time.Now()
+	sent := query
+	if c.connector.opts.comment {
+		sent = otx.SQLComment(ctx, query)
+	}
+	rows, err := q.QueryContext(ctx, sent, args)
+	if errors.Is(err, driver.ErrSkip) {
+		return nil, err
+	}
+	c.connector.record(ctx, query, start, err)
+
+	return rows, err
+}
+
+// Ping implements driver.Pinger.
+func (c *conn) Ping(ctx context.Context) error {
+	if p, ok := c.base.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+
+	return nil
+}
+
+// ResetSession implements driver.SessionResetter.
+func (c *conn) ResetSession(ctx context.Context) error {
+	if r, ok := c.base.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+
+	return nil
+}
+
+// IsValid implements driver.Validator.
+func (c *conn) IsValid() bool {
+	if v, ok := c.base.(driver.Validator); ok {
+		return v.IsValid()
+	}
+
+	return true
+}
+
+// CheckNamedValue implements driver.NamedValueChecker.
+func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
+	if n, ok := c.base.(driver.NamedValueChecker); ok {
+		return n.CheckNamedValue(nv)
+	}
+
+	return driver.ErrSkip
+}
+
+// stmt traces the executions of a prepared statement.
+type stmt struct {
+	base      driver.Stmt
+	query     string
+	connector *connector
+}
+
+var (
+	_ driver.StmtExecContext   = (*stmt)(nil)
+	_ driver.StmtQueryContext  = (*stmt)(nil)
+	_ driver.NamedValueChecker = (*stmt)(nil)
+)
+
+// Close implements driver.Stmt.
+func (s *stmt) Close() error {
+	return s.base.Close()
+}
+
+// NumInput implements driver.Stmt.
+func (s *stmt) NumInput() int {
+	return s.base.NumInput()
+}
+
+// Exec implements driver.Stmt.
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.base.Exec(args) //nolint:staticcheck // required by driver.Stmt
+}
+
+// Query implements driver.Stmt.
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.base.Query(args) //nolint:staticcheck // required by driver.Stmt
+}
+
+// ExecContext implements driver.StmtExecContext.
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	ctx, span := s.connector.start(ctx, s.query)
+	var (
+		res driver.Result
+		err error
+	)
+	if e, ok := s.base.(driver.StmtExecContext); ok {
+		res, err = e.ExecContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = namedValues(args); err == nil {
+			res, err = s.base.Exec(values) //nolint:staticcheck // fallback for drivers without ExecContext
+		}
+	}
+	end(span, err)
+
+	return res, err
+}
+
+// QueryContext implements driver.StmtQueryContext.
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	ctx, span := s.connector.start(ctx, s.query)
+	var (
+		rows driver.Rows
+		err  error
+	)
+	if q, ok := s.base.(driver.StmtQueryContext); ok {
+		rows, err = q.QueryContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = namedValues(args); err == nil {
+			rows, err = s.base.Query(values) //nolint:staticcheck // fallback for drivers without QueryContext
+		}
+	}
+	end(span, err)
+
+	return rows, err
+}
+
+// CheckNamedValue implements driver.NamedValueChecker.
+func (s *stmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if n, ok := s.base.(driver.NamedValueChecker); ok {
+		return n.CheckNamedValue(nv)
+	}
+
+	return driver.ErrSkip
+}
+
+// namedValues converts positional arguments for drivers without context support.
+func namedValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, ErrNamedArgsUnsupported
+		}
+		values[i] = arg.Value
+	}
+
+	return values, nil
+}
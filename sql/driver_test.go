@@ -0,0 +1,274 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/arloliu/otx"
+)
+
+var errBoom = errors.New("boom")
+
+// fakeDriver records the queries it receives. Without context support, it
+// only implements driver.Conn, so database/sql prepares every query.
+type fakeDriver struct {
+	mu        sync.Mutex
+	queries   []string
+	context   bool // Implement the context interfaces
+	skipExec  bool // Return driver.ErrSkip from ExecContext, like MySQL with args
+	failQuery bool
+}
+
+func (d *fakeDriver) record(query string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.queries = append(d.queries, query)
+}
+
+func (d *fakeDriver) Queries() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return append([]string(nil), d.queries...)
+}
+
+func (d *fakeDriver) Connect(context.Context) (driver.Conn, error) {
+	if d.context {
+		return &fakeContextConn{fakeConn{d}}, nil
+	}
+
+	return &fakeConn{d}, nil
+}
+
+func (d *fakeDriver) Driver() driver.Driver { return nil }
+
+type fakeConn struct{ d *fakeDriver }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) { return &fakeStmt{c.d, query}, nil }
+func (c *fakeConn) Close() error                              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)                 { return fakeTx{}, nil }
+
+type fakeContextConn struct{ fakeConn }
+
+func (c *fakeContextConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	if c.d.skipExec {
+		return nil, driver.ErrSkip
+	}
+	c.d.record(query)
+
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeContextConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	c.d.record(query)
+	if c.d.failQuery {
+		return nil, errBoom
+	}
+
+	return &fakeRows{}, nil
+}
+
+type fakeStmt struct {
+	d     *fakeDriver
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	s.d.record(s.query)
+
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	s.d.record(s.query)
+
+	return &fakeRows{}, nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeRows struct{ done bool }
+
+func (r *fakeRows) Columns() []string { return []string{"id"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = int64(1)
+
+	return nil
+}
+
+func openDB(t *testing.T, d *fakeDriver, opts ...Option) (*sql.DB, *tracetest.InMemoryExporter) {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	db := OpenDBWithProviders(d, tp, opts...)
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db, exporter
+}
+
+func attr(span tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+
+	return attribute.Value{}
+}
+
+func TestOpenDB_Query(t *testing.T) {
+	d := &fakeDriver{context: true}
+	db, exporter := openDB(t, d, WithDBSystem("postgresql"))
+
+	var id int
+	require.NoError(t, db.QueryRowContext(t.Context(), "select id from orders").Scan(&id))
+	assert.Equal(t, 1, id)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "SELECT", span.Name)
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind)
+	assert.Equal(t, "postgresql", attr(span, "db.system").AsString())
+	assert.Equal(t, "SELECT", attr(span, "db.operation").AsString())
+	assert.Equal(t, "select id from orders", attr(span, "db.statement").AsString())
+}
+
+func TestOpenDB_QueryError(t *testing.T) {
+	d := &fakeDriver{context: true, failQuery: true}
+	db, exporter := openDB(t, d, WithQueryText(false))
+
+	_, err := db.QueryContext(t.Context(), "SELECT 1")
+	require.ErrorIs(t, err, errBoom)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, attribute.INVALID, attr(spans[0], "db.statement").Type(), "query text disabled")
+}
+
+func TestOpenDB_QueryErrorClassified(t *testing.T) {
+	otx.SetErrorClassifier(func(error) ([]attribute.KeyValue, bool) {
+		return []attribute.KeyValue{attribute.String("db.error_class", "expected")}, false
+	})
+	t.Cleanup(func() { otx.SetErrorClassifier(nil) })
+
+	d := &fakeDriver{context: true, failQuery: true}
+	db, exporter := openDB(t, d)
+
+	_, err := db.QueryContext(t.Context(), "SELECT 1")
+	require.ErrorIs(t, err, errBoom)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	assert.Equal(t, "expected", attr(spans[0], "db.error_class").AsString())
+}
+
+func TestOpenDB_Prepared(t *testing.T) {
+	d := &fakeDriver{}
+	db, exporter := openDB(t, d, WithSQLComment(true))
+
+	stmt, err := db.PrepareContext(t.Context(), "INSERT INTO orders VALUES (?)")
+	require.NoError(t, err)
+	defer stmt.Close()
+	_, err = stmt.ExecContext(t.Context(), 1)
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "INSERT", spans[0].Name)
+	assert.Equal(t, []string{"INSERT INTO orders VALUES (?)"}, d.Queries(), "prepared statements are not commented")
+}
+
+func TestOpenDB_ErrSkip(t *testing.T) {
+	d := &fakeDriver{context: true, skipExec: true}
+	db, exporter := openDB(t, d)
+
+	_, err := db.ExecContext(t.Context(), "DELETE FROM orders WHERE id = ?", 1)
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1, "the skipped call records no span of its own")
+	assert.Equal(t, "DELETE", spans[0].Name)
+}
+
+func TestOpenDB_SQLComment(t *testing.T) {
+	d := &fakeDriver{context: true}
+	db, exporter := openDB(t, d, WithSQLComment(true))
+
+	tp := sdktrace.NewTracerProvider()
+	ctx, parent := tp.Tracer("test").Start(t.Context(), "parent")
+	defer parent.End()
+
+	_, err := db.ExecContext(ctx, "UPDATE orders SET paid = true;")
+	require.NoError(t, err)
+	_, err = db.ExecContext(t.Context(), "UPDATE orders SET paid = false")
+	require.NoError(t, err)
+
+	queries := d.Queries()
+	require.Len(t, queries, 2)
+	assert.True(t, strings.HasPrefix(queries[0], "UPDATE orders SET paid = true /*traceparent='00-"), queries[0])
+	assert.Contains(t, queries[0], parent.SpanContext().TraceID().String())
+	assert.True(t, strings.HasSuffix(queries[0], "*/;"), queries[0])
+	assert.Equal(t, "UPDATE orders SET paid = false", queries[1], "no comment without a span")
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "UPDATE orders SET paid = true;", attr(spans[0], "db.statement").AsString())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+}
+
+func TestOpenDB_Transaction(t *testing.T) {
+	db, _ := openDB(t, &fakeDriver{})
+
+	tx, err := db.BeginTx(t.Context(), nil)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+
+	_, err = db.BeginTx(t.Context(), &sql.TxOptions{ReadOnly: true})
+	require.ErrorIs(t, err, ErrTxOptionsUnsupported)
+	require.NoError(t, db.PingContext(t.Context()))
+}
+
+func TestNamedValues(t *testing.T) {
+	values, err := namedValues([]driver.NamedValue{{Ordinal: 1, Value: "acme"}})
+	require.NoError(t, err)
+	assert.Equal(t, []driver.Value{"acme"}, values)
+
+	_, err = namedValues([]driver.NamedValue{{Name: "tenant", Ordinal: 1, Value: "acme"}})
+	require.ErrorIs(t, err, ErrNamedArgsUnsupported)
+}
+
+func TestQueryOperation(t *testing.T) {
+	assert.Equal(t, "SELECT", queryOperation("  select * from t"))
+	assert.Equal(t, "COMMIT", queryOperation("commit;"))
+	assert.Empty(t, queryOperation(" \n"))
+}
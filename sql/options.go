@@ -0,0 +1,62 @@
+package sql
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+const instrumentationName = "github.com/arloliu/otx/sql"
+
+// options holds configuration for the instrumented connector.
+type options struct {
+	tracerName string
+	system     attribute.KeyValue // db.system, unset if empty
+	comment    bool               // Append sqlcommenter trace context to queries
+	queryText  bool               // Record queries as db.statement
+}
+
+// defaultOptions returns the default configuration.
+func defaultOptions() options {
+	return options{
+		tracerName: instrumentationName,
+		queryText:  true,
+	}
+}
+
+// Option configures the SQL instrumentation.
+type Option func(*options)
+
+// WithTracerName sets a custom tracer name.
+// Default is the package import path.
+func WithTracerName(name string) Option {
+	return func(o *options) {
+		o.tracerName = name
+	}
+}
+
+// WithDBSystem sets the db.system attribute of spans, e.g. "postgresql" or "mysql".
+func WithDBSystem(system string) Option {
+	return func(o *options) {
+		o.system = semconv.DBSystemKey.String(system)
+	}
+}
+
+// WithSQLComment appends the W3C trace context of the caller's span to queries
+// executed without preparing, as a sqlcommenter comment. See otx.SQLComment.
+// Default is false.
+//
+// Each trace makes the query text unique, which defeats caches keyed on it, such
+// as statement caches of drivers and poolers.
+func WithSQLComment(enabled bool) Option {
+	return func(o *options) {
+		o.comment = enabled
+	}
+}
+
+// WithQueryText records the query as the db.statement attribute. Default is true.
+// Disable it when queries embed values that must not reach the backend.
+func WithQueryText(enabled bool) Option {
+	return func(o *options) {
+		o.queryText = enabled
+	}
+}
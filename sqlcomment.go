@@ -0,0 +1,64 @@
+package otx
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SQLComment returns query with the W3C trace context of ctx appended as a
+// sqlcommenter comment, so databases and tools such as Cloud SQL Insights can
+// tie statements to traces:
+//
+//	SELECT * FROM orders /*traceparent='00-4bf92f...-00f067...-01'*/
+//
+// The comment goes before a trailing semicolon. The query is returned unchanged
+// when ctx carries no valid span context or the query already has a comment,
+// which the sqlcommenter specification forbids mutating.
+//
+// Queries carrying a trace ID are unique per trace, so don't use SQLComment for
+// statements cached by the driver or the database, such as prepared statements.
+//
+// Example:
+//
+//	rows, err := db.QueryContext(ctx, otx.SQLComment(ctx, "SELECT id FROM orders WHERE user_id = $1"), userID)
+func SQLComment(ctx context.Context, query string) string {
+	if !trace.SpanContextFromContext(ctx).IsValid() || hasSQLComment(query) {
+		return query
+	}
+
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	keys := carrier.Keys()
+	slices.Sort(keys)
+
+	var comment strings.Builder
+	comment.WriteString("/*")
+	for i, key := range keys {
+		if i > 0 {
+			comment.WriteByte(',')
+		}
+		comment.WriteString(url.QueryEscape(key))
+		comment.WriteString("='")
+		// Values are URL encoded, with the "+" QueryEscape uses for spaces as "%20"
+		comment.WriteString(strings.ReplaceAll(url.QueryEscape(carrier.Get(key)), "+", "%20"))
+		comment.WriteByte('\'')
+	}
+	comment.WriteString("*/")
+
+	trimmed := strings.TrimRight(query, " \t\r\n")
+	if stmt, ok := strings.CutSuffix(trimmed, ";"); ok {
+		return strings.TrimRight(stmt, " \t\r\n") + " " + comment.String() + ";"
+	}
+
+	return trimmed + " " + comment.String()
+}
+
+// hasSQLComment reports whether query contains a SQL comment.
+func hasSQLComment(query string) bool {
+	return strings.Contains(query, "/*") || strings.Contains(query, "--")
+}
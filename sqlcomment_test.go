@@ -0,0 +1,65 @@
+package otx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSQLComment(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    mustTraceID(t, "4bf92f3577b34da6a3ce929d0e0e4736"),
+		SpanID:     mustSpanID(t, "00f067aa0ba902b7"),
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(t.Context(), sc)
+	comment := "/*traceparent='00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01'*/"
+
+	tests := map[string]string{
+		"SELECT 1":                "SELECT 1 " + comment,
+		"SELECT 1;":               "SELECT 1 " + comment + ";",
+		"SELECT 1 ;\n":            "SELECT 1 " + comment + ";",
+		"SELECT 1 /* existing */": "SELECT 1 /* existing */",
+		"SELECT 1 -- existing":    "SELECT 1 -- existing",
+		"UPDATE t SET a = 1\n":    "UPDATE t SET a = 1 " + comment,
+	}
+	for query, want := range tests {
+		assert.Equal(t, want, SQLComment(ctx, query), query)
+	}
+
+	assert.Equal(t, "SELECT 1", SQLComment(t.Context(), "SELECT 1"), "no span context")
+}
+
+func TestSQLComment_TraceState(t *testing.T) {
+	state, err := trace.ParseTraceState("congo=t61rcWkgMzE,rojo=00f067aa0ba902b7")
+	require.NoError(t, err)
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceState: state,
+	})
+	ctx := trace.ContextWithSpanContext(t.Context(), sc)
+
+	assert.Equal(t,
+		"SELECT 1 /*traceparent='00-01000000000000000000000000000000-0200000000000000-00',"+
+			"tracestate='congo%3Dt61rcWkgMzE%2Crojo%3D00f067aa0ba902b7'*/",
+		SQLComment(ctx, "SELECT 1"))
+}
+
+func mustTraceID(t *testing.T, s string) trace.TraceID {
+	t.Helper()
+	id, err := trace.TraceIDFromHex(s)
+	require.NoError(t, err)
+
+	return id
+}
+
+func mustSpanID(t *testing.T, s string) trace.SpanID {
+	t.Helper()
+	id, err := trace.SpanIDFromHex(s)
+	require.NoError(t, err)
+
+	return id
+}
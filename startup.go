@@ -0,0 +1,93 @@
+package otx
+
+import (
+	"context"
+	"net/url"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startupSpanName is the name of the span emitted by NewTracerProvider when traces.startupSpan is set.
+const startupSpanName = "otx.init"
+
+// startupTracerName is the instrumentation scope of the startup span.
+const startupTracerName = "github.com/arloliu/otx"
+
+// Attribute keys recorded on the startup span.
+const (
+	attrConfigSampler         = "otx.config.sampler"
+	attrConfigPropagators     = "otx.config.propagators"
+	attrConfigProtocol        = "otx.config.otlp.protocol"
+	attrConfigHeaderKeys      = "otx.config.otlp.header_keys"
+	attrConfigTracesExporters = "otx.config.traces.exporters"
+	attrConfigTracesEndpoint  = "otx.config.traces.endpoint"
+	attrConfigLogsEnabled     = "otx.config.logs.enabled"
+	attrConfigLogsEndpoint    = "otx.config.logs.endpoint"
+	attrConfigMetricsEnabled  = "otx.config.metrics.enabled"
+	attrConfigMetricsEndpoint = "otx.config.metrics.endpoint"
+)
+
+// emitStartupSpan records the effective telemetry configuration on a short span
+// so deployed services document their setup in the tracing backend.
+// Header values are never recorded and endpoints are stripped of credentials.
+func emitStartupSpan(ctx context.Context, tp trace.TracerProvider, cfg *TelemetryConfig, sampler sdktrace.Sampler) {
+	_, span := tp.Tracer(startupTracerName).Start(ctx, startupSpanName,
+		trace.WithNewRoot(),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(startupAttributes(cfg, sampler)...),
+	)
+	span.End()
+}
+
+// startupAttributes returns the sanitized configuration attributes for the startup span.
+func startupAttributes(cfg *TelemetryConfig, sampler sdktrace.Sampler) []attribute.KeyValue {
+	propagators := "tracecontext,baggage"
+	if cfg.Propagation != nil && cfg.Propagation.Propagators != "" {
+		propagators = cfg.Propagation.Propagators
+	}
+
+	traces := resolveTraceExporterParams(cfg)
+	headerKeys := make([]string, 0, len(traces.Headers))
+	for k := range traces.Headers {
+		headerKeys = append(headerKeys, k)
+	}
+	slices.Sort(headerKeys)
+
+	attrs := []attribute.KeyValue{
+		attribute.String(attrConfigSampler, sampler.Description()),
+		attribute.StringSlice(attrConfigPropagators, splitPropagators(propagators)),
+		attribute.String(attrConfigProtocol, traces.Protocol),
+		attribute.StringSlice(attrConfigHeaderKeys, headerKeys),
+		attribute.StringSlice(attrConfigTracesExporters, exporterTypes(cfg.GetTracesExporters())),
+		attribute.String(attrConfigTracesEndpoint, sanitizeEndpoint(traces.Endpoint)),
+		attribute.Bool(attrConfigLogsEnabled, cfg.Logs.IsEnabled()),
+		attribute.Bool(attrConfigMetricsEnabled, cfg.Metrics.IsEnabled()),
+	}
+	if cfg.Logs.IsEnabled() {
+		attrs = append(attrs, attribute.String(attrConfigLogsEndpoint,
+			sanitizeEndpoint(resolveLogExporterParams(cfg).Endpoint)))
+	}
+	if cfg.Metrics.IsEnabled() {
+		attrs = append(attrs, attribute.String(attrConfigMetricsEndpoint,
+			sanitizeEndpoint(resolveMetricExporterParams(cfg).Endpoint)))
+	}
+
+	return attrs
+}
+
+// sanitizeEndpoint removes userinfo, query and fragment from URL endpoints.
+// host:port endpoints are returned unchanged.
+func sanitizeEndpoint(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil || !isHTTPSScheme(u.Scheme) {
+		return endpoint
+	}
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+
+	return u.String()
+}
@@ -0,0 +1,96 @@
+package otx
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	grpccodes "google.golang.org/grpc/codes"
+)
+
+// SetStatusFromHTTP records the HTTP response status code on the current span as
+// http.response.status_code and sets the span status following the semantic
+// conventions: 5xx codes are errors, 4xx codes are errors on client spans only,
+// as the server handled the request correctly, and codes outside 100-599 are
+// errors. Other codes leave the status unset.
+//
+// Spans of unknown kind, e.g. started through a non-SDK TracerProvider, are
+// treated as server spans.
+//
+// Example:
+//
+//	resp, err := client.Do(req)
+//	if err != nil {
+//	    otx.RecordError(ctx, err)
+//	    return err
+//	}
+//	otx.SetStatusFromHTTP(ctx, resp.StatusCode)
+func SetStatusFromHTTP(ctx context.Context, statusCode int) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	span.SetAttributes(semconv.HTTPResponseStatusCode(statusCode))
+
+	switch {
+	case statusCode < 100 || statusCode > 599:
+		span.SetStatus(codes.Error, "invalid HTTP status code "+strconv.Itoa(statusCode))
+	case statusCode >= 500, statusCode >= 400 && spanKind(span) == trace.SpanKindClient:
+		span.SetStatus(codes.Error, http.StatusText(statusCode))
+	}
+}
+
+// SetStatusFromGRPC records the gRPC status code on the current span as
+// rpc.grpc.status_code and sets the span status following the semantic
+// conventions: every code other than OK is an error on client spans, while
+// server spans are errors only for Unknown, DeadlineExceeded, Unimplemented,
+// Internal, Unavailable and DataLoss, which point at the server rather than the
+// request.
+//
+// Spans of unknown kind are treated as server spans.
+//
+// Example:
+//
+//	resp, err := client.GetOrder(ctx, req)
+//	otx.SetStatusFromGRPC(ctx, status.Code(err))
+func SetStatusFromGRPC(ctx context.Context, code grpccodes.Code) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+
+	if code == grpccodes.OK {
+		return
+	}
+	if spanKind(span) == trace.SpanKindClient || isServerGRPCError(code) {
+		span.SetStatus(codes.Error, code.String())
+	}
+}
+
+// isServerGRPCError reports whether code is an error on gRPC server spans.
+func isServerGRPCError(code grpccodes.Code) bool {
+	switch code {
+	case grpccodes.Unknown, grpccodes.DeadlineExceeded, grpccodes.Unimplemented,
+		grpccodes.Internal, grpccodes.Unavailable, grpccodes.DataLoss:
+		return true
+	default:
+		return false
+	}
+}
+
+// spanKind returns the kind of span, or SpanKindUnspecified if the span does not
+// expose it.
+func spanKind(span trace.Span) trace.SpanKind {
+	if ms, ok := span.(*metricSpan); ok {
+		span = ms.Span
+	}
+	if s, ok := span.(interface{ SpanKind() trace.SpanKind }); ok {
+		return s.SpanKind()
+	}
+
+	return trace.SpanKindUnspecified
+}
@@ -0,0 +1,87 @@
+package otx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	grpccodes "google.golang.org/grpc/codes"
+)
+
+func TestSetStatusFromHTTP(t *testing.T) {
+	tests := []struct {
+		name   string
+		kind   trace.SpanKind
+		status int
+		want   codes.Code
+	}{
+		{"server 200", trace.SpanKindServer, 200, codes.Unset},
+		{"server 404", trace.SpanKindServer, 404, codes.Unset},
+		{"server 503", trace.SpanKindServer, 503, codes.Error},
+		{"client 302", trace.SpanKindClient, 302, codes.Unset},
+		{"client 404", trace.SpanKindClient, 404, codes.Error},
+		{"client 500", trace.SpanKindClient, 500, codes.Error},
+		{"internal 404", trace.SpanKindInternal, 404, codes.Unset},
+		{"invalid", trace.SpanKindServer, 600, codes.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := setupTracing(t)
+
+			ctx, span := Start(t.Context(), "op", trace.WithSpanKind(tt.kind))
+			SetStatusFromHTTP(ctx, tt.status)
+			span.End()
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			assert.Equal(t, tt.want, spans[0].Status.Code)
+			assert.Contains(t, spans[0].Attributes, attribute.Int("http.response.status_code", tt.status))
+		})
+	}
+}
+
+func TestSetStatusFromGRPC(t *testing.T) {
+	tests := []struct {
+		name string
+		kind trace.SpanKind
+		code grpccodes.Code
+		want codes.Code
+	}{
+		{"server ok", trace.SpanKindServer, grpccodes.OK, codes.Unset},
+		{"server not found", trace.SpanKindServer, grpccodes.NotFound, codes.Unset},
+		{"server internal", trace.SpanKindServer, grpccodes.Internal, codes.Error},
+		{"server deadline", trace.SpanKindServer, grpccodes.DeadlineExceeded, codes.Error},
+		{"client ok", trace.SpanKindClient, grpccodes.OK, codes.Unset},
+		{"client not found", trace.SpanKindClient, grpccodes.NotFound, codes.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := setupTracing(t)
+
+			ctx, span := Start(t.Context(), "op", trace.WithSpanKind(tt.kind))
+			SetStatusFromGRPC(ctx, tt.code)
+			span.End()
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			assert.Equal(t, tt.want, spans[0].Status.Code)
+			assert.Contains(t, spans[0].Attributes, attribute.Int("rpc.grpc.status_code", int(tt.code)))
+		})
+	}
+}
+
+func TestSetStatusFromHTTP_SpanMetrics(t *testing.T) {
+	exporter := setupTracing(t)
+	EnableSpanMetrics(nil)
+	t.Cleanup(DisableSpanMetrics)
+
+	ctx, span := StartClient(t.Context(), "call")
+	SetStatusFromHTTP(ctx, 404)
+	span.End()
+
+	require.Len(t, exporter.GetSpans(), 1)
+	assert.Equal(t, codes.Error, exporter.GetSpans()[0].Status.Code, "kind is read through the metrics wrapper")
+}
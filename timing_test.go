@@ -0,0 +1,82 @@
+package otx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setStepClock makes Time read a clock returning the given times in order.
+func setStepClock(t *testing.T, times ...time.Time) {
+	t.Helper()
+
+	prev := stepNow
+	t.Cleanup(func() { stepNow = prev })
+	stepNow = func() time.Time {
+		require.NotEmpty(t, times, "unexpected clock read")
+		now := times[0]
+		times = times[1:]
+
+		return now
+	}
+}
+
+func TestTime_Events(t *testing.T) {
+	exporter := setupTracing(t)
+	base := time.Now()
+	setStepClock(t, base, base.Add(1500*time.Microsecond))
+
+	ctx, span := Start(t.Context(), "request")
+	stop := Time(ctx, "decode")
+	stop()
+	stop()
+	span.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1, "steps below the threshold add no span")
+	events := spans[0].Events
+	require.Len(t, events, 2, "stop records the step once")
+	assert.Equal(t, "decode.start", events[0].Name)
+	assert.Equal(t, "decode.end", events[1].Name)
+	assert.Equal(t, base, events[0].Time)
+	assert.Equal(t, base.Add(1500*time.Microsecond), events[1].Time)
+
+	require.Len(t, events[1].Attributes, 1)
+	assert.Equal(t, AttrStepDuration, string(events[1].Attributes[0].Key))
+	assert.InDelta(t, 1.5, events[1].Attributes[0].Value.AsFloat64(), 1e-9)
+}
+
+func TestTime_ChildSpanAboveThreshold(t *testing.T) {
+	exporter := setupTracing(t)
+	SetStepSpanThreshold(time.Millisecond)
+	t.Cleanup(func() { SetStepSpanThreshold(0) })
+	base := time.Now()
+	setStepClock(t,
+		base, base.Add(2*time.Millisecond), // query
+		base.Add(3*time.Millisecond), base.Add(3*time.Millisecond+time.Microsecond), // fast
+	)
+
+	ctx, span := Start(t.Context(), "request")
+	stop := Time(ctx, "query")
+	stop()
+	Time(ctx, "fast")()
+	span.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	query, request := spans[0], spans[1]
+	assert.Equal(t, "query", query.Name)
+	assert.Equal(t, request.SpanContext.SpanID(), query.Parent.SpanID())
+	assert.Equal(t, base, query.StartTime)
+	assert.Equal(t, base.Add(2*time.Millisecond), query.EndTime)
+
+	require.Len(t, request.Events, 2, "fast steps are still events")
+	assert.Equal(t, "fast.start", request.Events[0].Name)
+}
+
+func TestTime_NotRecording(t *testing.T) {
+	stop := Time(t.Context(), "step")
+	assert.NotPanics(t, stop)
+}
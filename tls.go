@@ -0,0 +1,63 @@
+package otx
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// tlsFiles holds the PEM files used to secure OTLP connections.
+type tlsFiles struct {
+	Certificate       string // CA certificates verifying the collector
+	ClientCertificate string // client certificate for mTLS
+	ClientKey         string // client private key for mTLS
+}
+
+// isSet reports whether any certificate file is configured.
+func (f tlsFiles) isSet() bool {
+	return f.Certificate != "" || f.ClientCertificate != "" || f.ClientKey != ""
+}
+
+// override returns f with the files set in signal taking precedence.
+// The client certificate and key are replaced together.
+func (f tlsFiles) override(signal tlsFiles) tlsFiles {
+	if signal.Certificate != "" {
+		f.Certificate = signal.Certificate
+	}
+	if signal.ClientCertificate != "" || signal.ClientKey != "" {
+		f.ClientCertificate, f.ClientKey = signal.ClientCertificate, signal.ClientKey
+	}
+
+	return f
+}
+
+// config loads the files into a TLS client configuration.
+// Returns nil if no file is configured, leaving the exporter defaults in place.
+func (f tlsFiles) config() (*tls.Config, error) {
+	if !f.isSet() {
+		return nil, nil //nolint:nilnil // nil config means exporter defaults
+	}
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if f.Certificate != "" {
+		pem, err := os.ReadFile(f.Certificate)
+		if err != nil {
+			return nil, fmt.Errorf("read OTLP certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("OTLP certificate %q contains no PEM certificates", f.Certificate)
+		}
+		cfg.RootCAs = pool
+	}
+	if f.ClientCertificate != "" || f.ClientKey != "" {
+		cert, err := tls.LoadX509KeyPair(f.ClientCertificate, f.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("load OTLP client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,167 @@
+package otx
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeKeyPair writes a self-signed certificate for 127.0.0.1 and its key to dir.
+func writeKeyPair(t *testing.T, dir, name string) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, name+".crt")
+	keyFile = filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certFile, keyFile
+}
+
+func TestTLSFilesConfig(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeKeyPair(t, dir, "client")
+	notPEM := filepath.Join(dir, "not.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("garbage"), 0o600))
+
+	cfg, err := tlsFiles{}.config()
+	require.NoError(t, err)
+	assert.Nil(t, cfg, "no files keep the exporter defaults")
+
+	cfg, err = tlsFiles{Certificate: certFile, ClientCertificate: certFile, ClientKey: keyFile}.config()
+	require.NoError(t, err)
+	assert.NotNil(t, cfg.RootCAs)
+	assert.Len(t, cfg.Certificates, 1)
+
+	_, err = tlsFiles{Certificate: filepath.Join(dir, "missing.crt")}.config()
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = tlsFiles{Certificate: notPEM}.config()
+	require.ErrorContains(t, err, "contains no PEM certificates")
+
+	_, err = tlsFiles{ClientCertificate: certFile, ClientKey: notPEM}.config()
+	require.ErrorContains(t, err, "load OTLP client certificate")
+}
+
+func TestOTLPCertificateEnv(t *testing.T) {
+	dir := t.TempDir()
+	serverCert, serverKey := writeKeyPair(t, dir, "server")
+	clientCert, clientKey := writeKeyPair(t, dir, "client")
+
+	pair, err := tls.LoadX509KeyPair(serverCert, serverKey)
+	require.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	caPEM, err := os.ReadFile(clientCert)
+	require.NoError(t, err)
+	require.True(t, clientCAs.AppendCertsFromPEM(caPEM))
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	server.TLS = &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{pair},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	defer server.Close()
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", server.URL)
+	t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "http/protobuf")
+	t.Setenv("OTEL_EXPORTER_OTLP_CERTIFICATE", serverCert)
+	t.Setenv("OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE", clientCert)
+	t.Setenv("OTEL_EXPORTER_OTLP_CLIENT_KEY", clientKey)
+
+	cfg, err := ParseConfig([]byte(`
+enabled: true
+serviceName: "tls"
+`))
+	require.NoError(t, err)
+	assert.Equal(t, serverCert, cfg.OTLP.Certificate)
+	assert.True(t, cfg.OTLP.IsInsecure(), "insecure keeps its default")
+	assert.False(t, resolveTraceExporterParams(cfg).Insecure, "certificates enable TLS")
+
+	require.NoError(t, CheckConnectivity(t.Context(), cfg))
+
+	exporter, err := buildOTLPTraceExporter(t.Context(), resolveTraceExporterParams(cfg))
+	require.NoError(t, err)
+	require.NoError(t, exporter.Shutdown(t.Context()))
+
+	t.Setenv("OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_CLIENT_KEY", "")
+	cfg, err = ParseConfig([]byte(`
+enabled: true
+serviceName: "tls"
+`))
+	require.NoError(t, err)
+	require.Error(t, CheckConnectivity(t.Context(), cfg), "the server requires a client certificate")
+}
+
+func TestOTLPSignalCertificateEnv(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_CERTIFICATE", "/etc/otel/ca.crt")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_CERTIFICATE", "/etc/otel/traces-ca.crt")
+	t.Setenv("OTEL_EXPORTER_OTLP_METRICS_CLIENT_CERTIFICATE", "/etc/otel/metrics.crt")
+	t.Setenv("OTEL_EXPORTER_OTLP_METRICS_CLIENT_KEY", "/etc/otel/metrics.key")
+
+	cfg, err := ParseConfig([]byte(`
+enabled: true
+serviceName: "tls"
+`))
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	assert.Equal(t, tlsFiles{Certificate: "/etc/otel/traces-ca.crt"}, resolveTraceExporterParams(cfg).TLS)
+	assert.Equal(t, tlsFiles{Certificate: "/etc/otel/ca.crt"}, resolveLogExporterParams(cfg).TLS)
+	assert.Equal(t, tlsFiles{
+		Certificate:       "/etc/otel/ca.crt",
+		ClientCertificate: "/etc/otel/metrics.crt",
+		ClientKey:         "/etc/otel/metrics.key",
+	}, resolveMetricExporterParams(cfg).TLS)
+
+	t.Setenv("OTEL_EXPORTER_OTLP_CERTIFICATE", "")
+	cfg, err = ParseConfig([]byte(`
+enabled: true
+serviceName: "tls"
+`))
+	require.NoError(t, err)
+	assert.False(t, resolveTraceExporterParams(cfg).Insecure, "signal certificates enable TLS")
+	assert.True(t, resolveLogExporterParams(cfg).Insecure)
+
+	cfg.Metrics.ClientKey = ""
+	require.ErrorContains(t, cfg.Validate(), "metrics.clientCertificate and metrics.clientKey must be set together")
+}
@@ -0,0 +1,70 @@
+package otx
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithSpan calls fn in a span named operation, started with Start, and ends the
+// span when fn returns. An error returned by fn is recorded on the span with
+// RecordError and returned. If fn panics, the panic is recorded as an error and
+// the span ended before the panic continues.
+//
+// Example:
+//
+//	err := otx.WithSpan(ctx, "SaveOrder", func(ctx context.Context) error {
+//	    return repo.Save(ctx, order)
+//	})
+func WithSpan(
+	ctx context.Context,
+	operation string,
+	fn func(ctx context.Context) error,
+	opts ...trace.SpanStartOption,
+) error {
+	_, err := Traced(ctx, operation, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	}, opts...)
+
+	return err
+}
+
+// Traced is WithSpan for functions returning a value: it calls fn in a span named
+// operation and returns its result, recording the error on the span.
+//
+// Example:
+//
+//	user, err := otx.Traced(ctx, "LoadUser", func(ctx context.Context) (*User, error) {
+//	    return repo.FindUser(ctx, id)
+//	})
+func Traced[T any](
+	ctx context.Context,
+	operation string,
+	fn func(ctx context.Context) (T, error),
+	opts ...trace.SpanStartOption,
+) (T, error) {
+	ctx, span := Start(ctx, operation, opts...)
+	defer span.End()
+	defer recordPanic(ctx)
+
+	result, err := fn(ctx)
+	RecordError(ctx, err)
+
+	return result, err
+}
+
+// recordPanic records a panic in progress on the span in ctx and panics again.
+// It must be called directly by defer.
+func recordPanic(ctx context.Context) {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+	err, ok := recovered.(error)
+	if !ok {
+		err = fmt.Errorf("panic: %v", recovered)
+	}
+	RecordError(ctx, err, trace.WithStackTrace(true))
+	panic(recovered)
+}
@@ -0,0 +1,78 @@
+package otx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraced(t *testing.T) {
+	exporter := setupTracing(t)
+
+	var inner trace.SpanContext
+	n, err := Traced(t.Context(), "count", func(ctx context.Context) (int, error) {
+		inner = trace.SpanContextFromContext(ctx)
+
+		return 42, nil
+	}, trace.WithSpanKind(trace.SpanKindClient))
+	require.NoError(t, err)
+	assert.Equal(t, 42, n)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "count", spans[0].Name)
+	assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind)
+	assert.Equal(t, inner.SpanID(), spans[0].SpanContext.SpanID(), "fn runs in the span's context")
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+}
+
+func TestTraced_Error(t *testing.T) {
+	exporter := setupTracing(t)
+
+	s, err := Traced(t.Context(), "load", func(context.Context) (string, error) {
+		return "partial", errBoom
+	})
+	require.ErrorIs(t, err, errBoom)
+	assert.Equal(t, "partial", s, "the result is returned with the error")
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, "boom", spans[0].Status.Description)
+	require.Len(t, spans[0].Events, 1)
+	assert.Equal(t, "exception", spans[0].Events[0].Name)
+}
+
+func TestTraced_Panic(t *testing.T) {
+	exporter := setupTracing(t)
+
+	assert.PanicsWithValue(t, "kaboom", func() {
+		_, _ = Traced(t.Context(), "explode", func(context.Context) (int, error) {
+			panic("kaboom")
+		})
+	})
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1, "the span is ended before the panic continues")
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, "panic: kaboom", spans[0].Status.Description)
+}
+
+func TestWithSpan(t *testing.T) {
+	exporter := setupTracing(t)
+
+	require.NoError(t, WithSpan(t.Context(), "ok", func(context.Context) error { return nil }))
+	err := WithSpan(t.Context(), "fail", func(context.Context) error { return errBoom })
+	require.ErrorIs(t, err, errBoom)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "ok", spans[0].Name)
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	assert.Equal(t, "fail", spans[1].Name)
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+}